@@ -54,11 +54,16 @@ func RegisterOpenAPIV2Routes(router *gin.Engine, api OpenAPIV2) {
 	changefeedGroup.POST("/:changefeed_id/resume", coordinatorMiddleware, api.resumeChangefeed)
 	changefeedGroup.POST("/:changefeed_id/pause", coordinatorMiddleware, api.pauseChangefeed)
 	changefeedGroup.DELETE("/:changefeed_id", coordinatorMiddleware, api.deleteChangefeed)
+	changefeedGroup.POST("/:changefeed_id/tables/move", coordinatorMiddleware, api.moveTable)
+	// the spans are listed by the node that runs the maintainer, the request is forwarded by the handler
+	changefeedGroup.GET("/:changefeed_id/spans", api.listSpans)
 
 	// capture apis
 	captureGroup := v2.Group("/captures")
 	captureGroup.Use(coordinatorMiddleware)
 	captureGroup.GET("", api.listCaptures)
+	captureGroup.POST("/:capture_id/drain", api.drainCapture)
+	captureGroup.DELETE("/:capture_id/drain", api.undrainCapture)
 
 	verifyTableGroup := v2.Group("/verify_table")
 	verifyTableGroup.POST("", api.verifyTable)
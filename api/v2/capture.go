@@ -18,7 +18,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/api"
+	"github.com/pingcap/tiflow/pkg/errors"
 )
 
 // listCaptures lists all captures
@@ -53,3 +56,63 @@ func (h *OpenAPIV2) listCaptures(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, resp)
 }
+
+// drainCapture drains a capture
+// @Summary Drain a capture
+// @Description Move all maintainers and tables out of the capture, the capture is not scheduled any more.
+// @Description The api returns the number of maintainers and tables still running on the capture,
+// @Description call it repeatedly until both of them are 0.
+// @Tags capture,v2
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Success 202 {object} DrainCaptureResp
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/captures/{capture_id}/drain [post]
+func (h *OpenAPIV2) drainCapture(c *gin.Context) {
+	captureID := c.Param(api.APIOpVarCaptureID)
+	if captureID == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid capture_id: %s", captureID))
+		return
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	maintainerCount, tableCount, err := coordinator.DrainNode(c.Request.Context(), node.ID(captureID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, &DrainCaptureResp{
+		CurrentMaintainerCount: maintainerCount,
+		CurrentTableCount:      tableCount,
+	})
+}
+
+// undrainCapture cancels the draining of a capture
+// @Summary Undrain a capture
+// @Description Cancel the draining of the capture, the maintainers and tables can be scheduled to it again.
+// @Tags capture,v2
+// @Produce json
+// @Param capture_id path string true "capture_id"
+// @Success 200 {object} EmptyResponse
+// @Failure 500,400 {object} model.HTTPError
+// @Router	/api/v2/captures/{capture_id}/drain [delete]
+func (h *OpenAPIV2) undrainCapture(c *gin.Context) {
+	captureID := c.Param(api.APIOpVarCaptureID)
+	if captureID == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid capture_id: %s", captureID))
+		return
+	}
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := coordinator.UndrainNode(c.Request.Context(), node.ID(captureID)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, &EmptyResponse{})
+}
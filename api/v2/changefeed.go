@@ -15,15 +15,21 @@ package v2
 
 import (
 	"context"
+	"encoding/hex"
 	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/api/middleware"
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/maintainer"
 	"github.com/pingcap/ticdc/pkg/common"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/txnutil/gc"
 	"github.com/pingcap/ticdc/version"
 	"github.com/pingcap/tiflow/cdc/api"
@@ -240,10 +246,68 @@ func (h *OpenAPIV2) listChangeFeeds(c *gin.Context) {
 
 // verifyTable verify table, return ineligibleTables and EligibleTables.
 func (h *OpenAPIV2) verifyTable(c *gin.Context) {
-	tables := &Tables{}
+	ctx := c.Request.Context()
+	cfg := getDefaultVerifyTableConfig()
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+
+	if cfg.StartTs == 0 {
+		ts, logical, err := h.server.GetPdClient().GetTS(ctx)
+		if err != nil {
+			_ = c.Error(errors.ErrPDEtcdAPIError.GenWithStackByArgs("fail to get ts from pd client"))
+			return
+		}
+		cfg.StartTs = oracle.ComposeTS(ts, logical)
+	}
+
+	replicaCfg := cfg.ReplicaConfig.ToInternalReplicaConfig()
+	tableFilter, err := filter.NewFilter(replicaCfg.Filter, "", replicaCfg.CaseSensitive)
+	if err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+
+	schemaStore := appcontext.GetService[schemastore.SchemaStore](appcontext.SchemaStore)
+	tables, err := getVerifiedTables(schemaStore, tableFilter, cfg.StartTs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 	c.JSON(http.StatusOK, tables)
 }
 
+// getVerifiedTables returns the tables at startTs classified by whether they are
+// filtered out by the tableFilter, and whether they can be replicated.
+func getVerifiedTables(
+	schemaStore schemastore.SchemaStore, tableFilter filter.Filter, startTs uint64,
+) (*Tables, error) {
+	verifiedTables, err := schemaStore.VerifyTables(startTs, tableFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := &Tables{}
+	for _, table := range verifiedTables {
+		tableName := TableName{
+			Schema:      table.SchemaName,
+			Table:       table.TableName,
+			TableID:     table.TableID,
+			IsPartition: table.IsPartition,
+		}
+		switch {
+		case table.Filtered:
+			tables.FilteredTables = append(tables.FilteredTables, tableName)
+		case table.Eligible:
+			tables.EligibleTables = append(tables.EligibleTables, tableName)
+		default:
+			tables.IneligibleTables = append(tables.IneligibleTables, tableName)
+		}
+	}
+	return tables, nil
+}
+
 // getChangefeed get detailed info of a changefeed
 // @Summary Get changefeed
 // @Description get detail information of a changefeed
@@ -271,6 +335,15 @@ func (h *OpenAPIV2) getChangeFeed(c *gin.Context) {
 	taskStatus := make([]model.CaptureTaskStatus, 0)
 	detail := toAPIModel(cfInfo, status.CheckpointTs,
 		status.CheckpointTs, taskStatus)
+	detail.BlockingDDLTs = status.BlockingDDLTs
+	for _, record := range status.ResumeHistory {
+		detail.ResumeHistory = append(detail.ResumeHistory, &ResumeRecord{
+			ResumeTime:      record.ResumeTime,
+			OldCheckpointTs: record.OldCheckpointTs,
+			NewCheckpointTs: record.NewCheckpointTs,
+			SkippedDDLTs:    record.SkippedDDLTs,
+		})
+	}
 	c.JSON(http.StatusOK, detail)
 }
 
@@ -411,6 +484,133 @@ func (h *OpenAPIV2) pauseChangefeed(c *gin.Context) {
 	c.JSON(http.StatusOK, &EmptyResponse{})
 }
 
+// listSpans lists all span replications of the changefeed
+// @Summary List the spans of a changefeed
+// @Description list the table spans of the changefeed with the node, state, checkpoint ts, resolved ts
+// @Description and the pending block event, the request is forwarded to the node that runs the maintainer
+// @Tags changefeed,v2
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Success 200 {array} SpanReplication
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/spans [get]
+func (h *OpenAPIV2) listSpans(c *gin.Context) {
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), model.DefaultNamespace)
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+
+	manager := appcontext.GetService[*maintainer.Manager](maintainer.ManagerName)
+	if m, ok := manager.GetMaintainer(changefeedDisplayName); ok {
+		spans := toAPISpans(m.GetSpanReplications())
+		c.JSON(http.StatusOK, &ListResponse[SpanReplication]{
+			Total: len(spans),
+			Items: spans,
+		})
+		return
+	}
+
+	// the maintainer is not running on this node, forward the request to the
+	// coordinator, and the coordinator forwards it to the maintainer node
+	if !h.server.IsCoordinator() {
+		middleware.ForwardToOwner(c, h.server)
+		return
+	}
+	co, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	target, err := co.GetMaintainerNode(c, changefeedDisplayName)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	self, err := h.server.SelfInfo()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if target.ID == self.ID {
+		// the maintainer is scheduled to this node but not created yet
+		_ = c.Error(errors.ErrSchedulerRequestFailed.GenWithStackByArgs("changefeed maintainer is not running"))
+		return
+	}
+	middleware.ForwardToServer(c, self.ID, target.AdvertiseAddr)
+}
+
+func toAPISpans(infos []*maintainer.SpanReplicationInfo) []SpanReplication {
+	spans := make([]SpanReplication, 0, len(infos))
+	for _, info := range infos {
+		span := SpanReplication{
+			DispatcherID:   info.ID.String(),
+			SchemaID:       info.SchemaID,
+			TableID:        info.Span.TableID,
+			StartKey:       hex.EncodeToString(info.Span.StartKey),
+			EndKey:         hex.EncodeToString(info.Span.EndKey),
+			NodeID:         info.NodeID.String(),
+			ComponentState: info.Status.ComponentStatus.String(),
+			CheckpointTs:   info.Status.CheckpointTs,
+			ResolvedTs:     info.Status.ResolvedTs,
+		}
+		if info.BlockEvent != nil {
+			span.BlockEvent = &BlockEvent{
+				BlockTs:     info.BlockEvent.BlockTs,
+				IsSyncPoint: info.BlockEvent.IsSyncPoint,
+				Stage:       info.BlockEvent.Stage,
+			}
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+// moveTable handles move table request.
+// @Summary Move a table to the target capture
+// @Description Move all spans of a table to the target capture, and pin the table to it.
+// @Description Call it repeatedly with the same parameters until the table is moved.
+// @Tags changefeed,v2
+// @Accept json
+// @Produce json
+// @Param changefeed_id path string true "changefeed_id"
+// @Param moveTableConfig body MoveTableConfig true "move table config"
+// @Success 202 {object} MoveTableResp
+// @Failure 500,400 {object} model.HTTPError
+// @Router /api/v2/changefeeds/{changefeed_id}/tables/move [post]
+func (h *OpenAPIV2) moveTable(c *gin.Context) {
+	ctx := c.Request.Context()
+	changefeedDisplayName := common.NewChangeFeedDisplayName(c.Param(api.APIOpVarChangefeedID), model.DefaultNamespace)
+	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
+			changefeedDisplayName.Name))
+		return
+	}
+	cfg := &MoveTableConfig{}
+	if err := c.BindJSON(cfg); err != nil {
+		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
+		return
+	}
+	if cfg.TableID <= 0 || cfg.TargetNodeID == "" {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid table_id: %d or target_node_id: %s",
+			cfg.TableID, cfg.TargetNodeID))
+		return
+	}
+
+	coordinator, err := h.server.GetCoordinator()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	finished, err := coordinator.MoveTable(ctx, changefeedDisplayName, cfg.TableID, node.ID(cfg.TargetNodeID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, &MoveTableResp{Finished: finished})
+}
+
 // resumeChangefeed handles resume changefeed request.
 // ResumeChangefeed resumes a changefeed
 // @Summary Resume a changefeed
@@ -432,17 +632,17 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 			changefeedDisplayName.Name))
 		return
 	}
-	if err := model.ValidateChangefeedID(changefeedDisplayName.Name); err != nil {
-		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack("invalid changefeed_id: %s",
-			changefeedDisplayName.Name))
-		return
-	}
 
 	cfg := new(ResumeChangefeedConfig)
 	if err := c.BindJSON(&cfg); err != nil {
 		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
 		return
 	}
+	if cfg.SkipBlockingDDL && cfg.OverwriteCheckpointTs != 0 {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"skip_blocking_ddl and overwrite_checkpoint_ts can not be set at the same time"))
+		return
+	}
 	coordinator, err := h.server.GetCoordinator()
 	if err != nil {
 		_ = c.Error(err)
@@ -459,6 +659,28 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 	if cfg.OverwriteCheckpointTs != 0 {
 		newCheckpointTs = cfg.OverwriteCheckpointTs
 	}
+	if cfg.SkipBlockingDDL {
+		// resume from the commit ts of the blocking ddl, so the ddl is treated as executed
+		if status.BlockingDDLTs == 0 {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+				"changefeed %s is not blocked by any ddl", changefeedDisplayName.Name))
+			return
+		}
+		// the spans blocked by the ddl report blockTs - 1 as their checkpoint, if the checkpoint of
+		// the changefeed is smaller than that, some spans have not flushed the changes before the ddl
+		if status.CheckpointTs+1 < status.BlockingDDLTs {
+			_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+				"checkpoint-ts %d of changefeed %s has not reached the blocking ddl %d, skip it may lose data",
+				status.CheckpointTs, changefeedDisplayName.Name, status.BlockingDDLTs))
+			return
+		}
+		newCheckpointTs = status.BlockingDDLTs
+	}
+	if cfInfo.TargetTs != 0 && newCheckpointTs > cfInfo.TargetTs {
+		_ = c.Error(errors.ErrAPIInvalidParam.GenWithStack(
+			"invalid checkpoint-ts %d, larger than target-ts %d", newCheckpointTs, cfInfo.TargetTs))
+		return
+	}
 
 	if err := verifyResumeChangefeedConfig(
 		ctx,
@@ -485,8 +707,10 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 			return
 		}
 	}()
-	err = coordinator.ResumeChangefeed(ctx, cfInfo.ChangefeedID, newCheckpointTs)
+	err = coordinator.ResumeChangefeed(ctx, cfInfo.ChangefeedID, newCheckpointTs, cfg.SkipBlockingDDL)
 	if err != nil {
+		// the changefeed is not resumed, the service gc safepoint set for resuming is useless
+		needRemoveGCSafePoint = true
 		_ = c.Error(err)
 		return
 	}
@@ -498,6 +722,7 @@ func (h *OpenAPIV2) resumeChangefeed(c *gin.Context) {
 // Can only update a changefeed's: TargetTs, SinkURI,
 // ReplicaConfig, PDAddrs, CAPath, CertPath, KeyPath,
 // SyncPointEnabled, SyncPointInterval
+// A running changefeed can only update its filter rules, memory quota, worker count and dispatch rules.
 // UpdateChangefeed updates a changefeed
 // @Summary Update a changefeed
 // @Description Update a changefeed
@@ -530,51 +755,61 @@ func (h *OpenAPIV2) updateChangefeed(c *gin.Context) {
 		return
 	}
 
-	switch oldCfInfo.State {
-	case model.StateStopped, model.StateFailed:
-	default:
-		_ = c.Error(
-			errors.ErrChangefeedUpdateRefused.GenWithStackByArgs(
-				"can only update changefeed config when it is stopped or failed",
-			),
-		)
-		return
-	}
-
 	updateCfConfig := &ChangefeedConfig{}
 	if err = c.BindJSON(updateCfConfig); err != nil {
 		_ = c.Error(errors.WrapError(errors.ErrAPIInvalidParam, err))
 		return
 	}
 
+	// the info returned by the coordinator is shared, modify a copy of it
+	newCfInfo, err := oldCfInfo.Clone()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
 	if updateCfConfig.TargetTs != 0 {
-		if updateCfConfig.TargetTs <= oldCfInfo.StartTs {
+		if updateCfConfig.TargetTs <= newCfInfo.StartTs {
 			_ = c.Error(errors.ErrChangefeedUpdateRefused.GenWithStack(
 				"can not update target_ts:%d less than start_ts:%d",
-				updateCfConfig.TargetTs, oldCfInfo.StartTs))
+				updateCfConfig.TargetTs, newCfInfo.StartTs))
 			return
 		}
-		oldCfInfo.TargetTs = updateCfConfig.TargetTs
+		newCfInfo.TargetTs = updateCfConfig.TargetTs
 	}
 	if updateCfConfig.ReplicaConfig != nil {
-		oldCfInfo.Config = updateCfConfig.ReplicaConfig.ToInternalReplicaConfig()
+		newCfInfo.Config = updateCfConfig.ReplicaConfig.ToInternalReplicaConfig()
 	}
 	if updateCfConfig.SinkURI != "" {
-		oldCfInfo.SinkURI = updateCfConfig.SinkURI
+		newCfInfo.SinkURI = updateCfConfig.SinkURI
+	}
+
+	switch oldCfInfo.State {
+	case model.StateStopped, model.StateFailed:
+	default:
+		// a running changefeed can only be updated in place
+		if !config.IsLiveUpdatable(oldCfInfo, newCfInfo) {
+			_ = c.Error(
+				errors.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+					"can only update the filter rules, memory quota, worker count and dispatch rules " +
+						"of a running changefeed, pause it to update other configs",
+				),
+			)
+			return
+		}
 	}
 
 	// verify changefeed filter
-	_, err = filter.NewFilter(oldCfInfo.Config.Filter, "", oldCfInfo.Config.CaseSensitive)
+	_, err = filter.NewFilter(newCfInfo.Config.Filter, "", newCfInfo.Config.CaseSensitive)
 	if err != nil {
 		_ = c.Error(errors.ErrChangefeedUpdateRefused.
 			GenWithStackByArgs(errors.Cause(err).Error()))
 		return
 	}
-	if err := coordinator.UpdateChangefeed(ctx, oldCfInfo); err != nil {
+	if err := coordinator.UpdateChangefeed(ctx, newCfInfo); err != nil {
 		_ = c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, toAPIModel(oldCfInfo, status.CheckpointTs, status.CheckpointTs, nil))
+	c.JSON(http.StatusOK, toAPIModel(newCfInfo, status.CheckpointTs, status.CheckpointTs, nil))
 }
 
 // verifyResumeChangefeedConfig verifies the changefeed config before resuming a changefeed
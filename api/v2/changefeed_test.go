@@ -0,0 +1,54 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v2
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/logservice/schemastore"
+	"github.com/pingcap/ticdc/pkg/filter"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSchemaStore struct {
+	schemastore.SchemaStore
+	tables []schemastore.VerifiedTable
+}
+
+func (m *mockSchemaStore) VerifyTables(snapTs uint64, filter filter.Filter) ([]schemastore.VerifiedTable, error) {
+	return m.tables, nil
+}
+
+func TestGetVerifiedTables(t *testing.T) {
+	store := &mockSchemaStore{
+		tables: []schemastore.VerifiedTable{
+			{SchemaName: "test", TableName: "t1", TableID: 100, Eligible: true},
+			{SchemaName: "test", TableName: "t2", TableID: 101},
+			{SchemaName: "test", TableName: "t3", TableID: 102, IsPartition: true, Eligible: true},
+			{SchemaName: "test", TableName: "t4", TableID: 103, Filtered: true},
+		},
+	}
+	tables, err := getVerifiedTables(store, nil, 100)
+	require.NoError(t, err)
+	require.Equal(t, []TableName{
+		{Schema: "test", Table: "t1", TableID: 100},
+		{Schema: "test", Table: "t3", TableID: 102, IsPartition: true},
+	}, tables.EligibleTables)
+	require.Equal(t, []TableName{
+		{Schema: "test", Table: "t2", TableID: 101},
+	}, tables.IneligibleTables)
+	require.Equal(t, []TableName{
+		{Schema: "test", Table: "t4", TableID: 103},
+	}, tables.FilteredTables)
+}
@@ -47,10 +47,12 @@ type Tso struct {
 	LogicTime int64 `json:"logic_time"`
 }
 
-// Tables contains IneligibleTables and EligibleTables
+// Tables contains IneligibleTables, EligibleTables and FilteredTables
 type Tables struct {
 	IneligibleTables []TableName `json:"ineligible_tables,omitempty"`
 	EligibleTables   []TableName `json:"eligible_tables,omitempty"`
+	// FilteredTables are the tables ignored by the filter rules
+	FilteredTables []TableName `json:"filtered_tables,omitempty"`
 }
 
 // TableName contains table information
@@ -80,6 +82,9 @@ func getDefaultVerifyTableConfig() *VerifyTableConfig {
 type ResumeChangefeedConfig struct {
 	PDConfig
 	OverwriteCheckpointTs uint64 `json:"overwrite_checkpoint_ts"`
+	// SkipBlockingDDL resumes the changefeed from the commit ts of the ddl that blocks it,
+	// so the ddl is skipped. It can not be used with OverwriteCheckpointTs
+	SkipBlockingDDL bool `json:"skip_blocking_ddl"`
 }
 
 // PDConfig is a configuration used to connect to pd
@@ -405,6 +410,7 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 				LargeMessageHandle:           largeMessageHandle,
 				GlueSchemaRegistryConfig:     glueSchemaRegistryConfig,
 				OutputRawChangeEvent:         c.Sink.KafkaConfig.OutputRawChangeEvent,
+				ExactlyOnce:                  c.Sink.KafkaConfig.ExactlyOnce,
 			}
 		}
 		var mysqlConfig *config.MySQLConfig
@@ -502,6 +508,10 @@ func (c *ReplicaConfig) toInternalReplicaConfigWithOriginConfig(
 		if c.Sink.SendAllBootstrapAtStart != nil {
 			res.Sink.SendAllBootstrapAtStart = util.AddressOf(*c.Sink.SendAllBootstrapAtStart)
 		}
+
+		if c.Sink.SendSyncPointMarker != nil {
+			res.Sink.SendSyncPointMarker = util.AddressOf(*c.Sink.SendSyncPointMarker)
+		}
 	}
 	if c.Mounter != nil {
 		res.Mounter = &config.MounterConfig{
@@ -676,6 +686,7 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 				LargeMessageHandle:           largeMessageHandle,
 				GlueSchemaRegistryConfig:     glueSchemaRegistryConfig,
 				OutputRawChangeEvent:         cloned.Sink.KafkaConfig.OutputRawChangeEvent,
+				ExactlyOnce:                  cloned.Sink.KafkaConfig.ExactlyOnce,
 			}
 		}
 		var mysqlConfig *MySQLConfig
@@ -802,6 +813,10 @@ func ToAPIReplicaConfig(c *config.ReplicaConfig) *ReplicaConfig {
 			res.Sink.SendAllBootstrapAtStart = util.AddressOf(*cloned.Sink.SendAllBootstrapAtStart)
 		}
 
+		if cloned.Sink.SendSyncPointMarker != nil {
+			res.Sink.SendSyncPointMarker = util.AddressOf(*cloned.Sink.SendSyncPointMarker)
+		}
+
 		if cloned.Sink.DebeziumDisableSchema != nil {
 			res.Sink.DebeziumDisableSchema = util.AddressOf(*cloned.Sink.DebeziumDisableSchema)
 		}
@@ -968,6 +983,7 @@ type SinkConfig struct {
 	SendBootstrapInMsgCount          *int32              `json:"send_bootstrap_in_msg_count,omitempty"`
 	SendBootstrapToAllPartition      *bool               `json:"send_bootstrap_to_all_partition,omitempty"`
 	SendAllBootstrapAtStart          *bool               `json:"send-all-bootstrap-at-start,omitempty"`
+	SendSyncPointMarker              *bool               `json:"send_sync_point_marker,omitempty"`
 	DebeziumDisableSchema            *bool               `json:"debezium_disable_schema,omitempty"`
 	DebeziumConfig                   *DebeziumConfig     `json:"debezium,omitempty"`
 	OpenProtocolConfig               *OpenProtocolConfig `json:"open,omitempty"`
@@ -1087,6 +1103,18 @@ type ChangeFeedInfo struct {
 	CheckpointTs   uint64                    `json:"checkpoint_ts"`
 	CheckpointTime model.JSONTime            `json:"checkpoint_time"`
 	TaskStatus     []model.CaptureTaskStatus `json:"task_status,omitempty"`
+
+	// BlockingDDLTs is the commit ts of the ddl that blocks the changefeed, 0 if there is no blocking ddl
+	BlockingDDLTs uint64          `json:"blocking_ddl_ts,omitempty"`
+	ResumeHistory []*ResumeRecord `json:"resume_history,omitempty"`
+}
+
+// ResumeRecord records a resume that overwrites the checkpoint of a changefeed
+type ResumeRecord struct {
+	ResumeTime      time.Time `json:"resume_time"`
+	OldCheckpointTs uint64    `json:"old_checkpoint_ts"`
+	NewCheckpointTs uint64    `json:"new_checkpoint_ts"`
+	SkippedDDLTs    uint64    `json:"skipped_ddl_ts,omitempty"`
 }
 
 // SyncedStatus describes the detail of a changefeed's synced status
@@ -1182,6 +1210,45 @@ type Capture struct {
 	ClusterID     string `json:"cluster_id"`
 }
 
+// SpanReplication is the replication status of a table span of the changefeed
+type SpanReplication struct {
+	DispatcherID   string `json:"dispatcher_id"`
+	SchemaID       int64  `json:"schema_id"`
+	TableID        int64  `json:"table_id"`
+	StartKey       string `json:"start_key"`
+	EndKey         string `json:"end_key"`
+	NodeID         string `json:"node_id"`
+	ComponentState string `json:"component_state"`
+	CheckpointTs   uint64 `json:"checkpoint_ts"`
+	ResolvedTs     uint64 `json:"resolved_ts"`
+	// BlockEvent is the pending ddl or sync point event that blocks the span
+	BlockEvent *BlockEvent `json:"block_event,omitempty"`
+}
+
+// BlockEvent is a ddl or sync point event that blocks the spans
+type BlockEvent struct {
+	BlockTs     uint64 `json:"block_ts"`
+	IsSyncPoint bool   `json:"is_sync_point"`
+	Stage       string `json:"stage"`
+}
+
+// DrainCaptureResp is the response of the drain capture api
+type DrainCaptureResp struct {
+	CurrentMaintainerCount int `json:"current_maintainer_count"`
+	CurrentTableCount      int `json:"current_table_count"`
+}
+
+// MoveTableConfig is used by the move table api
+type MoveTableConfig struct {
+	TableID      int64  `json:"table_id"`
+	TargetNodeID string `json:"target_node_id"`
+}
+
+// MoveTableResp is the response of the move table api
+type MoveTableResp struct {
+	Finished bool `json:"finished"`
+}
+
 // CodecConfig represents a MQ codec configuration
 type CodecConfig struct {
 	EnableTiDBExtension            *bool   `json:"enable_tidb_extension,omitempty"`
@@ -1263,6 +1330,7 @@ type KafkaConfig struct {
 	LargeMessageHandle           *LargeMessageHandleConfig `json:"large_message_handle,omitempty"`
 	GlueSchemaRegistryConfig     *GlueSchemaRegistryConfig `json:"glue_schema_registry_config,omitempty"`
 	OutputRawChangeEvent         *bool                     `json:"output_raw_change_event,omitempty"`
+	ExactlyOnce                  *bool                     `json:"exactly_once,omitempty"`
 }
 
 // MySQLConfig represents a MySQL sink configuration
@@ -27,6 +27,8 @@ func newCmdCapture(f factory.Factory) *cobra.Command {
 	}
 	cmds.AddCommand(
 		newCmdListCapture(f),
+		newCmdDrainCapture(f),
+		newCmdUndrainCapture(f),
 		// TODO: add resign owner command
 	)
 
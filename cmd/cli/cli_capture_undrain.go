@@ -0,0 +1,81 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cli
+
+import (
+	"github.com/pingcap/ticdc/cmd/factory"
+	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	cmdcontext "github.com/pingcap/tiflow/pkg/cmd/context"
+	"github.com/pingcap/tiflow/pkg/cmd/util"
+	"github.com/spf13/cobra"
+)
+
+// undrainCaptureOptions defines flags for the `cli capture undrain` command.
+type undrainCaptureOptions struct {
+	apiv2Client apiv2client.APIV2Interface
+
+	captureID string
+}
+
+// newUndrainCaptureOptions creates new undrainCaptureOptions for the `cli capture undrain` command.
+func newUndrainCaptureOptions() *undrainCaptureOptions {
+	return &undrainCaptureOptions{}
+}
+
+// addFlags receives a *cobra.Command reference and binds
+// flags related to template printing to it.
+func (o *undrainCaptureOptions) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&o.captureID, "capture-id", "", "the id of the capture to be undrained")
+	_ = cmd.MarkPersistentFlagRequired("capture-id")
+}
+
+// complete adapts from the command line args to the data and client required.
+func (o *undrainCaptureOptions) complete(f factory.Factory) error {
+	apiv2Client, err := f.APIV2Client()
+	if err != nil {
+		return err
+	}
+	o.apiv2Client = apiv2Client
+	return nil
+}
+
+// run runs the `cli capture undrain` command.
+func (o *undrainCaptureOptions) run(cmd *cobra.Command) error {
+	ctx := cmdcontext.GetDefaultContext()
+
+	if err := o.apiv2Client.Captures().Undrain(ctx, o.captureID); err != nil {
+		return err
+	}
+	cmd.Printf("capture %s is undrained\n", o.captureID)
+	return nil
+}
+
+// newCmdUndrainCapture creates the `cli capture undrain` command.
+func newCmdUndrainCapture(f factory.Factory) *cobra.Command {
+	o := newUndrainCaptureOptions()
+
+	command := &cobra.Command{
+		Use:   "undrain",
+		Short: "Cancel the draining of a capture, maintainers and tables can be scheduled to it again",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			util.CheckErr(o.complete(f))
+			util.CheckErr(o.run(cmd))
+		},
+	}
+
+	o.addFlags(command)
+
+	return command
+}
@@ -25,6 +25,7 @@ import (
 	v2 "github.com/pingcap/ticdc/api/v2"
 	"github.com/pingcap/ticdc/cmd/factory"
 	apiv2client "github.com/pingcap/ticdc/pkg/api/v2"
+	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tiflow/cdc/model"
@@ -295,18 +296,22 @@ func (o *createChangefeedOptions) run(ctx context.Context, cmd *cobra.Command) e
 		return err
 	}
 
+	if len(tables.EligibleTables) == 0 && len(tables.IneligibleTables) == 0 {
+		cmd.Printf("[WARN] No table matches the filter rules at start-ts %d\n", o.startTs)
+	}
+
 	ignoreIneligibleTables := false
 	if len(tables.IneligibleTables) != 0 {
 		if o.cfg.ForceReplicate {
 			cmd.Printf("[WARN] Force to replicate some ineligible tables, "+
-				"these tables do not have a primary key or a not-null unique key: %#v\n"+
+				"these tables do not have a primary key or a not-null unique key: %v\n"+
 				"[WARN] This may cause data redundancy, "+
 				"please refer to the official documentation for details.\n",
-				tables.IneligibleTables)
+				tableNames(tables.IneligibleTables))
 		} else {
 			cmd.Printf("[WARN] Some tables are not eligible to replicate, "+
-				"because they do not have a primary key or a not-null unique key: %#v\n",
-				tables.IneligibleTables)
+				"because they do not have a primary key or a not-null unique key: %v\n",
+				tableNames(tables.IneligibleTables))
 			if !o.commonChangefeedOptions.noConfirm {
 				ignoreIneligibleTables, err = confirmIgnoreIneligibleTables(cmd)
 				if err != nil {
@@ -366,3 +371,12 @@ func newCmdCreateChangefeed(f factory.Factory) *cobra.Command {
 
 	return command
 }
+
+// tableNames returns the quoted names of the tables, used to print the pre-check result.
+func tableNames(tables []v2.TableName) []string {
+	names := make([]string, 0, len(tables))
+	for _, table := range tables {
+		names = append(names, common.QuoteSchema(table.Schema, table.Table))
+	}
+	return names
+}
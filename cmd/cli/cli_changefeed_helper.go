@@ -89,6 +89,22 @@ func confirmOverwriteCheckpointTs(
 	return nil
 }
 
+// confirmSkipBlockingDDL prompts the user to confirm skipping the ddl that blocks the changefeed.
+func confirmSkipBlockingDDL(
+	cmd *cobra.Command, changefeedID string, blockingDDLTs uint64,
+) error {
+	cmd.Printf("You are skipping the ddl with commit ts %d of changefeed(%s),"+
+		" the ddl will not be replicated to the downstream.\nConfirm that you know"+
+		" what this command will do and use it at your own risk [Y/N]", blockingDDLTs, changefeedID)
+	confirmed := readYOrN(cmd)
+	if !confirmed {
+		cmd.Printf("Abort changefeed resume.\n")
+		return cerror.ErrCliAborted.FastGenByArgs("cli changefeed resume")
+	}
+
+	return nil
+}
+
 // confirmIgnoreIneligibleTables confirm if user need to ignore ineligible tables.
 // If ignore it will return true.
 func confirmIgnoreIneligibleTables(cmd *cobra.Command) (bool, error) {
@@ -45,6 +45,7 @@ type cfMeta struct {
 	ErrorHis       []int64                   `json:"error_history,omitempty"`
 	CreatorVersion string                    `json:"creator_version"`
 	TaskStatus     []model.CaptureTaskStatus `json:"task_status,omitempty"`
+	Spans          []v2.SpanReplication      `json:"spans,omitempty"`
 }
 
 // queryChangefeedOptions defines flags for the `cli changefeed query` command.
@@ -52,6 +53,7 @@ type queryChangefeedOptions struct {
 	apiClientV2  apiv2client.APIV2Interface
 	changefeedID string
 	simplified   bool
+	verbose      bool
 	namespace    string
 }
 
@@ -65,6 +67,7 @@ func newQueryChangefeedOptions() *queryChangefeedOptions {
 func (o *queryChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&o.namespace, "namespace", "n", "default", "Replication task (changefeed) Namespace")
 	cmd.PersistentFlags().BoolVarP(&o.simplified, "simple", "s", false, "Output simplified replication status")
+	cmd.PersistentFlags().BoolVarP(&o.verbose, "verbose", "v", false, "Output the replication status of all table spans")
 	cmd.PersistentFlags().StringVarP(&o.changefeedID, "changefeed-id", "c", "", "Replication task (changefeed) ID")
 	_ = cmd.MarkPersistentFlagRequired("changefeed-id")
 }
@@ -116,6 +119,13 @@ func (o *queryChangefeedOptions) run(cmd *cobra.Command) error {
 		CreatorVersion: detail.CreatorVersion,
 		TaskStatus:     detail.TaskStatus,
 	}
+	if o.verbose {
+		spans, err := o.apiClientV2.Changefeeds().ListSpans(ctx, o.namespace, o.changefeedID)
+		if err != nil {
+			return err
+		}
+		meta.Spans = spans
+	}
 	return util.JSONPrint(cmd, meta)
 }
 
@@ -37,6 +37,7 @@ type resumeChangefeedOptions struct {
 	changefeedDetail      *v2.ChangeFeedInfo
 	noConfirm             bool
 	overwriteCheckpointTs string
+	skipBlockingDDL       bool
 	currentTso            *v2.Tso
 	checkpointTs          uint64
 
@@ -59,6 +60,8 @@ func (o *resumeChangefeedOptions) addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().BoolVar(&o.noConfirm, "no-confirm", false, "Don't ask user whether to ignore ineligible table")
 	cmd.PersistentFlags().StringVar(&o.overwriteCheckpointTs, "overwrite-checkpoint-ts", "",
 		"Overwrite the changefeed checkpoint ts, should be 'now' or a specified tso value")
+	cmd.PersistentFlags().BoolVar(&o.skipBlockingDDL, "skip-blocking-ddl", false,
+		"Skip the ddl that blocks the changefeed by resuming from its commit ts")
 	cmd.PersistentFlags().StringVar(&o.upstreamPDAddrs, "upstream-pd", "",
 		"upstream PD address, use ',' to separate multiple PDs")
 	cmd.PersistentFlags().StringVar(&o.upstreamCaPath, "upstream-ca", "",
@@ -114,6 +117,7 @@ func (o *resumeChangefeedOptions) getResumeChangefeedConfig() *v2.ResumeChangefe
 	upstreamConfig := o.getUpstreamConfig()
 	return &v2.ResumeChangefeedConfig{
 		OverwriteCheckpointTs: o.checkpointTs,
+		SkipBlockingDDL:       o.skipBlockingDDL,
 		PDConfig:              upstreamConfig.PDConfig,
 	}
 }
@@ -142,6 +146,9 @@ func (o *resumeChangefeedOptions) getChangefeedInfo(ctx context.Context) (
 // confirmResumeChangefeedCheck prompts the user to confirm the use of a large data gap when noConfirm is turned off.
 func (o *resumeChangefeedOptions) confirmResumeChangefeedCheck(cmd *cobra.Command) error {
 	if !o.noConfirm {
+		if o.skipBlockingDDL {
+			return confirmSkipBlockingDDL(cmd, o.changefeedID, o.changefeedDetail.BlockingDDLTs)
+		}
 		if len(o.overwriteCheckpointTs) == 0 {
 			return confirmLargeDataGap(cmd, o.currentTso.Timestamp,
 				o.changefeedDetail.CheckpointTs, "resume")
@@ -166,6 +173,18 @@ func (o *resumeChangefeedOptions) validateParams(ctx context.Context) error {
 	}
 	o.currentTso = tso
 
+	if o.skipBlockingDDL {
+		if len(o.overwriteCheckpointTs) != 0 {
+			return cerror.ErrCliInvalidCheckpointTs.GenWithStack(
+				"--skip-blocking-ddl and --overwrite-checkpoint-ts can not be used together")
+		}
+		if detail.BlockingDDLTs == 0 {
+			return cerror.ErrCliInvalidCheckpointTs.GenWithStack(
+				"changefeed %s is not blocked by any ddl", o.changefeedID)
+		}
+		return nil
+	}
+
 	if len(o.overwriteCheckpointTs) == 0 {
 		return nil
 	}
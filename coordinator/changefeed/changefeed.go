@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/redo"
 	"github.com/pingcap/tiflow/pkg/sink"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -31,15 +32,19 @@ import (
 
 // Changefeed is a memory present for changefeed info and status
 type Changefeed struct {
-	ID          common.ChangeFeedID
-	info        *atomic.Pointer[config.ChangeFeedInfo]
-	isMQSink    bool
-	nodeID      node.ID
-	configBytes []byte
+	ID       common.ChangeFeedID
+	info     *atomic.Pointer[config.ChangeFeedInfo]
+	isMQSink bool
+	nodeID   node.ID
 	// it's saved to the backend db
 	lastSavedCheckpointTs *atomic.Uint64
 	// the heartbeatpb.MaintainerStatus is read only
 	status *atomic.Pointer[heartbeatpb.MaintainerStatus]
+	// resumeHistory is the audit trail of checkpoint overwrites, it's saved to the backend db with the checkpoint
+	resumeHistory *atomic.Pointer[[]*config.ResumeRecord]
+	// configVersion is increased every time the info of the running changefeed is updated,
+	// the update is resent until the maintainer reports the version in its status
+	configVersion *atomic.Uint64
 
 	backoff *Backoff
 }
@@ -53,11 +58,6 @@ func NewChangefeed(cfID common.ChangeFeedID,
 		log.Panic("unable to marshal changefeed config",
 			zap.Error(err))
 	}
-	bytes, err := json.Marshal(info)
-	if err != nil {
-		log.Panic("unable to marshal changefeed config",
-			zap.Error(err))
-	}
 	log.Info("changefeed instance created",
 		zap.String("id", cfID.String()),
 		zap.Uint64("checkpointTs", checkpointTs),
@@ -65,7 +65,6 @@ func NewChangefeed(cfID common.ChangeFeedID,
 	return &Changefeed{
 		ID:                    cfID,
 		info:                  atomic.NewPointer(info),
-		configBytes:           bytes,
 		lastSavedCheckpointTs: atomic.NewUint64(checkpointTs),
 		isMQSink:              sink.IsMQScheme(uri.Scheme),
 		// init the first Status
@@ -74,7 +73,9 @@ func NewChangefeed(cfID common.ChangeFeedID,
 				CheckpointTs: checkpointTs,
 				FeedState:    string(info.State),
 			}),
-		backoff: NewBackoff(cfID, *info.Config.ChangefeedErrorStuckDuration, checkpointTs),
+		resumeHistory: atomic.NewPointer[[]*config.ResumeRecord](nil),
+		configVersion: atomic.NewUint64(0),
+		backoff:       NewBackoff(cfID, *info.Config.ChangefeedErrorStuckDuration, checkpointTs),
 	}
 }
 
@@ -86,6 +87,27 @@ func (c *Changefeed) SetInfo(info *config.ChangeFeedInfo) {
 	c.info.Store(info)
 }
 
+// UpdateRunningInfo replaces the info of the running changefeed and increases the config version
+func (c *Changefeed) UpdateRunningInfo(info *config.ChangeFeedInfo) {
+	c.info.Store(info)
+	c.configVersion.Inc()
+}
+
+func (c *Changefeed) GetConfigVersion() uint64 {
+	return c.configVersion.Load()
+}
+
+// SetConfigVersion sets the config version reported by the working maintainer,
+// it's called when the coordinator is bootstrapped
+func (c *Changefeed) SetConfigVersion(version uint64) {
+	c.configVersion.Store(version)
+}
+
+// IsConfigAcked returns true if the maintainer has received the latest info of the changefeed
+func (c *Changefeed) IsConfigAcked(status *heartbeatpb.MaintainerStatus) bool {
+	return status.ConfigVersion >= c.configVersion.Load()
+}
+
 func (c *Changefeed) StartFinished() {
 	c.backoff.StartFinished()
 }
@@ -117,10 +139,48 @@ func (c *Changefeed) IsMQSink() bool {
 	return c.isMQSink
 }
 
+// NeedCheckpointTsMessage returns true if the checkpointTs should be sent to the table trigger
+// event dispatcher, the mq sinks write it to the downstream and the redo log records it in the redo meta
+func (c *Changefeed) NeedCheckpointTsMessage() bool {
+	consistent := c.GetInfo().Config.Consistent
+	return c.isMQSink || (consistent != nil && redo.IsConsistentEnabled(consistent.Level))
+}
+
 func (c *Changefeed) GetStatus() *heartbeatpb.MaintainerStatus {
 	return c.status.Load()
 }
 
+// ForceUpdateCheckpointTs overwrites the checkpoint ts of the changefeed, it's called when the changefeed
+// is resumed with a new checkpoint ts, the changefeed must be stopped
+func (c *Changefeed) ForceUpdateCheckpointTs(checkpointTs uint64) {
+	c.status.Store(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: checkpointTs,
+		FeedState:    string(c.GetInfo().State),
+	})
+	c.lastSavedCheckpointTs.Store(checkpointTs)
+}
+
+func (c *Changefeed) GetResumeHistory() []*config.ResumeRecord {
+	history := c.resumeHistory.Load()
+	if history == nil {
+		return nil
+	}
+	return *history
+}
+
+func (c *Changefeed) SetResumeHistory(history []*config.ResumeRecord) {
+	c.resumeHistory.Store(&history)
+}
+
+// NewPersistentStatus builds the status that saved to the backend db
+func (c *Changefeed) NewPersistentStatus(checkpointTs uint64, progress config.Progress) *config.ChangeFeedStatus {
+	return &config.ChangeFeedStatus{
+		CheckpointTs:  checkpointTs,
+		Progress:      progress,
+		ResumeHistory: c.GetResumeHistory(),
+	}
+}
+
 func (c *Changefeed) SetLastSavedCheckPointTs(ts uint64) {
 	c.lastSavedCheckpointTs.Store(ts)
 }
@@ -133,12 +193,34 @@ func (c *Changefeed) NewAddMaintainerMessage(server node.ID) *messaging.TargetMe
 	return messaging.NewSingleTargetMessage(server,
 		messaging.MaintainerManagerTopic,
 		&heartbeatpb.AddMaintainerRequest{
-			Id:           c.ID.ToPB(),
-			CheckpointTs: c.GetStatus().CheckpointTs,
-			Config:       c.configBytes,
+			Id:            c.ID.ToPB(),
+			CheckpointTs:  c.GetStatus().CheckpointTs,
+			Config:        c.marshalInfo(),
+			ConfigVersion: c.GetConfigVersion(),
+		})
+}
+
+// NewUpdateMaintainerMessage builds the message to apply the current changefeed info
+// to the running maintainer
+func (c *Changefeed) NewUpdateMaintainerMessage() *messaging.TargetMessage {
+	return messaging.NewSingleTargetMessage(c.nodeID,
+		messaging.MaintainerManagerTopic,
+		&heartbeatpb.UpdateMaintainerRequest{
+			ChangefeedID:  c.ID.ToPB(),
+			Config:        c.marshalInfo(),
+			ConfigVersion: c.GetConfigVersion(),
 		})
 }
 
+func (c *Changefeed) marshalInfo() []byte {
+	bytes, err := json.Marshal(c.GetInfo())
+	if err != nil {
+		log.Panic("unable to marshal changefeed config",
+			zap.Error(err))
+	}
+	return bytes
+}
+
 func (c *Changefeed) NewRemoveMaintainerMessage(server node.ID, caseCade, removed bool) *messaging.TargetMessage {
 	return RemoveMaintainerMessage(c.ID, server, caseCade, removed)
 }
@@ -147,8 +229,9 @@ func (c *Changefeed) NewCheckpointTsMessage(ts uint64) *messaging.TargetMessage
 	return messaging.NewSingleTargetMessage(c.nodeID,
 		messaging.MaintainerManagerTopic,
 		&heartbeatpb.CheckpointTsMessage{
-			ChangefeedID: c.ID.ToPB(),
-			CheckpointTs: ts,
+			ChangefeedID:   c.ID.ToPB(),
+			CheckpointTs:   ts,
+			RedoResolvedTs: c.GetStatus().RedoResolvedTs,
 		})
 }
 
@@ -259,6 +259,15 @@ func (db *ChangefeedDB) GetByChangefeedDisplayName(displayName common.ChangeFeed
 	return db.changefeeds[db.changefeedDisplayNames[displayName]]
 }
 
+// IsStopped returns true if the changefeed is in the stopped map
+func (db *ChangefeedDB) IsStopped(id common.ChangeFeedID) bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	_, ok := db.stopped[id]
+	return ok
+}
+
 // Resume moves a changefeed to the absent map, and waiting for scheduling
 func (db *ChangefeedDB) Resume(id common.ChangeFeedID, resetBackoff bool) {
 	db.lock.Lock()
@@ -355,6 +364,7 @@ func (db *ChangefeedDB) ReplaceStoppedChangefeed(cf *config.ChangeFeedInfo) {
 		return
 	}
 	newCf := NewChangefeed(cf.ChangefeedID, cf, oldCf.GetStatus().CheckpointTs)
+	newCf.SetResumeHistory(oldCf.GetResumeHistory())
 	db.stopped[cf.ChangefeedID] = newCf
 }
 
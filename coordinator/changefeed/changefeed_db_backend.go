@@ -26,18 +26,19 @@ type Backend interface {
 	GetAllChangefeeds(ctx context.Context) (map[common.ChangeFeedID]*ChangefeedMetaWrapper, error)
 	// CreateChangefeed saves changefeed info and status to db
 	CreateChangefeed(ctx context.Context, info *config.ChangeFeedInfo) error
-	// UpdateChangefeed updates changefeed info  to db
-	UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, checkpointTs uint64, progress config.Progress) error
+	// UpdateChangefeed updates changefeed info and status to db
+	UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, status *config.ChangeFeedStatus) error
 	// PauseChangefeed persists the pause status to db for a changefeed
 	PauseChangefeed(ctx context.Context, id common.ChangeFeedID) error
 	// DeleteChangefeed removes all related info of a changefeed from db
 	DeleteChangefeed(ctx context.Context, id common.ChangeFeedID) error
 	// SetChangefeedProgress persists the operation progress status to db for a changefeed
 	SetChangefeedProgress(ctx context.Context, id common.ChangeFeedID, progress config.Progress) error
-	// ResumeChangefeed persists the resumed status to db for a changefeed
-	ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64) error
+	// ResumeChangefeed persists the resumed status to db for a changefeed,
+	// the checkpoint and the resume history are overwritten if newCheckpointTs is not 0
+	ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64, resumeHistory []*config.ResumeRecord) error
 	// UpdateChangefeedCheckpointTs persists the checkpoints for changefeeds
-	UpdateChangefeedCheckpointTs(ctx context.Context, cps map[common.ChangeFeedID]uint64) error
+	UpdateChangefeedCheckpointTs(ctx context.Context, statuses map[common.ChangeFeedID]*config.ChangeFeedStatus) error
 }
 
 // ChangefeedMetaWrapper is a wrapper for the changefeed load from the DB
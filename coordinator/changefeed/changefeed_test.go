@@ -0,0 +1,83 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForceUpdateCheckpointTs(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		SinkURI:      "mysql://127.0.0.1:3306",
+		State:        model.StateFailed,
+		Config:       config.GetDefaultReplicaConfig(),
+	}, 10)
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 99, BlockingDdlTs: 100})
+	require.Empty(t, cf.GetResumeHistory())
+
+	// skip the blocking ddl, the reported status is dropped
+	history := config.AppendResumeHistory(cf.GetResumeHistory(), &config.ResumeRecord{
+		ResumeTime:      time.Now(),
+		OldCheckpointTs: 99,
+		NewCheckpointTs: 100,
+		SkippedDDLTs:    100,
+	})
+	cf.SetResumeHistory(history)
+	cf.ForceUpdateCheckpointTs(100)
+	require.Equal(t, uint64(100), cf.GetStatus().CheckpointTs)
+	require.Zero(t, cf.GetStatus().BlockingDdlTs)
+	require.Equal(t, uint64(100), cf.GetLastSavedCheckPointTs())
+
+	status := cf.NewPersistentStatus(120, config.ProgressNone)
+	require.Equal(t, uint64(120), status.CheckpointTs)
+	require.Len(t, status.ResumeHistory, 1)
+	require.Equal(t, uint64(100), status.ResumeHistory[0].SkippedDDLTs)
+
+	// only the latest records are kept
+	for i := 0; i < 20; i++ {
+		history = config.AppendResumeHistory(history, &config.ResumeRecord{NewCheckpointTs: uint64(200 + i)})
+	}
+	require.Len(t, history, 10)
+	require.Equal(t, uint64(219), history[9].NewCheckpointTs)
+}
+
+func TestCheckpointTsMessageWithRedo(t *testing.T) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	info := &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		SinkURI:      "mysql://127.0.0.1:3306",
+		State:        model.StateNormal,
+		Config:       config.GetDefaultReplicaConfig(),
+	}
+	cf := NewChangefeed(cfID, info, 10)
+	require.False(t, cf.NeedCheckpointTsMessage())
+
+	// the redo meta of the mysql sink is updated by the checkpointTs message
+	info.Config.Consistent.Level = "eventual"
+	require.True(t, cf.NeedCheckpointTsMessage())
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{CheckpointTs: 20, RedoResolvedTs: 30})
+	msg := cf.NewCheckpointTsMessage(cf.GetStatus().CheckpointTs)
+	req := msg.Message[0].(*heartbeatpb.CheckpointTsMessage)
+	require.Equal(t, uint64(20), req.CheckpointTs)
+	require.Equal(t, uint64(30), req.RedoResolvedTs)
+}
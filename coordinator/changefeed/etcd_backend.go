@@ -166,16 +166,12 @@ func (b *EtcdBackend) CreateChangefeed(ctx context.Context,
 	return nil
 }
 
-func (b *EtcdBackend) UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, checkpointTs uint64, progress config.Progress) error {
+func (b *EtcdBackend) UpdateChangefeed(ctx context.Context, info *config.ChangeFeedInfo, status *config.ChangeFeedStatus) error {
 	infoKey := etcd.GetEtcdKeyChangeFeedInfo(b.etcdClient.GetClusterID(), info.ChangefeedID.DisplayName)
 	newStr, err := info.Marshal()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	status := &config.ChangeFeedStatus{
-		CheckpointTs: checkpointTs,
-		Progress:     progress,
-	}
 	statusStr, err := status.Marshal()
 	if err != nil {
 		return errors.Trace(err)
@@ -254,7 +250,7 @@ func (b *EtcdBackend) DeleteChangefeed(ctx context.Context,
 }
 
 func (b *EtcdBackend) ResumeChangefeed(ctx context.Context,
-	id common.ChangeFeedID, newCheckpointTs uint64) error {
+	id common.ChangeFeedID, newCheckpointTs uint64, resumeHistory []*config.ResumeRecord) error {
 	info, err := b.etcdClient.GetChangeFeedInfo(ctx, id.DisplayName)
 	if err != nil {
 		return errors.Trace(err)
@@ -274,6 +270,7 @@ func (b *EtcdBackend) ResumeChangefeed(ctx context.Context,
 			return errors.Trace(err)
 		}
 		status.CheckpointTs = newCheckpointTs
+		status.ResumeHistory = resumeHistory
 		jobValue, err := status.Marshal()
 		if err != nil {
 			return errors.Trace(err)
@@ -318,7 +315,7 @@ func (b *EtcdBackend) SetChangefeedProgress(ctx context.Context, id common.Chang
 	return nil
 }
 
-func (b *EtcdBackend) UpdateChangefeedCheckpointTs(ctx context.Context, cps map[common.ChangeFeedID]uint64) error {
+func (b *EtcdBackend) UpdateChangefeedCheckpointTs(ctx context.Context, statuses map[common.ChangeFeedID]*config.ChangeFeedStatus) error {
 	opsThen := make([]clientv3.Op, 0, 128)
 	batchSize := 0
 
@@ -333,8 +330,7 @@ func (b *EtcdBackend) UpdateChangefeedCheckpointTs(ctx context.Context, cps map[
 		}
 		return err
 	}
-	for cfID, checkpointTs := range cps {
-		status := &config.ChangeFeedStatus{CheckpointTs: checkpointTs, Progress: config.ProgressNone}
+	for cfID, status := range statuses {
 		jobValue, err := status.Marshal()
 		if err != nil {
 			return errors.Trace(err)
@@ -67,9 +67,31 @@ type Controller struct {
 
 	lastPrintStatusTime time.Time
 
+	// the move table requests submitted by the api
+	tableMoves struct {
+		sync.Mutex
+		m map[common.ChangeFeedID]map[int64]*tableMove
+	}
+
 	apiLock sync.RWMutex
 }
 
+// tableMove tracks the progress of a move table request
+type tableMove struct {
+	target     node.ID
+	submitTime time.Time
+	// accepted is true once the maintainer reports the table as pinned to the target node
+	accepted bool
+	// finished is true once the maintainer reports all spans of the table are moved to the target node
+	finished bool
+	// unpinned is true if the table is unpinned by the maintainer after it's accepted,
+	// since the target node is unavailable or the table is removed
+	unpinned bool
+}
+
+// moveTableAcceptTimeout is the max duration to wait for the maintainer to accept a move table request
+const moveTableAcceptTimeout = 30 * time.Second
+
 type ChangefeedStateChangeEvent struct {
 	ChangefeedID common.ChangeFeedID
 	State        model.FeedState
@@ -107,6 +129,7 @@ func NewController(
 		stateChangedCh:      stateChangedCh,
 		lastPrintStatusTime: time.Now(),
 	}
+	c.tableMoves.m = make(map[common.ChangeFeedID]map[int64]*tableMove)
 	c.bootstrapper = bootstrap.NewBootstrapper[heartbeatpb.CoordinatorBootstrapResponse]("coordinator", c.newBootstrapMessage)
 	// init bootstrapper nodes
 	nodes := c.nodeManager.GetAliveNodes()
@@ -156,6 +179,10 @@ func (c *Controller) HandleEvent(event *Event) bool {
 func (c *Controller) onPeriodTask() {
 	// resend bootstrap message
 	c.sendMessages(c.bootstrapper.ResendBootstrapMessage())
+	// resend drain node requests, so the new nodes know the draining nodes
+	for _, id := range c.nodeManager.GetDrainingNodes() {
+		c.sendMessages(c.newDrainNodeMessages(id))
+	}
 	c.collectMetrics()
 }
 
@@ -252,6 +279,7 @@ func (c *Controller) HandleStatus(from node.ID, statusList []*heartbeatpb.Mainta
 	for _, status := range statusList {
 		cfID := common.NewChangefeedIDFromPB(status.ChangefeedID)
 		c.operatorController.UpdateOperatorStatus(cfID, from, status)
+		c.updateTableMoves(cfID, status.PinnedTables)
 		cf := c.GetTask(cfID)
 		if cf == nil {
 			if status.State != heartbeatpb.ComponentState_Working {
@@ -280,6 +308,14 @@ func (c *Controller) HandleStatus(from node.ID, statusList []*heartbeatpb.Mainta
 				zap.Stringer("node", nodeID))
 			continue
 		}
+		if status.State == heartbeatpb.ComponentState_Working && !cf.IsConfigAcked(status) {
+			// the update maintainer request may be lost, resend it until the maintainer acks it
+			log.Info("changefeed config is not acked by the maintainer, resend it",
+				zap.String("changefeed", cfID.Name()),
+				zap.Uint64("ackedVersion", status.ConfigVersion),
+				zap.Uint64("version", cf.GetConfigVersion()))
+			_ = c.messageCenter.SendCommand(cf.NewUpdateMaintainerMessage())
+		}
 		changed, state, err := cf.UpdateStatus(status)
 		if changed {
 			log.Info("changefeed status changed",
@@ -325,6 +361,7 @@ func (c *Controller) FinishBootstrap(workingMap map[common.ChangeFeedID]remoteMa
 		rm, ok := workingMap[cfID]
 		if !ok {
 			cf := changefeed.NewChangefeed(cfID, cfMeta.Info, cfMeta.Status.CheckpointTs)
+			cf.SetResumeHistory(cfMeta.Status.ResumeHistory)
 			if shouldRunChangefeed(cf.GetInfo().State) {
 				c.changefeedDB.AddAbsentChangefeed(cf)
 			} else {
@@ -334,6 +371,9 @@ func (c *Controller) FinishBootstrap(workingMap map[common.ChangeFeedID]remoteMa
 			log.Info("maintainer already working in other server",
 				zap.String("changefeed", cfID.String()))
 			cf := changefeed.NewChangefeed(cfID, cfMeta.Info, rm.status.CheckpointTs)
+			cf.SetResumeHistory(cfMeta.Status.ResumeHistory)
+			// the version is not persisted, continue with the one acked by the working maintainer
+			cf.SetConfigVersion(rm.status.ConfigVersion)
 			c.changefeedDB.AddReplicatingMaintainer(cf, rm.nodeID)
 			// delete it
 			delete(workingMap, cfID)
@@ -402,6 +442,9 @@ func (c *Controller) RemoveChangefeed(ctx context.Context, id common.ChangeFeedI
 		return 0, errors.Trace(err)
 	}
 	c.operatorController.StopChangefeed(ctx, id, true)
+	c.tableMoves.Lock()
+	delete(c.tableMoves.m, id)
+	c.tableMoves.Unlock()
 	return cf.GetStatus().CheckpointTs, nil
 }
 
@@ -426,7 +469,10 @@ func (c *Controller) PauseChangefeed(ctx context.Context, id common.ChangeFeedID
 	return nil
 }
 
-func (c *Controller) ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64) error {
+// ResumeChangefeed resumes a stopped changefeed, the checkpoint ts is overwritten if newCheckpointTs is not 0
+// and differs from the current checkpoint, the overwrite is recorded in the resume history of the changefeed.
+// If skipBlockingDDL is true, newCheckpointTs must be the commit ts of the ddl that blocks the changefeed
+func (c *Controller) ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64, skipBlockingDDL bool) error {
 	c.apiLock.Lock()
 	defer c.apiLock.Unlock()
 
@@ -434,9 +480,42 @@ func (c *Controller) ResumeChangefeed(ctx context.Context, id common.ChangeFeedI
 	if cf == nil {
 		return errors.New("changefeed not found")
 	}
-	if err := c.backend.ResumeChangefeed(ctx, id, newCheckpointTs); err != nil {
+	status := cf.GetStatus()
+	if skipBlockingDDL && (status.BlockingDdlTs == 0 || status.BlockingDdlTs != newCheckpointTs) {
+		return cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"the blocking ddl of the changefeed is changed, please retry")
+	}
+	resumeHistory := cf.GetResumeHistory()
+	overwritten := newCheckpointTs != 0 && newCheckpointTs != status.CheckpointTs
+	if overwritten {
+		switch cf.GetInfo().State {
+		case model.StateStopped, model.StateFailed:
+		default:
+			return cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+				"can only overwrite the checkpoint when the changefeed is stopped or failed")
+		}
+		record := &config.ResumeRecord{
+			ResumeTime:      time.Now(),
+			OldCheckpointTs: status.CheckpointTs,
+			NewCheckpointTs: newCheckpointTs,
+		}
+		if skipBlockingDDL {
+			record.SkippedDDLTs = status.BlockingDdlTs
+		}
+		resumeHistory = config.AppendResumeHistory(resumeHistory, record)
+		log.Info("resume changefeed with a new checkpoint ts",
+			zap.String("changefeed", id.Name()),
+			zap.Uint64("oldCheckpointTs", status.CheckpointTs),
+			zap.Uint64("newCheckpointTs", newCheckpointTs),
+			zap.Uint64("skippedDDLTs", record.SkippedDDLTs))
+	}
+	if err := c.backend.ResumeChangefeed(ctx, id, newCheckpointTs, resumeHistory); err != nil {
 		return errors.Trace(err)
 	}
+	if overwritten {
+		cf.SetResumeHistory(resumeHistory)
+		cf.ForceUpdateCheckpointTs(newCheckpointTs)
+	}
 	if clone, err := cf.GetInfo().Clone(); err != nil {
 		return errors.Trace(err)
 	} else {
@@ -455,13 +534,39 @@ func (c *Controller) UpdateChangefeed(ctx context.Context, change *config.Change
 	if cf == nil {
 		return errors.New("changefeed not found")
 	}
-	if err := c.backend.UpdateChangefeed(ctx, change, cf.GetStatus().CheckpointTs, config.ProgressStopping); err != nil {
+	if !c.changefeedDB.IsStopped(change.ChangefeedID) {
+		return c.updateRunningChangefeed(ctx, cf, change)
+	}
+	if err := c.backend.UpdateChangefeed(ctx, change, cf.NewPersistentStatus(cf.GetStatus().CheckpointTs, config.ProgressStopping)); err != nil {
 		return errors.Trace(err)
 	}
 	c.changefeedDB.ReplaceStoppedChangefeed(change)
 	return nil
 }
 
+// updateRunningChangefeed applies the change to a running changefeed in place,
+// the maintainer diffs the new info and reconfigures the spans and the sinks.
+func (c *Controller) updateRunningChangefeed(ctx context.Context, cf *changefeed.Changefeed, change *config.ChangeFeedInfo) error {
+	if !config.IsLiveUpdatable(cf.GetInfo(), change) {
+		return cerror.ErrChangefeedUpdateRefused.GenWithStackByArgs(
+			"the change can not be applied to a running changefeed, please pause it first")
+	}
+	if err := c.backend.UpdateChangefeed(ctx, change, cf.NewPersistentStatus(cf.GetStatus().CheckpointTs, config.ProgressNone)); err != nil {
+		return errors.Trace(err)
+	}
+	cf.UpdateRunningInfo(change)
+	log.Info("update running changefeed",
+		zap.String("changefeed", cf.ID.Name()),
+		zap.Stringer("node", cf.GetNodeID()),
+		zap.Uint64("version", cf.GetConfigVersion()))
+	// if the maintainer is not created yet, it will be created with the new info,
+	// otherwise the request is resent until the maintainer acks it in the status
+	if cf.GetNodeID() != "" {
+		_ = c.messageCenter.SendCommand(cf.NewUpdateMaintainerMessage())
+	}
+	return nil
+}
+
 func (c *Controller) ListChangefeeds(_ context.Context) ([]*config.ChangeFeedInfo, []*config.ChangeFeedStatus, error) {
 	c.apiLock.RLock()
 	defer c.apiLock.RUnlock()
@@ -484,7 +589,193 @@ func (c *Controller) GetChangefeed(_ context.Context, changefeedDisplayName comm
 	if cf == nil {
 		return nil, nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedDisplayName.Name)
 	}
-	return cf.GetInfo(), &config.ChangeFeedStatus{CheckpointTs: cf.GetStatus().CheckpointTs}, nil
+	status := cf.GetStatus()
+	return cf.GetInfo(), &config.ChangeFeedStatus{
+		CheckpointTs:  status.CheckpointTs,
+		ResumeHistory: cf.GetResumeHistory(),
+		BlockingDDLTs: status.BlockingDdlTs,
+	}, nil
+}
+
+// GetMaintainerNode returns the node that the maintainer of the changefeed is running on
+func (c *Controller) GetMaintainerNode(_ context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*node.Info, error) {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	cf := c.changefeedDB.GetByChangefeedDisplayName(changefeedDisplayName)
+	if cf == nil {
+		return nil, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedDisplayName.Name)
+	}
+	info, ok := c.nodeManager.GetAliveNodes()[cf.GetNodeID()]
+	if !ok {
+		return nil, cerror.ErrSchedulerRequestFailed.GenWithStackByArgs("changefeed maintainer is not scheduled")
+	}
+	return info, nil
+}
+
+// DrainNode marks the node as draining, and notifies all nodes to move the maintainers and table spans out of it.
+// it returns the number of maintainers and table spans still running on the node, the caller can call it repeatedly
+// until both of them are 0.
+func (c *Controller) DrainNode(_ context.Context, target node.ID) (int, int, error) {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	if !c.bootstrapped.Load() {
+		return 0, 0, errors.New("not initialized, wait a moment")
+	}
+	if _, ok := c.nodeManager.GetAliveNodes()[target]; !ok {
+		return 0, 0, cerror.ErrCaptureNotExist.GenWithStackByArgs(target)
+	}
+	schedulableNodes := c.nodeManager.GetSchedulableNodes()
+	delete(schedulableNodes, target)
+	if len(schedulableNodes) == 0 {
+		return 0, 0, cerror.ErrSchedulerRequestFailed.GenWithStackByArgs("no other node is available to take over the tasks")
+	}
+	if !c.nodeManager.IsNodeDraining(target) {
+		log.Info("drain node", zap.Stringer("node", target))
+		c.nodeManager.DrainNode(target)
+		c.sendMessages(c.newDrainNodeMessages(target))
+	}
+
+	maintainerCount := c.changefeedDB.GetTaskSizePerNode()[target]
+	tableCount := 0
+	for _, cf := range c.changefeedDB.GetAllChangefeeds() {
+		if cf.GetNodeID() == "" {
+			continue
+		}
+		for _, count := range cf.GetStatus().SpanCounts {
+			if node.ID(count.NodeId) == target {
+				tableCount += int(count.Count)
+			}
+		}
+	}
+	return maintainerCount, tableCount, nil
+}
+
+// UndrainNode cancels the draining of the node, and notifies all nodes that the tasks can be scheduled to it again.
+func (c *Controller) UndrainNode(_ context.Context, target node.ID) error {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	if !c.bootstrapped.Load() {
+		return errors.New("not initialized, wait a moment")
+	}
+	if _, ok := c.nodeManager.GetAliveNodes()[target]; !ok {
+		return cerror.ErrCaptureNotExist.GenWithStackByArgs(target)
+	}
+	if c.nodeManager.UndrainNode(target) {
+		log.Info("undrain node", zap.Stringer("node", target))
+	}
+	// always notify the nodes, in case the previous undrain requests are lost
+	c.sendMessages(c.newUndrainNodeMessages(target))
+	return nil
+}
+
+// MoveTable moves all spans of the table to the target node, the caller can call it repeatedly
+// until it returns true, which means the table is moved.
+func (c *Controller) MoveTable(_ context.Context, changefeedDisplayName common.ChangeFeedDisplayName, tableID int64, target node.ID) (bool, error) {
+	c.apiLock.RLock()
+	defer c.apiLock.RUnlock()
+
+	cf := c.changefeedDB.GetByChangefeedDisplayName(changefeedDisplayName)
+	if cf == nil {
+		return false, cerror.ErrChangeFeedNotExists.GenWithStackByArgs(changefeedDisplayName.Name)
+	}
+	maintainerNode := cf.GetNodeID()
+	if maintainerNode == "" {
+		return false, cerror.ErrSchedulerRequestFailed.GenWithStackByArgs("changefeed is not running")
+	}
+	if _, ok := c.nodeManager.GetSchedulableNodes()[target]; !ok {
+		return false, cerror.ErrCaptureNotExist.GenWithStackByArgs(target)
+	}
+
+	c.tableMoves.Lock()
+	defer c.tableMoves.Unlock()
+	moves, ok := c.tableMoves.m[cf.ID]
+	if !ok {
+		moves = make(map[int64]*tableMove)
+		c.tableMoves.m[cf.ID] = moves
+	}
+	if mv, ok := moves[tableID]; ok && mv.target == target {
+		if mv.finished {
+			delete(moves, tableID)
+			return true, nil
+		}
+		if mv.unpinned {
+			delete(moves, tableID)
+			return false, cerror.ErrSchedulerRequestFailed.GenWithStackByArgs(
+				"move table request is canceled by the maintainer, since the target node is unavailable or the table is removed")
+		}
+		if mv.accepted {
+			return false, nil
+		}
+		if time.Since(mv.submitTime) > moveTableAcceptTimeout {
+			delete(moves, tableID)
+			return false, cerror.ErrSchedulerRequestFailed.GenWithStackByArgs(
+				"move table request is not accepted by the maintainer, please check the table id")
+		}
+	} else {
+		log.Info("move table",
+			zap.String("changefeed", cf.ID.Name()),
+			zap.Int64("table", tableID),
+			zap.Stringer("target", target))
+		moves[tableID] = &tableMove{target: target, submitTime: time.Now()}
+	}
+	// the request is resent until the maintainer accepts it
+	_ = c.messageCenter.SendCommand(messaging.NewSingleTargetMessage(maintainerNode,
+		messaging.MaintainerManagerTopic,
+		&heartbeatpb.MoveTableRequest{
+			ChangefeedID: cf.ID.ToPB(),
+			TableId:      tableID,
+			TargetNodeId: target.String(),
+		}))
+	return false, nil
+}
+
+// updateTableMoves updates the move table progress by the pinned tables reported by the maintainer
+func (c *Controller) updateTableMoves(cfID common.ChangeFeedID, pinnedTables []*heartbeatpb.PinnedTable) {
+	c.tableMoves.Lock()
+	defer c.tableMoves.Unlock()
+
+	moves, ok := c.tableMoves.m[cfID]
+	if !ok {
+		return
+	}
+	pinned := make(map[int64]*heartbeatpb.PinnedTable, len(pinnedTables))
+	for _, t := range pinnedTables {
+		pinned[t.TableId] = t
+	}
+	for tableID, mv := range moves {
+		t, ok := pinned[tableID]
+		if ok && node.ID(t.TargetNodeId) == mv.target {
+			mv.accepted = true
+			mv.finished = !t.Moving
+		} else if mv.accepted {
+			mv.unpinned = true
+		}
+	}
+}
+
+// newDrainNodeMessages returns the drain node requests sent to all alive nodes
+func (c *Controller) newDrainNodeMessages(target node.ID) []*messaging.TargetMessage {
+	var msgs []*messaging.TargetMessage
+	for id := range c.nodeManager.GetAliveNodes() {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(id,
+			messaging.MaintainerManagerTopic,
+			&heartbeatpb.DrainNodeRequest{NodeId: target.String()}))
+	}
+	return msgs
+}
+
+// newUndrainNodeMessages returns the undrain node requests sent to all alive nodes
+func (c *Controller) newUndrainNodeMessages(target node.ID) []*messaging.TargetMessage {
+	var msgs []*messaging.TargetMessage
+	for id := range c.nodeManager.GetAliveNodes() {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(id,
+			messaging.MaintainerManagerTopic,
+			&heartbeatpb.DrainNodeRequest{NodeId: target.String(), Undrain: true}))
+	}
+	return msgs
 }
 
 // GetTask queries a task by channgefeed ID, return nil if not found
@@ -0,0 +1,212 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coordinator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/coordinator/changefeed"
+	"github.com/pingcap/ticdc/coordinator/operator"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+// newControllerForTest creates a bootstrapped controller with a changefeed whose maintainer is on the first node
+func newControllerForTest(nodes ...node.ID) (*Controller, *changefeed.Changefeed) {
+	selfNode := node.NewInfo("127.0.0.1:8300", "")
+	mc := messaging.NewMessageCenter(context.Background(), selfNode.ID, 100, config.NewDefaultMessageCenterConfig(), nil)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	for _, id := range nodes {
+		nodeManager.GetAliveNodes()[id] = &node.Info{ID: id}
+	}
+	c := &Controller{
+		bootstrapped:  atomic.NewBool(true),
+		messageCenter: mc,
+		changefeedDB:  changefeed.NewChangefeedDB(),
+		nodeManager:   nodeManager,
+	}
+	c.tableMoves.m = make(map[common.ChangeFeedID]map[int64]*tableMove)
+
+	cfID := common.NewChangeFeedIDWithName("test")
+	cf := changefeed.NewChangefeed(cfID, &config.ChangeFeedInfo{
+		ChangefeedID: cfID,
+		SinkURI:      "mysql://127.0.0.1:3306",
+		State:        model.StateNormal,
+		Config:       config.GetDefaultReplicaConfig(),
+	}, 10)
+	c.changefeedDB.AddReplicatingMaintainer(cf, nodes[0])
+	return c, cf
+}
+
+func TestControllerDrainNode(t *testing.T) {
+	ctx := context.Background()
+	c, cf := newControllerForTest("node1", "node2")
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 10,
+		SpanCounts: []*heartbeatpb.NodeSpanCount{
+			{NodeId: "node1", Count: 3},
+			{NodeId: "node2", Count: 1},
+		},
+	})
+
+	_, _, err := c.DrainNode(ctx, "node3")
+	require.Error(t, err)
+
+	maintainerCount, tableCount, err := c.DrainNode(ctx, "node1")
+	require.NoError(t, err)
+	require.Equal(t, 1, maintainerCount)
+	require.Equal(t, 3, tableCount)
+	require.True(t, c.nodeManager.IsNodeDraining("node1"))
+	// the last schedulable node can't be drained
+	_, _, err = c.DrainNode(ctx, "node2")
+	require.Error(t, err)
+
+	// the tables are moved out of the draining node
+	cf.UpdateStatus(&heartbeatpb.MaintainerStatus{
+		CheckpointTs: 11,
+		SpanCounts: []*heartbeatpb.NodeSpanCount{
+			{NodeId: "node2", Count: 4},
+		},
+	})
+	maintainerCount, tableCount, err = c.DrainNode(ctx, "node1")
+	require.NoError(t, err)
+	require.Equal(t, 1, maintainerCount)
+	require.Equal(t, 0, tableCount)
+
+	require.Error(t, c.UndrainNode(ctx, "node3"))
+	require.NoError(t, c.UndrainNode(ctx, "node1"))
+	require.False(t, c.nodeManager.IsNodeDraining("node1"))
+	// undrain is idempotent
+	require.NoError(t, c.UndrainNode(ctx, "node1"))
+	require.Len(t, c.nodeManager.GetSchedulableNodes(), 2)
+}
+
+func TestControllerMoveTable(t *testing.T) {
+	ctx := context.Background()
+	c, cf := newControllerForTest("node1", "node2")
+	displayName := cf.ID.DisplayName
+
+	_, err := c.MoveTable(ctx, common.NewChangeFeedDisplayName("unknown", displayName.Namespace), 1, "node2")
+	require.Error(t, err)
+	_, err = c.MoveTable(ctx, displayName, 1, "node3")
+	require.Error(t, err)
+
+	// the table is moved after the maintainer reports it's not moving any more
+	moved, err := c.MoveTable(ctx, displayName, 1, "node2")
+	require.NoError(t, err)
+	require.False(t, moved)
+	c.updateTableMoves(cf.ID, []*heartbeatpb.PinnedTable{{TableId: 1, TargetNodeId: "node2", Moving: true}})
+	moved, err = c.MoveTable(ctx, displayName, 1, "node2")
+	require.NoError(t, err)
+	require.False(t, moved)
+	c.updateTableMoves(cf.ID, []*heartbeatpb.PinnedTable{{TableId: 1, TargetNodeId: "node2"}})
+	moved, err = c.MoveTable(ctx, displayName, 1, "node2")
+	require.NoError(t, err)
+	require.True(t, moved)
+
+	// the table pinned to another node is not treated as moved
+	moved, err = c.MoveTable(ctx, displayName, 1, "node1")
+	require.NoError(t, err)
+	require.False(t, moved)
+	c.updateTableMoves(cf.ID, []*heartbeatpb.PinnedTable{{TableId: 1, TargetNodeId: "node2"}})
+	moved, err = c.MoveTable(ctx, displayName, 1, "node1")
+	require.NoError(t, err)
+	require.False(t, moved)
+
+	// the request is failed if the maintainer doesn't accept it in time
+	c.tableMoves.m[cf.ID][1].submitTime = time.Now().Add(-moveTableAcceptTimeout)
+	_, err = c.MoveTable(ctx, displayName, 1, "node1")
+	require.Error(t, err)
+
+	// the request is failed if the table is unpinned by the maintainer
+	moved, err = c.MoveTable(ctx, displayName, 2, "node2")
+	require.NoError(t, err)
+	require.False(t, moved)
+	c.updateTableMoves(cf.ID, []*heartbeatpb.PinnedTable{{TableId: 2, TargetNodeId: "node2", Moving: true}})
+	c.updateTableMoves(cf.ID, nil)
+	_, err = c.MoveTable(ctx, displayName, 2, "node2")
+	require.Error(t, err)
+}
+
+// recordMessageCenter records the commands sent by the controller
+type recordMessageCenter struct {
+	messaging.MessageCenter
+	commands []*messaging.TargetMessage
+}
+
+func (m *recordMessageCenter) SendCommand(msg *messaging.TargetMessage) error {
+	m.commands = append(m.commands, msg)
+	return nil
+}
+
+func TestControllerUpdateRunningChangefeed(t *testing.T) {
+	ctx := context.Background()
+	c, cf := newControllerForTest("node1", "node2")
+	mc := &recordMessageCenter{MessageCenter: c.messageCenter}
+	backend := &mockBackend{changefeeds: make(map[common.ChangeFeedID]*changefeed.ChangefeedMetaWrapper)}
+	c.messageCenter = mc
+	c.backend = backend
+	c.operatorController = operator.NewOperatorController(mc, node.NewInfo("127.0.0.1:8300", ""), c.changefeedDB, backend, 10)
+
+	// the sink uri can't be changed for a running changefeed
+	change, err := cf.GetInfo().Clone()
+	require.NoError(t, err)
+	change.SinkURI = "mysql://127.0.0.1:3307"
+	require.Error(t, c.UpdateChangefeed(ctx, change))
+	require.Equal(t, uint64(0), cf.GetConfigVersion())
+	require.Empty(t, mc.commands)
+
+	change, err = cf.GetInfo().Clone()
+	require.NoError(t, err)
+	change.Config.Filter.Rules = []string{"test.*"}
+	require.NoError(t, c.UpdateChangefeed(ctx, change))
+	require.Equal(t, uint64(1), cf.GetConfigVersion())
+	require.Equal(t, []string{"test.*"}, cf.GetInfo().Config.Filter.Rules)
+	require.Len(t, mc.commands, 1)
+	require.Equal(t, node.ID("node1"), mc.commands[0].To)
+	req := mc.commands[0].Message[0].(*heartbeatpb.UpdateMaintainerRequest)
+	require.Equal(t, uint64(1), req.ConfigVersion)
+
+	// the request is resent until the maintainer acks the version
+	c.HandleStatus("node1", []*heartbeatpb.MaintainerStatus{{
+		ChangefeedID: cf.ID.ToPB(),
+		State:        heartbeatpb.ComponentState_Working,
+		CheckpointTs: 10,
+	}})
+	require.Len(t, mc.commands, 2)
+	require.Equal(t, uint64(1), mc.commands[1].Message[0].(*heartbeatpb.UpdateMaintainerRequest).ConfigVersion)
+	c.HandleStatus("node1", []*heartbeatpb.MaintainerStatus{{
+		ChangefeedID:  cf.ID.ToPB(),
+		State:         heartbeatpb.ComponentState_Working,
+		CheckpointTs:  10,
+		ConfigVersion: 1,
+	}})
+	require.Len(t, mc.commands, 2)
+	// the status from the node which doesn't own the maintainer is ignored
+	c.HandleStatus("node2", []*heartbeatpb.MaintainerStatus{{
+		ChangefeedID: cf.ID.ToPB(),
+		State:        heartbeatpb.ComponentState_Working,
+		CheckpointTs: 10,
+	}})
+	require.Len(t, mc.commands, 2)
+}
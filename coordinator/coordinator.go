@@ -149,7 +149,7 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 	if event.State == model.StateFailed || event.State == model.StateFinished {
 		progress = config.ProgressStopping
 	}
-	if err := c.backend.UpdateChangefeed(context.Background(), cfInfo, cf.GetStatus().CheckpointTs, progress); err != nil {
+	if err := c.backend.UpdateChangefeed(context.Background(), cfInfo, cf.NewPersistentStatus(cf.GetStatus().CheckpointTs, progress)); err != nil {
 		log.Error("failed to update changefeed state",
 			zap.Error(err))
 		return errors.Trace(err)
@@ -168,11 +168,11 @@ func (c *coordinator) handleStateChangedEvent(ctx context.Context, event *Change
 }
 
 func (c *coordinator) saveCheckpointTs(ctx context.Context, cfs map[common.ChangeFeedID]*changefeed.Changefeed) error {
-	statusMap := make(map[common.ChangeFeedID]uint64)
+	statusMap := make(map[common.ChangeFeedID]*config.ChangeFeedStatus)
 	for _, upCf := range cfs {
 		reportedCheckpointTs := upCf.GetStatus().CheckpointTs
 		if upCf.GetLastSavedCheckPointTs() < reportedCheckpointTs {
-			statusMap[upCf.ID] = reportedCheckpointTs
+			statusMap[upCf.ID] = upCf.NewPersistentStatus(reportedCheckpointTs, config.ProgressNone)
 		}
 	}
 	if len(statusMap) == 0 {
@@ -184,13 +184,13 @@ func (c *coordinator) saveCheckpointTs(ctx context.Context, cfs map[common.Chang
 		return errors.Trace(err)
 	}
 	// update the last saved checkpoint ts and send checkpointTs to maintainer
-	for id, cp := range statusMap {
+	for id, status := range statusMap {
 		cf, ok := cfs[id]
 		if !ok {
 			continue
 		}
-		cf.SetLastSavedCheckPointTs(cp)
-		if cf.IsMQSink() {
+		cf.SetLastSavedCheckPointTs(status.CheckpointTs)
+		if cf.NeedCheckpointTsMessage() {
 			msg := cf.NewCheckpointTsMessage(cf.GetLastSavedCheckPointTs())
 			c.sendMessages([]*messaging.TargetMessage{msg})
 		}
@@ -210,8 +210,8 @@ func (c *coordinator) PauseChangefeed(ctx context.Context, id common.ChangeFeedI
 	return c.controller.PauseChangefeed(ctx, id)
 }
 
-func (c *coordinator) ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64) error {
-	return c.controller.ResumeChangefeed(ctx, id, newCheckpointTs)
+func (c *coordinator) ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64, skipBlockingDDL bool) error {
+	return c.controller.ResumeChangefeed(ctx, id, newCheckpointTs, skipBlockingDDL)
 }
 
 func (c *coordinator) UpdateChangefeed(ctx context.Context, change *config.ChangeFeedInfo) error {
@@ -226,6 +226,22 @@ func (c *coordinator) GetChangefeed(ctx context.Context, changefeedDisplayName c
 	return c.controller.GetChangefeed(ctx, changefeedDisplayName)
 }
 
+func (c *coordinator) GetMaintainerNode(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*node.Info, error) {
+	return c.controller.GetMaintainerNode(ctx, changefeedDisplayName)
+}
+
+func (c *coordinator) DrainNode(ctx context.Context, target node.ID) (int, int, error) {
+	return c.controller.DrainNode(ctx, target)
+}
+
+func (c *coordinator) UndrainNode(ctx context.Context, target node.ID) error {
+	return c.controller.UndrainNode(ctx, target)
+}
+
+func (c *coordinator) MoveTable(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName, tableID int64, target node.ID) (bool, error) {
+	return c.controller.MoveTable(ctx, changefeedDisplayName, tableID, target)
+}
+
 func shouldRunChangefeed(state model.FeedState) bool {
 	switch state {
 	case model.StateStopped, model.StateFailed, model.StateFinished:
@@ -228,7 +228,7 @@ func TestCoordinatorScheduling(t *testing.T) {
 	info := node.NewInfo("127.0.0.1:8300", "")
 	nodeManager.GetAliveNodes()[info.ID] = info
 	mc := messaging.NewMessageCenter(ctx,
-		info.ID, 100, config.NewDefaultMessageCenterConfig())
+		info.ID, 100, config.NewDefaultMessageCenterConfig(), nil)
 	appcontext.SetService(appcontext.MessageCenter, mc)
 	m := NewMaintainerManager(mc)
 	go m.Run(ctx)
@@ -287,7 +287,7 @@ func TestScaleNode(t *testing.T) {
 	appcontext.SetService(watcher.NodeManagerName, nodeManager)
 	info := node.NewInfo("127.0.0.1:8300", "")
 	nodeManager.GetAliveNodes()[info.ID] = info
-	mc1 := messaging.NewMessageCenter(ctx, info.ID, 0, config.NewDefaultMessageCenterConfig())
+	mc1 := messaging.NewMessageCenter(ctx, info.ID, 0, config.NewDefaultMessageCenterConfig(), nil)
 	appcontext.SetService(appcontext.MessageCenter, mc1)
 	startMaintainerNode(ctx, info, mc1, nodeManager)
 
@@ -321,10 +321,10 @@ func TestScaleNode(t *testing.T) {
 
 	// add two nodes
 	info2 := node.NewInfo("127.0.0.1:8400", "")
-	mc2 := messaging.NewMessageCenter(ctx, info2.ID, 0, config.NewDefaultMessageCenterConfig())
+	mc2 := messaging.NewMessageCenter(ctx, info2.ID, 0, config.NewDefaultMessageCenterConfig(), nil)
 	startMaintainerNode(ctx, info2, mc2, nodeManager)
 	info3 := node.NewInfo("127.0.0.1:8500", "")
-	mc3 := messaging.NewMessageCenter(ctx, info3.ID, 0, config.NewDefaultMessageCenterConfig())
+	mc3 := messaging.NewMessageCenter(ctx, info3.ID, 0, config.NewDefaultMessageCenterConfig(), nil)
 	startMaintainerNode(ctx, info3, mc3, nodeManager)
 	// notify node changes
 	_, _ = nodeManager.Tick(ctx, &orchestrator.GlobalReactorState{
@@ -357,7 +357,7 @@ func TestBootstrapWithUnStoppedChangefeed(t *testing.T) {
 	appcontext.SetService(watcher.NodeManagerName, nodeManager)
 	info := node.NewInfo("127.0.0.1:8700", "")
 	nodeManager.GetAliveNodes()[info.ID] = info
-	mc1 := messaging.NewMessageCenter(ctx, info.ID, 0, config.NewDefaultMessageCenterConfig())
+	mc1 := messaging.NewMessageCenter(ctx, info.ID, 0, config.NewDefaultMessageCenterConfig(), nil)
 	appcontext.SetService(appcontext.MessageCenter, mc1)
 	mNode := startMaintainerNode(ctx, info, mc1, nodeManager)
 
@@ -476,7 +476,7 @@ func (m *mockBackend) GetAllChangefeeds(_ context.Context) (map[common.ChangeFee
 	return m.changefeeds, nil
 }
 
-func (m *mockBackend) UpdateChangefeedCheckpointTs(_ context.Context, _ map[common.ChangeFeedID]uint64) error {
+func (m *mockBackend) UpdateChangefeedCheckpointTs(_ context.Context, _ map[common.ChangeFeedID]*config.ChangeFeedStatus) error {
 	return nil
 }
 
@@ -488,10 +488,14 @@ func (m *mockBackend) DeleteChangefeed(_ context.Context, _ common.ChangeFeedID)
 	return nil
 }
 
-func (m *mockBackend) ResumeChangefeed(_ context.Context, _ common.ChangeFeedID, _ uint64) error {
+func (m *mockBackend) ResumeChangefeed(_ context.Context, _ common.ChangeFeedID, _ uint64, _ []*config.ResumeRecord) error {
 	return nil
 }
 
 func (m *mockBackend) PauseChangefeed(_ context.Context, _ common.ChangeFeedID) error {
 	return nil
 }
+
+func (m *mockBackend) UpdateChangefeed(_ context.Context, _ *config.ChangeFeedInfo, _ *config.ChangeFeedStatus) error {
+	return nil
+}
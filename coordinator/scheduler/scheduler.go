@@ -27,6 +27,7 @@ import (
 // Scheduler generates operators for the maintainers, and push them to the operator controller
 // it generates add operator for the absent maintainers, and move operator for the unbalanced replicating maintainer
 // currently, it only supports balance the maintainers by size
+// it also moves the maintainers out of the draining nodes
 type Scheduler struct {
 	batchSize            int
 	random               *rand.Rand
@@ -70,24 +71,63 @@ func (s *Scheduler) Execute() time.Time {
 			return time.Now().Add(time.Millisecond * 100)
 		}
 		absent, nodeSize := s.changefeedDB.GetWaitingSchedulingChangefeeds(s.absent, availableSize)
-		// add the absent node to the node size map
-		// todo: use the bootstrap nodes
-		for id := range s.nodeManager.GetAliveNodes() {
-			if _, ok := nodeSize[id]; !ok {
-				nodeSize[id] = 0
-			}
-		}
+		s.fillSchedulableNodeSize(nodeSize)
 		scheduler.BasicSchedule(availableSize, absent, nodeSize, func(cf *changefeed.Changefeed, nodeID node.ID) bool {
 			return s.operatorController.AddOperator(operator.NewAddMaintainerOperator(s.changefeedDB, cf, nodeID))
 		})
 
 		s.absent = absent[:0]
-	} else {
+	} else if !s.drain() {
 		s.balance()
 	}
 	return time.Now().Add(time.Millisecond * 500)
 }
 
+// drain moves the maintainers out of the draining nodes, it returns false if there is no draining node
+func (s *Scheduler) drain() bool {
+	drainingNodes := s.nodeManager.GetDrainingNodes()
+	if len(drainingNodes) == 0 {
+		return false
+	}
+	availableSize := s.batchSize - s.operatorController.OperatorSize()
+	if availableSize <= 0 {
+		return true
+	}
+	nodeSize := s.changefeedDB.GetTaskSizePerNode()
+	var victims []*changefeed.Changefeed
+	for _, id := range drainingNodes {
+		if nodeSize[id] > 0 {
+			victims = append(victims, s.changefeedDB.GetByNodeID(id)...)
+		}
+	}
+	if len(victims) == 0 {
+		return true
+	}
+	s.fillSchedulableNodeSize(nodeSize)
+	scheduler.BasicSchedule(availableSize, victims, nodeSize, func(cf *changefeed.Changefeed, nodeID node.ID) bool {
+		return s.operatorController.AddOperator(operator.NewMoveMaintainerOperator(s.changefeedDB, cf, cf.GetNodeID(), nodeID))
+	})
+	return true
+}
+
+// fillSchedulableNodeSize removes the unschedulable nodes from the node size map,
+// and adds the schedulable nodes without any maintainer to it
+func (s *Scheduler) fillSchedulableNodeSize(nodeSize map[node.ID]int) {
+	schedulableNodes := s.nodeManager.GetSchedulableNodes()
+	for id := range nodeSize {
+		if _, ok := schedulableNodes[id]; !ok {
+			delete(nodeSize, id)
+		}
+	}
+	// add the absent node to the node size map
+	// todo: use the bootstrap nodes
+	for id := range schedulableNodes {
+		if _, ok := nodeSize[id]; !ok {
+			nodeSize[id] = 0
+		}
+	}
+}
+
 // balance balances the maintainers by size
 func (s *Scheduler) balance() {
 	if time.Since(s.lastRebalanceTime) < s.checkBalanceInterval {
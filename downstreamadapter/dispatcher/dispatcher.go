@@ -17,6 +17,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/sink"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
@@ -80,8 +81,10 @@ type Dispatcher struct {
 	creatationPDTs uint64
 	// componentStatus is the status of the dispatcher, such as working, removing, stopped.
 	componentStatus *ComponentStateWithMutex
-	// the config of filter
-	filterConfig *config.FilterConfig
+	// the config of filter, it can be updated when the changefeed filter is changed
+	filterConfig atomic.Pointer[config.FilterConfig]
+	// bdrMode is true if the changefeed is in BDR mode
+	bdrMode bool
 
 	// tableInfo is the latest table info of the dispatcher
 	tableInfo atomic.Pointer[common.TableInfo]
@@ -140,6 +143,7 @@ func NewDispatcher(
 	schemaIDToDispatchers *SchemaIDToDispatchers,
 	syncPointConfig *syncpoint.SyncPointConfig,
 	filterConfig *config.FilterConfig,
+	bdrMode bool,
 	currentPdTs uint64,
 	errCh chan error) *Dispatcher {
 	dispatcher := &Dispatcher{
@@ -152,7 +156,7 @@ func NewDispatcher(
 		syncPointConfig:       syncPointConfig,
 		componentStatus:       newComponentStateWithMutex(heartbeatpb.ComponentState_Working),
 		resolvedTs:            newTsWithMutex(startTs),
-		filterConfig:          filterConfig,
+		bdrMode:               bdrMode,
 		isRemoving:            atomic.Bool{},
 		blockEventStatus:      BlockEventStatus{blockPendingEvent: nil},
 		tableProgress:         types.NewTableProgress(),
@@ -162,6 +166,7 @@ func NewDispatcher(
 		creatationPDTs:        currentPdTs,
 		errCh:                 errCh,
 	}
+	dispatcher.filterConfig.Store(filterConfig)
 
 	// when the dispatcher is a table trigger event dispatcher, we need to create a tableSchemaStore
 	// Because we only need to calculate the tableNames or TableIds in the sink
@@ -305,6 +310,22 @@ func (d *Dispatcher) HandleEvents(dispatcherEvents []DispatcherEvent, wakeCallba
 			d.dealWithBlockEvent(event)
 		case commonEvent.TypeHandshakeEvent:
 			log.Warn("Receive handshake event unexpectedly", zap.Any("event", event), zap.Stringer("dispatcher", d.id))
+		case commonEvent.TypeErrorEvent:
+			// The event service can't scan the events of the dispatcher,
+			// report the error to the maintainer.
+			event := event.(*commonEvent.ErrorEvent)
+			log.Error("dispatcher receive error event",
+				zap.Stringer("dispatcher", d.id),
+				zap.Uint64("commitTs", event.GetCommitTs()),
+				zap.String("error", event.Message))
+			select {
+			case d.errCh <- errors.New(event.Message):
+			default:
+				log.Error("error channel is full, discard error",
+					zap.Any("ChangefeedID", d.changefeedID.String()),
+					zap.Any("DispatcherID", d.id.String()),
+					zap.String("error", event.Message))
+			}
 		default:
 			log.Panic("Unexpected event type", zap.Any("event Type", event.GetType()), zap.Stringer("dispatcher", d.id), zap.Uint64("commitTs", event.GetCommitTs()))
 		}
@@ -466,6 +487,20 @@ func (d *Dispatcher) GetCheckpointTs() uint64 {
 	return checkpointTs
 }
 
+// GetRedoResolvedTs returns the ts that all the events of the dispatcher
+// with commitTs less than or equal to it are flushed to the redo log.
+func (d *Dispatcher) GetRedoResolvedTs() uint64 {
+	redoResolvedTs, isEmpty := d.tableProgress.GetRedoResolvedTs()
+	if redoResolvedTs == 0 {
+		return d.GetResolvedTs()
+	}
+
+	if isEmpty {
+		return max(redoResolvedTs, d.GetResolvedTs())
+	}
+	return redoResolvedTs
+}
+
 func (d *Dispatcher) GetId() common.DispatcherID {
 	return d.id
 }
@@ -494,7 +529,13 @@ func (d *Dispatcher) EnableSyncPoint() bool {
 }
 
 func (d *Dispatcher) GetFilterConfig() *eventpb.FilterConfig {
-	return toFilterConfigPB(d.filterConfig)
+	return toFilterConfigPB(d.filterConfig.Load())
+}
+
+// SetFilterConfig sets the filter config of the dispatcher,
+// it takes effect after the dispatcher is registered or reset in the event service.
+func (d *Dispatcher) SetFilterConfig(filterConfig *config.FilterConfig) {
+	d.filterConfig.Store(filterConfig)
 }
 
 func toFilterConfigPB(filter *config.FilterConfig) *eventpb.FilterConfig {
@@ -523,6 +564,10 @@ func toFilterConfigPB(filter *config.FilterConfig) *eventpb.FilterConfig {
 	return filterConfig
 }
 
+func (d *Dispatcher) GetBDRMode() bool {
+	return d.bdrMode
+}
+
 func (d *Dispatcher) GetSyncPointInterval() time.Duration {
 	if d.syncPointConfig != nil {
 		return d.syncPointConfig.SyncPointInterval
@@ -557,6 +602,7 @@ func (d *Dispatcher) TryClose() (w heartbeatpb.Watermark, ok bool) {
 	if (d.sink.IsNormal() && d.tableProgress.Empty()) || !d.sink.IsNormal() {
 		w.CheckpointTs = d.GetCheckpointTs()
 		w.ResolvedTs = d.GetResolvedTs()
+		w.RedoResolvedTs = d.GetRedoResolvedTs()
 
 		d.componentStatus.Set(heartbeatpb.ComponentState_Stopped)
 		return w, true
@@ -602,6 +648,7 @@ func (d *Dispatcher) GetBlockEventStatus() *heartbeatpb.State {
 func (d *Dispatcher) GetHeartBeatInfo(h *HeartBeatInfo) {
 	h.Watermark.CheckpointTs = d.GetCheckpointTs()
 	h.Watermark.ResolvedTs = d.GetResolvedTs()
+	h.Watermark.RedoResolvedTs = d.GetRedoResolvedTs()
 	h.EventSizePerSecond = d.tableProgress.GetEventSizePerSecond()
 	h.Id = d.GetId()
 	h.ComponentStatus = d.GetComponentStatus()
@@ -1,6 +1,7 @@
 package dispatcher
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -9,10 +10,12 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/syncpoint"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
 	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tiflow/pkg/spanz"
 
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -56,6 +59,10 @@ func (s *mockSink) SinkType() psink.SinkType {
 	return psink.MysqlSinkType
 }
 
+func (s *mockSink) UpdateSinkConfig(sinkConfig *config.SinkConfig) error {
+	return nil
+}
+
 func (s *mockSink) IsNormal() bool {
 	return true
 }
@@ -97,6 +104,7 @@ func newDispatcherForTest(sink *mockSink) *Dispatcher {
 			SyncPointRetention: time.Duration(10 * time.Minute),
 		}, // syncPointConfig
 		nil,          //filterConfig
+		false,        //bdrMode
 		common.Ts(0), //pdTs
 		make(chan error, 1),
 	)
@@ -136,8 +144,9 @@ func TestDispatcherHandleEvents(t *testing.T) {
 	require.Equal(t, true, isEmpty)
 	require.Equal(t, uint64(0), checkpointTs)
 
+	nodeID := node.NewID()
 	// ===== dml event =====
-	block := dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(dmlEvent)}, callback)
+	block := dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, dmlEvent)}, callback)
 	require.Equal(t, true, block)
 	require.Equal(t, 1, len(sink.dmls))
 
@@ -164,7 +173,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 		},
 	}
 
-	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(ddlEvent)}, callback)
+	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, ddlEvent)}, callback)
 	require.Equal(t, true, block)
 	require.Equal(t, 0, len(sink.dmls))
 	// no pending event
@@ -191,7 +200,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			},
 		},
 	}
-	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(ddlEvent2)}, callback)
+	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, ddlEvent2)}, callback)
 	require.Equal(t, true, block)
 	require.Equal(t, 0, len(sink.dmls))
 	// no pending event
@@ -234,7 +243,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 			TableIDs:      []int64{0, 1},
 		},
 	}
-	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(ddlEvent3)}, callback)
+	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, ddlEvent3)}, callback)
 	require.Equal(t, true, block)
 	require.Equal(t, 0, len(sink.dmls))
 	// pending event
@@ -292,7 +301,7 @@ func TestDispatcherHandleEvents(t *testing.T) {
 	syncPointEvent := &commonEvent.SyncPointEvent{
 		CommitTs: 6,
 	}
-	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(syncPointEvent)}, callback)
+	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, syncPointEvent)}, callback)
 	require.Equal(t, true, block)
 	require.Equal(t, 0, len(sink.dmls))
 	// pending event
@@ -336,10 +345,29 @@ func TestDispatcherHandleEvents(t *testing.T) {
 	resolvedEvent := commonEvent.ResolvedEvent{
 		ResolvedTs: 7,
 	}
-	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(resolvedEvent)}, callback)
+	block = dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, resolvedEvent)}, callback)
 	require.Equal(t, false, block)
 	require.Equal(t, 0, len(sink.dmls))
 	require.Equal(t, uint64(7), dispatcher.GetResolvedTs())
 	checkpointTs = dispatcher.GetCheckpointTs()
 	require.Equal(t, uint64(7), checkpointTs)
 }
+
+// test the error met by the event service is reported to the maintainer
+func TestDispatcherHandleErrorEvent(t *testing.T) {
+	sink := newMockSink()
+	dispatcher := newDispatcherForTest(sink)
+
+	nodeID := node.NewID()
+	errorEvent := commonEvent.NewErrorEvent(dispatcher.id, 10, errors.New("evaluate filter expression failed"))
+	block := dispatcher.HandleEvents([]DispatcherEvent{NewDispatcherEvent(&nodeID, errorEvent)}, callback)
+	require.False(t, block)
+	require.Equal(t, 0, len(sink.dmls))
+
+	select {
+	case err := <-dispatcher.errCh:
+		require.ErrorContains(t, err, "evaluate filter expression failed")
+	default:
+		require.FailNow(t, "the error is not reported")
+	}
+}
@@ -15,6 +15,7 @@ package dispatchermanager
 
 import (
 	"context"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -61,6 +62,12 @@ type EventDispatcherManager struct {
 
 	changefeedID common.ChangeFeedID
 	config       *config.ChangefeedConfig
+	// memoryQuota is the memory quota of the changefeed in the event collector,
+	// it can be updated by the maintainer bootstrap request after the changefeed config is changed
+	memoryQuota atomic.Uint64
+	// filterConfig is the filter config of the new dispatchers,
+	// it can be updated by the maintainer after the changefeed filter is changed
+	filterConfig atomic.Pointer[config.FilterConfig]
 
 	sink         sink.Sink
 	maintainerID node.ID
@@ -123,6 +130,8 @@ func NewEventDispatcherManager(changefeedID common.ChangeFeedID,
 		metricResolvedTs:               metrics.EventDispatcherManagerResolvedTsGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 		metricResolvedTsLag:            metrics.EventDispatcherManagerResolvedTsLagGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
 	}
+	manager.memoryQuota.Store(cfConfig.MemoryQuota)
+	manager.filterConfig.Store(cfConfig.Filter)
 
 	// Set Sync Point Config
 	if cfConfig.EnableSyncPoint {
@@ -239,6 +248,7 @@ func (e *EventDispatcherManager) close(remove bool) {
 	metrics.CreateDispatcherDuration.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherManagerCheckpointTsGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
 	metrics.EventDispatcherManagerResolvedTsGauge.DeleteLabelValues(e.changefeedID.Namespace(), e.changefeedID.Name())
+	appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).RemoveChangefeedMetrics(e.changefeedID)
 
 	e.closed.Store(true)
 	log.Info("event dispatcher manager closed", zap.Stringer("changefeedID", e.changefeedID))
@@ -331,7 +341,8 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo) er
 			schemaIds[idx],
 			e.schemaIDToDispatchers,
 			e.syncPointConfig,
-			e.config.Filter,
+			e.filterConfig.Load(),
+			e.config.BDRMode,
 			pdTsList[idx],
 			e.errCh)
 
@@ -345,7 +356,7 @@ func (e *EventDispatcherManager) newDispatchers(infos []dispatcherCreateInfo) er
 			e.schemaIDToDispatchers.Set(schemaIds[idx], id)
 		}
 
-		appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).AddDispatcher(d, int(e.config.MemoryQuota))
+		appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).AddDispatcher(d, e.memoryQuota.Load())
 
 		e.dispatcherMap.Set(id, d)
 		e.statusesChan <- &heartbeatpb.TableSpanStatus{
@@ -520,6 +531,7 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 					ID:              id.ToPB(),
 					ComponentStatus: heartbeatpb.ComponentState_Stopped,
 					CheckpointTs:    watermark.CheckpointTs,
+					ResolvedTs:      watermark.ResolvedTs,
 				})
 				toRemoveDispatcherIDs = append(toRemoveDispatcherIDs, id)
 				removedDispatcherSchemaIDs = append(removedDispatcherSchemaIDs, dispatcherItem.GetSchemaID())
@@ -532,6 +544,7 @@ func (e *EventDispatcherManager) aggregateDispatcherHeartbeats(needCompleteStatu
 				ID:                 id.ToPB(),
 				ComponentStatus:    heartBeatInfo.ComponentStatus,
 				CheckpointTs:       heartBeatInfo.Watermark.CheckpointTs,
+				ResolvedTs:         heartBeatInfo.Watermark.ResolvedTs,
 				EventSizePerSecond: heartBeatInfo.EventSizePerSecond,
 			})
 		}
@@ -587,6 +600,43 @@ func (e *EventDispatcherManager) GetMaintainerID() node.ID {
 	return e.maintainerID
 }
 
+// UpdateMemoryQuota updates the memory quota of the changefeed in the event collector,
+// the dispatchers created later also use the new quota.
+func (e *EventDispatcherManager) UpdateMemoryQuota(memoryQuota uint64) {
+	if e.memoryQuota.Swap(memoryQuota) == memoryQuota {
+		return
+	}
+	appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).UpdateChangefeedMemoryQuota(e.changefeedID, memoryQuota)
+}
+
+// UpdateConfig applies the config updated on a running changefeed. The new dispatchers use the new filter,
+// and the table trigger event dispatcher is reset to receive the ddl events with the new filter.
+func (e *EventDispatcherManager) UpdateConfig(cfConfig *config.ChangefeedConfig) error {
+	e.UpdateMemoryQuota(cfConfig.MemoryQuota)
+	if cfConfig.SinkConfig != nil {
+		if err := e.sink.UpdateSinkConfig(cfConfig.SinkConfig); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if reflect.DeepEqual(e.filterConfig.Load(), cfConfig.Filter) {
+		return nil
+	}
+	filter, err := filter.NewFilter(cfConfig.Filter, cfConfig.TimeZone, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.filter = filter
+	e.filterConfig.Store(cfConfig.Filter)
+	if d := e.tableTriggerEventDispatcher; d != nil {
+		d.SetFilterConfig(cfConfig.Filter)
+		appcontext.GetService[*eventcollector.EventCollector](appcontext.EventCollector).ResetDispatcher(d.GetId())
+	}
+	log.Info("event dispatcher manager filter updated",
+		zap.Stringer("changefeedID", e.changefeedID),
+		zap.Strings("rules", cfConfig.Filter.Rules))
+	return nil
+}
+
 func (e *EventDispatcherManager) GetChangeFeedID() common.ChangeFeedID {
 	return e.changefeedID
 }
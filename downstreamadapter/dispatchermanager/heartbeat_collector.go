@@ -305,7 +305,14 @@ func (h *CheckpointTsMessageHandler) Handle(eventDispatcherManager *EventDispatc
 		panic("invalid message count")
 	}
 	checkpointTsMessage := messages[0]
-	if eventDispatcherManager.tableTriggerEventDispatcher != nil && eventDispatcherManager.sink.SinkType() != sink.MysqlSinkType {
+	// The mysql sink doesn't need the checkpointTs, except that the redo log is enabled,
+	// in which case the checkpointTs is recorded in the redo meta.
+	redoSink, isRedoSink := eventDispatcherManager.sink.(*sink.RedoSink)
+	if eventDispatcherManager.tableTriggerEventDispatcher != nil &&
+		(eventDispatcherManager.sink.SinkType() != sink.MysqlSinkType || isRedoSink) {
+		if isRedoSink {
+			redoSink.AddRedoResolvedTs(checkpointTsMessage.RedoResolvedTs)
+		}
 		tableTriggerEventDispatcher := eventDispatcherManager.tableTriggerEventDispatcher
 		tableTriggerEventDispatcher.HandleCheckpointTs(checkpointTsMessage.CheckpointTs)
 	}
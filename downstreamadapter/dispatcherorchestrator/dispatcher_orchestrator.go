@@ -54,6 +54,8 @@ func (m *DispatcherOrchestrator) RecvMaintainerRequest(_ context.Context, msg *m
 		return m.handleAddDispatcherManager(msg.From, req)
 	case *heartbeatpb.MaintainerCloseRequest:
 		return m.handleRemoveDispatcherManager(msg.From, req)
+	case *heartbeatpb.UpdateDispatcherManagerRequest:
+		return m.handleUpdateDispatcherManager(msg.From, req)
 	default:
 		log.Panic("unknown message type", zap.Any("message", msg.Message))
 	}
@@ -65,12 +67,12 @@ func (m *DispatcherOrchestrator) handleAddDispatcherManager(from node.ID, req *h
 	manager, exists := m.dispatcherManagers[cfId]
 	var err error
 	var startTs uint64
+	cfConfig := &config.ChangefeedConfig{}
+	if err := json.Unmarshal(req.Config, cfConfig); err != nil {
+		log.Panic("failed to unmarshal changefeed config", zap.String("changefeed id", cfId.Name()), zap.Error(err))
+		return err
+	}
 	if !exists {
-		cfConfig := &config.ChangefeedConfig{}
-		if err := json.Unmarshal(req.Config, cfConfig); err != nil {
-			log.Panic("failed to unmarshal changefeed config", zap.String("changefeed id", cfId.Name()), zap.Error(err))
-			return err
-		}
 		manager, startTs, err = dispatchermanager.NewEventDispatcherManager(cfId, cfConfig, req.TableTriggerEventDispatcherId, req.StartTs, from)
 		// Fast return the error to maintainer.
 		if err != nil {
@@ -78,17 +80,21 @@ func (m *DispatcherOrchestrator) handleAddDispatcherManager(from node.ID, req *h
 
 			response := &heartbeatpb.MaintainerBootstrapResponse{
 				ChangefeedID: req.ChangefeedID,
-				Err: &heartbeatpb.RunningError{
-					Time:    time.Now().String(),
-					Node:    from.String(),
-					Code:    string(apperror.ErrorCode(err)),
-					Message: err.Error(),
-				},
+				Err:          newRunningError(from, err),
 			}
 			return m.sendResponse(from, messaging.MaintainerManagerTopic, response)
 		}
 		m.dispatcherManagers[cfId] = manager
 		metrics.EventDispatcherManagerGauge.WithLabelValues(cfId.Namespace(), cfId.Name()).Inc()
+	} else if err := manager.UpdateConfig(cfConfig); err != nil {
+		// the maintainer may be restarted with a new changefeed config,
+		// report the error to the maintainer, so the changefeed is not running with the stale config
+		log.Error("failed to update dispatcher manager config", zap.Error(err), zap.Any("ChangefeedID", cfId.Name()))
+		response := &heartbeatpb.MaintainerBootstrapResponse{
+			ChangefeedID: req.ChangefeedID,
+			Err:          newRunningError(from, err),
+		}
+		return m.sendResponse(from, messaging.MaintainerManagerTopic, response)
 	}
 
 	if manager.GetMaintainerID() != from {
@@ -119,6 +125,38 @@ func (m *DispatcherOrchestrator) handleRemoveDispatcherManager(from node.ID, req
 	return m.sendResponse(from, messaging.MaintainerTopic, response)
 }
 
+// handleUpdateDispatcherManager applies the config updated on a running changefeed,
+// the maintainer waits for the response of all nodes before it changes the tables.
+func (m *DispatcherOrchestrator) handleUpdateDispatcherManager(from node.ID, req *heartbeatpb.UpdateDispatcherManagerRequest) error {
+	cfId := common.NewChangefeedIDFromPB(req.ChangefeedID)
+	response := &heartbeatpb.UpdateDispatcherManagerResponse{
+		ChangefeedID: req.ChangefeedID,
+		Version:      req.Version,
+	}
+	// the dispatcher manager is not created yet, it will be created with the new config
+	if manager, ok := m.dispatcherManagers[cfId]; ok {
+		cfConfig := &config.ChangefeedConfig{}
+		err := json.Unmarshal(req.Config, cfConfig)
+		if err == nil {
+			err = manager.UpdateConfig(cfConfig)
+		}
+		if err != nil {
+			log.Error("failed to update dispatcher manager config", zap.Error(err), zap.Any("ChangefeedID", cfId.Name()))
+			response.Err = newRunningError(from, err)
+		}
+	}
+	return m.sendResponse(from, messaging.MaintainerManagerTopic, response)
+}
+
+func newRunningError(from node.ID, err error) *heartbeatpb.RunningError {
+	return &heartbeatpb.RunningError{
+		Time:    time.Now().String(),
+		Node:    from.String(),
+		Code:    string(apperror.ErrorCode(err)),
+		Message: err.Error(),
+	}
+}
+
 func createBootstrapResponse(changefeedID *heartbeatpb.ChangefeedID, manager *dispatchermanager.EventDispatcherManager, startTs uint64) *heartbeatpb.MaintainerBootstrapResponse {
 	response := &heartbeatpb.MaintainerBootstrapResponse{
 		ChangefeedID: changefeedID,
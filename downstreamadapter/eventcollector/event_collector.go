@@ -25,6 +25,9 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/eventpb"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logservicepb"
+	"github.com/pingcap/ticdc/pkg/apperror"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -41,17 +44,24 @@ type DispatcherRequest struct {
 	Dispatcher *dispatcher.Dispatcher
 	ActionType eventpb.ActionType
 	StartTs    uint64
+	// ServerID is the event service to send the request to. It's only set for the remove request,
+	// the other requests are sent to the current event service of the dispatcher.
+	ServerID node.ID
 }
 
 const (
 	eventServiceTopic         = messaging.EventServiceTopic
 	eventCollectorTopic       = messaging.EventCollectorTopic
 	typeRegisterDispatcherReq = messaging.TypeRegisterDispatcherRequest
+
+	// waitCandidatesTimeout is the max time to wait for the reusable event services from the log coordinator,
+	// the dispatcher is registered to the local event service after it.
+	waitCandidatesTimeout = 2 * time.Second
 )
 
 /*
 EventCollector is the relay between EventService and DispatcherManager, responsible for:
-1. Send dispatcher request to EventService.
+1. Send dispatcher request to EventService, which can be a remote one holding the data of the dispatcher.
 2. Collect the events from EvenService and dispatch them to different dispatchers.
 3. Generate SyncPoint Event for dispatchers when necessary.
 EventCollector is an instance-level component.
@@ -76,11 +86,33 @@ type EventCollector struct {
 		id node.ID
 	}
 
+	// changefeedMetrics holds the metrics of the changefeeds which have dispatchers on this node.
+	// The feedbacks only update the metrics of the changefeeds in the map,
+	// so a late feedback doesn't create the metrics of a removed changefeed again.
+	changefeedMetrics struct {
+		sync.Mutex
+		m map[common.GID]*changefeedMetric
+	}
+
 	metricDispatcherReceivedKVEventCount         prometheus.Counter
 	metricDispatcherReceivedResolvedTsEventCount prometheus.Counter
 	metricReceiveEventLagDuration                prometheus.Observer
 }
 
+type changefeedMetric struct {
+	memoryQuota prometheus.Gauge
+	pauseCount  prometheus.Counter
+	resumeCount prometheus.Counter
+}
+
+func newChangefeedMetric(changefeedID common.ChangeFeedID) *changefeedMetric {
+	return &changefeedMetric{
+		memoryQuota: metrics.EventCollectorMemoryQuotaGauge.WithLabelValues(changefeedID.Namespace(), changefeedID.Name()),
+		pauseCount:  metrics.EventCollectorFeedbackCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "pause"),
+		resumeCount: metrics.EventCollectorFeedbackCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "resume"),
+	}
+}
+
 func New(ctx context.Context, globalMemoryQuota int64, serverId node.ID) *EventCollector {
 	eventCollector := EventCollector{
 		serverId:                             serverId,
@@ -92,6 +124,7 @@ func New(ctx context.Context, globalMemoryQuota int64, serverId node.ID) *EventC
 		metricDispatcherReceivedResolvedTsEventCount: metrics.DispatcherReceivedEventCount.WithLabelValues("ResolvedTs"),
 		metricReceiveEventLagDuration:                metrics.EventCollectorReceivedEventLagDuration.WithLabelValues("Msg"),
 	}
+	eventCollector.changefeedMetrics.m = make(map[common.GID]*changefeedMetric)
 	eventCollector.ds = NewEventDynamicStream(&eventCollector)
 	eventCollector.mc.RegisterHandler(messaging.EventCollectorTopic, eventCollector.RecvEventsMessage)
 
@@ -115,7 +148,9 @@ func New(ctx context.Context, globalMemoryQuota int64, serverId node.ID) *EventC
 	return &eventCollector
 }
 
-func (c *EventCollector) AddDispatcher(target *dispatcher.Dispatcher, memoryQuota int) {
+// AddDispatcher adds the dispatcher to the area of its changefeed in the dynamic stream,
+// memoryQuota is the max memory usage of the pending events of the changefeed.
+func (c *EventCollector) AddDispatcher(target *dispatcher.Dispatcher, memoryQuota uint64) {
 	stat := &DispatcherStat{
 		dispatcherID: target.GetId(),
 		target:       target,
@@ -123,33 +158,214 @@ func (c *EventCollector) AddDispatcher(target *dispatcher.Dispatcher, memoryQuot
 	c.dispatcherMap.Store(target.GetId(), stat)
 	metrics.EventCollectorRegisteredDispatcherCount.Inc()
 
-	areaSetting := dynstream.NewAreaSettings()
-	areaSetting.MaxPendingSize = memoryQuota
-	err := c.ds.AddPath(target.GetId(), stat, areaSetting)
+	err := c.ds.AddPath(target.GetId(), stat, c.newAreaSettings(target.GetChangefeedID(), memoryQuota))
 	if err != nil {
 		log.Error("add dispatcher to dynamic stream failed", zap.Error(err))
 	}
 
+	// Ask the log coordinator whether another node has the data of the dispatcher,
+	// the dispatcher is registered after the response is received or timeout.
+	if c.requestReusableEventService(stat) {
+		return
+	}
+	c.registerDispatcher(stat, c.serverId)
+}
+
+// requestReusableEventService sends the request to the log coordinator to find the event services
+// which can serve the dispatcher without pulling data from TiKV again.
+// It returns false if the request is not sent, and the dispatcher should use the local event service.
+func (c *EventCollector) requestReusableEventService(stat *DispatcherStat) bool {
+	// the table trigger event dispatcher only needs the ddl events from the local schema store
+	if stat.target.GetTableSpan().Equal(heartbeatpb.DDLSpan) {
+		return false
+	}
+	c.coordinatorInfo.RLock()
+	coordinatorID := c.coordinatorInfo.id
+	c.coordinatorInfo.RUnlock()
+	if coordinatorID == "" {
+		return false
+	}
+
+	stat.eventServiceInfo.Lock()
+	stat.eventServiceInfo.waitCandidatesSince = time.Now()
+	stat.eventServiceInfo.Unlock()
+	err := c.mc.SendCommand(messaging.NewSingleTargetMessage(coordinatorID, messaging.LogCoordinatorTopic,
+		&logservicepb.ReusableEventServiceRequest{
+			Id:      stat.target.GetId().ToPB(),
+			Span:    stat.target.GetTableSpan(),
+			StartTs: stat.target.GetStartTs(),
+		}))
+	if err != nil {
+		log.Info("failed to send reusable event service request to log coordinator, use the local event service",
+			zap.Stringer("dispatcher", stat.target.GetId()), zap.Error(err))
+		stat.eventServiceInfo.Lock()
+		stat.eventServiceInfo.waitCandidatesSince = time.Time{}
+		stat.eventServiceInfo.Unlock()
+		return false
+	}
+	return true
+}
+
+// handleReusableEventServiceResponse registers the dispatcher to the first candidate event service,
+// or to the local one if there is no candidate.
+func (c *EventCollector) handleReusableEventServiceResponse(resp *logservicepb.ReusableEventServiceResponse) {
+	value, ok := c.dispatcherMap.Load(common.NewDispatcherIDFromPB(resp.GetId()))
+	if !ok {
+		return
+	}
+	stat := value.(*DispatcherStat)
+	if !stat.stopWaitingCandidates() {
+		// the dispatcher has been registered because of timeout
+		return
+	}
+	serverID := c.serverId
+	if len(resp.GetNodes()) > 0 {
+		serverID = node.ID(resp.GetNodes()[0])
+	}
+	log.Info("receive reusable event service response",
+		zap.Stringer("dispatcher", stat.target.GetId()),
+		zap.Strings("candidates", resp.GetNodes()),
+		zap.Stringer("eventService", serverID))
+	c.registerDispatcher(stat, serverID)
+}
+
+// registerDispatcher registers the dispatcher to the event service on serverID,
+// it's used for both the first registration and the switch of the event service.
+func (c *EventCollector) registerDispatcher(stat *DispatcherStat, serverID node.ID) {
+	stat.eventServiceInfo.Lock()
+	oldServerID := stat.eventServiceInfo.serverID
+	if oldServerID == serverID {
+		stat.eventServiceInfo.Unlock()
+		return
+	}
+	stat.eventServiceInfo.serverID = serverID
+	stat.eventServiceInfo.Unlock()
+
+	startTs := stat.target.GetStartTs()
+	if oldServerID != "" {
+		// the events sent by the old event service are dropped,
+		// the dispatcher continues from its checkpoint after the handshake with the new one.
+		stat.reset()
+		startTs = stat.target.GetCheckpointTs()
+		c.mustSendDispatcherRequest(DispatcherRequest{
+			Dispatcher: stat.target,
+			ActionType: eventpb.ActionType_ACTION_TYPE_REMOVE,
+			ServerID:   oldServerID,
+		})
+	}
 	// TODO: handle the return error(now even it return error, it will be retried later, we can just ignore it now)
 	c.mustSendDispatcherRequest(DispatcherRequest{
-		Dispatcher: target,
-		StartTs:    target.GetStartTs(),
+		Dispatcher: stat.target,
+		StartTs:    startTs,
 		ActionType: eventpb.ActionType_ACTION_TYPE_REGISTER,
 	})
 }
 
+// OnNodeChanges moves the dispatchers registered to the removed nodes back to the local event service.
+func (c *EventCollector) OnNodeChanges(activeNodes map[node.ID]*node.Info) {
+	c.coordinatorInfo.Lock()
+	if _, ok := activeNodes[c.coordinatorInfo.id]; !ok {
+		c.coordinatorInfo.id = ""
+	}
+	c.coordinatorInfo.Unlock()
+
+	c.dispatcherMap.Range(func(_, value interface{}) bool {
+		stat := value.(*DispatcherStat)
+		serverID := stat.getEventServiceID()
+		if serverID == "" || serverID == c.serverId {
+			return true
+		}
+		if _, ok := activeNodes[serverID]; !ok {
+			log.Info("event service is offline, register the dispatcher to the local event service",
+				zap.Stringer("dispatcher", stat.target.GetId()),
+				zap.Stringer("eventService", serverID))
+			c.registerDispatcher(stat, c.serverId)
+		}
+		return true
+	})
+}
+
+// UpdateChangefeedMemoryQuota updates the memory quota of the changefeed area,
+// it takes effect on the pending events immediately.
+func (c *EventCollector) UpdateChangefeedMemoryQuota(changefeedID common.ChangeFeedID, memoryQuota uint64) {
+	c.ds.SetAreaSettings(changefeedID.ID(), c.newAreaSettings(changefeedID, memoryQuota))
+	log.Info("update changefeed memory quota",
+		zap.Stringer("changefeedID", changefeedID),
+		zap.Uint64("memoryQuota", memoryQuota))
+}
+
+// RemoveChangefeedMetrics removes the metrics of the changefeed, it's called when the changefeed is removed from this node.
+func (c *EventCollector) RemoveChangefeedMetrics(changefeedID common.ChangeFeedID) {
+	c.changefeedMetrics.Lock()
+	defer c.changefeedMetrics.Unlock()
+	delete(c.changefeedMetrics.m, changefeedID.ID())
+	metrics.EventCollectorMemoryQuotaGauge.DeleteLabelValues(changefeedID.Namespace(), changefeedID.Name())
+	metrics.EventCollectorFeedbackCount.DeletePartialMatch(
+		prometheus.Labels{"namespace": changefeedID.Namespace(), "changefeed": changefeedID.Name()})
+}
+
+// newAreaSettings returns the area settings of a changefeed, the memory quota is capped by the global memory quota.
+func (c *EventCollector) newAreaSettings(changefeedID common.ChangeFeedID, memoryQuota uint64) dynstream.AreaSettings {
+	if c.globalMemoryQuota > 0 && memoryQuota > uint64(c.globalMemoryQuota) {
+		log.Warn("changefeed memory quota exceeds the global memory quota, use the global one",
+			zap.Stringer("changefeedID", changefeedID),
+			zap.Uint64("memoryQuota", memoryQuota),
+			zap.Int64("globalMemoryQuota", c.globalMemoryQuota))
+		memoryQuota = uint64(c.globalMemoryQuota)
+	}
+	areaSetting := dynstream.NewAreaSettings()
+	areaSetting.MaxPendingSize = int(memoryQuota)
+
+	c.changefeedMetrics.Lock()
+	defer c.changefeedMetrics.Unlock()
+	metric, ok := c.changefeedMetrics.m[changefeedID.ID()]
+	if !ok {
+		metric = newChangefeedMetric(changefeedID)
+		c.changefeedMetrics.m[changefeedID.ID()] = metric
+	}
+	metric.memoryQuota.Set(float64(memoryQuota))
+	return areaSetting
+}
+
+// recordFeedback counts the pause or resume feedback of the changefeed,
+// the feedback of a changefeed whose metrics are removed is ignored.
+func (c *EventCollector) recordFeedback(changefeedID common.ChangeFeedID, pause bool) {
+	c.changefeedMetrics.Lock()
+	defer c.changefeedMetrics.Unlock()
+	metric, ok := c.changefeedMetrics.m[changefeedID.ID()]
+	if !ok {
+		return
+	}
+	if pause {
+		metric.pauseCount.Inc()
+	} else {
+		metric.resumeCount.Inc()
+	}
+}
+
 func (c *EventCollector) RemoveDispatcher(target *dispatcher.Dispatcher) {
-	c.dispatcherMap.Delete(target.GetId())
+	value, ok := c.dispatcherMap.LoadAndDelete(target.GetId())
+	if !ok {
+		return
+	}
 
 	err := c.ds.RemovePath(target.GetId())
 	if err != nil {
 		log.Error("remove dispatcher from dynamic stream failed", zap.Error(err))
 	}
 
+	stat := value.(*DispatcherStat)
+	stat.stopWaitingCandidates()
+	serverID := stat.getEventServiceID()
+	if serverID == "" {
+		// the dispatcher is not registered to any event service yet
+		return
+	}
 	// TODO: handle the return error(now even it return error, it will be retried later, we can just ignore it now)
 	c.mustSendDispatcherRequest(DispatcherRequest{
 		Dispatcher: target,
 		ActionType: eventpb.ActionType_ACTION_TYPE_REMOVE,
+		ServerID:   serverID,
 	})
 }
 
@@ -168,6 +384,16 @@ func (c *EventCollector) ResetDispatcherStat(stat *DispatcherStat) {
 	}
 }
 
+// ResetDispatcher resets the dispatcher in the event service, the dispatcher is registered again
+// with its latest config and starts from its checkpoint ts.
+func (c *EventCollector) ResetDispatcher(dispatcherID common.DispatcherID) {
+	value, ok := c.dispatcherMap.Load(dispatcherID)
+	if !ok {
+		return
+	}
+	c.ResetDispatcherStat(value.(*DispatcherStat))
+}
+
 func (c *EventCollector) processFeedback(ctx context.Context) {
 	defer c.wg.Done()
 	for {
@@ -175,6 +401,7 @@ func (c *EventCollector) processFeedback(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case feedback := <-c.ds.Feedback():
+			c.recordFeedback(feedback.Dest.target.GetChangefeedID(), feedback.Pause)
 			if feedback.Pause {
 				c.dispatcherRequestChan.In() <- DispatcherRequest{
 					Dispatcher: feedback.Dest.target,
@@ -192,10 +419,14 @@ func (c *EventCollector) processFeedback(ctx context.Context) {
 
 func (c *EventCollector) processDispatcherRequests(ctx context.Context) {
 	defer c.wg.Done()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
+		case <-ticker.C:
+			c.checkWaitingDispatchers()
 		case req := <-c.dispatcherRequestChan.Out():
 			if err := c.mustSendDispatcherRequest(req); err != nil {
 				log.Error("failed to process dispatcher action", zap.Error(err))
@@ -206,19 +437,78 @@ func (c *EventCollector) processDispatcherRequests(ctx context.Context) {
 	}
 }
 
-// mustSendDispatcherRequest will keep retrying to send the dispatcher request to EventService until it succeed.
-// Caller should avoid to use this method if the remote EventService maybe offline forever.
-// And this method may be deprecated in the future.
+// checkWaitingDispatchers registers the dispatchers which wait for the log coordinator too long
+// to the local event service.
+func (c *EventCollector) checkWaitingDispatchers() {
+	c.dispatcherMap.Range(func(_, value interface{}) bool {
+		stat := value.(*DispatcherStat)
+		stat.eventServiceInfo.RLock()
+		since := stat.eventServiceInfo.waitCandidatesSince
+		stat.eventServiceInfo.RUnlock()
+		if since.IsZero() || time.Since(since) < waitCandidatesTimeout {
+			return true
+		}
+		if stat.stopWaitingCandidates() {
+			log.Info("wait reusable event service timeout, use the local event service",
+				zap.Stringer("dispatcher", stat.target.GetId()))
+			c.registerDispatcher(stat, c.serverId)
+		}
+		return true
+	})
+}
+
+// mustSendDispatcherRequest will keep retrying to send the dispatcher request to the event service
+// of the dispatcher until it succeed. If the event service is on a remote node and cannot be reached,
+// the dispatcher is registered to the local event service instead.
 func (c *EventCollector) mustSendDispatcherRequest(req DispatcherRequest) error {
+	var stat *DispatcherStat
+	serverID := req.ServerID
+	if serverID == "" {
+		value, ok := c.dispatcherMap.Load(req.Dispatcher.GetId())
+		if !ok {
+			// the dispatcher is removed, the request is useless
+			return nil
+		}
+		stat = value.(*DispatcherStat)
+		serverID = stat.getEventServiceID()
+		if serverID == "" {
+			// the dispatcher will be registered with the latest states later
+			return nil
+		}
+	}
+
+	err := c.sendDispatcherRequest(serverID, req)
+	if err == nil {
+		return nil
+	}
+	if serverID != c.serverId {
+		if appErr, ok := err.(apperror.AppError); ok && appErr.Type == apperror.ErrorTypeTargetNotFound {
+			// the remote event service is offline, the dispatcher states on it are gone with it
+			if stat != nil {
+				log.Info("remote event service not found, register the dispatcher to the local event service",
+					zap.Stringer("dispatcher", req.Dispatcher.GetId()),
+					zap.Stringer("eventService", serverID))
+				c.registerDispatcher(stat, c.serverId)
+			}
+			return err
+		}
+	}
+	log.Info("failed to send dispatcher request message to event service, try again later", zap.Error(err))
+	// Put the request back to the channel for later retry.
+	c.dispatcherRequestChan.In() <- req
+	return err
+}
+
+// sendDispatcherRequest sends the dispatcher request to the event service on serverID.
+func (c *EventCollector) sendDispatcherRequest(serverID node.ID, req DispatcherRequest) error {
 	message := &messaging.RegisterDispatcherRequest{
 		RegisterDispatcherRequest: &eventpb.RegisterDispatcherRequest{
 			ChangefeedId: req.Dispatcher.GetChangefeedID().ToPB(),
 			DispatcherId: req.Dispatcher.GetId().ToPB(),
 			ActionType:   req.ActionType,
-			// FIXME: It can be another server id in the future.
-			ServerId:  c.serverId.String(),
-			TableSpan: req.Dispatcher.GetTableSpan(),
-			StartTs:   req.StartTs,
+			ServerId:     c.serverId.String(),
+			TableSpan:    req.Dispatcher.GetTableSpan(),
+			StartTs:      req.StartTs,
 		},
 	}
 
@@ -226,25 +516,18 @@ func (c *EventCollector) mustSendDispatcherRequest(req DispatcherRequest) error
 	if req.ActionType == eventpb.ActionType_ACTION_TYPE_REGISTER ||
 		req.ActionType == eventpb.ActionType_ACTION_TYPE_RESET {
 		message.RegisterDispatcherRequest.FilterConfig = req.Dispatcher.GetFilterConfig()
+		message.RegisterDispatcherRequest.BdrMode = req.Dispatcher.GetBDRMode()
 		message.RegisterDispatcherRequest.EnableSyncPoint = req.Dispatcher.EnableSyncPoint()
 		message.RegisterDispatcherRequest.SyncPointInterval = uint64(req.Dispatcher.GetSyncPointInterval().Seconds())
 		message.RegisterDispatcherRequest.SyncPointTs = syncpoint.CalculateStartSyncPointTs(req.StartTs, req.Dispatcher.GetSyncPointInterval())
 	}
 
-	err := c.mc.SendCommand(&messaging.TargetMessage{
-		To:      c.serverId,
+	return c.mc.SendCommand(&messaging.TargetMessage{
+		To:      serverID,
 		Topic:   eventServiceTopic,
 		Type:    typeRegisterDispatcherReq,
 		Message: []messaging.IOTypeT{message},
 	})
-
-	if err != nil {
-		log.Info("failed to send dispatcher request message to event service, try again later", zap.Error(err))
-		// Put the request back to the channel for later retry.
-		c.dispatcherRequestChan.In() <- req
-		return err
-	}
-	return nil
 }
 
 // RecvEventsMessage is the handler for the events message from EventService.
@@ -257,17 +540,19 @@ func (c *EventCollector) RecvEventsMessage(_ context.Context, targetMessage *mes
 			c.coordinatorInfo.Lock()
 			c.coordinatorInfo.id = targetMessage.From
 			c.coordinatorInfo.Unlock()
+		case *logservicepb.ReusableEventServiceResponse:
+			c.handleReusableEventServiceResponse(msg.(*logservicepb.ReusableEventServiceResponse))
 		case commonEvent.Event:
 			event := msg.(commonEvent.Event)
 			switch event.GetType() {
 			case commonEvent.TypeBatchResolvedEvent:
 				for _, e := range event.(*commonEvent.BatchResolvedEvent).Events {
 					c.metricDispatcherReceivedResolvedTsEventCount.Inc()
-					c.ds.In() <- dispatcher.NewDispatcherEvent(e)
+					c.ds.In() <- dispatcher.NewDispatcherEvent(&targetMessage.From, e)
 				}
 			default:
 				c.metricDispatcherReceivedKVEventCount.Inc()
-				c.ds.In() <- dispatcher.NewDispatcherEvent(event)
+				c.ds.In() <- dispatcher.NewDispatcherEvent(&targetMessage.From, event)
 			}
 		default:
 			log.Panic("invalid message type", zap.Any("msg", msg))
@@ -322,6 +607,47 @@ type DispatcherStat struct {
 	// Dispatcher will be ready when it receives the handshake event from eventService.
 	// If false, the dispatcher will drop the event it received.
 	isReady atomic.Bool
+
+	// eventServiceInfo is the event service which the dispatcher is registered to.
+	eventServiceInfo struct {
+		sync.RWMutex
+		// serverID is empty before the dispatcher is registered.
+		serverID node.ID
+		// waitCandidatesSince is the time when the dispatcher starts to wait for
+		// the reusable event services from the log coordinator, it's zero if not waiting.
+		waitCandidatesSince time.Time
+	}
+}
+
+func (d *DispatcherStat) getEventServiceID() node.ID {
+	d.eventServiceInfo.RLock()
+	defer d.eventServiceInfo.RUnlock()
+	return d.eventServiceInfo.serverID
+}
+
+// stopWaitingCandidates returns true if the dispatcher was waiting for the log coordinator.
+func (d *DispatcherStat) stopWaitingCandidates() bool {
+	d.eventServiceInfo.Lock()
+	defer d.eventServiceInfo.Unlock()
+	if d.eventServiceInfo.waitCandidatesSince.IsZero() {
+		return false
+	}
+	d.eventServiceInfo.waitCandidatesSince = time.Time{}
+	return true
+}
+
+// filterStaleEvents drops the events which are not sent by the current event service of the dispatcher,
+// they may come from the old event service after the dispatcher is moved.
+func (d *DispatcherStat) filterStaleEvents(dispatcherEvents []dispatcher.DispatcherEvent) []dispatcher.DispatcherEvent {
+	serverID := d.getEventServiceID()
+	result := dispatcherEvents[:0]
+	for _, dispatcherEvent := range dispatcherEvents {
+		if dispatcherEvent.From != nil && *dispatcherEvent.From != serverID {
+			continue
+		}
+		result = append(result, dispatcherEvent)
+	}
+	return result
 }
 
 func (d *DispatcherStat) reset() {
@@ -0,0 +1,99 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventcollector
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/downstreamadapter/dispatcher"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/utils/dynstream"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDynamicStream records the area settings set by the event collector.
+type mockDynamicStream struct {
+	dynstream.DynamicStream[common.GID, common.DispatcherID, dispatcher.DispatcherEvent, *DispatcherStat, *EventsHandler]
+	settings map[common.GID]dynstream.AreaSettings
+}
+
+func (m *mockDynamicStream) SetAreaSettings(area common.GID, settings dynstream.AreaSettings) {
+	m.settings[area] = settings
+}
+
+func newEventCollectorForTest(globalMemoryQuota int64) (*EventCollector, *mockDynamicStream) {
+	ds := &mockDynamicStream{settings: make(map[common.GID]dynstream.AreaSettings)}
+	c := &EventCollector{
+		globalMemoryQuota: globalMemoryQuota,
+		ds:                ds,
+	}
+	c.changefeedMetrics.m = make(map[common.GID]*changefeedMetric)
+	return c, ds
+}
+
+func TestUpdateChangefeedMemoryQuota(t *testing.T) {
+	c, ds := newEventCollectorForTest(1024)
+	cfID := common.NewChangeFeedIDWithName("test-update-memory-quota")
+	defer c.RemoveChangefeedMetrics(cfID)
+	quotaGauge := metrics.EventCollectorMemoryQuotaGauge.WithLabelValues(cfID.Namespace(), cfID.Name())
+
+	c.UpdateChangefeedMemoryQuota(cfID, 512)
+	require.Equal(t, 512, ds.settings[cfID.ID()].MaxPendingSize)
+	require.Equal(t, float64(512), testutil.ToFloat64(quotaGauge))
+
+	// the quota larger than the global one is capped
+	c.UpdateChangefeedMemoryQuota(cfID, 4096)
+	require.Equal(t, 1024, ds.settings[cfID.ID()].MaxPendingSize)
+	require.Equal(t, float64(1024), testutil.ToFloat64(quotaGauge))
+
+	// no cap without the global quota
+	c.globalMemoryQuota = 0
+	c.UpdateChangefeedMemoryQuota(cfID, 4096)
+	require.Equal(t, 4096, ds.settings[cfID.ID()].MaxPendingSize)
+	require.Equal(t, float64(4096), testutil.ToFloat64(quotaGauge))
+}
+
+func TestChangefeedMetrics(t *testing.T) {
+	c, _ := newEventCollectorForTest(1024)
+	cfID := common.NewChangeFeedIDWithName("test-changefeed-metrics")
+	countMetrics := func() int {
+		return testutil.CollectAndCount(metrics.EventCollectorMemoryQuotaGauge) +
+			testutil.CollectAndCount(metrics.EventCollectorFeedbackCount)
+	}
+	before := countMetrics()
+
+	// the feedbacks are ignored before the area of the changefeed is set
+	c.recordFeedback(cfID, true)
+	require.Equal(t, before, countMetrics())
+
+	settings := c.newAreaSettings(cfID, 256)
+	require.Equal(t, 256, settings.MaxPendingSize)
+	c.recordFeedback(cfID, true)
+	c.recordFeedback(cfID, true)
+	c.recordFeedback(cfID, false)
+	require.Equal(t, float64(2), testutil.ToFloat64(
+		metrics.EventCollectorFeedbackCount.WithLabelValues(cfID.Namespace(), cfID.Name(), "pause")))
+	require.Equal(t, float64(1), testutil.ToFloat64(
+		metrics.EventCollectorFeedbackCount.WithLabelValues(cfID.Namespace(), cfID.Name(), "resume")))
+	require.Equal(t, before+3, countMetrics())
+
+	// the late feedbacks don't create the metrics of the removed changefeed again
+	c.RemoveChangefeedMetrics(cfID)
+	require.Equal(t, before, countMetrics())
+	c.recordFeedback(cfID, true)
+	c.recordFeedback(cfID, false)
+	require.Equal(t, before, countMetrics())
+}
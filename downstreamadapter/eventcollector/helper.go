@@ -62,6 +62,10 @@ func (h *EventsHandler) Path(event dispatcher.DispatcherEvent) common.Dispatcher
 }
 
 func (h *EventsHandler) Handle(stat *DispatcherStat, events ...dispatcher.DispatcherEvent) bool {
+	events = stat.filterStaleEvents(events)
+	if len(events) == 0 {
+		return false
+	}
 	// If the dispatcher is not ready, try to find handshake event to make the dispatcher ready.
 	if !stat.isReady.Load() {
 		ready, restEvents := stat.checkHandshakeEvents(events)
@@ -98,6 +102,7 @@ const (
 	DataGroupDDL             = 2
 	DataGroupSyncPoint       = 3
 	DataGroupHandshake       = 4
+	DataGroupError           = 5
 )
 
 func (h *EventsHandler) GetType(event dispatcher.DispatcherEvent) dynstream.EventType {
@@ -112,6 +117,8 @@ func (h *EventsHandler) GetType(event dispatcher.DispatcherEvent) dynstream.Even
 		return dynstream.EventType{DataGroup: DataGroupSyncPoint, Property: dynstream.NonBatchable}
 	case commonEvent.TypeHandshakeEvent:
 		return dynstream.EventType{DataGroup: DataGroupHandshake, Property: dynstream.NonBatchable}
+	case commonEvent.TypeErrorEvent:
+		return dynstream.EventType{DataGroup: DataGroupError, Property: dynstream.NonBatchable}
 	default:
 		log.Panic("unknown event type", zap.Int("type", int(event.GetType())))
 	}
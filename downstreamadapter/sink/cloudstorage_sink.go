@@ -0,0 +1,208 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	putil "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// CloudStorageSink writes the events into the external storage, such as S3, GCS
+// and local file system. The DML events are written into data files partitioned
+// by table and date, and the DDL events are written into schema files.
+type CloudStorageSink struct {
+	changefeedID common.ChangeFeedID
+
+	dmlWorker *worker.CloudStorageDMLWorker
+	ddlWorker *worker.CloudStorageDDLWorker
+
+	storage    storage.ExternalStorage
+	statistics *metrics.Statistics
+
+	errgroup *errgroup.Group
+	errCh    chan error
+	isNormal uint32 // if sink is normal, isNormal is 1, otherwise is 0
+}
+
+func (s *CloudStorageSink) SinkType() SinkType {
+	return CloudStorageSinkType
+}
+
+func NewCloudStorageSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL,
+	sinkConfig *ticonfig.SinkConfig, errCh chan error,
+) (*CloudStorageSink, error) {
+	errGroup, ctx := errgroup.WithContext(ctx)
+
+	config := cloudstorage.NewConfig()
+	if err := config.Apply(sinkURI, sinkConfig); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	protocol, err := helper.GetProtocol(putil.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if protocol != ticonfig.ProtocolCsv && protocol != ticonfig.ProtocolCanalJSON {
+		return nil, cerror.ErrSinkUnknownProtocol.GenWithStack(
+			"cloud storage sink doesn't support protocol %s, only csv and canal-json are supported", protocol)
+	}
+	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, math.MaxInt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	storage, err := putil.GetExternalStorageFromURI(ctx, sinkURI.String())
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrStorageInitialize, err)
+	}
+
+	statistics := metrics.NewStatistics(changefeedID, "CloudStorageSink")
+	dmlWorker, err := worker.NewCloudStorageDMLWorker(
+		ctx, changefeedID, storage, config, encoderConfig,
+		helper.GetFileExtension(protocol), statistics, errGroup)
+	if err != nil {
+		storage.Close()
+		statistics.Close()
+		return nil, errors.Trace(err)
+	}
+	ddlWorker := worker.NewCloudStorageDDLWorker(ctx, changefeedID, storage, config, statistics, errGroup)
+
+	sink := &CloudStorageSink{
+		changefeedID: changefeedID,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+		storage:      storage,
+		statistics:   statistics,
+		errgroup:     errGroup,
+		errCh:        errCh,
+		isNormal:     1,
+	}
+	go sink.run()
+	return sink, nil
+}
+
+func (s *CloudStorageSink) run() {
+	s.dmlWorker.Run()
+	s.ddlWorker.Run()
+
+	err := s.errgroup.Wait()
+	if errors.Cause(err) != context.Canceled {
+		atomic.StoreUint32(&s.isNormal, 0)
+		select {
+		case s.errCh <- err:
+		default:
+			log.Error("error channel is full, discard error",
+				zap.Any("ChangefeedID", s.changefeedID.String()),
+				zap.Error(err))
+		}
+	}
+}
+
+func (s *CloudStorageSink) IsNormal() bool {
+	return atomic.LoadUint32(&s.isNormal) == 1
+}
+
+func (s *CloudStorageSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *types.TableProgress) {
+	if event.Len() == 0 {
+		return
+	}
+	tableProgress.Add(event)
+	s.dmlWorker.GetEventChan() <- event
+}
+
+func (s *CloudStorageSink) PassBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) {
+	tableProgress.Pass(event)
+	event.PostFlush()
+}
+
+func (s *CloudStorageSink) WriteBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) error {
+	tableProgress.Add(event)
+	switch event := event.(type) {
+	case *commonEvent.DDLEvent:
+		if event.TiDBOnly {
+			// run callback directly and return
+			event.PostFlush()
+			return nil
+		}
+		err := s.ddlWorker.WriteBlockEvent(event)
+		if err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
+	case *commonEvent.SyncPointEvent:
+		log.Error("CloudStorageSink doesn't support Sync Point Event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("event", event))
+	default:
+		log.Error("CloudStorageSink doesn't support this type of block event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("event type", event.GetType()))
+	}
+	event.PostFlush()
+	return nil
+}
+
+func (s *CloudStorageSink) AddCheckpointTs(ts uint64) {
+	s.ddlWorker.AddCheckpointTs(ts)
+}
+
+func (s *CloudStorageSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+}
+
+// UpdateSinkConfig does nothing, the cloud storage sink has no config that can be updated in place.
+func (s *CloudStorageSink) UpdateSinkConfig(_ *ticonfig.SinkConfig) error {
+	return nil
+}
+
+func (s *CloudStorageSink) Close(removeDDLTsItem bool) error {
+	err := s.ddlWorker.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = s.dmlWorker.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	s.storage.Close()
+	s.statistics.Close()
+	return nil
+}
+
+func (s *CloudStorageSink) CheckStartTsList(tableIds []int64, startTsList []int64) ([]int64, error) {
+	return startTsList, nil
+}
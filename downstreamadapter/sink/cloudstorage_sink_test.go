@@ -0,0 +1,127 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func cloudStorageSinkForTest(t *testing.T, protocol string) (*CloudStorageSink, string) {
+	dir := t.TempDir()
+	sinkURI, err := url.Parse(fmt.Sprintf("file://%s?flush-interval=2s", dir))
+	require.NoError(t, err)
+
+	sinkConfig := config.GetDefaultReplicaConfig().Sink
+	sinkConfig.Protocol = util.AddressOf(protocol)
+
+	changefeedID := common.ChangefeedID4Test("test", "test")
+	sink, err := NewCloudStorageSink(context.Background(), changefeedID, sinkURI, sinkConfig, make(chan error, 16))
+	require.NoError(t, err)
+	return sink, dir
+}
+
+func TestCloudStorageSinkBasicFunctionality(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+	tableInfo := helper.GetTableInfo(job)
+
+	cases := []struct {
+		protocol  string
+		extension string
+		expected  string
+	}{
+		{protocol: "csv", extension: ".csv", expected: "\"I\",\"t\",\"test\",1,\"test\"\r\n"},
+		{protocol: "canal-json", extension: ".json", expected: `"data":[{"id":"2","name":"test"}]`},
+	}
+	for i, tc := range cases {
+		sink, dir := cloudStorageSinkForTest(t, tc.protocol)
+		require.Equal(t, CloudStorageSinkType, sink.SinkType())
+		tableProgress := types.NewTableProgress()
+		var count atomic.Int64
+
+		ddlEvent := &commonEvent.DDLEvent{
+			Type:       byte(job.Type),
+			Query:      job.Query,
+			SchemaName: job.SchemaName,
+			TableName:  job.TableName,
+			TableInfo:  tableInfo,
+			FinishedTs: job.BinlogInfo.FinishedTS,
+			PostTxnFlushed: []func(){
+				func() { count.Add(1) },
+			},
+		}
+		err := sink.WriteBlockEvent(ddlEvent, tableProgress)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), count.Load())
+
+		// the schema file of the DDL is written.
+		schemaFiles, err := filepath.Glob(filepath.Join(dir,
+			fmt.Sprintf("test/t/meta/schema_%d_*.json", job.BinlogInfo.FinishedTS)))
+		require.NoError(t, err)
+		require.Len(t, schemaFiles, 1)
+
+		dmlEvent := helper.DML2Event("test", "t", fmt.Sprintf("insert into t values (%d, 'test')", i+1))
+		dmlEvent.PostTxnFlushed = []func(){
+			func() { count.Add(1) },
+		}
+		dmlEvent.CommitTs = job.BinlogInfo.FinishedTS + 1
+		sink.AddDMLEvent(dmlEvent, tableProgress)
+
+		// the event is flushed after the flush interval.
+		require.Eventually(t, func() bool {
+			return count.Load() == 2
+		}, 10*time.Second, 100*time.Millisecond)
+		_, isEmpty := tableProgress.GetCheckpointTs()
+		require.True(t, isEmpty)
+
+		// the data file is written under the directory of the table version
+		// which is written by the DDL.
+		date := time.Now().Format("2006-01-02")
+		dataDir := filepath.Join(dir, fmt.Sprintf("test/t/%d/%s", job.BinlogInfo.FinishedTS, date))
+		data, err := os.ReadFile(filepath.Join(dataDir, "CDC00000000000000000001"+tc.extension))
+		require.NoError(t, err)
+		require.Contains(t, string(data), tc.expected)
+		index, err := os.ReadFile(filepath.Join(dataDir, "meta/CDC.index"))
+		require.NoError(t, err)
+		require.Equal(t, "CDC00000000000000000001"+tc.extension+"\n", string(index))
+
+		sink.AddCheckpointTs(dmlEvent.CommitTs)
+		require.Eventually(t, func() bool {
+			data, err := os.ReadFile(filepath.Join(dir, "metadata"))
+			return err == nil &&
+				string(data) == fmt.Sprintf(`{"checkpoint-ts":%d}`, dmlEvent.CommitTs)
+		}, 10*time.Second, 100*time.Millisecond)
+
+		require.True(t, sink.IsNormal())
+		require.NoError(t, sink.Close(false))
+	}
+}
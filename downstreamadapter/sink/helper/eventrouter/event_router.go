@@ -14,6 +14,8 @@
 package eventrouter
 
 import (
+	"sync/atomic"
+
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter/partition"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter/topic"
@@ -34,15 +36,44 @@ type Rule struct {
 // an event should be dispatched to.
 type EventRouter struct {
 	defaultTopic string
-	rules        []Rule
+	protocol     config.Protocol
+	scheme       string
+	// rules can be replaced when the dispatch rules of the changefeed are updated
+	rules atomic.Pointer[[]Rule]
 }
 
 // NewEventRouter creates a new EventRouter.
 func NewEventRouter(sinkConfig *config.SinkConfig, protocol config.Protocol, defaultTopic, scheme string) (*EventRouter, error) {
+	router := &EventRouter{
+		defaultTopic: defaultTopic,
+		protocol:     protocol,
+		scheme:       scheme,
+	}
+	rules, err := router.newRules(sinkConfig)
+	if err != nil {
+		return nil, err
+	}
+	router.rules.Store(&rules)
+	return router, nil
+}
+
+// UpdateRules rebuilds the rules by the new sink config, the events routed later use the new rules.
+func (s *EventRouter) UpdateRules(sinkConfig *config.SinkConfig) error {
+	rules, err := s.newRules(sinkConfig)
+	if err != nil {
+		return err
+	}
+	s.rules.Store(&rules)
+	return nil
+}
+
+func (s *EventRouter) newRules(sinkConfig *config.SinkConfig) ([]Rule, error) {
 	// If an event does not match any dispatching rules in the config file,
 	// it will be dispatched by the default partition dispatcher and
 	// static topic dispatcher because it matches *.* rule.
-	ruleConfigs := append(sinkConfig.DispatchRules, &config.DispatchRule{
+	ruleConfigs := make([]*config.DispatchRule, 0, len(sinkConfig.DispatchRules)+1)
+	ruleConfigs = append(ruleConfigs, sinkConfig.DispatchRules...)
+	ruleConfigs = append(ruleConfigs, &config.DispatchRule{
 		Matcher:       []string{"*.*"},
 		PartitionRule: "default",
 		TopicRule:     "",
@@ -59,19 +90,15 @@ func NewEventRouter(sinkConfig *config.SinkConfig, protocol config.Protocol, def
 			f = tableFilter.CaseInsensitive(f)
 		}
 
-		d := partition.GetPartitionGenerator(ruleConfig.PartitionRule, scheme, ruleConfig.IndexName, ruleConfig.Columns)
+		d := partition.GetPartitionGenerator(ruleConfig.PartitionRule, s.scheme, ruleConfig.IndexName, ruleConfig.Columns)
 
-		topicGenerator, err := topic.GetTopicGenerator(ruleConfig.TopicRule, defaultTopic, protocol, scheme)
+		topicGenerator, err := topic.GetTopicGenerator(ruleConfig.TopicRule, s.defaultTopic, s.protocol, s.scheme)
 		if err != nil {
 			return nil, err
 		}
 		rules = append(rules, Rule{partitionDispatcher: d, topicGenerator: topicGenerator, Filter: f})
 	}
-
-	return &EventRouter{
-		defaultTopic: defaultTopic,
-		rules:        rules,
-	}, nil
+	return rules, nil
 }
 
 // GetTopicForRowChange returns the target topic for row changes.
@@ -147,7 +174,7 @@ func (s *EventRouter) GetDefaultTopic() string {
 }
 
 func (s *EventRouter) matchTopicGenerator(schema, table string) topic.TopicGenerator {
-	for _, rule := range s.rules {
+	for _, rule := range *s.rules.Load() {
 		if !rule.MatchTable(schema, table) {
 			continue
 		}
@@ -158,7 +185,7 @@ func (s *EventRouter) matchTopicGenerator(schema, table string) topic.TopicGener
 }
 
 func (s *EventRouter) matchPartitonGenerator(schema, table string) partition.PartitionGenerator {
-	for _, rule := range s.rules {
+	for _, rule := range *s.rules.Load() {
 		if !rule.MatchTable(schema, table) {
 			continue
 		}
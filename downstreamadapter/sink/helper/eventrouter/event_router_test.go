@@ -0,0 +1,46 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventrouter
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRules(t *testing.T) {
+	router, err := NewEventRouter(&config.SinkConfig{}, config.ProtocolCanalJSON, "test", "kafka")
+	require.NoError(t, err)
+	tables := []*commonEvent.SchemaTableName{{SchemaName: "db", TableName: "t"}}
+	require.Equal(t, []string{"test"}, router.GetActiveTopics(tables))
+
+	err = router.UpdateRules(&config.SinkConfig{
+		DispatchRules: []*config.DispatchRule{
+			{Matcher: []string{"db.*"}, PartitionRule: "default", TopicRule: "{schema}_{table}"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"db_t", "test"}, router.GetActiveTopics(tables))
+
+	// the rules are not changed if the new rules are invalid
+	err = router.UpdateRules(&config.SinkConfig{
+		DispatchRules: []*config.DispatchRule{
+			{Matcher: []string{"db.["}, PartitionRule: "default"},
+		},
+	})
+	require.Error(t, err)
+	require.Equal(t, []string{"db_t", "test"}, router.GetActiveTopics(tables))
+}
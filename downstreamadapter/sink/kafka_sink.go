@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	ticonfig "github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/metrics"
@@ -35,6 +36,8 @@ import (
 	"github.com/pingcap/ticdc/pkg/sink/kafka"
 	v2 "github.com/pingcap/ticdc/pkg/sink/kafka/v2"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/sink"
 	tikafka "github.com/pingcap/tiflow/pkg/sink/kafka"
@@ -44,16 +47,22 @@ import (
 )
 
 type KafkaSink struct {
+	ctx          context.Context
 	changefeedID common.ChangeFeedID
 
 	dmlWorker *worker.KafkaDMLWorker
 	ddlWorker *worker.KafkaDDLWorker
+	// transactionalProducer is used by the dmlWorker in the exactly-once mode, otherwise it's nil.
+	transactionalProducer *producer.KafkaTransactionalDMLProducer
 
 	// the module used by dmlWorker and ddlWorker
+	eventRouter *eventrouter.EventRouter
 	// KafkaSink need to close it when Close() is called
 	adminClient  tikafka.ClusterAdminClient
 	topicManager topicmanager.TopicManager
 	statistics   *metrics.Statistics
+	// sendSyncPointMarker is true if the sync point events are sent as marker messages
+	sendSyncPointMarker bool
 
 	errgroup *errgroup.Group
 	errCh    chan error
@@ -64,7 +73,8 @@ func (s *KafkaSink) SinkType() SinkType {
 	return KafkaSinkType
 }
 
-func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, sinkConfig *ticonfig.SinkConfig, errCh chan error) (*KafkaSink, error) {
+func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL, config *ticonfig.ChangefeedConfig, errCh chan error) (*KafkaSink, error) {
+	sinkConfig := config.SinkConfig
 	errGroup, ctx := errgroup.WithContext(ctx)
 	topic, err := helper.GetTopic(sinkURI)
 	if err != nil {
@@ -83,6 +93,13 @@ func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI
 
 	statistics := metrics.NewStatistics(changefeedID, "KafkaSink")
 
+	if options.ExactlyOnce && config.EnableTableAcrossNodes {
+		// the committed ts is recorded by table, so the spans of a split table
+		// may skip the events not committed yet when they're restarted.
+		return nil, cerror.ErrKafkaInvalidConfig.GenWithStack(
+			"exactly-once can't be enabled with enable-table-across-nodes")
+	}
+
 	factoryCreator := kafka.NewSaramaFactory
 	if utils.GetOrZero(sinkConfig.EnableKafkaSinkV2) {
 		factoryCreator = v2.NewFactory
@@ -135,14 +152,28 @@ func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI
 		return nil, errors.Trace(err)
 	}
 
-	failpointCh := make(chan error, 1)
-	dmlAsyncProducer, err := factory.AsyncProducer(ctx, failpointCh)
-	if err != nil {
-		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
-	}
-
 	metricsCollector := factory.MetricsCollector(utils.RoleProcessor, adminClient)
-	dmlProducer := producer.NewKafkaDMLProducer(ctx, changefeedID, dmlAsyncProducer, metricsCollector)
+	var (
+		dmlProducer           dmlproducer.DMLProducer
+		transactionalProducer *producer.KafkaTransactionalDMLProducer
+	)
+	if options.ExactlyOnce {
+		transactionalProducer, err = newKafkaTransactionalDMLProducer(ctx, changefeedID, factory, metricsCollector)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		errGroup.Go(func() error {
+			return transactionalProducer.Run(ctx)
+		})
+		dmlProducer = transactionalProducer
+	} else {
+		failpointCh := make(chan error, 1)
+		dmlAsyncProducer, err := factory.AsyncProducer(ctx, failpointCh)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+		}
+		dmlProducer = producer.NewKafkaDMLProducer(ctx, changefeedID, dmlAsyncProducer, metricsCollector)
+	}
 	encoderGroup := codec.NewEncoderGroup(ctx, sinkConfig, encoderConfig, changefeedID)
 
 	dmlWorker := worker.NewKafkaWorker(ctx, changefeedID, protocol, dmlProducer, encoderGroup, columnSelector, eventRouter, topicManager, statistics, errGroup)
@@ -161,19 +192,60 @@ func NewKafkaSink(ctx context.Context, changefeedID common.ChangeFeedID, sinkURI
 	ddlWorker := worker.NewKafkaDDLWorker(ctx, changefeedID, protocol, ddlProducer, encoder, eventRouter, topicManager, statistics, errGroup)
 
 	sink := &KafkaSink{
-		changefeedID: changefeedID,
-		dmlWorker:    dmlWorker,
-		ddlWorker:    ddlWorker,
-		adminClient:  adminClient,
-		topicManager: topicManager,
-		statistics:   statistics,
-		errgroup:     errGroup,
-		errCh:        errCh,
+		ctx:                   ctx,
+		changefeedID:          changefeedID,
+		dmlWorker:             dmlWorker,
+		ddlWorker:             ddlWorker,
+		transactionalProducer: transactionalProducer,
+		eventRouter:           eventRouter,
+		adminClient:           adminClient,
+		topicManager:          topicManager,
+		statistics:            statistics,
+		sendSyncPointMarker:   sinkConfig.ShouldSendSyncPointMarker(),
+		errgroup:              errGroup,
+		errCh:                 errCh,
 	}
 	go sink.run()
 	return sink, nil
 }
 
+// newKafkaTransactionalDMLProducer creates the DML producer for the exactly-once mode,
+// the DML messages are sent in kafka transactions, and the DDL messages are still
+// sent by the sync producer, since they're sent after the previous DML events are committed.
+func newKafkaTransactionalDMLProducer(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	factory kafka.Factory,
+	metricsCollector tikafka.MetricsCollector,
+) (*producer.KafkaTransactionalDMLProducer, error) {
+	transactionalID := kafka.NewTransactionalID(ticonfig.GetGlobalServerConfig().AdvertiseAddr, changefeedID)
+	txnProducer, err := factory.TransactionalProducer(ctx, transactionalID, kafka.MarkerTopic(changefeedID))
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+	}
+	dmlProducer, err := producer.NewKafkaTransactionalDMLProducer(ctx, changefeedID, txnProducer, metricsCollector,
+		func(transactionalID string) bool {
+			return isTransactionalIDAlive(changefeedID, transactionalID)
+		})
+	if err != nil {
+		txnProducer.Close()
+		return nil, errors.Trace(err)
+	}
+	return dmlProducer, nil
+}
+
+// isTransactionalIDAlive returns true if the transactional id belongs to an alive capture,
+// the transactional id of a dead capture is fenced before its tables are restarted.
+func isTransactionalIDAlive(changefeedID common.ChangeFeedID, transactionalID string) bool {
+	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
+	for _, info := range nodeManager.GetAliveNodes() {
+		if kafka.NewTransactionalID(info.AdvertiseAddr, changefeedID) == transactionalID {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *KafkaSink) run() {
 	s.dmlWorker.Run()
 	s.ddlWorker.Run()
@@ -200,6 +272,9 @@ func (s *KafkaSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *type
 		return
 	}
 	tableProgress.Add(event)
+	if s.transactionalProducer != nil {
+		s.transactionalProducer.WrapEvent(event)
+	}
 	s.dmlWorker.GetEventChan() <- event
 }
 
@@ -223,16 +298,23 @@ func (s *KafkaSink) WriteBlockEvent(event commonEvent.BlockEvent, tableProgress
 			return errors.Trace(err)
 		}
 	case *commonEvent.SyncPointEvent:
-		log.Error("KafkaSink doesn't support Sync Point Event",
-			zap.String("namespace", s.changefeedID.Namespace()),
-			zap.String("changefeed", s.changefeedID.Name()),
-			zap.Any("event", event))
+		// the marker is only sent when it's enabled explicitly,
+		// since the consumers of the old versions don't recognize it.
+		if !s.sendSyncPointMarker {
+			break
+		}
+		err := s.ddlWorker.WriteSyncPointEvent(event)
+		if err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
 	default:
 		log.Error("KafkaSink doesn't support this type of block event",
 			zap.String("namespace", s.changefeedID.Namespace()),
 			zap.String("changefeed", s.changefeedID.Name()),
 			zap.Any("event type", event.GetType()))
 	}
+	event.PostFlush()
 	return nil
 }
 
@@ -244,6 +326,11 @@ func (s *KafkaSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore)
 	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
 }
 
+// UpdateSinkConfig applies the new dispatch rules, the events sent later are routed by the new rules.
+func (s *KafkaSink) UpdateSinkConfig(sinkConfig *ticonfig.SinkConfig) error {
+	return s.eventRouter.UpdateRules(sinkConfig)
+}
+
 func (s *KafkaSink) Close(removeDDLTsItem bool) error {
 	err := s.ddlWorker.Close()
 	if err != nil {
@@ -263,5 +350,13 @@ func (s *KafkaSink) Close(removeDDLTsItem bool) error {
 }
 
 func (s *KafkaSink) CheckStartTsList(tableIds []int64, startTsList []int64) ([]int64, error) {
+	if s.transactionalProducer == nil {
+		return startTsList, nil
+	}
+	startTsList, err := s.transactionalProducer.CheckStartTsList(s.ctx, tableIds, startTsList)
+	if err != nil {
+		atomic.StoreUint32(&s.isNormal, 0)
+		return nil, errors.Trace(err)
+	}
 	return startTsList, nil
 }
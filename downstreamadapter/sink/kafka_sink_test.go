@@ -0,0 +1,59 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKafkaSinkExactlyOnceConfig(t *testing.T) {
+	sinkURI, err := url.Parse("kafka://127.0.0.1:9092/test?protocol=canal-json&kafka-client-id=test&exactly-once=true")
+	require.NoError(t, err)
+
+	// the tables can't be split into multiple spans in the exactly-once mode
+	replicaConfig := config.GetDefaultReplicaConfig()
+	replicaConfig.Scheduler.EnableTableAcrossNodes = true
+	require.Error(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	replicaConfig.Scheduler.EnableTableAcrossNodes = false
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	changefeedID := common.ChangefeedID4Test("test", "test")
+	cfg := &config.ChangefeedConfig{
+		ChangefeedID:           changefeedID,
+		SinkURI:                sinkURI.String(),
+		SinkConfig:             replicaConfig.Sink,
+		EnableTableAcrossNodes: true,
+	}
+	_, err = NewKafkaSink(context.Background(), changefeedID, sinkURI, cfg, make(chan error, 1))
+	require.ErrorContains(t, err, "enable-table-across-nodes")
+
+	// the transactions are sent by the sarama client when kafka-go is enabled
+	replicaConfig.Sink.EnableKafkaSinkV2 = util.AddressOf(true)
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	// the parameter in the sink uri overrides the kafka config
+	sinkURI, err = url.Parse("kafka://127.0.0.1:9092/test?protocol=canal-json&exactly-once=false")
+	require.NoError(t, err)
+	replicaConfig = config.GetDefaultReplicaConfig()
+	replicaConfig.Sink.KafkaConfig = &config.KafkaConfig{ExactlyOnce: util.AddressOf(true)}
+	replicaConfig.Scheduler.EnableTableAcrossNodes = true
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+}
@@ -17,7 +17,9 @@ import (
 	"context"
 	"database/sql"
 	"net/url"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
@@ -28,6 +30,8 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tiflow/pkg/causality"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
@@ -36,7 +40,11 @@ import (
 )
 
 const (
-	prime = 31
+	// conflictDetectorSlots is the number of slots of the conflict detector,
+	// the conflict keys are hashed into the slots to find the conflicting events.
+	conflictDetectorSlots = 16 * 1024
+	// conflictDetectorCacheSize is the max number of resolved events cached for each worker.
+	conflictDetectorCacheSize = 1024
 )
 
 // MysqlSink is responsible for writing data to mysql downstream.
@@ -44,9 +52,23 @@ const (
 type MysqlSink struct {
 	changefeedID common.ChangeFeedID
 
-	ddlWorker   *worker.MysqlDDLWorker
+	ctx context.Context
+	cfg *mysql.MysqlConfig
+
+	ddlWorker *worker.MysqlDDLWorker
+
+	// dmlLock protects the dml workers and the conflict detector,
+	// they are replaced when the worker count is updated.
+	dmlLock     sync.RWMutex
 	dmlWorker   []*worker.MysqlDMLWorker
 	workerCount int
+	// pendingTxns is the number of the dml events added to the conflict detector but not flushed yet.
+	pendingTxns atomic.Int64
+
+	// conflictDetector dispatches the dml events to the dml workers,
+	// the events modifying the same primary key or unique key are executed in order,
+	// and the others can be executed concurrently, even if they belong to the same table.
+	conflictDetector *causality.ConflictDetector[*commonEvent.DMLEvent]
 
 	db         *sql.DB
 	errgroup   *errgroup.Group
@@ -60,8 +82,7 @@ func NewMysqlSink(ctx context.Context, changefeedID common.ChangeFeedID, workerC
 	errgroup, ctx := errgroup.WithContext(ctx)
 	mysqlSink := MysqlSink{
 		changefeedID: changefeedID,
-		dmlWorker:    make([]*worker.MysqlDMLWorker, workerCount),
-		workerCount:  workerCount,
+		ctx:          ctx,
 		errgroup:     errgroup,
 		statistics:   metrics.NewStatistics(changefeedID, "TxnSink"),
 		errCh:        errCh,
@@ -73,12 +94,22 @@ func NewMysqlSink(ctx context.Context, changefeedID common.ChangeFeedID, workerC
 		return nil, err
 	}
 	cfg.SyncPointRetention = utils.GetOrZero(config.SyncPointRetention)
-
-	for i := 0; i < workerCount; i++ {
-		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
+	if config.TiDBSourceID != 0 {
+		cfg.SourceID = config.TiDBSourceID
 	}
-	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
+	cfg.BDRMode = config.BDRMode
+	if cfg.BDRMode && !cfg.IsWriteSourceExisted {
+		db.Close()
+		return nil, cerror.ErrSinkURIInvalid.GenWithStack(
+			"downstream database does not support BDR mode, please check your config, changefeed: %s",
+			changefeedID.String())
+	}
+
+	mysqlSink.cfg = cfg
 	mysqlSink.db = db
+	mysqlSink.conflictDetector, mysqlSink.dmlWorker = mysqlSink.newDMLWorkers(workerCount)
+	mysqlSink.workerCount = workerCount
+	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
 
 	go mysqlSink.run()
 
@@ -90,29 +121,44 @@ func NewMysqlSinkWithDBAndConfig(ctx context.Context, changefeedID common.Change
 	errgroup, ctx := errgroup.WithContext(ctx)
 	mysqlSink := MysqlSink{
 		changefeedID: changefeedID,
-		dmlWorker:    make([]*worker.MysqlDMLWorker, workerCount),
-		workerCount:  workerCount,
+		ctx:          ctx,
 		errgroup:     errgroup,
 		statistics:   metrics.NewStatistics(changefeedID, "TxnSink"),
 		errCh:        errCh,
 		isNormal:     1,
 	}
 
-	for i := 0; i < workerCount; i++ {
-		mysqlSink.dmlWorker[i] = worker.NewMysqlDMLWorker(ctx, db, cfg, i, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
-	}
-	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
+	mysqlSink.cfg = cfg
 	mysqlSink.db = db
+	mysqlSink.conflictDetector, mysqlSink.dmlWorker = mysqlSink.newDMLWorkers(workerCount)
+	mysqlSink.workerCount = workerCount
+	mysqlSink.ddlWorker = worker.NewMysqlDDLWorker(ctx, db, cfg, mysqlSink.changefeedID, errgroup, mysqlSink.statistics)
 
 	go mysqlSink.run()
 
 	return &mysqlSink, nil
 }
 
+// newDMLWorkers creates the conflict detector and the dml workers receiving the events from it.
+func (s *MysqlSink) newDMLWorkers(workerCount int) (*causality.ConflictDetector[*commonEvent.DMLEvent], []*worker.MysqlDMLWorker) {
+	conflictDetector := causality.NewConflictDetector[*commonEvent.DMLEvent](conflictDetectorSlots, causality.TxnCacheOption{
+		Count:         workerCount,
+		Size:          conflictDetectorCacheSize,
+		BlockStrategy: causality.BlockStrategyWaitEmpty,
+	})
+	dmlWorker := make([]*worker.MysqlDMLWorker, workerCount)
+	for i := 0; i < workerCount; i++ {
+		dmlWorker[i] = worker.NewMysqlDMLWorker(s.ctx, s.db, s.cfg, i, conflictDetector.GetOutChByCacheID(int64(i)), s.changefeedID, s.errgroup, s.statistics)
+	}
+	return conflictDetector, dmlWorker
+}
+
 func (s *MysqlSink) run() {
+	s.dmlLock.RLock()
 	for i := 0; i < s.workerCount; i++ {
 		s.dmlWorker[i].Run()
 	}
+	s.dmlLock.RUnlock()
 	err := s.errgroup.Wait()
 	if errors.Cause(err) != context.Canceled {
 		atomic.StoreUint32(&s.isNormal, 0)
@@ -139,18 +185,76 @@ func (s *MysqlSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore)
 	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
 }
 
+// UpdateSinkConfig applies the new worker count. The dml events are blocked until
+// the pending events are flushed, then they are sent to the new workers.
+func (s *MysqlSink) UpdateSinkConfig(sinkConfig *config.SinkConfig) error {
+	workerCount := getMysqlWorkerCount(sinkConfig)
+	s.dmlLock.Lock()
+	defer s.dmlLock.Unlock()
+	if workerCount == s.workerCount {
+		return nil
+	}
+
+	// the events conflicting with the pending ones must not be executed by the new
+	// workers concurrently, so wait for all pending events to be flushed.
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+	for s.pendingTxns.Load() > 0 {
+		if !s.IsNormal() {
+			return cerror.ErrMySQLTxnError.GenWithStack("mysql sink is abnormal, can not update the worker count")
+		}
+		select {
+		case <-s.ctx.Done():
+			return errors.WithStack(s.ctx.Err())
+		case <-ticker.C:
+		}
+	}
+
+	// start the new workers before stopping the old ones, so the errgroup is never empty
+	conflictDetector, dmlWorker := s.newDMLWorkers(workerCount)
+	for _, w := range dmlWorker {
+		w.Run()
+	}
+	for _, w := range s.dmlWorker {
+		w.Stop()
+	}
+	s.conflictDetector.Close()
+	s.conflictDetector, s.dmlWorker = conflictDetector, dmlWorker
+	log.Info("mysql sink worker count updated",
+		zap.String("changefeed", s.changefeedID.String()),
+		zap.Int("oldWorkerCount", s.workerCount),
+		zap.Int("newWorkerCount", workerCount))
+	s.workerCount = workerCount
+	// keep an extra connection for the prepared statements, see NewMysqlConfigAndDB
+	s.db.SetMaxIdleConns(workerCount + 1)
+	s.db.SetMaxOpenConns(workerCount + 1)
+	return nil
+}
+
+// getMysqlWorkerCount returns the number of dml workers set in the sink config.
+func getMysqlWorkerCount(sinkConfig *config.SinkConfig) int {
+	if sinkConfig != nil && sinkConfig.MySQLConfig != nil {
+		workerCount := utils.GetOrZero(sinkConfig.MySQLConfig.WorkerCount)
+		if workerCount > 0 {
+			return workerCount
+		}
+	}
+	return mysql.DefaultWorkerCount
+}
+
 func (s *MysqlSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *types.TableProgress) {
 	if event.Len() == 0 {
 		return
 	}
 
 	tableProgress.Add(event)
-
-	// Considering that the parity of tableID is not necessarily even,
-	// directly dividing by the number of buckets may cause unevenness between buckets.
-	// Therefore, we first take the modulus of the prime number and then take the modulus of the bucket.
-	index := int64(event.PhysicalTableID) % prime % int64(s.workerCount)
-	s.dmlWorker[index].GetEventChan() <- event
+	// the event is counted as pending under the lock, so UpdateSinkConfig never
+	// waits for an event that is blocked before reaching the conflict detector
+	s.dmlLock.RLock()
+	s.pendingTxns.Add(1)
+	event.AddPostFlushFunc(func() { s.pendingTxns.Add(-1) })
+	s.conflictDetector.Add(event)
+	s.dmlLock.RUnlock()
 }
 
 func (s *MysqlSink) PassBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) {
@@ -183,9 +287,12 @@ func (s *MysqlSink) Close(removeDDLTsItem bool) error {
 	if removeDDLTsItem {
 		return s.ddlWorker.RemoveDDLTsItem()
 	}
+	s.dmlLock.Lock()
 	for i := 0; i < s.workerCount; i++ {
 		s.dmlWorker[i].Close()
 	}
+	s.conflictDetector.Close()
+	s.dmlLock.Unlock()
 
 	s.ddlWorker.Close()
 
@@ -16,6 +16,8 @@ package sink
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,8 +25,10 @@ import (
 	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
 	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -125,7 +129,7 @@ func TestMysqlSinkBasicFunctionality(t *testing.T) {
 	mock.ExpectCommit()
 
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?)").
 		WithArgs(1, "test", 2, "test2").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
@@ -174,7 +178,7 @@ func TestMysqlSinkMeetsDMLError(t *testing.T) {
 	dmlEvent.CommitTs = 2
 
 	mock.ExpectBegin()
-	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?);INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?)").
 		WithArgs(1, "test", 2, "test2").
 		WillReturnError(errors.New("connect: connection refused"))
 	mock.ExpectRollback()
@@ -247,3 +251,126 @@ func TestMysqlSinkMeetsDDLError(t *testing.T) {
 
 	require.Equal(t, sink.IsNormal(), false)
 }
+
+// test the worker count is updated in place, and the events added
+// after the update are flushed by the new workers
+func TestMysqlSinkUpdateWorkerCount(t *testing.T) {
+	sink, mock := mysqlSinkForTest(t)
+	tableProgress := types.NewTableProgress()
+
+	var flushed atomic.Int64
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int primary key, name varchar(32));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.PostTxnFlushed = []func(){
+		func() { flushed.Add(1) },
+	}
+	dmlEvent.CommitTs = 2
+	dmlEvent2 := helper.DML2Event("test", "t", "insert into t values (2, 'test2')")
+	dmlEvent2.PostTxnFlushed = []func(){
+		func() { flushed.Add(1) },
+	}
+	dmlEvent2.CommitTs = 3
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?)").
+		WithArgs(2, "test2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	sink.AddDMLEvent(dmlEvent, tableProgress)
+	// the update waits for the pending event to be flushed
+	err := sink.UpdateSinkConfig(&config.SinkConfig{
+		MySQLConfig: &config.MySQLConfig{WorkerCount: util.AddressOf(4)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), flushed.Load())
+	require.Equal(t, int64(0), sink.pendingTxns.Load())
+	require.Equal(t, 4, sink.workerCount)
+	require.Len(t, sink.dmlWorker, 4)
+
+	sink.AddDMLEvent(dmlEvent2, tableProgress)
+	require.Eventually(t, func() bool {
+		return flushed.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.True(t, sink.IsNormal())
+
+	// the worker count falls back to the default one if it's not set
+	err = sink.UpdateSinkConfig(&config.SinkConfig{})
+	require.NoError(t, err)
+	require.Equal(t, mysql.DefaultWorkerCount, sink.workerCount)
+	require.True(t, sink.IsNormal())
+}
+
+// Test the worker count can be updated while the dml events are added concurrently
+func TestMysqlSinkUpdateWorkerCountConcurrently(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(
+		func(_, _ string) error { return nil })))
+	require.NoError(t, err)
+	mock.MatchExpectationsInOrder(false)
+	cfg := mysql.NewMysqlConfig()
+	cfg.DMLMaxRetry = 1
+	cfg.MaxAllowedPacket = int64(variable.DefMaxAllowedPacket)
+	cfg.CachePrepStmts = false
+	sink, err := NewMysqlSinkWithDBAndConfig(context.Background(), common.ChangefeedID4Test("test", "test"), 2, cfg, db, make(chan error, 16))
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	const eventCount = 50
+	var flushed atomic.Int64
+	events := make([]*commonEvent.DMLEvent, 0, eventCount)
+	for i := 0; i < eventCount; i++ {
+		event := helper.DML2Event("test", "t", fmt.Sprintf("insert into t values (%d, 'test')", i))
+		event.CommitTs = uint64(i + 1)
+		event.PostTxnFlushed = []func(){
+			func() { flushed.Add(1) },
+		}
+		events = append(events, event)
+		mock.ExpectBegin()
+		mock.ExpectExec("").WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+	}
+
+	tableProgress := types.NewTableProgress()
+	go func() {
+		for _, event := range events {
+			sink.AddDMLEvent(event, tableProgress)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		done := make(chan error, 1)
+		go func() {
+			done <- sink.UpdateSinkConfig(&config.SinkConfig{
+				MySQLConfig: &config.MySQLConfig{WorkerCount: util.AddressOf(i%3 + 1)},
+			})
+		}()
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "update the worker count is blocked")
+		}
+	}
+	require.Eventually(t, func() bool {
+		return flushed.Load() == eventCount
+	}, 10*time.Second, 10*time.Millisecond)
+	require.True(t, sink.IsNormal())
+}
@@ -0,0 +1,283 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+
+	pulsarClient "github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/eventrouter"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/helper/topicmanager"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	ticonfig "github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/codec"
+	"github.com/pingcap/ticdc/pkg/sink/pulsar"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink"
+	utils "github.com/pingcap/tiflow/pkg/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// PulsarSink sends the events to pulsar. It shares the dml and ddl workers
+// with the KafkaSink, the only difference is the producers used by the workers.
+type PulsarSink struct {
+	changefeedID common.ChangeFeedID
+
+	dmlWorker *worker.KafkaDMLWorker
+	ddlWorker *worker.KafkaDDLWorker
+
+	// the module used by dmlWorker and ddlWorker
+	eventRouter *eventrouter.EventRouter
+	// PulsarSink need to close it when Close() is called
+	topicManager topicmanager.TopicManager
+	statistics   *metrics.Statistics
+	// sendSyncPointMarker is true if the sync point events are sent as marker messages
+	sendSyncPointMarker bool
+
+	errgroup *errgroup.Group
+	errCh    chan error
+	isNormal uint32 // if sink is normal, isNormal is 1, otherwise is 0
+}
+
+func (s *PulsarSink) SinkType() SinkType {
+	return PulsarSinkType
+}
+
+func NewPulsarSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL,
+	sinkConfig *ticonfig.SinkConfig, errCh chan error,
+) (*PulsarSink, error) {
+	return newPulsarSink(ctx, changefeedID, sinkURI, sinkConfig, errCh,
+		pulsar.NewCreatorFactory, producer.NewPulsarDMLProducer, producer.NewPulsarDDLProducer)
+}
+
+func newPulsarSink(
+	ctx context.Context, changefeedID common.ChangeFeedID, sinkURI *url.URL,
+	sinkConfig *ticonfig.SinkConfig, errCh chan error,
+	clientCreator pulsar.FactoryCreator,
+	dmlProducerCreator producer.PulsarDMLProducerCreator,
+	ddlProducerCreator producer.PulsarDDLProducerCreator,
+) (*PulsarSink, error) {
+	errGroup, ctx := errgroup.WithContext(ctx)
+	topic, err := helper.GetTopic(sinkURI)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	scheme := sink.GetScheme(sinkURI)
+	protocol, err := helper.GetProtocol(utils.GetOrZero(sinkConfig.Protocol))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	pulsarConfig, err := pulsar.NewPulsarConfig(sinkURI, sinkConfig.PulsarConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarInvalidConfig, err)
+	}
+	sinkConfig.PulsarConfig = pulsarConfig
+
+	eventRouter, err := eventrouter.NewEventRouter(sinkConfig, protocol, topic, scheme)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	columnSelector, err := columnselector.NewColumnSelectors(sinkConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	encoderConfig, err := util.GetEncoderConfig(changefeedID, sinkURI, protocol, sinkConfig, ticonfig.DefaultMaxMessageBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// for ddl worker
+	encoder, err := codec.NewEventEncoder(ctx, encoderConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// The dml producer and the ddl producer own their clients after they are created,
+	// the client is closed when the producer is closed.
+	dmlClient, err := clientCreator(pulsarConfig, changefeedID, sinkConfig)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewClient, err)
+	}
+	dmlProducer, err := dmlProducerCreator(ctx, changefeedID, dmlClient, sinkConfig, errCh)
+	if err != nil {
+		closePulsarClient(dmlClient)
+		return nil, errors.Trace(err)
+	}
+
+	ddlClient, err := clientCreator(pulsarConfig, changefeedID, sinkConfig)
+	if err != nil {
+		dmlProducer.Close()
+		return nil, cerror.WrapError(cerror.ErrPulsarNewClient, err)
+	}
+	ddlProducer, err := ddlProducerCreator(ctx, changefeedID, ddlClient, sinkConfig)
+	if err != nil {
+		dmlProducer.Close()
+		closePulsarClient(ddlClient)
+		return nil, errors.Trace(err)
+	}
+
+	topicManager, err := topicmanager.NewPulsarTopicManager(pulsarConfig, dmlClient)
+	if err != nil {
+		dmlProducer.Close()
+		ddlProducer.Close()
+		return nil, errors.Trace(err)
+	}
+
+	statistics := metrics.NewStatistics(changefeedID, "PulsarSink")
+	encoderGroup := codec.NewEncoderGroup(ctx, sinkConfig, encoderConfig, changefeedID)
+	dmlWorker := worker.NewKafkaWorker(ctx, changefeedID, protocol, dmlProducer, encoderGroup, columnSelector, eventRouter, topicManager, statistics, errGroup)
+	ddlWorker := worker.NewKafkaDDLWorker(ctx, changefeedID, protocol, ddlProducer, encoder, eventRouter, topicManager, statistics, errGroup)
+
+	sink := &PulsarSink{
+		changefeedID:        changefeedID,
+		dmlWorker:           dmlWorker,
+		ddlWorker:           ddlWorker,
+		eventRouter:         eventRouter,
+		topicManager:        topicManager,
+		statistics:          statistics,
+		sendSyncPointMarker: sinkConfig.ShouldSendSyncPointMarker(),
+		errgroup:            errGroup,
+		errCh:               errCh,
+		isNormal:            1,
+	}
+	go sink.run()
+	return sink, nil
+}
+
+// closePulsarClient closes the client which is not owned by any producer.
+// It may take a long time to close the client, so close it asynchronously.
+func closePulsarClient(client pulsarClient.Client) {
+	if client != nil {
+		go client.Close()
+	}
+}
+
+func (s *PulsarSink) run() {
+	s.dmlWorker.Run()
+	s.ddlWorker.Run()
+
+	err := s.errgroup.Wait()
+	if errors.Cause(err) != context.Canceled {
+		atomic.StoreUint32(&s.isNormal, 0)
+		select {
+		case s.errCh <- err:
+		default:
+			log.Error("error channel is full, discard error",
+				zap.Any("ChangefeedID", s.changefeedID.String()),
+				zap.Error(err))
+		}
+	}
+}
+
+func (s *PulsarSink) IsNormal() bool {
+	return atomic.LoadUint32(&s.isNormal) == 1
+}
+
+func (s *PulsarSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *types.TableProgress) {
+	if event.Len() == 0 {
+		return
+	}
+	tableProgress.Add(event)
+	s.dmlWorker.GetEventChan() <- event
+}
+
+func (s *PulsarSink) PassBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) {
+	tableProgress.Pass(event)
+	event.PostFlush()
+}
+
+func (s *PulsarSink) WriteBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) error {
+	tableProgress.Add(event)
+	switch event := event.(type) {
+	case *commonEvent.DDLEvent:
+		if event.TiDBOnly {
+			// run callback directly and return
+			event.PostFlush()
+			return nil
+		}
+		err := s.ddlWorker.WriteBlockEvent(event)
+		if err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
+	case *commonEvent.SyncPointEvent:
+		// the marker is only sent when it's enabled explicitly,
+		// since the consumers of the old versions don't recognize it.
+		if !s.sendSyncPointMarker {
+			break
+		}
+		err := s.ddlWorker.WriteSyncPointEvent(event)
+		if err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
+	default:
+		log.Error("PulsarSink doesn't support this type of block event",
+			zap.String("namespace", s.changefeedID.Namespace()),
+			zap.String("changefeed", s.changefeedID.Name()),
+			zap.Any("event type", event.GetType()))
+	}
+	event.PostFlush()
+	return nil
+}
+
+func (s *PulsarSink) AddCheckpointTs(ts uint64) {
+	s.ddlWorker.GetCheckpointTsChan() <- ts
+}
+
+func (s *PulsarSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	s.ddlWorker.SetTableSchemaStore(tableSchemaStore)
+}
+
+// UpdateSinkConfig applies the new dispatch rules, the events sent later are routed by the new rules.
+func (s *PulsarSink) UpdateSinkConfig(sinkConfig *ticonfig.SinkConfig) error {
+	return s.eventRouter.UpdateRules(sinkConfig)
+}
+
+func (s *PulsarSink) Close(removeDDLTsItem bool) error {
+	err := s.ddlWorker.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = s.dmlWorker.Close()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	s.topicManager.Close()
+	s.statistics.Close()
+	return nil
+}
+
+func (s *PulsarSink) CheckStartTsList(tableIds []int64, startTsList []int64) ([]int64, error) {
+	return startTsList, nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/downstreamadapter/worker/producer"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	pulsarConfig "github.com/pingcap/ticdc/pkg/sink/pulsar"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	"github.com/stretchr/testify/require"
+)
+
+func pulsarSinkForTest(t *testing.T) (*PulsarSink, *producer.PulsarMockProducer, *producer.PulsarMockProducer) {
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/test?protocol=canal-json&enable-tidb-extension=true")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+
+	dmlProducer := producer.NewPulsarMockProducer()
+	ddlProducer := producer.NewPulsarMockProducer()
+	changefeedID := common.ChangefeedID4Test("test", "test")
+	sink, err := newPulsarSink(context.Background(), changefeedID, sinkURI, replicaConfig.Sink, make(chan error, 16),
+		pulsarConfig.NewMockCreatorFactory,
+		func(context.Context, common.ChangeFeedID, pulsar.Client, *config.SinkConfig, chan error) (dmlproducer.DMLProducer, error) {
+			return dmlProducer, nil
+		},
+		func(context.Context, common.ChangeFeedID, pulsar.Client, *config.SinkConfig) (ddlproducer.DDLProducer, error) {
+			return ddlProducer, nil
+		})
+	require.NoError(t, err)
+	return sink, dmlProducer, ddlProducer
+}
+
+func TestPulsarSinkBasicFunctionality(t *testing.T) {
+	sink, dmlProducer, ddlProducer := pulsarSinkForTest(t)
+	require.Equal(t, PulsarSinkType, sink.SinkType())
+	sink.SetTableSchemaStore(util.NewTableSchemaStore())
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	tableProgress := types.NewTableProgress()
+	var count atomic.Int64
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Type:       byte(job.Type),
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		TableInfo:  helper.GetTableInfo(job),
+		FinishedTs: job.BinlogInfo.FinishedTS,
+		PostTxnFlushed: []func(){
+			func() { count.Add(1) },
+		},
+	}
+	err := sink.WriteBlockEvent(ddlEvent, tableProgress)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count.Load())
+	require.Len(t, ddlProducer.GetEvents("test"), 1)
+	require.Contains(t, string(ddlProducer.GetEvents("test")[0].Payload), job.Query)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2')")
+	dmlEvent.PostTxnFlushed = []func(){
+		func() { count.Add(1) },
+	}
+	dmlEvent.CommitTs = job.BinlogInfo.FinishedTS + 1
+	sink.AddDMLEvent(dmlEvent, tableProgress)
+
+	// the callback of the transaction is called after all the rows are sent.
+	require.Eventually(t, func() bool {
+		return count.Load() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	_, isEmpty := tableProgress.GetCheckpointTs()
+	require.True(t, isEmpty)
+
+	events := dmlProducer.GetEvents("test")
+	require.Len(t, events, 2)
+	require.Contains(t, string(events[0].Payload), `"data":[{"id":"1","name":"test"}]`)
+	require.Contains(t, string(events[1].Payload), `"data":[{"id":"2","name":"test2"}]`)
+
+	// the checkpoint is broadcast to the default topic when there is no table.
+	sink.AddCheckpointTs(dmlEvent.CommitTs)
+	require.Eventually(t, func() bool {
+		return len(ddlProducer.GetEvents("test")) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the sync point marker is not sent by default, but the event is flushed.
+	syncPointEvent := &commonEvent.SyncPointEvent{
+		CommitTs: dmlEvent.CommitTs + 1,
+		PostTxnFlushed: []func(){
+			func() { count.Add(1) },
+		},
+	}
+	err = sink.WriteBlockEvent(syncPointEvent, tableProgress)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count.Load())
+	require.Len(t, ddlProducer.GetEvents("test"), 2)
+
+	// the sync point is broadcast like the checkpoint, and flushed after it's sent.
+	sink.sendSyncPointMarker = true
+	syncPointEvent = &commonEvent.SyncPointEvent{
+		CommitTs: dmlEvent.CommitTs + 2,
+		PostTxnFlushed: []func(){
+			func() { count.Add(1) },
+		},
+	}
+	err = sink.WriteBlockEvent(syncPointEvent, tableProgress)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), count.Load())
+	events = ddlProducer.GetEvents("test")
+	require.Len(t, events, 3)
+	require.Contains(t, string(events[2].Payload), `"type":"TIDB_SYNCPOINT"`)
+	require.Contains(t, string(events[2].Payload), fmt.Sprintf(`"primaryTs":%d`, syncPointEvent.CommitTs))
+
+	require.True(t, sink.IsNormal())
+	require.NoError(t, sink.Close(false))
+}
+
+type closeCountingClient struct {
+	pulsar.Client
+	closed atomic.Int64
+}
+
+func (c *closeCountingClient) Close() {
+	c.closed.Add(1)
+}
+
+func TestPulsarSinkCloseClientsOnError(t *testing.T) {
+	sinkURI, err := url.Parse("pulsar://127.0.0.1:6650/test?protocol=canal-json")
+	require.NoError(t, err)
+	replicaConfig := config.GetDefaultReplicaConfig()
+	require.NoError(t, replicaConfig.ValidateAndAdjust(sinkURI))
+	changefeedID := common.ChangefeedID4Test("test", "test")
+
+	var clients []*closeCountingClient
+	clientCreator := func(*config.PulsarConfig, common.ChangeFeedID, *config.SinkConfig) (pulsar.Client, error) {
+		client := &closeCountingClient{}
+		clients = append(clients, client)
+		return client, nil
+	}
+	dmlProducerCreator := func(context.Context, common.ChangeFeedID, pulsar.Client, *config.SinkConfig, chan error) (dmlproducer.DMLProducer, error) {
+		return producer.NewPulsarMockProducer(), nil
+	}
+	ddlProducerCreator := func(context.Context, common.ChangeFeedID, pulsar.Client, *config.SinkConfig) (ddlproducer.DDLProducer, error) {
+		return producer.NewPulsarMockProducer(), nil
+	}
+	injectedErr := errors.New("injected error")
+
+	// the dml client is closed if the dml producer is not created.
+	_, err = newPulsarSink(context.Background(), changefeedID, sinkURI, replicaConfig.Sink, make(chan error, 16),
+		clientCreator,
+		func(context.Context, common.ChangeFeedID, pulsar.Client, *config.SinkConfig, chan error) (dmlproducer.DMLProducer, error) {
+			return nil, injectedErr
+		},
+		ddlProducerCreator)
+	require.ErrorIs(t, err, injectedErr)
+	require.Len(t, clients, 1)
+	require.Eventually(t, func() bool {
+		return clients[0].closed.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the ddl client is closed if the ddl producer is not created.
+	clients = nil
+	_, err = newPulsarSink(context.Background(), changefeedID, sinkURI, replicaConfig.Sink, make(chan error, 16),
+		clientCreator, dmlProducerCreator,
+		func(context.Context, common.ChangeFeedID, pulsar.Client, *config.SinkConfig) (ddlproducer.DDLProducer, error) {
+			return nil, injectedErr
+		})
+	require.ErrorIs(t, err, injectedErr)
+	require.Len(t, clients, 2)
+	require.Eventually(t, func() bool {
+		return clients[1].closed.Load() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
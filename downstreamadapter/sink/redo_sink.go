@@ -0,0 +1,168 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/downstreamadapter/worker"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/sink/util"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// RedoSink persists the row and DDL events into the redo log ahead of the downstream sink.
+// An event is only handed over to the downstream sink after it is flushed to the redo log,
+// so the downstream never gets ahead of the redo log, and the redo log can be applied to
+// the downstream by `cdc redo apply` after a disaster.
+type RedoSink struct {
+	changefeedID common.ChangeFeedID
+	// sink is the downstream sink.
+	sink Sink
+
+	dmlWorker *worker.RedoDMLWorker
+	ddlWorker *worker.RedoDDLWorker
+
+	errgroup *errgroup.Group
+	errCh    chan error
+	isNormal uint32 // if sink is normal, isNormal is 1, otherwise is 0
+}
+
+func (s *RedoSink) SinkType() SinkType {
+	return s.sink.SinkType()
+}
+
+func NewRedoSink(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	cfg *config.ConsistentConfig,
+	sink Sink,
+	errCh chan error,
+) (*RedoSink, error) {
+	errGroup, ctx := errgroup.WithContext(ctx)
+	dmlWorker, err := worker.NewRedoDMLWorker(ctx, changefeedID, cfg, errGroup)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ddlWorker, err := worker.NewRedoDDLWorker(ctx, changefeedID, cfg, errGroup)
+	if err != nil {
+		dmlWorker.Close()
+		return nil, errors.Trace(err)
+	}
+	redoSink := &RedoSink{
+		changefeedID: changefeedID,
+		sink:         sink,
+		dmlWorker:    dmlWorker,
+		ddlWorker:    ddlWorker,
+		errgroup:     errGroup,
+		errCh:        errCh,
+		isNormal:     1,
+	}
+	go redoSink.run()
+	return redoSink, nil
+}
+
+func (s *RedoSink) run() {
+	s.dmlWorker.Run()
+	s.ddlWorker.Run()
+
+	err := s.errgroup.Wait()
+	if errors.Cause(err) != context.Canceled {
+		atomic.StoreUint32(&s.isNormal, 0)
+		select {
+		case s.errCh <- err:
+		default:
+			log.Error("error channel is full, discard error",
+				zap.Any("ChangefeedID", s.changefeedID.String()),
+				zap.Error(err))
+		}
+	}
+}
+
+func (s *RedoSink) IsNormal() bool {
+	return atomic.LoadUint32(&s.isNormal) == 1 && s.sink.IsNormal()
+}
+
+// AddDMLEvent writes the event into the redo log, and adds it to the downstream sink
+// after it is flushed. The event is tracked by the table progress from now on,
+// so the checkpointTs of the table can't advance before the event is written to the downstream,
+// and the redo resolvedTs of the table can't advance before the event is flushed to the redo log.
+func (s *RedoSink) AddDMLEvent(event *commonEvent.DMLEvent, tableProgress *types.TableProgress) {
+	if event.Len() == 0 {
+		return
+	}
+	tableProgress.Add(event)
+	tableProgress.AddRedo(event)
+	s.dmlWorker.AddDMLEvent(event, func() {
+		tableProgress.RemoveRedo(event)
+		s.sink.AddDMLEvent(event, tableProgress)
+	})
+}
+
+func (s *RedoSink) PassBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) {
+	s.sink.PassBlockEvent(event, tableProgress)
+}
+
+func (s *RedoSink) WriteBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress) error {
+	// Only DDL events are written to the redo log, sync point events are
+	// meaningless for the redo applier.
+	if event, ok := event.(*commonEvent.DDLEvent); ok {
+		if err := s.ddlWorker.WriteBlockEvent(event); err != nil {
+			atomic.StoreUint32(&s.isNormal, 0)
+			return errors.Trace(err)
+		}
+	}
+	return s.sink.WriteBlockEvent(event, tableProgress)
+}
+
+// AddCheckpointTs updates the redo meta with the checkpointTs of the changefeed.
+func (s *RedoSink) AddCheckpointTs(ts uint64) {
+	s.ddlWorker.AddCheckpointTs(ts)
+	s.sink.AddCheckpointTs(ts)
+}
+
+// AddRedoResolvedTs updates the redo meta with the redo resolvedTs of the changefeed,
+// which is the min redo resolvedTs of all the table spans.
+func (s *RedoSink) AddRedoResolvedTs(ts uint64) {
+	s.ddlWorker.AddResolvedTs(ts)
+}
+
+func (s *RedoSink) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStore) {
+	s.sink.SetTableSchemaStore(tableSchemaStore)
+}
+
+func (s *RedoSink) UpdateSinkConfig(sinkConfig *config.SinkConfig) error {
+	return s.sink.UpdateSinkConfig(sinkConfig)
+}
+
+func (s *RedoSink) CheckStartTsList(tableIds []int64, startTsList []int64) ([]int64, error) {
+	return s.sink.CheckStartTsList(tableIds, startTsList)
+}
+
+func (s *RedoSink) Close(removeDDLTsItem bool) error {
+	if err := s.dmlWorker.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := s.ddlWorker.Close(removeDDLTsItem); err != nil {
+		return errors.Trace(err)
+	}
+	return s.sink.Close(removeDDLTsItem)
+}
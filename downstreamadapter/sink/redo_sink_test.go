@@ -0,0 +1,236 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sink
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/pingcap/ticdc/downstreamadapter/sink/types"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/pkg/applier"
+	"github.com/pingcap/tiflow/pkg/redo"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the events are written into the redo log before written to the downstream,
+// and the redo meta can be read by the redo applier.
+func TestRedoSinkBasicFunctionality(t *testing.T) {
+	mysqlSink, mock := mysqlSinkForTest(t)
+	dir := t.TempDir()
+	cfg := &config.ConsistentConfig{
+		Level:                 string(redo.ConsistentLevelEventual),
+		MaxLogSize:            redo.DefaultMaxLogSize,
+		FlushIntervalInMs:     redo.MinFlushIntervalInMs,
+		MetaFlushIntervalInMs: redo.MinFlushIntervalInMs,
+		EncodingWorkerNum:     2,
+		FlushWorkerNum:        2,
+		Storage:               "file://" + dir,
+	}
+	sink, err := NewRedoSink(context.Background(), common.ChangefeedID4Test("test", "test"), cfg, mysqlSink, make(chan error, 16))
+	require.NoError(t, err)
+	require.Equal(t, MysqlSinkType, sink.SinkType())
+
+	count := 0
+	tableProgress := types.NewTableProgress()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	ddlEvent := &commonEvent.DDLEvent{
+		Query:      job.Query,
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		FinishedTs: 1,
+		BlockedTables: &commonEvent.InfluencedTables{
+			InfluenceType: commonEvent.InfluenceTypeNormal,
+			TableIDs:      []int64{0},
+		},
+		NeedAddedTables: []commonEvent.Table{{TableID: 1, SchemaID: 1}},
+		PostTxnFlushed: []func(){
+			func() { count++ },
+		},
+	}
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')", "insert into t values (2, 'test2');")
+	dmlEvent.PostTxnFlushed = []func(){
+		func() { count++ },
+	}
+	dmlEvent.CommitTs = 2
+
+	mock.ExpectBegin()
+	mock.ExpectExec("USE `test`;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("create table t (id int primary key, name varchar(32));").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE DATABASE IF NOT EXISTS tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("USE tidb_cdc").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS ddl_ts_v1
+		(
+			ticdc_cluster_id varchar (255),
+			changefeed varchar(255),
+			ddl_ts varchar(18),
+			table_id bigint(21),
+			created_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			INDEX (ticdc_cluster_id, changefeed, table_id),
+			PRIMARY KEY (ticdc_cluster_id, changefeed, table_id)
+		);`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO tidb_cdc.ddl_ts_v1 (ticdc_cluster_id, changefeed, ddl_ts, table_id) VALUES ('default', 'test/test', '1', 0), ('default', 'test/test', '1', 1) ON DUPLICATE KEY UPDATE ddl_ts=VALUES(ddl_ts), created_at=CURRENT_TIMESTAMP;").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t` (`id`,`name`) VALUES (?,?),(?,?)").
+		WithArgs(1, "test", 2, "test2").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	err = sink.WriteBlockEvent(ddlEvent, tableProgress)
+	require.NoError(t, err)
+
+	// The event is tracked by the table progress until it is written to the downstream.
+	sink.AddDMLEvent(dmlEvent, tableProgress)
+	ts, isEmpty := tableProgress.GetCheckpointTs()
+	require.Equal(t, uint64(1), ts)
+	require.False(t, isEmpty)
+
+	require.Eventually(t, func() bool {
+		return tableProgress.Empty()
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, mock.ExpectationsWereMet())
+	require.Equal(t, 2, count)
+
+	// Both the row log and the ddl log are written into the storage.
+	var rowLogs, ddlLogs int
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), redo.LogEXT) {
+			continue
+		}
+		if strings.Contains(file.Name(), redo.RedoRowLogFileType) {
+			rowLogs++
+		}
+		if strings.Contains(file.Name(), redo.RedoDDLLogFileType) {
+			ddlLogs++
+		}
+	}
+	require.Positive(t, rowLogs)
+	require.Positive(t, ddlLogs)
+
+	// The redo meta can be read by the redo applier.
+	sink.AddCheckpointTs(1)
+	ap := applier.NewRedoApplier(&applier.RedoApplierConfig{Storage: cfg.Storage, Dir: t.TempDir()})
+	require.Eventually(t, func() bool {
+		checkpointTs, resolvedTs, err := ap.ReadMeta(context.Background())
+		return err == nil && checkpointTs == 1 && resolvedTs == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	require.True(t, sink.IsNormal())
+	require.NoError(t, sink.Close(false))
+}
+
+// Test the resolvedTs of the redo meta is the redo resolvedTs reported for all the tables,
+// so the redo applier can replay the events between the checkpointTs and the resolvedTs.
+func TestRedoSinkMetaWithTablesAtDifferentSpeeds(t *testing.T) {
+	mysqlSink, mock := mysqlSinkForTest(t)
+	dir := t.TempDir()
+	cfg := &config.ConsistentConfig{
+		Level:                 string(redo.ConsistentLevelEventual),
+		MaxLogSize:            redo.DefaultMaxLogSize,
+		FlushIntervalInMs:     redo.MinFlushIntervalInMs,
+		MetaFlushIntervalInMs: redo.MinFlushIntervalInMs,
+		EncodingWorkerNum:     2,
+		FlushWorkerNum:        2,
+		Storage:               "file://" + dir,
+	}
+	sink, err := NewRedoSink(context.Background(), common.ChangefeedID4Test("test", "test"), cfg, mysqlSink, make(chan error, 16))
+	require.NoError(t, err)
+	defer sink.Close(false)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+	helper.DDL2Job("create table t1 (id int primary key, name varchar(32));")
+	helper.DDL2Job("create table t2 (id int primary key, name varchar(32));")
+
+	// The event of the fast table t1 is flushed to the redo log and the downstream,
+	// so the redo resolvedTs of t1 is advanced.
+	fastProgress := types.NewTableProgress()
+	fastEvent := helper.DML2Event("test", "t1", "insert into t1 values (1, 'test')")
+	fastEvent.CommitTs = 10
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t1` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	sink.AddDMLEvent(fastEvent, fastProgress)
+	require.Eventually(t, func() bool {
+		return fastProgress.Empty()
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, mock.ExpectationsWereMet())
+	redoResolvedTs, isEmpty := fastProgress.GetRedoResolvedTs()
+	require.Equal(t, uint64(9), redoResolvedTs)
+	require.True(t, isEmpty)
+
+	// The slow table t2 is only resolved to 7, so the redo resolvedTs of
+	// the changefeed is 7, and the checkpointTs is held back to 5.
+	sink.AddCheckpointTs(5)
+	sink.AddRedoResolvedTs(7)
+	ap := applier.NewRedoApplier(&applier.RedoApplierConfig{Storage: cfg.Storage, Dir: t.TempDir()})
+	require.Eventually(t, func() bool {
+		checkpointTs, resolvedTs, err := ap.ReadMeta(context.Background())
+		return err == nil && checkpointTs == 5 && resolvedTs == 7
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// The slow table catches up.
+	slowProgress := types.NewTableProgress()
+	slowEvent := helper.DML2Event("test", "t2", "insert into t2 values (1, 'test')")
+	slowEvent.CommitTs = 8
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `test`.`t2` (`id`,`name`) VALUES (?,?)").
+		WithArgs(1, "test").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	sink.AddDMLEvent(slowEvent, slowProgress)
+	require.Eventually(t, func() bool {
+		return slowProgress.Empty()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The resolvedTs of the redo meta never goes back.
+	sink.AddCheckpointTs(9)
+	sink.AddRedoResolvedTs(6)
+	require.Eventually(t, func() bool {
+		checkpointTs, resolvedTs, err := ap.ReadMeta(context.Background())
+		return err == nil && checkpointTs == 9 && resolvedTs == 9
+	}, 5*time.Second, 50*time.Millisecond)
+	sink.AddRedoResolvedTs(12)
+	require.Eventually(t, func() bool {
+		checkpointTs, resolvedTs, err := ap.ReadMeta(context.Background())
+		return err == nil && checkpointTs == 9 && resolvedTs == 12
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	sinkutil "github.com/pingcap/ticdc/pkg/sink/util"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/redo"
 	"github.com/pingcap/tiflow/pkg/sink"
 )
 
@@ -31,6 +32,8 @@ type SinkType int
 const (
 	MysqlSinkType SinkType = iota
 	KafkaSinkType
+	CloudStorageSinkType
+	PulsarSinkType
 )
 
 type Sink interface {
@@ -39,6 +42,8 @@ type Sink interface {
 	PassBlockEvent(event commonEvent.BlockEvent, tableProgress *types.TableProgress)
 	AddCheckpointTs(ts uint64)
 	SetTableSchemaStore(tableSchemaStore *sinkutil.TableSchemaStore)
+	// UpdateSinkConfig applies the sink config updated on a running changefeed
+	UpdateSinkConfig(sinkConfig *config.SinkConfig) error
 	CheckStartTsList(tableIds []int64, startTsList []int64) ([]int64, error)
 	Close(removeDDLTsItem bool) error
 	SinkType() SinkType
@@ -46,6 +51,18 @@ type Sink interface {
 }
 
 func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID, errCh chan error) (Sink, error) {
+	sink, err := newDownstreamSink(ctx, config, changefeedID, errCh)
+	if err != nil || sink == nil {
+		return sink, err
+	}
+	// Write the events into the redo log ahead of the downstream sink if redo log is enabled.
+	if config.Consistent != nil && redo.IsConsistentEnabled(config.Consistent.Level) {
+		return NewRedoSink(ctx, changefeedID, config.Consistent, sink, errCh)
+	}
+	return sink, nil
+}
+
+func newDownstreamSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID common.ChangeFeedID, errCh chan error) (Sink, error) {
 	sinkURI, err := url.Parse(config.SinkURI)
 	if err != nil {
 		return nil, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
@@ -53,9 +70,14 @@ func NewSink(ctx context.Context, config *config.ChangefeedConfig, changefeedID
 	scheme := sink.GetScheme(sinkURI)
 	switch scheme {
 	case sink.MySQLScheme, sink.MySQLSSLScheme, sink.TiDBScheme, sink.TiDBSSLScheme:
-		return NewMysqlSink(ctx, changefeedID, 16, config, sinkURI, errCh)
+		return NewMysqlSink(ctx, changefeedID, getMysqlWorkerCount(config.SinkConfig), config, sinkURI, errCh)
 	case sink.KafkaScheme, sink.KafkaSSLScheme:
-		return NewKafkaSink(ctx, changefeedID, sinkURI, config.SinkConfig, errCh)
+		return NewKafkaSink(ctx, changefeedID, sinkURI, config, errCh)
+	case sink.FileScheme, sink.S3Scheme, sink.GCSScheme, sink.GSScheme,
+		sink.AzblobScheme, sink.AzureScheme, sink.CloudStorageNoopScheme:
+		return NewCloudStorageSink(ctx, changefeedID, sinkURI, config.SinkConfig, errCh)
+	case sink.PulsarScheme, sink.PulsarSSLScheme, sink.PulsarHTTPScheme, sink.PulsarHTTPSScheme:
+		return NewPulsarSink(ctx, changefeedID, sinkURI, config.SinkConfig, errCh)
 	}
 	return nil, nil
 }
@@ -36,6 +36,11 @@ type TableProgress struct {
 	elemMap     map[Ts]*list.Element
 	maxCommitTs uint64
 
+	// redoList holds the events which are not flushed to the redo log yet,
+	// it's only used when the redo log is enabled.
+	redoList    *list.List
+	redoElemMap map[Ts]*list.Element
+
 	// cumulate dml event size for a period of time,
 	// it will be cleared after once query
 	cumulateEventSize int64
@@ -55,16 +60,23 @@ func NewTableProgress() *TableProgress {
 		list:              list.New(),
 		elemMap:           make(map[Ts]*list.Element),
 		maxCommitTs:       0,
+		redoList:          list.New(),
+		redoElemMap:       make(map[Ts]*list.Element),
 		cumulateEventSize: 0,
 		lastQueryTime:     time.Now(),
 	}
 }
 
 // Add inserts a new event into the TableProgress.
+// Adding an event which is already in the TableProgress is a no-op,
+// so a sink wrapping another sink can track the event before handing it over.
 func (p *TableProgress) Add(event commonEvent.FlushableEvent) {
 	ts := Ts{startTs: event.GetStartTs(), commitTs: event.GetCommitTs()}
 	p.rwMutex.Lock()
 	defer p.rwMutex.Unlock()
+	if _, ok := p.elemMap[ts]; ok {
+		return
+	}
 	elem := p.list.PushBack(ts)
 	p.elemMap[ts] = elem
 	p.maxCommitTs = event.GetCommitTs()
@@ -87,6 +99,29 @@ func (p *TableProgress) Remove(event commonEvent.Event) {
 	p.cumulateEventSize += event.GetSize()
 }
 
+// AddRedo marks an event added to the TableProgress as not flushed to the redo log yet,
+// RemoveRedo must be called after the event is flushed to the redo log.
+func (p *TableProgress) AddRedo(event commonEvent.FlushableEvent) {
+	ts := Ts{startTs: event.GetStartTs(), commitTs: event.GetCommitTs()}
+	p.rwMutex.Lock()
+	defer p.rwMutex.Unlock()
+	if _, ok := p.redoElemMap[ts]; ok {
+		return
+	}
+	p.redoElemMap[ts] = p.redoList.PushBack(ts)
+}
+
+// RemoveRedo marks an event as flushed to the redo log.
+func (p *TableProgress) RemoveRedo(event commonEvent.Event) {
+	ts := Ts{startTs: event.GetStartTs(), commitTs: event.GetCommitTs()}
+	p.rwMutex.Lock()
+	defer p.rwMutex.Unlock()
+	if elem, ok := p.redoElemMap[ts]; ok {
+		p.redoList.Remove(elem)
+		delete(p.redoElemMap, ts)
+	}
+}
+
 // Empty checks if the TableProgress is empty.
 func (p *TableProgress) Empty() bool {
 	p.rwMutex.RLock()
@@ -122,6 +157,28 @@ func (p *TableProgress) GetCheckpointTs() (uint64, bool) {
 	return p.list.Front().Value.(Ts).commitTs - 1, false
 }
 
+// GetRedoResolvedTs returns the redo resolved timestamp for the table span,
+// all the events with commitTs less than or equal to it are flushed to the redo log.
+// It returns:
+// 1. The commitTs of the earliest event not flushed to the redo log minus 1, if there are such events.
+// 2. The highest commitTs seen minus 1, if all the events are flushed to the redo log.
+// 3. 0, if no events have been processed yet.
+//
+// It also returns a boolean indicating whether all the events are flushed to the redo log.
+// If so and resolvedTs > redoResolvedTs, use resolvedTs as the actual redoResolvedTs.
+func (p *TableProgress) GetRedoResolvedTs() (uint64, bool) {
+	p.rwMutex.RLock()
+	defer p.rwMutex.RUnlock()
+
+	if p.redoList.Len() == 0 {
+		if p.maxCommitTs == 0 {
+			return 0, true
+		}
+		return p.maxCommitTs - 1, true
+	}
+	return p.redoList.Front().Value.(Ts).commitTs - 1, false
+}
+
 // GetEventSizePerSecond returns the sum-dml-event-size/s between the last query time and now.
 // Besides, it clears the cumulateEventSize and update lastQueryTime to prepare for the next query.
 func (p *TableProgress) GetEventSizePerSecond() float32 {
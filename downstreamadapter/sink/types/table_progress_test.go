@@ -56,3 +56,73 @@ func TestTableProgress(t *testing.T) {
 	assert.Equal(t, uint64(3), checkpointTs)
 	assert.True(t, isEmpty)
 }
+
+func TestTableProgressAddTwice(t *testing.T) {
+	tp := NewTableProgress()
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	dmlEvent.StartTs = 1
+	dmlEvent.CommitTs = 2
+
+	// Adding the same event twice only tracks it once.
+	tp.Add(dmlEvent)
+	tp.Add(dmlEvent)
+	assert.Equal(t, 1, tp.list.Len())
+
+	dmlEvent.PostFlush()
+	checkpointTs, isEmpty := tp.GetCheckpointTs()
+	assert.Equal(t, uint64(1), checkpointTs)
+	assert.True(t, isEmpty)
+}
+
+func TestTableProgressRedoResolvedTs(t *testing.T) {
+	tp := NewTableProgress()
+	redoResolvedTs, isEmpty := tp.GetRedoResolvedTs()
+	assert.Equal(t, uint64(0), redoResolvedTs)
+	assert.True(t, isEmpty)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32));")
+	require.NotNil(t, job)
+
+	event1 := helper.DML2Event("test", "t", "insert into t values (1, 'test')")
+	event1.StartTs = 1
+	event1.CommitTs = 2
+	event2 := helper.DML2Event("test", "t", "insert into t values (2, 'test')")
+	event2.StartTs = 3
+	event2.CommitTs = 4
+
+	tp.Add(event1)
+	tp.AddRedo(event1)
+	tp.Add(event2)
+	tp.AddRedo(event2)
+	redoResolvedTs, isEmpty = tp.GetRedoResolvedTs()
+	assert.Equal(t, uint64(1), redoResolvedTs)
+	assert.False(t, isEmpty)
+
+	// The first event is flushed to the redo log, but not to the downstream.
+	tp.RemoveRedo(event1)
+	redoResolvedTs, isEmpty = tp.GetRedoResolvedTs()
+	assert.Equal(t, uint64(3), redoResolvedTs)
+	assert.False(t, isEmpty)
+	checkpointTs, _ := tp.GetCheckpointTs()
+	assert.Equal(t, uint64(1), checkpointTs)
+
+	tp.RemoveRedo(event2)
+	redoResolvedTs, isEmpty = tp.GetRedoResolvedTs()
+	assert.Equal(t, uint64(3), redoResolvedTs)
+	assert.True(t, isEmpty)
+	checkpointTs, isEmpty = tp.GetCheckpointTs()
+	assert.Equal(t, uint64(1), checkpointTs)
+	assert.False(t, isEmpty)
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// checkpointFileName is the name of the file which records the checkpoint ts
+	// of the changefeed in the root directory of the external storage.
+	checkpointFileName = "metadata"
+	// checkpointFlushInterval is the interval to write the checkpoint file.
+	checkpointFlushInterval = 2 * time.Second
+)
+
+type checkpointMetadata struct {
+	CheckpointTs uint64 `json:"checkpoint-ts"`
+}
+
+// CloudStorageDDLWorker writes the schema files of the DDL events and the
+// checkpoint file into the external storage.
+type CloudStorageDDLWorker struct {
+	changefeedID common.ChangeFeedID
+	storage      storage.ExternalStorage
+	config       *cloudstorage.Config
+	statistics   *metrics.Statistics
+
+	checkpointTs        atomic.Uint64
+	flushedCheckpointTs uint64
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
+}
+
+// NewCloudStorageDDLWorker creates a ddl worker for the cloud storage sink.
+func NewCloudStorageDDLWorker(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	storage storage.ExternalStorage,
+	config *cloudstorage.Config,
+	statistics *metrics.Statistics,
+	errGroup *errgroup.Group,
+) *CloudStorageDDLWorker {
+	ctx, cancel := context.WithCancel(ctx)
+	return &CloudStorageDDLWorker{
+		changefeedID: changefeedID,
+		storage:      storage,
+		config:       config,
+		statistics:   statistics,
+		ctx:          ctx,
+		cancel:       cancel,
+		errGroup:     errGroup,
+	}
+}
+
+func (w *CloudStorageDDLWorker) Run() {
+	w.errGroup.Go(func() error {
+		return w.flushCheckpointTs()
+	})
+}
+
+// WriteBlockEvent writes the schema file of the DDL event.
+func (w *CloudStorageDDLWorker) WriteBlockEvent(event *commonEvent.DDLEvent) error {
+	var def cloudstorage.TableDefinition
+	def.FromDDLEvent(event, w.config.OutputColumnID)
+	return w.statistics.RecordDDLExecution(func() error {
+		path, err := def.GenerateSchemaFilePath()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		log.Debug("write ddl event to external storage",
+			zap.String("namespace", w.changefeedID.Namespace()),
+			zap.String("changefeed", w.changefeedID.Name()),
+			zap.String("path", path),
+			zap.String("query", event.Query))
+		encodedDef, err := def.MarshalWithQuery()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(w.storage.WriteFile(w.ctx, path, encodedDef))
+	})
+}
+
+// AddCheckpointTs records the checkpoint ts, which is written into the
+// checkpoint file periodically.
+func (w *CloudStorageDDLWorker) AddCheckpointTs(ts uint64) {
+	for {
+		current := w.checkpointTs.Load()
+		if ts <= current || w.checkpointTs.CompareAndSwap(current, ts) {
+			return
+		}
+	}
+}
+
+func (w *CloudStorageDDLWorker) flushCheckpointTs() error {
+	ticker := time.NewTicker(checkpointFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return errors.Trace(w.ctx.Err())
+		case <-ticker.C:
+			ts := w.checkpointTs.Load()
+			if ts == w.flushedCheckpointTs {
+				continue
+			}
+			data, err := json.Marshal(checkpointMetadata{CheckpointTs: ts})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.storage.WriteFile(w.ctx, checkpointFileName, data); err != nil {
+				return errors.Trace(err)
+			}
+			w.flushedCheckpointTs = ts
+		}
+	}
+}
+
+func (w *CloudStorageDDLWorker) Close() error {
+	w.cancel()
+	return nil
+}
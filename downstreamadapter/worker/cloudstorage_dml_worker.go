@@ -0,0 +1,261 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"bytes"
+	"context"
+	"path"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/ticdc/pkg/sink/cloudstorage"
+	"github.com/pingcap/ticdc/pkg/sink/codec"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// CloudStorageDMLWorker encodes the DML events and writes them into data files
+// of the external storage. Events of the same table are always handled by the
+// same writer, so the files of a table are written in order.
+type CloudStorageDMLWorker struct {
+	changefeedID common.ChangeFeedID
+	eventChan    chan *commonEvent.DMLEvent
+	writers      []*storageWriter
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
+}
+
+// NewCloudStorageDMLWorker creates a dml worker for the cloud storage sink.
+func NewCloudStorageDMLWorker(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	storage storage.ExternalStorage,
+	config *cloudstorage.Config,
+	encoderConfig *newcommon.Config,
+	extension string,
+	statistics *metrics.Statistics,
+	errGroup *errgroup.Group,
+) (*CloudStorageDMLWorker, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	writers := make([]*storageWriter, 0, config.WorkerCount)
+	for i := 0; i < config.WorkerCount; i++ {
+		txnEncoder, err := codec.NewTxnEventEncoder(encoderConfig)
+		if err != nil {
+			cancel()
+			return nil, errors.Trace(err)
+		}
+		writers = append(writers, &storageWriter{
+			id:                i,
+			changefeedID:      changefeedID,
+			storage:           storage,
+			config:            config,
+			encoder:           txnEncoder,
+			filePathGenerator: cloudstorage.NewFilePathGenerator(changefeedID, config, storage, extension, nil),
+			statistics:        statistics,
+			inputCh:           make(chan *commonEvent.DMLEvent, 32),
+			batches:           make(map[cloudstorage.VersionedTableName]*tableBatch),
+		})
+	}
+	return &CloudStorageDMLWorker{
+		changefeedID: changefeedID,
+		eventChan:    make(chan *commonEvent.DMLEvent, 32),
+		writers:      writers,
+		ctx:          ctx,
+		cancel:       cancel,
+		errGroup:     errGroup,
+	}, nil
+}
+
+func (w *CloudStorageDMLWorker) Run() {
+	w.errGroup.Go(func() error {
+		return w.dispatchEvents()
+	})
+	for _, writer := range w.writers {
+		writer := writer
+		w.errGroup.Go(func() error {
+			return writer.run(w.ctx)
+		})
+	}
+}
+
+func (w *CloudStorageDMLWorker) GetEventChan() chan<- *commonEvent.DMLEvent {
+	return w.eventChan
+}
+
+// dispatchEvents dispatches the events to the writers by the physical table id.
+func (w *CloudStorageDMLWorker) dispatchEvents() error {
+	for {
+		select {
+		case <-w.ctx.Done():
+			return errors.Trace(w.ctx.Err())
+		case event := <-w.eventChan:
+			index := uint64(event.PhysicalTableID) % uint64(len(w.writers))
+			select {
+			case <-w.ctx.Done():
+				return errors.Trace(w.ctx.Err())
+			case w.writers[index].inputCh <- event:
+			}
+		}
+	}
+}
+
+func (w *CloudStorageDMLWorker) Close() error {
+	w.cancel()
+	return nil
+}
+
+// tableBatch is the encoded data of a table which is not flushed yet.
+type tableBatch struct {
+	tableInfo *common.TableInfo
+	messages  []*ticommon.Message
+	size      int
+}
+
+// storageWriter accumulates the encoded events per table, and writes them into
+// a new data file when the flush interval is reached or the file size is exceeded.
+type storageWriter struct {
+	id                int
+	changefeedID      common.ChangeFeedID
+	storage           storage.ExternalStorage
+	config            *cloudstorage.Config
+	encoder           encoder.TxnEventEncoder
+	filePathGenerator *cloudstorage.FilePathGenerator
+	statistics        *metrics.Statistics
+
+	inputCh chan *commonEvent.DMLEvent
+	batches map[cloudstorage.VersionedTableName]*tableBatch
+}
+
+func (w *storageWriter) run(ctx context.Context) error {
+	ticker := time.NewTicker(w.config.FlushInterval)
+	defer ticker.Stop()
+
+	log.Info("cloud storage sink writer started",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.Int("workerID", w.id))
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case event := <-w.inputCh:
+			table, err := w.appendEvent(event)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if w.batches[table].size >= w.config.FileSize {
+				if err := w.flushTable(ctx, table); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		case <-ticker.C:
+			for table := range w.batches {
+				if err := w.flushTable(ctx, table); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+	}
+}
+
+func (w *storageWriter) appendEvent(event *commonEvent.DMLEvent) (cloudstorage.VersionedTableName, error) {
+	table := cloudstorage.VersionedTableName{
+		TableNameWithPhysicTableID: common.TableName{
+			Schema:      event.TableInfo.GetSchemaName(),
+			Table:       event.TableInfo.GetTableName(),
+			TableID:     event.PhysicalTableID,
+			IsPartition: event.TableInfo.IsPartitionTable(),
+		},
+		TableInfoVersion: event.TableInfo.GetVersion(),
+	}
+	if err := w.encoder.AppendTxnEvent(event); err != nil {
+		return table, errors.Trace(err)
+	}
+	batch, ok := w.batches[table]
+	if !ok {
+		batch = &tableBatch{tableInfo: event.TableInfo}
+		w.batches[table] = batch
+	}
+	for _, msg := range w.encoder.Build() {
+		batch.messages = append(batch.messages, msg)
+		batch.size += len(msg.Value)
+	}
+	return table, nil
+}
+
+// flushTable writes the schema file if necessary, then writes the index file
+// and the data file of the table, and runs the callbacks of the flushed events.
+func (w *storageWriter) flushTable(ctx context.Context, table cloudstorage.VersionedTableName) error {
+	batch := w.batches[table]
+	delete(w.batches, table)
+	if len(batch.messages) == 0 {
+		return nil
+	}
+
+	if err := w.filePathGenerator.CheckOrWriteSchema(ctx, table, batch.tableInfo); err != nil {
+		return errors.Trace(err)
+	}
+	date := w.filePathGenerator.GenerateDateStr()
+	dataFilePath, err := w.filePathGenerator.GenerateDataFilePath(ctx, table, date)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	indexFilePath := w.filePathGenerator.GenerateIndexFilePath(table, date)
+
+	// The index file records the name of the latest data file, it is written
+	// before the data file, so that the index of a file is never reused.
+	if err := w.storage.WriteFile(ctx, indexFilePath, []byte(path.Base(dataFilePath)+"\n")); err != nil {
+		return errors.Trace(err)
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, batch.size))
+	rowsCount := 0
+	for _, msg := range batch.messages {
+		buf.Write(msg.Value)
+		rowsCount += msg.GetRowsCount()
+	}
+	err = w.statistics.RecordBatchExecution(func() (int, int64, error) {
+		if err := w.storage.WriteFile(ctx, dataFilePath, buf.Bytes()); err != nil {
+			return 0, 0, err
+		}
+		return rowsCount, int64(buf.Len()), nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	log.Debug("cloud storage sink flush data file",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.String("path", dataFilePath),
+		zap.Int("rows", rowsCount),
+		zap.Int("bytes", buf.Len()))
+
+	for _, msg := range batch.messages {
+		if msg.Callback != nil {
+			msg.Callback()
+		}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
@@ -80,17 +81,18 @@ func NewKafkaDDLWorker(
 ) *KafkaDDLWorker {
 	ctx, cancel := context.WithCancel(ctx)
 	return &KafkaDDLWorker{
-		ctx:           ctx,
-		changeFeedID:  id,
-		protocol:      protocol,
-		encoder:       encoder,
-		producer:      producer,
-		eventRouter:   eventRouter,
-		topicManager:  topicManager,
-		statistics:    statistics,
-		partitionRule: getDDLDispatchRule(protocol),
-		cancel:        cancel,
-		errGroup:      errGroup,
+		ctx:              ctx,
+		changeFeedID:     id,
+		protocol:         protocol,
+		checkpointTsChan: make(chan uint64, 16),
+		encoder:          encoder,
+		producer:         producer,
+		eventRouter:      eventRouter,
+		topicManager:     topicManager,
+		statistics:       statistics,
+		partitionRule:    getDDLDispatchRule(protocol),
+		cancel:           cancel,
+		errGroup:         errGroup,
 	}
 }
 
@@ -113,6 +115,15 @@ func (w *KafkaDDLWorker) WriteBlockEvent(event *event.DDLEvent) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// Some protocols, such as avro, do not send the DDL event to the downstream.
+	if message == nil {
+		log.Info("Skip ddl event since the protocol does not encode it",
+			zap.String("namespace", w.changeFeedID.Namespace()),
+			zap.String("changefeed", w.changeFeedID.Name()),
+			zap.String("protocol", w.protocol.String()),
+			zap.String("query", event.Query))
+		return nil
+	}
 
 	topic := w.eventRouter.GetTopicForDDL(event)
 	partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
@@ -139,6 +150,51 @@ func (w *KafkaDDLWorker) WriteBlockEvent(event *event.DDLEvent) error {
 	return nil
 }
 
+// WriteSyncPointEvent broadcasts the sync point to all the partitions of the active topics,
+// so the consumers know all the tables have caught up to the same ts. There is no tso of
+// the downstream like the mysql sink, so the secondary ts is the commit ts as well.
+func (w *KafkaDDLWorker) WriteSyncPointEvent(event *event.SyncPointEvent) error {
+	message, err := w.encoder.EncodeSyncPointEvent(event.GetCommitTs(), event.GetCommitTs())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if message == nil {
+		log.Debug("Skip sync point event since the protocol does not encode it",
+			zap.String("namespace", w.changeFeedID.Namespace()),
+			zap.String("changefeed", w.changeFeedID.Name()),
+			zap.String("protocol", w.protocol.String()),
+			zap.Uint64("primaryTs", event.GetCommitTs()))
+		return nil
+	}
+	return w.statistics.RecordDDLExecution(func() error {
+		return w.broadcastToActiveTopics(event.GetCommitTs(), message)
+	})
+}
+
+// broadcastToActiveTopics sends the message to all the partitions of the topics
+// which the tables at the ts are dispatched to.
+func (w *KafkaDDLWorker) broadcastToActiveTopics(ts uint64, message *ticommon.Message) error {
+	tableNames := w.tableSchemaStore.GetAllTableNames(ts)
+	// NOTICE: When there are no tables to replicate,
+	// we need to send the message to the default topic.
+	// This will be compatible with the old behavior.
+	topics := []string{w.eventRouter.GetDefaultTopic()}
+	if len(tableNames) != 0 {
+		topics = w.eventRouter.GetActiveTopics(tableNames)
+	}
+	for _, topic := range topics {
+		partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = w.producer.SyncBroadcastMessage(w.ctx, topic, partitionNum, message)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents() error {
 	checkpointTsMessageDuration := metrics.CheckpointTsMessageDuration.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
 	checkpointTsMessageCount := metrics.CheckpointTsMessageCount.WithLabelValues(w.changeFeedID.Namespace(), w.changeFeedID.Name())
@@ -161,38 +217,15 @@ func (w *KafkaDDLWorker) encodeAndSendCheckpointEvents() error {
 			}
 			start := time.Now()
 
-			tableNames := w.tableSchemaStore.GetAllTableNames(ts)
 			msg, err := w.encoder.EncodeCheckpointEvent(ts)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			// NOTICE: When there are no tables to replicate,
-			// we need to send checkpoint ts to the default topic.
-			// This will be compatible with the old behavior.
-			if len(tableNames) == 0 {
-				topic := w.eventRouter.GetDefaultTopic()
-				partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
-				if err != nil {
-					return errors.Trace(err)
-				}
-				log.Debug("Emit checkpointTs to default topic",
-					zap.String("topic", topic), zap.Uint64("checkpointTs", ts))
-				err = w.producer.SyncBroadcastMessage(w.ctx, topic, partitionNum, msg)
-				if err != nil {
-					return errors.Trace(err)
-				}
-			} else {
-				topics := w.eventRouter.GetActiveTopics(tableNames)
-				for _, topic := range topics {
-					partitionNum, err := w.topicManager.GetPartitionNum(w.ctx, topic)
-					if err != nil {
-						return errors.Trace(err)
-					}
-					err = w.producer.SyncBroadcastMessage(w.ctx, topic, partitionNum, msg)
-					if err != nil {
-						return errors.Trace(err)
-					}
-				}
+			if msg == nil {
+				continue
+			}
+			if err = w.broadcastToActiveTopics(ts, msg); err != nil {
+				return errors.Trace(err)
 			}
 
 			checkpointTsMessageCount.Inc()
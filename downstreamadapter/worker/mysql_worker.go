@@ -26,19 +26,24 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/mysql"
 	"github.com/pingcap/ticdc/pkg/sink/util"
+	"github.com/pingcap/tiflow/pkg/causality"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
-// MysqlDMLWorker is use to flush the dml event downstream
+// MysqlDMLWorker is use to flush the dml event downstream.
+// The events are received from the conflict detector, events conflicting with
+// each other are never sent to different workers at the same time.
 type MysqlDMLWorker struct {
 	ctx          context.Context
 	errGroup     *errgroup.Group
 	changefeedID common.ChangeFeedID
 
-	eventChan   chan *commonEvent.DMLEvent
+	eventChan   <-chan causality.TxnWithNotifier[*commonEvent.DMLEvent]
 	mysqlWriter *mysql.MysqlWriter
 	id          int
+	// stopCh is closed when the worker is replaced, the worker exits without an error.
+	stopCh chan struct{}
 
 	maxRows int
 }
@@ -48,6 +53,7 @@ func NewMysqlDMLWorker(
 	db *sql.DB,
 	config *mysql.MysqlConfig,
 	id int,
+	eventChan <-chan causality.TxnWithNotifier[*commonEvent.DMLEvent],
 	changefeedID common.ChangeFeedID,
 	errGroup *errgroup.Group,
 	statistics *metrics.Statistics) *MysqlDMLWorker {
@@ -56,16 +62,13 @@ func NewMysqlDMLWorker(
 		mysqlWriter:  mysql.NewMysqlWriter(ctx, db, config, changefeedID, statistics),
 		id:           id,
 		maxRows:      config.MaxTxnRow,
-		eventChan:    make(chan *commonEvent.DMLEvent, 16),
+		eventChan:    eventChan,
 		changefeedID: changefeedID,
 		errGroup:     errGroup,
+		stopCh:       make(chan struct{}),
 	}
 }
 
-func (w *MysqlDMLWorker) GetEventChan() chan *commonEvent.DMLEvent {
-	return w.eventChan
-}
-
 func (w *MysqlDMLWorker) Run() {
 	w.errGroup.Go(func() error {
 		namespace := w.changefeedID.Namespace()
@@ -84,14 +87,21 @@ func (w *MysqlDMLWorker) Run() {
 		totalStart := time.Now()
 
 		events := make([]*commonEvent.DMLEvent, 0)
+		// postTxnExecuted are used to notify the conflict detector after the events are flushed,
+		// so the events conflicting with them can be sent to workers.
+		postTxnExecuted := make([]func(), 0)
 		rows := 0
 		for {
 			needFlush := false
 			select {
 			case <-w.ctx.Done():
 				return errors.Trace(w.ctx.Err())
-			case txnEvent := <-w.eventChan:
+			case <-w.stopCh:
+				return nil
+			case txn := <-w.eventChan:
+				txnEvent := txn.TxnEvent
 				events = append(events, txnEvent)
+				postTxnExecuted = append(postTxnExecuted, txn.PostTxnExecuted)
 				rows += int(txnEvent.Len())
 				if rows > w.maxRows {
 					needFlush = true
@@ -100,9 +110,11 @@ func (w *MysqlDMLWorker) Run() {
 					delay := time.NewTimer(10 * time.Millisecond)
 					for !needFlush {
 						select {
-						case txnEvent := <-w.eventChan:
+						case txn := <-w.eventChan:
+							txnEvent := txn.TxnEvent
 							workerHandledRows.Add(float64(txnEvent.Len()))
 							events = append(events, txnEvent)
+							postTxnExecuted = append(postTxnExecuted, txn.PostTxnExecuted)
 							rows += int(txnEvent.Len())
 							if rows > w.maxRows {
 								needFlush = true
@@ -124,6 +136,9 @@ func (w *MysqlDMLWorker) Run() {
 				if err != nil {
 					return errors.Trace(err)
 				}
+				for _, notify := range postTxnExecuted {
+					notify()
+				}
 				workerFlushDuration.Observe(time.Since(start).Seconds())
 				// we record total time to calcuate the worker busy ratio.
 				// so we record the total time after flushing, to unified statistics on
@@ -131,12 +146,19 @@ func (w *MysqlDMLWorker) Run() {
 				workerTotalDuration.Observe(time.Since(totalStart).Seconds())
 				totalStart = time.Now()
 				events = events[:0]
+				postTxnExecuted = postTxnExecuted[:0]
 				rows = 0
 			}
 		}
 	})
 }
 
+// Stop stops the worker, it's called after all the events sent to the worker are flushed.
+// The mysql writer is not closed since the statement cache is shared with the other workers.
+func (w *MysqlDMLWorker) Stop() {
+	close(w.stopCh)
+}
+
 func (w *MysqlDMLWorker) Close() {
 	w.mysqlWriter.Close()
 }
@@ -0,0 +1,367 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	pkafka "github.com/pingcap/ticdc/pkg/sink/kafka"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/pkg/sink/kafka"
+	"go.uber.org/zap"
+)
+
+// transactionInterval is the interval to commit the kafka transaction,
+// the events are flushed only after the transaction containing them is committed.
+const transactionInterval = time.Second
+
+// committedMarker is sent to the marker topic in each transaction.
+type committedMarker struct {
+	// Tables is the commitTs of the last committed event of each table,
+	// all the events of the table whose commitTs is not larger than it are committed.
+	Tables map[int64]uint64 `json:"tables"`
+}
+
+// stagedEvent is an event whose messages are all in the transaction to be committed.
+type stagedEvent struct {
+	tableID   int64
+	commitTs  uint64
+	callbacks []func()
+}
+
+// KafkaTransactionalDMLProducer sends the DML messages to kafka in transactions,
+// it's used by the kafka sink in the exactly-once mode.
+type KafkaTransactionalDMLProducer struct {
+	id commonType.ChangeFeedID
+	// producer is used to send messages to kafka in transactions.
+	producer pkafka.TransactionalProducer
+	// metricsCollector is used to report metrics.
+	metricsCollector kafka.MetricsCollector
+	// isAlive returns whether the capture owning the transactional id is alive.
+	isAlive func(transactionalID string) bool
+	// fenced is the transactional ids of the dead captures fenced by this producer.
+	fenced map[string]struct{}
+
+	// mu protects the fields below.
+	mu sync.Mutex
+	// messages and callbacks are sent in the next transaction.
+	messages  []*pkafka.TransactionalMessage
+	callbacks []func()
+	// committedTs is the commitTs of the last committed event of each table.
+	committedTs map[int64]uint64
+	// wrapped is the number of the wrapped events not staged yet of each table and commitTs.
+	wrapped map[int64]map[uint64]int
+	closed  bool
+
+	// staged is only accessed in the goroutine committing the transactions.
+	staged []stagedEvent
+}
+
+// NewKafkaTransactionalDMLProducer creates a new kafka transactional producer,
+// the committed ts recorded by the previous producer with the same transactional id
+// is inherited, so it's not lost if the tables are moved to other nodes later.
+// isAlive is used to find the transactional ids of the dead captures to fence.
+func NewKafkaTransactionalDMLProducer(
+	ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	producer pkafka.TransactionalProducer,
+	metricsCollector kafka.MetricsCollector,
+	isAlive func(transactionalID string) bool,
+) (*KafkaTransactionalDMLProducer, error) {
+	log.Info("Starting kafka transactional DML producer ...",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.String("transactionalID", producer.TransactionalID()))
+
+	markers, err := producer.ReadMarkers(ctx)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrKafkaNewProducer, err)
+	}
+	committedTs := make(map[int64]uint64)
+	if marker, ok := markers[producer.TransactionalID()]; ok {
+		if committedTs, err = decodeCommittedMarker(marker); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &KafkaTransactionalDMLProducer{
+		id:               changefeedID,
+		producer:         producer,
+		metricsCollector: metricsCollector,
+		isAlive:          isAlive,
+		fenced:           make(map[string]struct{}),
+		committedTs:      committedTs,
+		wrapped:          make(map[int64]map[uint64]int),
+	}, nil
+}
+
+// Run commits the transaction periodically until the context is done.
+func (k *KafkaTransactionalDMLProducer) Run(ctx context.Context) error {
+	if k.metricsCollector != nil {
+		go k.metricsCollector.Run(ctx)
+	}
+	ticker := time.NewTicker(transactionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Trace(ctx.Err())
+		case <-ticker.C:
+			if err := k.commit(ctx); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// WrapEvent defers the flush of the event until the transaction containing
+// its last message is committed, it must be called before the event is sent to the worker.
+func (k *KafkaTransactionalDMLProducer) WrapEvent(event *commonEvent.DMLEvent) {
+	tableID, commitTs, callbacks := event.PhysicalTableID, event.CommitTs, event.PostTxnFlushed
+	k.mu.Lock()
+	if k.wrapped[tableID] == nil {
+		k.wrapped[tableID] = make(map[uint64]int)
+	}
+	k.wrapped[tableID][commitTs]++
+	k.mu.Unlock()
+	event.PostTxnFlushed = []func(){func() {
+		k.staged = append(k.staged, stagedEvent{
+			tableID:   tableID,
+			commitTs:  commitTs,
+			callbacks: callbacks,
+		})
+	}}
+}
+
+// AsyncSendMessage adds the message to the next transaction.
+func (k *KafkaTransactionalDMLProducer) AsyncSendMessage(
+	_ context.Context, topic string,
+	partition int32, message *common.Message,
+) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	k.messages = append(k.messages, &pkafka.TransactionalMessage{
+		Topic:     topic,
+		Partition: partition,
+		Key:       message.Key,
+		Value:     message.Value,
+	})
+	k.callbacks = append(k.callbacks, message.Callback)
+	return nil
+}
+
+// commit sends the pending messages with the marker in one transaction,
+// and flushes the events after it's committed.
+func (k *KafkaTransactionalDMLProducer) commit(ctx context.Context) error {
+	k.mu.Lock()
+	messages, callbacks := k.messages, k.callbacks
+	k.messages, k.callbacks = nil, nil
+	k.mu.Unlock()
+	if len(messages) == 0 {
+		return nil
+	}
+
+	// The callbacks of the messages only stage the events wrapped by WrapEvent,
+	// an event is staged when all its messages are in this transaction or the committed ones.
+	for _, callback := range callbacks {
+		if callback != nil {
+			callback()
+		}
+	}
+	staged := k.staged
+	k.staged = nil
+
+	k.mu.Lock()
+	committedTs := make(map[int64]uint64, len(k.committedTs))
+	for tableID, ts := range k.committedTs {
+		committedTs[tableID] = ts
+	}
+	for _, event := range staged {
+		k.wrapped[event.tableID][event.commitTs]--
+		if k.wrapped[event.tableID][event.commitTs] == 0 {
+			delete(k.wrapped[event.tableID], event.commitTs)
+		}
+	}
+	for _, event := range staged {
+		if ts := k.resumeTs(event.tableID, event.commitTs); ts > committedTs[event.tableID] {
+			committedTs[event.tableID] = ts
+		}
+	}
+	k.mu.Unlock()
+
+	marker, err := json.Marshal(&committedMarker{Tables: committedTs})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	start := time.Now()
+	if err = k.producer.SendTransaction(ctx, messages, marker); err != nil {
+		log.Error("Kafka transaction failed",
+			zap.String("namespace", k.id.Namespace()),
+			zap.String("changefeed", k.id.Name()),
+			zap.Int("messages", len(messages)),
+			zap.Error(err))
+		return errors.Trace(err)
+	}
+	log.Debug("Kafka transaction committed",
+		zap.String("namespace", k.id.Namespace()),
+		zap.String("changefeed", k.id.Name()),
+		zap.Int("messages", len(messages)),
+		zap.Int("events", len(staged)),
+		zap.Duration("duration", time.Since(start)))
+
+	k.mu.Lock()
+	k.committedTs = committedTs
+	k.mu.Unlock()
+	for _, event := range staged {
+		for _, callback := range event.callbacks {
+			callback()
+		}
+	}
+	return nil
+}
+
+// resumeTs returns the ts recorded in the marker for the staged commitTs of the table,
+// it's below the commitTs of any event wrapped but not staged yet, so the events
+// of a partially emitted commitTs are sent again after restarts instead of being skipped.
+func (k *KafkaTransactionalDMLProducer) resumeTs(tableID int64, commitTs uint64) uint64 {
+	resumeTs := commitTs
+	for ts := range k.wrapped[tableID] {
+		if ts <= resumeTs {
+			resumeTs = ts - 1
+		}
+	}
+	return resumeTs
+}
+
+// CheckStartTsList returns the start ts of the tables to avoid duplicating the
+// committed events, it's the max of the given start ts and the committed ts
+// recorded by all the producers of the changefeed.
+// The tables are moved from a live capture only after their events are committed,
+// but a dead capture may still be committing them, so its transactional id is fenced first.
+func (k *KafkaTransactionalDMLProducer) CheckStartTsList(
+	ctx context.Context, tableIDs []int64, startTsList []int64,
+) ([]int64, error) {
+	markers, err := k.producer.ReadMarkers(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	fenced, err := k.fenceDeadProducers(ctx, markers, tableIDs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if fenced {
+		// the dead producers may commit the transactions before they're fenced
+		if markers, err = k.producer.ReadMarkers(ctx); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	committedTs := make(map[int64]uint64)
+	for _, marker := range markers {
+		tables, err := decodeCommittedMarker(marker)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for tableID, ts := range tables {
+			committedTs[tableID] = max(committedTs[tableID], ts)
+		}
+	}
+	k.mu.Lock()
+	for tableID, ts := range k.committedTs {
+		committedTs[tableID] = max(committedTs[tableID], ts)
+	}
+	k.mu.Unlock()
+
+	result := make([]int64, len(startTsList))
+	for idx, startTs := range startTsList {
+		result[idx] = max(startTs, int64(committedTs[tableIDs[idx]]))
+		if result[idx] != startTs {
+			log.Info("skip the events committed to kafka",
+				zap.String("namespace", k.id.Namespace()),
+				zap.String("changefeed", k.id.Name()),
+				zap.Int64("tableID", tableIDs[idx]),
+				zap.Int64("startTs", startTs),
+				zap.Int64("committedTs", result[idx]))
+		}
+	}
+	return result, nil
+}
+
+// fenceDeadProducers fences the transactional ids of the dead captures which committed
+// any of the tables, it returns true if any transactional id is fenced.
+func (k *KafkaTransactionalDMLProducer) fenceDeadProducers(
+	ctx context.Context, markers map[string][]byte, tableIDs []int64,
+) (bool, error) {
+	fenced := false
+	for transactionalID, marker := range markers {
+		if transactionalID == k.producer.TransactionalID() || k.isAlive(transactionalID) {
+			continue
+		}
+		if _, ok := k.fenced[transactionalID]; ok {
+			continue
+		}
+		tables, err := decodeCommittedMarker(marker)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		for _, tableID := range tableIDs {
+			if _, ok := tables[tableID]; !ok {
+				continue
+			}
+			if err = k.producer.Fence(ctx, transactionalID); err != nil {
+				return false, errors.Trace(err)
+			}
+			k.fenced[transactionalID] = struct{}{}
+			fenced = true
+			break
+		}
+	}
+	return fenced, nil
+}
+
+// Close closes the producer, the messages not committed are dropped,
+// and the events containing them are never flushed.
+func (k *KafkaTransactionalDMLProducer) Close() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.closed {
+		log.Warn("Kafka transactional DML producer already closed",
+			zap.String("namespace", k.id.Namespace()),
+			zap.String("changefeed", k.id.Name()))
+		return
+	}
+	k.closed = true
+	k.messages, k.callbacks = nil, nil
+	k.producer.Close()
+}
+
+func decodeCommittedMarker(value []byte) (map[int64]uint64, error) {
+	marker := &committedMarker{}
+	if err := json.Unmarshal(value, marker); err != nil {
+		return nil, cerror.WrapError(cerror.ErrUnmarshalFailed, err)
+	}
+	if marker.Tables == nil {
+		marker.Tables = make(map[int64]uint64)
+	}
+	return marker.Tables, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	pkafka "github.com/pingcap/ticdc/pkg/sink/kafka"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func newTransactionalDMLProducerForTest(
+	t *testing.T, transactionalID string, store *pkafka.MockTransactionStore,
+) (*KafkaTransactionalDMLProducer, *pkafka.MockTransactionalProducer) {
+	txnProducer := pkafka.NewMockTransactionalProducer(transactionalID, store)
+	p, err := NewKafkaTransactionalDMLProducer(context.Background(),
+		commonType.NewChangeFeedIDWithName("test"), txnProducer, nil,
+		func(transactionalID string) bool { return transactionalID != "dead" })
+	require.NoError(t, err)
+	return p, txnProducer
+}
+
+// sendEvent wraps the event and sends each of its rows as a message,
+// the event is flushed when the callbacks of all the messages are called.
+func sendEvent(
+	t *testing.T, p *KafkaTransactionalDMLProducer,
+	event *commonEvent.DMLEvent, rows int, value string,
+) {
+	p.WrapEvent(event)
+	var called atomic.Int64
+	for i := 0; i < rows; i++ {
+		err := p.AsyncSendMessage(context.Background(), "topic", int32(i), &common.Message{
+			Value: []byte(value),
+			Callback: func() {
+				if called.Inc() == int64(rows) {
+					event.PostFlush()
+				}
+			},
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestTransactionalDMLProducerCommit(t *testing.T) {
+	store := pkafka.NewMockTransactionStore()
+	p, txnProducer := newTransactionalDMLProducerForTest(t, "a", store)
+	ctx := context.Background()
+
+	var flushed atomic.Int64
+	event := &commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 10}
+	event.AddPostFlushFunc(func() { flushed.Inc() })
+	sendEvent(t, p, event, 2, "v1")
+	// The event is not flushed before the transaction is committed.
+	require.Equal(t, int64(0), flushed.Load())
+	require.Empty(t, store.CommittedMessages())
+
+	require.NoError(t, p.commit(ctx))
+	require.Equal(t, int64(1), flushed.Load())
+	require.Len(t, store.CommittedMessages(), 2)
+
+	// The failed transaction is not committed, and the event is not flushed.
+	event = &commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 20}
+	event.AddPostFlushFunc(func() { flushed.Inc() })
+	sendEvent(t, p, event, 1, "v2")
+	txnProducer.InjectError(errors.New("injected error"))
+	require.Error(t, p.commit(ctx))
+	require.Equal(t, int64(1), flushed.Load())
+	require.Len(t, store.CommittedMessages(), 2)
+
+	// The restarted producer skips the committed events.
+	p.Close()
+	p, _ = newTransactionalDMLProducerForTest(t, "a", store)
+	startTsList, err := p.CheckStartTsList(ctx, []int64{1, 2}, []int64{5, 5})
+	require.NoError(t, err)
+	require.Equal(t, []int64{10, 5}, startTsList)
+	startTsList, err = p.CheckStartTsList(ctx, []int64{1}, []int64{15})
+	require.NoError(t, err)
+	require.Equal(t, []int64{15}, startTsList)
+
+	// The committed ts of the previous producer is inherited.
+	event = &commonEvent.DMLEvent{PhysicalTableID: 2, CommitTs: 30}
+	sendEvent(t, p, event, 1, "v3")
+	require.NoError(t, p.commit(ctx))
+	markers, err := txnProducer.ReadMarkers(ctx)
+	require.NoError(t, err)
+	tables, err := decodeCommittedMarker(markers["a"])
+	require.NoError(t, err)
+	require.Equal(t, map[int64]uint64{1: 10, 2: 30}, tables)
+}
+
+func TestTransactionalDMLProducerPartialEvent(t *testing.T) {
+	store := pkafka.NewMockTransactionStore()
+	p, _ := newTransactionalDMLProducerForTest(t, "a", store)
+	ctx := context.Background()
+
+	var flushed atomic.Bool
+	event := &commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 10}
+	event.AddPostFlushFunc(func() { flushed.Store(true) })
+	p.WrapEvent(event)
+	var called atomic.Int64
+	callback := func() {
+		if called.Inc() == 2 {
+			event.PostFlush()
+		}
+	}
+	send := func() {
+		require.NoError(t, p.AsyncSendMessage(ctx, "topic", 0, &common.Message{Callback: callback}))
+	}
+
+	// Only the first message of the event is committed.
+	send()
+	require.NoError(t, p.commit(ctx))
+	require.False(t, flushed.Load())
+	startTsList, err := p.CheckStartTsList(ctx, []int64{1}, []int64{5})
+	require.NoError(t, err)
+	require.Equal(t, []int64{5}, startTsList)
+
+	send()
+	require.NoError(t, p.commit(ctx))
+	require.True(t, flushed.Load())
+
+	// The markers of other producers are considered.
+	other, _ := newTransactionalDMLProducerForTest(t, "b", store)
+	startTsList, err = other.CheckStartTsList(ctx, []int64{1}, []int64{5})
+	require.NoError(t, err)
+	require.Equal(t, []int64{10}, startTsList)
+}
+
+func TestTransactionalDMLProducerPartialCommitTs(t *testing.T) {
+	store := pkafka.NewMockTransactionStore()
+	p, _ := newTransactionalDMLProducerForTest(t, "a", store)
+	ctx := context.Background()
+
+	// Two events of the table have the same commitTs, only the first one is committed.
+	sendEvent(t, p, &commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 10}, 1, "v1")
+	event := &commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 10}
+	p.WrapEvent(event)
+	require.NoError(t, p.commit(ctx))
+	// The restarted table sends the events of commitTs 10 again.
+	startTsList, err := p.CheckStartTsList(ctx, []int64{1}, []int64{5})
+	require.NoError(t, err)
+	require.Equal(t, []int64{9}, startTsList)
+
+	require.NoError(t, p.AsyncSendMessage(ctx, "topic", 0, &common.Message{Callback: event.PostFlush}))
+	// The event with a larger commitTs is not committed yet.
+	p.WrapEvent(&commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 20})
+	require.NoError(t, p.commit(ctx))
+	startTsList, err = p.CheckStartTsList(ctx, []int64{1}, []int64{5})
+	require.NoError(t, err)
+	require.Equal(t, []int64{10}, startTsList)
+}
+
+func TestTransactionalDMLProducerFenceDeadProducer(t *testing.T) {
+	store := pkafka.NewMockTransactionStore()
+	ctx := context.Background()
+	dead, _ := newTransactionalDMLProducerForTest(t, "dead", store)
+	alive, _ := newTransactionalDMLProducerForTest(t, "alive", store)
+	sendEvent(t, dead, &commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 10}, 1, "v1")
+	require.NoError(t, dead.commit(ctx))
+	sendEvent(t, alive, &commonEvent.DMLEvent{PhysicalTableID: 2, CommitTs: 10}, 1, "v2")
+	require.NoError(t, alive.commit(ctx))
+
+	// The tables of the alive capture don't fence any producers.
+	p, _ := newTransactionalDMLProducerForTest(t, "new", store)
+	startTsList, err := p.CheckStartTsList(ctx, []int64{2}, []int64{5})
+	require.NoError(t, err)
+	require.Equal(t, []int64{10}, startTsList)
+	sendEvent(t, dead, &commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 20}, 1, "v3")
+	require.NoError(t, dead.commit(ctx))
+
+	// The dead capture can't commit the events of its tables after they're restarted.
+	startTsList, err = p.CheckStartTsList(ctx, []int64{1}, []int64{5})
+	require.NoError(t, err)
+	require.Equal(t, []int64{20}, startTsList)
+	sendEvent(t, dead, &commonEvent.DMLEvent{PhysicalTableID: 1, CommitTs: 30}, 1, "v4")
+	require.Error(t, dead.commit(ctx))
+	sendEvent(t, alive, &commonEvent.DMLEvent{PhysicalTableID: 2, CommitTs: 30}, 1, "v5")
+	require.NoError(t, alive.commit(ctx))
+	require.Len(t, store.CommittedMessages(), 4)
+}
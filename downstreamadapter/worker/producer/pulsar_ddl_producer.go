@@ -0,0 +1,156 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/metrics/mq"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+// PulsarDDLProducerCreator is the creator of the pulsar DDL producer,
+// it is used to replace the producer in the unit tests.
+// The producer owns the client only if it's created successfully,
+// otherwise the caller should close the client.
+type PulsarDDLProducerCreator func(
+	ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	client pulsar.Client,
+	sinkConfig *config.SinkConfig,
+) (ddlproducer.DDLProducer, error)
+
+// Assert DDLProducer implementation
+var _ ddlproducer.DDLProducer = (*pulsarDDLProducer)(nil)
+
+// pulsarDDLProducer is used to send messages to pulsar synchronously.
+type pulsarDDLProducer struct {
+	id      commonType.ChangeFeedID
+	client  pulsar.Client
+	pConfig *config.PulsarConfig
+	// producers is used to send messages to pulsar, one producer per topic.
+	producers *lru.Cache
+	// closedMu is used to protect `closed`.
+	closedMu sync.RWMutex
+	closed   bool
+}
+
+// NewPulsarDDLProducer creates a new pulsar producer for replicating DDL.
+func NewPulsarDDLProducer(
+	_ context.Context,
+	changefeedID commonType.ChangeFeedID,
+	client pulsar.Client,
+	sinkConfig *config.SinkConfig,
+) (ddlproducer.DDLProducer, error) {
+	log.Info("Starting pulsar DDL producer ...",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()))
+
+	if sinkConfig.PulsarConfig == nil {
+		return nil, cerror.ErrPulsarInvalidConfig.
+			GenWithStackByArgs("pulsar config is empty")
+	}
+	pulsarConfig := sinkConfig.PulsarConfig
+	producers, err := newPulsarProducerCache(pulsarConfig, client)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+	return &pulsarDDLProducer{
+		id:        changefeedID,
+		client:    client,
+		pConfig:   pulsarConfig,
+		producers: producers,
+	}, nil
+}
+
+// SyncBroadcastMessage sends the message to the topic, pulsar consumers
+// consume all the partitions, so totalPartitionsNum is not used.
+func (p *pulsarDDLProducer) SyncBroadcastMessage(ctx context.Context, topic string,
+	totalPartitionsNum int32, message *common.Message,
+) error {
+	return p.SyncSendMessage(ctx, topic, totalPartitionsNum, message)
+}
+
+// SyncSendMessage sends the message to the topic, the partition is chosen by
+// the partition key of the message, so partitionNum is not used.
+func (p *pulsarDDLProducer) SyncSendMessage(ctx context.Context, topic string,
+	partitionNum int32, message *common.Message,
+) error {
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+	if p.closed {
+		return cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+
+	wrapperSchemaAndTopic(message)
+	mq.IncPublishedDDLCount(topic, p.id.Name(), message)
+
+	producer, err := getProducerByTopic(p.producers, p.pConfig, p.client, topic)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+
+	data := &pulsar.ProducerMessage{
+		Payload: message.Value,
+		Key:     message.GetPartitionKey(),
+	}
+	mID, err := producer.Send(ctx, data)
+	if err != nil {
+		log.Error("Pulsar DDL producer send message failed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()),
+			zap.String("topic", topic),
+			zap.Error(err))
+		mq.IncPublishedDDLFail(topic, p.id.Name(), message)
+		return cerror.WrapError(cerror.ErrPulsarSendMessage, err)
+	}
+
+	if message.Type == model.MessageTypeDDL {
+		log.Info("Pulsar DDL producer send ddl message success",
+			zap.Any("mID", mID), zap.String("topic", topic),
+			zap.String("ddl", string(message.Value)))
+	} else {
+		log.Debug("Pulsar DDL producer send message success",
+			zap.Any("mID", mID), zap.String("topic", topic))
+	}
+	mq.IncPublishedDDLSuccess(topic, p.id.Name(), message)
+	return nil
+}
+
+// Close closes all the producers and the client.
+func (p *pulsarDDLProducer) Close() {
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	if p.closed {
+		log.Warn("Pulsar DDL producer already closed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()))
+		return
+	}
+	p.closed = true
+	for _, topic := range p.producers.Keys() {
+		p.producers.Remove(topic)
+	}
+	p.client.Close()
+}
@@ -0,0 +1,312 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/pingcap/log"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/metrics/mq"
+	ticonfig "github.com/pingcap/tiflow/pkg/config"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"go.uber.org/zap"
+)
+
+// PulsarDMLProducerCreator is the creator of the pulsar DML producer,
+// it is used to replace the producer in the unit tests.
+// The producer owns the client only if it's created successfully,
+// otherwise the caller should close the client.
+type PulsarDMLProducerCreator func(
+	ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	client pulsar.Client,
+	sinkConfig *config.SinkConfig,
+	errCh chan error,
+) (dmlproducer.DMLProducer, error)
+
+var _ dmlproducer.DMLProducer = (*pulsarDMLProducer)(nil)
+
+// pulsarDMLProducer is used to send messages to pulsar.
+type pulsarDMLProducer struct {
+	// id indicates which processor (changefeed) this sink belongs to.
+	id commonType.ChangeFeedID
+	// We hold the client to make close operation faster.
+	client pulsar.Client
+	// producers is used to send messages to pulsar.
+	// One topic only use one producer, so we want to have many topics but use less memory,
+	// lru is a good idea to solve this question.
+	producers *lru.Cache
+
+	// closedMu is used to protect `closed`.
+	// We need to ensure that closed producers are never written to.
+	closedMu sync.RWMutex
+	// closed is used to indicate whether the producer is closed.
+	// We also use it to guard against double closes.
+	closed bool
+
+	// errChan is used to report the error of the async send.
+	errChan chan error
+
+	pConfig *config.PulsarConfig
+}
+
+// NewPulsarDMLProducer creates a new pulsar producer for replicating DML.
+func NewPulsarDMLProducer(
+	ctx context.Context,
+	changefeedID commonType.ChangeFeedID,
+	client pulsar.Client,
+	sinkConfig *config.SinkConfig,
+	errCh chan error,
+) (dmlproducer.DMLProducer, error) {
+	log.Info("Creating pulsar DML producer ...",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()))
+	start := time.Now()
+
+	if sinkConfig.PulsarConfig == nil {
+		return nil, cerror.ErrPulsarInvalidConfig.
+			GenWithStackByArgs("pulsar config is empty")
+	}
+	pulsarConfig := sinkConfig.PulsarConfig
+	producers, err := newPulsarProducerCache(pulsarConfig, client)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+
+	p := &pulsarDMLProducer{
+		id:        changefeedID,
+		client:    client,
+		producers: producers,
+		pConfig:   pulsarConfig,
+		closed:    false,
+		errChan:   errCh,
+	}
+	log.Info("Pulsar DML producer created",
+		zap.String("namespace", changefeedID.Namespace()),
+		zap.String("changefeed", changefeedID.Name()),
+		zap.Duration("duration", time.Since(start)))
+	return p, nil
+}
+
+// AsyncSendMessage async send one message, the callback of the message
+// is called after the message is acknowledged by the broker.
+func (p *pulsarDMLProducer) AsyncSendMessage(
+	ctx context.Context, topic string,
+	partition int32, message *common.Message,
+) error {
+	wrapperSchemaAndTopic(message)
+
+	// We have to hold the lock to avoid writing to a closed producer.
+	// Close may be blocked for a long time.
+	p.closedMu.RLock()
+	defer p.closedMu.RUnlock()
+
+	// If producers are closed, we should skip the message and return an error.
+	if p.closed {
+		return cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+	data := &pulsar.ProducerMessage{
+		Payload: message.Value,
+		Key:     message.GetPartitionKey(),
+	}
+
+	producer, err := getProducerByTopic(p.producers, p.pConfig, p.client, topic)
+	if err != nil {
+		return cerror.WrapError(cerror.ErrPulsarNewProducer, err)
+	}
+
+	producer.SendAsync(ctx, data,
+		func(id pulsar.MessageID, m *pulsar.ProducerMessage, err error) {
+			if err != nil {
+				e := cerror.WrapError(cerror.ErrPulsarAsyncSendMessage, err)
+				log.Error("Pulsar DML producer async send error",
+					zap.String("namespace", p.id.Namespace()),
+					zap.String("changefeed", p.id.Name()),
+					zap.Int("messageSize", len(m.Payload)),
+					zap.String("topic", topic),
+					zap.String("schema", message.GetSchema()),
+					zap.Error(err))
+				mq.IncPublishedDMLFail(topic, p.id.Name(), message.GetSchema())
+				// use this select to avoid send error to a closed channel
+				// the ctx will always be called before the errChan is closed
+				select {
+				case <-ctx.Done():
+					return
+				case p.errChan <- e:
+				default:
+					log.Warn("Error channel is full in pulsar DML producer",
+						zap.String("namespace", p.id.Namespace()),
+						zap.String("changefeed", p.id.Name()),
+						zap.Error(e))
+				}
+			} else if message.Callback != nil {
+				message.Callback()
+				mq.IncPublishedDMLSuccess(topic, p.id.Name(), message.GetSchema())
+			}
+		})
+
+	mq.IncPublishedDMLCount(topic, p.id.Name(), message.GetSchema())
+	return nil
+}
+
+func (p *pulsarDMLProducer) Close() {
+	// We have to hold the lock to synchronize closing with writing.
+	p.closedMu.Lock()
+	defer p.closedMu.Unlock()
+	// If the producer has already been closed, we should skip this close operation.
+	if p.closed {
+		// We need to guard against double closing the clients,
+		// which could lead to panic.
+		log.Warn("Pulsar DML producer already closed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()))
+		return
+	}
+	p.closed = true
+	start := time.Now()
+	for _, topic := range p.producers.Keys() {
+		// the evict callback closes the producer of the topic.
+		p.producers.Remove(topic)
+	}
+	p.client.Close()
+	log.Info("Pulsar DML producer closed",
+		zap.String("namespace", p.id.Namespace()),
+		zap.String("changefeed", p.id.Name()),
+		zap.Duration("duration", time.Since(start)))
+}
+
+// newPulsarProducerCache creates the lru cache of the producers, which holds
+// the producer of the default topic. The evicted producers are closed.
+func newPulsarProducerCache(pConfig *config.PulsarConfig, client pulsar.Client) (*lru.Cache, error) {
+	defaultTopicName := pConfig.GetDefaultTopicName()
+	defaultProducer, err := newPulsarProducer(pConfig, client, defaultTopicName)
+	if err != nil {
+		return nil, err
+	}
+
+	producerCacheSize := config.DefaultPulsarProducerCacheSize
+	if pConfig.PulsarProducerCacheSize != nil {
+		producerCacheSize = int(*pConfig.PulsarProducerCacheSize)
+	}
+	producers, err := lru.NewWithEvict(producerCacheSize, func(key interface{}, value interface{}) {
+		pulsarProducer, ok := value.(pulsar.Producer)
+		if ok && pulsarProducer != nil {
+			pulsarProducer.Close()
+		}
+	})
+	if err != nil {
+		defaultProducer.Close()
+		return nil, err
+	}
+	producers.Add(defaultTopicName, defaultProducer)
+	return producers, nil
+}
+
+// newPulsarProducer creates a pulsar producer,
+// one topic is used by one producer.
+func newPulsarProducer(
+	pConfig *config.PulsarConfig,
+	client pulsar.Client,
+	topicName string,
+) (pulsar.Producer, error) {
+	maxReconnectToBroker := uint(config.DefaultMaxReconnectToPulsarBroker)
+	option := pulsar.ProducerOptions{
+		Topic:                topicName,
+		MaxReconnectToBroker: &maxReconnectToBroker,
+	}
+	if pConfig.BatchingMaxMessages != nil {
+		option.BatchingMaxMessages = *pConfig.BatchingMaxMessages
+	}
+	if pConfig.BatchingMaxPublishDelay != nil {
+		option.BatchingMaxPublishDelay = pConfig.BatchingMaxPublishDelay.Duration()
+	}
+	if pConfig.CompressionType != nil {
+		option.CompressionType = pConfig.CompressionType.Value()
+		option.CompressionLevel = pulsar.Default
+	}
+	if pConfig.SendTimeout != nil {
+		option.SendTimeout = pConfig.SendTimeout.Duration()
+	}
+
+	producer, err := client.CreateProducer(option)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("create pulsar producer success", zap.String("topic", topicName))
+	return producer, nil
+}
+
+// getProducerByTopic gets the producer of the topic from the cache,
+// if it does not exist, a new producer is created and added to the cache.
+func getProducerByTopic(
+	producers *lru.Cache,
+	pConfig *config.PulsarConfig,
+	client pulsar.Client,
+	topicName string,
+) (pulsar.Producer, error) {
+	if target, ok := producers.Get(topicName); ok {
+		if producer, ok := target.(pulsar.Producer); ok && producer != nil {
+			return producer, nil
+		}
+	}
+	producer, err := newPulsarProducer(pConfig, client, topicName)
+	if err != nil {
+		return nil, err
+	}
+	producers.Add(topicName, producer)
+	return producer, nil
+}
+
+// wrapperSchemaAndTopic sets the schema and table of the message,
+// if the protocol does not set them.
+func wrapperSchemaAndTopic(m *common.Message) {
+	if m.Schema != nil {
+		return
+	}
+	switch m.Protocol {
+	case ticonfig.ProtocolMaxwell:
+		mx := &maxwellMessage{}
+		err := json.Unmarshal(m.Value, mx)
+		if err != nil {
+			log.Error("unmarshal maxwell message failed", zap.Error(err))
+			return
+		}
+		if len(mx.Database) > 0 {
+			m.Schema = &mx.Database
+		}
+		if len(mx.Table) > 0 {
+			m.Table = &mx.Table
+		}
+	case ticonfig.ProtocolCanal:
+		// canal protocol set multi schemas in one topic
+		schema := "multi_schema"
+		m.Schema = &schema
+	}
+}
+
+// maxwellMessage is the message format of maxwell
+type maxwellMessage struct {
+	Database string `json:"database"`
+	Table    string `json:"table"`
+}
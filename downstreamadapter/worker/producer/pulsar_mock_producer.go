@@ -0,0 +1,135 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package producer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apache/pulsar-client-go/pulsar"
+	commonType "github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/sink/ddlsink/mq/ddlproducer"
+	"github.com/pingcap/tiflow/cdc/sink/dmlsink/mq/dmlproducer"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/sink/codec/common"
+)
+
+var (
+	_ dmlproducer.DMLProducer = (*PulsarMockProducer)(nil)
+	_ ddlproducer.DDLProducer = (*PulsarMockProducer)(nil)
+)
+
+// PulsarMockProducer is a mock pulsar producer, it records the messages
+// sent to each topic in memory instead of sending them to the broker.
+type PulsarMockProducer struct {
+	mu     sync.Mutex
+	events map[string][]*pulsar.ProducerMessage
+	closed bool
+}
+
+// NewPulsarMockProducer creates a mock pulsar producer.
+func NewPulsarMockProducer() *PulsarMockProducer {
+	return &PulsarMockProducer{
+		events: make(map[string][]*pulsar.ProducerMessage),
+	}
+}
+
+// NewMockPulsarDMLProducer creates a mock producer for replicating DML,
+// it matches the signature of PulsarDMLProducerCreator.
+func NewMockPulsarDMLProducer(
+	_ context.Context,
+	_ commonType.ChangeFeedID,
+	_ pulsar.Client,
+	_ *config.SinkConfig,
+	_ chan error,
+) (dmlproducer.DMLProducer, error) {
+	return NewPulsarMockProducer(), nil
+}
+
+// NewMockPulsarDDLProducer creates a mock producer for replicating DDL,
+// it matches the signature of PulsarDDLProducerCreator.
+func NewMockPulsarDDLProducer(
+	_ context.Context,
+	_ commonType.ChangeFeedID,
+	_ pulsar.Client,
+	_ *config.SinkConfig,
+) (ddlproducer.DDLProducer, error) {
+	return NewPulsarMockProducer(), nil
+}
+
+// AsyncSendMessage records the message and calls its callback immediately.
+func (p *PulsarMockProducer) AsyncSendMessage(
+	ctx context.Context, topic string, partition int32, message *common.Message,
+) error {
+	if err := p.record(topic, message); err != nil {
+		return err
+	}
+	if message.Callback != nil {
+		message.Callback()
+	}
+	return nil
+}
+
+// SyncBroadcastMessage records the message.
+func (p *PulsarMockProducer) SyncBroadcastMessage(
+	ctx context.Context, topic string, totalPartitionsNum int32, message *common.Message,
+) error {
+	return p.record(topic, message)
+}
+
+// SyncSendMessage records the message.
+func (p *PulsarMockProducer) SyncSendMessage(
+	ctx context.Context, topic string, partitionNum int32, message *common.Message,
+) error {
+	return p.record(topic, message)
+}
+
+func (p *PulsarMockProducer) record(topic string, message *common.Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return cerror.ErrPulsarProducerClosed.GenWithStackByArgs()
+	}
+	p.events[topic] = append(p.events[topic], &pulsar.ProducerMessage{
+		Payload: message.Value,
+		Key:     message.GetPartitionKey(),
+	})
+	return nil
+}
+
+// Close marks the producer as closed, the recorded messages are kept.
+func (p *PulsarMockProducer) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+}
+
+// GetAllEvents returns the messages received by the mock producer.
+func (p *PulsarMockProducer) GetAllEvents() []*pulsar.ProducerMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var events []*pulsar.ProducerMessage
+	for _, v := range p.events {
+		events = append(events, v...)
+	}
+	return events
+}
+
+// GetEvents returns the messages sent to the topic.
+func (p *PulsarMockProducer) GetEvents(topic string) []*pulsar.ProducerMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*pulsar.ProducerMessage(nil), p.events[topic]...)
+}
@@ -0,0 +1,183 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	tiredo "github.com/pingcap/tiflow/cdc/redo"
+	"github.com/pingcap/tiflow/cdc/redo/writer"
+	"github.com/pingcap/tiflow/pkg/redo"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// RedoDDLWorker writes the DDL events into the redo log, and maintains the
+// redo meta(checkpointTs and resolvedTs) of the changefeed.
+//
+// The redo meta is only maintained by the node which receives the checkpointTs
+// of the changefeed, that is, the node holding the table trigger event dispatcher.
+type RedoDDLWorker struct {
+	changefeedID common.ChangeFeedID
+	cfg          *config.ConsistentConfig
+	writer       writer.RedoLogWriter
+
+	checkpointTs atomic.Uint64
+	resolvedTs   atomic.Uint64
+
+	metaMu sync.Mutex
+	// metaManager is created when the first checkpointTs is received.
+	metaManager tiredo.MetaManager
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
+}
+
+// NewRedoDDLWorker creates a ddl worker for the redo sink.
+func NewRedoDDLWorker(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	cfg *config.ConsistentConfig,
+	errGroup *errgroup.Group,
+) (*RedoDDLWorker, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	logWriter, err := newRedoLogWriter(ctx, changefeedID, cfg, redo.RedoDDLLogFileType)
+	if err != nil {
+		cancel()
+		return nil, errors.Trace(err)
+	}
+	return &RedoDDLWorker{
+		changefeedID: changefeedID,
+		cfg:          cfg,
+		writer:       logWriter,
+		ctx:          ctx,
+		cancel:       cancel,
+		errGroup:     errGroup,
+	}, nil
+}
+
+func (w *RedoDDLWorker) Run() {
+	w.errGroup.Go(func() error {
+		return w.updateMeta()
+	})
+}
+
+// WriteBlockEvent writes the DDL event into the redo log and flushes it.
+func (w *RedoDDLWorker) WriteBlockEvent(event *commonEvent.DDLEvent) error {
+	if err := w.writer.WriteEvents(w.ctx, event); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.writer.FlushLog(w.ctx); err != nil {
+		return errors.Trace(err)
+	}
+	log.Info("redo ddl worker write ddl event",
+		zap.String("namespace", w.changefeedID.Namespace()),
+		zap.String("changefeed", w.changefeedID.Name()),
+		zap.String("query", event.Query),
+		zap.Uint64("commitTs", event.GetCommitTs()))
+	return nil
+}
+
+// AddCheckpointTs records the checkpointTs of the changefeed, which is written
+// into the redo meta periodically. The redo applier replays the redo logs from it.
+func (w *RedoDDLWorker) AddCheckpointTs(checkpointTs uint64) {
+	updateMax(&w.checkpointTs, checkpointTs)
+}
+
+// AddResolvedTs records the redo resolvedTs of the changefeed, which is written
+// into the redo meta periodically. The redo applier replays the redo logs up to it.
+//
+// The resolvedTs must be the min redo resolvedTs of all the table spans, otherwise
+// the redo applier restores an inconsistent snapshot.
+func (w *RedoDDLWorker) AddResolvedTs(resolvedTs uint64) {
+	updateMax(&w.resolvedTs, resolvedTs)
+}
+
+func (w *RedoDDLWorker) updateMeta() error {
+	flushIntervalInMs := w.cfg.MetaFlushIntervalInMs
+	if flushIntervalInMs < redo.MinFlushIntervalInMs {
+		flushIntervalInMs = redo.DefaultMetaFlushIntervalInMs
+	}
+	ticker := time.NewTicker(time.Duration(flushIntervalInMs) * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.ctx.Done():
+			return errors.Trace(w.ctx.Err())
+		case <-ticker.C:
+			checkpointTs := w.checkpointTs.Load()
+			if checkpointTs == 0 {
+				continue
+			}
+			// The events are flushed to the redo log before written to the downstream,
+			// so the resolvedTs is never less than the checkpointTs, unless it's not reported yet.
+			resolvedTs := max(checkpointTs, w.resolvedTs.Load())
+			w.getOrCreateMetaManager(checkpointTs).UpdateMeta(checkpointTs, resolvedTs)
+		}
+	}
+}
+
+func (w *RedoDDLWorker) getOrCreateMetaManager(startTs uint64) tiredo.MetaManager {
+	w.metaMu.Lock()
+	defer w.metaMu.Unlock()
+	if w.metaManager == nil {
+		metaManager := tiredo.NewMetaManager(toRedoChangefeedID(w.changefeedID), toRedoConsistentConfig(w.cfg), startTs)
+		w.errGroup.Go(func() error {
+			return metaManager.Run(w.ctx)
+		})
+		w.metaManager = metaManager
+		log.Info("redo meta manager started",
+			zap.String("namespace", w.changefeedID.Namespace()),
+			zap.String("changefeed", w.changefeedID.Name()),
+			zap.Uint64("startTs", startTs))
+	}
+	return w.metaManager
+}
+
+// Close closes the worker, all the redo logs and the redo meta are removed
+// if the changefeed is removed.
+func (w *RedoDDLWorker) Close(removeChangefeed bool) error {
+	w.cancel()
+	err := w.writer.Close()
+	if err != nil && errors.Cause(err) != context.Canceled {
+		return errors.Trace(err)
+	}
+
+	w.metaMu.Lock()
+	defer w.metaMu.Unlock()
+	if removeChangefeed && w.metaManager != nil && w.metaManager.Running() {
+		if err := w.metaManager.Cleanup(context.Background()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func updateMax(ts *atomic.Uint64, value uint64) {
+	for {
+		current := ts.Load()
+		if value <= current || ts.CompareAndSwap(current, value) {
+			return
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/redo/writer"
+	"github.com/pingcap/tiflow/cdc/redo/writer/factory"
+	ticonfig "github.com/pingcap/tiflow/pkg/config"
+	"github.com/pingcap/tiflow/pkg/redo"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+type redoDMLTask struct {
+	event *commonEvent.DMLEvent
+	// callback is called after the rows of the event are flushed to the redo log.
+	callback func()
+}
+
+// RedoDMLWorker writes the rows of the DML events into the redo log.
+// The rows are flushed to the redo storage periodically, and the callback of
+// each event is called in order after the event is flushed, so the event can be
+// written to the downstream only after it is persisted in the redo log.
+type RedoDMLWorker struct {
+	changefeedID  common.ChangeFeedID
+	writer        writer.RedoLogWriter
+	flushInterval time.Duration
+	eventChan     chan *redoDMLTask
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	errGroup *errgroup.Group
+}
+
+// NewRedoDMLWorker creates a dml worker for the redo sink.
+func NewRedoDMLWorker(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	cfg *config.ConsistentConfig,
+	errGroup *errgroup.Group,
+) (*RedoDMLWorker, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	logWriter, err := newRedoLogWriter(ctx, changefeedID, cfg, redo.RedoRowLogFileType)
+	if err != nil {
+		cancel()
+		return nil, errors.Trace(err)
+	}
+	flushIntervalInMs := cfg.FlushIntervalInMs
+	if flushIntervalInMs < redo.MinFlushIntervalInMs {
+		flushIntervalInMs = redo.DefaultFlushIntervalInMs
+	}
+	return &RedoDMLWorker{
+		changefeedID:  changefeedID,
+		writer:        logWriter,
+		flushInterval: time.Duration(flushIntervalInMs) * time.Millisecond,
+		eventChan:     make(chan *redoDMLTask, 32),
+		ctx:           ctx,
+		cancel:        cancel,
+		errGroup:      errGroup,
+	}, nil
+}
+
+func (w *RedoDMLWorker) Run() {
+	w.errGroup.Go(func() error {
+		return w.writeEvents()
+	})
+}
+
+// AddDMLEvent writes the event into the redo log, the callback is called
+// after the event is flushed.
+func (w *RedoDMLWorker) AddDMLEvent(event *commonEvent.DMLEvent, callback func()) {
+	select {
+	case <-w.ctx.Done():
+	case w.eventChan <- &redoDMLTask{event: event, callback: callback}:
+	}
+}
+
+func (w *RedoDMLWorker) writeEvents() error {
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	var pending []*redoDMLTask
+	for {
+		select {
+		case <-w.ctx.Done():
+			return errors.Trace(w.ctx.Err())
+		case task := <-w.eventChan:
+			rows, err := task.event.ToRedoRowEvents()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			events := make([]writer.RedoEvent, 0, len(rows))
+			for _, row := range rows {
+				events = append(events, row)
+			}
+			if err := w.writer.WriteEvents(w.ctx, events...); err != nil {
+				return errors.Trace(err)
+			}
+			pending = append(pending, task)
+		case <-ticker.C:
+			if len(pending) == 0 {
+				continue
+			}
+			if err := w.writer.FlushLog(w.ctx); err != nil {
+				return errors.Trace(err)
+			}
+			for _, task := range pending {
+				task.callback()
+			}
+			log.Debug("redo dml worker flush events",
+				zap.String("namespace", w.changefeedID.Namespace()),
+				zap.String("changefeed", w.changefeedID.Name()),
+				zap.Int("count", len(pending)))
+			pending = nil
+		}
+	}
+}
+
+func (w *RedoDMLWorker) Close() error {
+	w.cancel()
+	err := w.writer.Close()
+	if err != nil && errors.Cause(err) != context.Canceled {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// newRedoLogWriter creates a redo log writer which writes the redo logs
+// in the same format as TiCDC(tiflow), so the logs can be applied by the redo applier.
+func newRedoLogWriter(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	cfg *config.ConsistentConfig,
+	logType string,
+) (writer.RedoLogWriter, error) {
+	return factory.NewRedoLogWriter(ctx, &writer.LogWriterConfig{
+		ConsistentConfig:  *toRedoConsistentConfig(cfg),
+		LogType:           logType,
+		CaptureID:         config.GetGlobalServerConfig().AdvertiseAddr,
+		ChangeFeedID:      toRedoChangefeedID(changefeedID),
+		MaxLogSizeInBytes: cfg.MaxLogSize * redo.Megabyte,
+	})
+}
+
+func toRedoChangefeedID(changefeedID common.ChangeFeedID) model.ChangeFeedID {
+	return model.ChangeFeedID{
+		Namespace: changefeedID.Namespace(),
+		ID:        changefeedID.Name(),
+	}
+}
+
+// toRedoConsistentConfig converts the consistent config to the one used by the redo module.
+func toRedoConsistentConfig(cfg *config.ConsistentConfig) *ticonfig.ConsistentConfig {
+	consistentConfig := &ticonfig.ConsistentConfig{
+		Level:                 cfg.Level,
+		MaxLogSize:            cfg.MaxLogSize,
+		FlushIntervalInMs:     cfg.FlushIntervalInMs,
+		MetaFlushIntervalInMs: cfg.MetaFlushIntervalInMs,
+		EncodingWorkerNum:     cfg.EncodingWorkerNum,
+		FlushWorkerNum:        cfg.FlushWorkerNum,
+		Storage:               cfg.Storage,
+		UseFileBackend:        cfg.UseFileBackend,
+		Compression:           cfg.Compression,
+		FlushConcurrency:      cfg.FlushConcurrency,
+	}
+	if cfg.MemoryUsage != nil {
+		consistentConfig.MemoryUsage = &ticonfig.ConsistentMemoryUsage{
+			MemoryQuotaPercentage: cfg.MemoryUsage.MemoryQuotaPercentage,
+		}
+	}
+	return consistentConfig
+}
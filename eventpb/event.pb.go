@@ -527,6 +527,7 @@ type RegisterDispatcherRequest struct {
 	EnableSyncPoint   bool                      `protobuf:"varint,8,opt,name=enable_sync_point,json=enableSyncPoint,proto3" json:"enable_sync_point,omitempty"`
 	SyncPointTs       uint64                    `protobuf:"varint,9,opt,name=sync_point_ts,json=syncPointTs,proto3" json:"sync_point_ts,omitempty"`
 	SyncPointInterval uint64                    `protobuf:"varint,10,opt,name=sync_point_interval,json=syncPointInterval,proto3" json:"sync_point_interval,omitempty"`
+	BdrMode           bool                      `protobuf:"varint,11,opt,name=bdr_mode,json=bdrMode,proto3" json:"bdr_mode,omitempty"`
 }
 
 func (m *RegisterDispatcherRequest) Reset()         { *m = RegisterDispatcherRequest{} }
@@ -632,6 +633,13 @@ func (m *RegisterDispatcherRequest) GetSyncPointInterval() uint64 {
 	return 0
 }
 
+func (m *RegisterDispatcherRequest) GetBdrMode() bool {
+	if m != nil {
+		return m.BdrMode
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterEnum("eventpb.OpType", OpType_name, OpType_value)
 	proto.RegisterEnum("eventpb.ActionType", ActionType_name, ActionType_value)
@@ -648,65 +656,66 @@ func init() {
 func init() { proto.RegisterFile("eventpb/event.proto", fileDescriptor_d7fb2554dfcf7f7d) }
 
 var fileDescriptor_d7fb2554dfcf7f7d = []byte{
-	// 918 bytes of a gzipped FileDescriptorProto
+	// 932 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x41, 0x6f, 0xe3, 0x44,
-	0x14, 0x8e, 0x93, 0x34, 0x89, 0x9f, 0xd3, 0x5d, 0x77, 0xba, 0x5d, 0xdc, 0x2d, 0x84, 0x90, 0x03,
-	0x0a, 0x95, 0x48, 0x21, 0x80, 0x90, 0x56, 0x68, 0xa5, 0xd2, 0x7a, 0x91, 0x0f, 0xdb, 0x46, 0x63,
-	0x77, 0x25, 0xb8, 0x58, 0x8e, 0x3d, 0x49, 0x0d, 0xee, 0xd8, 0xf5, 0x4c, 0xb2, 0xc9, 0xbf, 0x80,
-	0x9f, 0xc0, 0xbf, 0xe1, 0xb8, 0x47, 0x6e, 0xa0, 0xf6, 0xc0, 0x9f, 0xe0, 0x80, 0x3c, 0xe3, 0xd8,
-	0xce, 0x16, 0x21, 0x71, 0xca, 0xcc, 0xfb, 0xbe, 0x37, 0xf3, 0xbe, 0xef, 0xcd, 0x73, 0x60, 0x9f,
-	0x2c, 0x09, 0xe5, 0xc9, 0xf4, 0x44, 0xfc, 0x8e, 0x92, 0x34, 0xe6, 0x31, 0x6a, 0xe7, 0xc1, 0x67,
-	0x47, 0xd7, 0xc4, 0x4b, 0xf9, 0x94, 0x78, 0x19, 0xa3, 0x58, 0x4b, 0xd6, 0xe0, 0x8f, 0x3a, 0x3c,
-	0x36, 0x33, 0xe2, 0xcb, 0x30, 0xe2, 0x24, 0xc5, 0x8b, 0x88, 0x20, 0x03, 0xda, 0x37, 0x1e, 0xf7,
-	0xaf, 0x49, 0x6a, 0x28, 0xfd, 0xc6, 0x50, 0xc5, 0x9b, 0x2d, 0xfa, 0x08, 0xba, 0xe1, 0x9c, 0xc6,
-	0x29, 0x71, 0xc5, 0xe1, 0x46, 0x5d, 0xc0, 0x9a, 0x8c, 0x89, 0x63, 0xd0, 0x07, 0x00, 0x39, 0x85,
-	0xdd, 0x46, 0x46, 0x43, 0x10, 0x54, 0x19, 0xb1, 0x6f, 0x23, 0xf4, 0x35, 0x18, 0x39, 0x1c, 0x52,
-	0x46, 0x52, 0xee, 0x2e, 0xbd, 0x68, 0x41, 0x5c, 0xb2, 0x4a, 0x52, 0xa3, 0xd9, 0x57, 0x86, 0x2a,
-	0x3e, 0x90, 0xb8, 0x25, 0xe0, 0xd7, 0x19, 0x6a, 0xae, 0x92, 0x14, 0xbd, 0x80, 0xf7, 0xf3, 0xc4,
-	0x45, 0x12, 0x78, 0x9c, 0xb8, 0x94, 0xbc, 0xa9, 0x26, 0xef, 0x88, 0xe4, 0xfc, 0xf0, 0x2b, 0x41,
-	0xb9, 0x20, 0x6f, 0xfe, 0x23, 0x3f, 0x8e, 0x82, 0x6a, 0x7e, 0xeb, 0x61, 0xfe, 0x65, 0x14, 0x94,
-	0xf9, 0x65, 0xe1, 0x01, 0x89, 0x08, 0x27, 0xd5, 0xdc, 0x76, 0xb5, 0xf0, 0x73, 0x01, 0x17, 0x89,
-	0x83, 0x5f, 0x14, 0xe8, 0x4a, 0x73, 0xcf, 0x62, 0x3a, 0x0b, 0xe7, 0xe8, 0x09, 0xec, 0xa4, 0x8b,
-	0x88, 0xb0, 0xdc, 0x5c, 0xb9, 0x41, 0x9f, 0xc2, 0x7e, 0x7e, 0x3e, 0x5f, 0x51, 0x97, 0x71, 0x2f,
-	0xe5, 0x2e, 0x67, 0xc2, 0xe1, 0x26, 0xd6, 0x25, 0xe4, 0xac, 0xa8, 0x9d, 0x01, 0x0e, 0x43, 0xdf,
-	0x40, 0xb7, 0xd2, 0x36, 0x26, 0x8c, 0xd6, 0xc6, 0xc6, 0x28, 0x6f, 0xfa, 0xe8, 0x9d, 0x9e, 0xe2,
-	0x2d, 0xf6, 0xa0, 0x0b, 0x80, 0x09, 0x8b, 0xa3, 0x25, 0x09, 0x1c, 0x36, 0x58, 0xc0, 0x8e, 0xec,
-	0x9d, 0x0e, 0x8d, 0x9f, 0xc8, 0xda, 0x50, 0xfa, 0xca, 0xb0, 0x8b, 0xb3, 0x65, 0x56, 0xab, 0xd0,
-	0x69, 0xd4, 0x45, 0x4c, 0x6e, 0xd0, 0x33, 0xe8, 0x6c, 0xbc, 0x31, 0x1a, 0x02, 0x28, 0xf6, 0x68,
-	0x08, 0xed, 0x38, 0x71, 0xf9, 0x3a, 0x21, 0xa2, 0x9f, 0x8f, 0xc6, 0x8f, 0x8b, 0x9a, 0x2e, 0x13,
-	0x67, 0x9d, 0x10, 0xdc, 0x8a, 0xc5, 0xef, 0xe0, 0x47, 0xe8, 0x38, 0x2b, 0x2a, 0x6f, 0xfe, 0x18,
-	0x5a, 0x82, 0x25, 0x4d, 0xd1, 0xc6, 0x8f, 0xb6, 0x85, 0xe0, 0x1c, 0x45, 0x47, 0xa0, 0xfa, 0xf1,
-	0xcd, 0x4d, 0x98, 0x7b, 0xa3, 0x0c, 0x9b, 0xb8, 0x23, 0x03, 0x0e, 0x43, 0x87, 0xd0, 0x29, 0x7c,
-	0x6b, 0x08, 0xac, 0xcd, 0xa4, 0x5d, 0x03, 0x0d, 0x54, 0xc7, 0x9b, 0x46, 0xc4, 0xa2, 0xb3, 0x78,
-	0xf0, 0x97, 0x02, 0xaa, 0xb4, 0x83, 0x90, 0x00, 0x7d, 0x06, 0x90, 0x39, 0xbe, 0x75, 0xfd, 0x5e,
-	0x71, 0xfd, 0xa6, 0x42, 0xac, 0xf2, 0x7c, 0xc5, 0xd0, 0x87, 0xa0, 0xa5, 0xb9, 0x7b, 0x65, 0x19,
-	0x90, 0x16, 0x86, 0xa2, 0x17, 0xb0, 0x1b, 0x84, 0x2c, 0x91, 0x43, 0xe3, 0x86, 0x81, 0xa8, 0x46,
-	0x1b, 0x1f, 0x8e, 0x2a, 0x93, 0x38, 0x3a, 0x2f, 0x18, 0xd6, 0x39, 0xee, 0x96, 0x7c, 0x2b, 0x10,
-	0x2f, 0xc4, 0xe3, 0x61, 0x2c, 0x1c, 0xac, 0x63, 0xb9, 0x41, 0x9f, 0x03, 0xf0, 0x4c, 0x83, 0x1b,
-	0xd2, 0x59, 0x2c, 0xde, 0xbb, 0x36, 0x46, 0x65, 0xa1, 0x1b, 0x79, 0x58, 0xe5, 0x85, 0xd2, 0xbf,
-	0x1b, 0x70, 0x88, 0xc9, 0x3c, 0x64, 0x9c, 0xa4, 0xe5, 0x7d, 0x98, 0xdc, 0x2e, 0x08, 0xe3, 0x59,
-	0x99, 0xfe, 0xb5, 0x47, 0xe7, 0x64, 0x46, 0x48, 0x90, 0x95, 0xa9, 0xfc, 0x4b, 0x99, 0x67, 0x05,
-	0x23, 0x2b, 0xb3, 0xe4, 0x5b, 0xc1, 0x43, 0x99, 0xf5, 0xff, 0x27, 0xf3, 0xab, 0x8d, 0x20, 0x96,
-	0x78, 0x34, 0xf7, 0xe8, 0xe9, 0x56, 0xb2, 0x10, 0x65, 0x27, 0x1e, 0xcd, 0x45, 0x65, 0xcb, 0xad,
-	0x36, 0x37, 0xb7, 0xda, 0x9c, 0x3d, 0x0f, 0x46, 0xd2, 0xa5, 0xac, 0x46, 0x7e, 0x11, 0x3a, 0x32,
-	0x60, 0x05, 0xe8, 0x4b, 0xd0, 0x3c, 0x9f, 0x87, 0x31, 0x95, 0xaf, 0xb3, 0x25, 0x5e, 0xe7, 0x7e,
-	0x61, 0xe0, 0xa9, 0xc0, 0xc4, 0x0b, 0x05, 0xaf, 0x58, 0xa3, 0xe7, 0xb0, 0x3b, 0x13, 0x53, 0xe3,
-	0xfa, 0x62, 0x7c, 0xc5, 0xb0, 0x6b, 0xe3, 0x83, 0x22, 0xaf, 0x3a, 0xdb, 0xb8, 0x3b, 0xab, 0x4e,
-	0xfa, 0x31, 0xec, 0x11, 0x2a, 0x15, 0xae, 0xa9, 0xef, 0x26, 0x71, 0x48, 0xb9, 0xd1, 0xe9, 0x2b,
-	0xc3, 0x0e, 0x7e, 0x2c, 0x01, 0x7b, 0x4d, 0xfd, 0x49, 0x16, 0x46, 0x03, 0xd8, 0x2d, 0x49, 0x99,
-	0x34, 0x55, 0x48, 0xd3, 0xd8, 0x86, 0xe1, 0x30, 0x34, 0x82, 0xfd, 0x0a, 0x27, 0xa4, 0x9c, 0xa4,
-	0x4b, 0x2f, 0x32, 0x40, 0x30, 0xf7, 0x0a, 0xa6, 0x95, 0x03, 0xc7, 0x9f, 0x40, 0x4b, 0xce, 0x1c,
-	0xda, 0x05, 0x55, 0xae, 0x26, 0x0b, 0xae, 0xd7, 0x90, 0x0e, 0x5d, 0xb9, 0x95, 0x1f, 0x2b, 0x5d,
-	0x39, 0xfe, 0x55, 0x01, 0x28, 0x1d, 0x40, 0x47, 0xf0, 0xde, 0xe9, 0x99, 0x63, 0x5d, 0x5e, 0xb8,
-	0xce, 0xf7, 0x13, 0xd3, 0xbd, 0xba, 0xb0, 0x27, 0xe6, 0x99, 0xf5, 0xd2, 0x32, 0xcf, 0xf5, 0x1a,
-	0x32, 0xe0, 0x49, 0x15, 0xc4, 0xe6, 0x77, 0x96, 0xed, 0x98, 0x58, 0x57, 0xd0, 0x53, 0x40, 0xdb,
-	0xc8, 0xab, 0xcb, 0xd7, 0xa6, 0x5e, 0x47, 0x07, 0xb0, 0x57, 0x8d, 0x4f, 0x4e, 0xaf, 0x6c, 0x53,
-	0x6f, 0x3c, 0xa4, 0xdb, 0x57, 0xaf, 0x4c, 0xbd, 0xf9, 0x2e, 0x1d, 0x9b, 0xb6, 0xe9, 0xe8, 0x3b,
-	0xdf, 0x3e, 0xff, 0xed, 0xae, 0xa7, 0xbc, 0xbd, 0xeb, 0x29, 0x7f, 0xde, 0xf5, 0x94, 0x9f, 0xef,
-	0x7b, 0xb5, 0xb7, 0xf7, 0xbd, 0xda, 0xef, 0xf7, 0xbd, 0xda, 0x0f, 0xfd, 0x79, 0xc8, 0xaf, 0x17,
-	0xd3, 0x91, 0x1f, 0xdf, 0x9c, 0x24, 0x21, 0x9d, 0xfb, 0x5e, 0x72, 0xc2, 0x43, 0x3f, 0xf0, 0x4f,
-	0xf2, 0x2e, 0x4d, 0x5b, 0xe2, 0xef, 0xee, 0x8b, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x72, 0x76,
-	0x72, 0xb5, 0x2b, 0x07, 0x00, 0x00,
+	0x14, 0xae, 0x93, 0x34, 0x89, 0x9f, 0xd3, 0xad, 0x3b, 0xdd, 0x2e, 0xee, 0x16, 0x42, 0xc9, 0x01,
+	0x85, 0x4a, 0xa4, 0x10, 0x40, 0x48, 0x2b, 0xb4, 0x52, 0x69, 0xbd, 0xc8, 0x87, 0xb6, 0xd1, 0xc4,
+	0x5d, 0x09, 0x2e, 0x96, 0x63, 0xbf, 0xa4, 0x06, 0x67, 0xec, 0xda, 0x93, 0x6c, 0xf2, 0x2f, 0xe0,
+	0xc4, 0x99, 0x7f, 0xc3, 0x71, 0x8f, 0xdc, 0x40, 0xed, 0x81, 0xbf, 0x81, 0x3c, 0xe3, 0x38, 0xce,
+	0x16, 0x21, 0x71, 0xca, 0xcc, 0xfb, 0xbe, 0x37, 0xf3, 0xbe, 0xef, 0xcd, 0x73, 0x60, 0x1f, 0xe7,
+	0xc8, 0x78, 0x3c, 0x3a, 0x15, 0xbf, 0xbd, 0x38, 0x89, 0x78, 0x44, 0x1a, 0x79, 0xf0, 0xf9, 0xd1,
+	0x2d, 0xba, 0x09, 0x1f, 0xa1, 0x9b, 0x31, 0x8a, 0xb5, 0x64, 0x75, 0xfe, 0xac, 0xc0, 0xae, 0x99,
+	0x11, 0x5f, 0x05, 0x21, 0xc7, 0x84, 0xce, 0x42, 0x24, 0x06, 0x34, 0xa6, 0x2e, 0xf7, 0x6e, 0x31,
+	0x31, 0x94, 0xe3, 0x6a, 0x57, 0xa5, 0xab, 0x2d, 0xf9, 0x08, 0x5a, 0xc1, 0x84, 0x45, 0x09, 0x3a,
+	0xe2, 0x70, 0xa3, 0x22, 0x60, 0x4d, 0xc6, 0xc4, 0x31, 0xe4, 0x03, 0x80, 0x9c, 0x92, 0xde, 0x85,
+	0x46, 0x55, 0x10, 0x54, 0x19, 0x19, 0xde, 0x85, 0xe4, 0x6b, 0x30, 0x72, 0x38, 0x60, 0x29, 0x26,
+	0xdc, 0x99, 0xbb, 0xe1, 0x0c, 0x1d, 0x5c, 0xc4, 0x89, 0x51, 0x3b, 0x56, 0xba, 0x2a, 0x3d, 0x90,
+	0xb8, 0x25, 0xe0, 0xd7, 0x19, 0x6a, 0x2e, 0xe2, 0x84, 0xbc, 0x84, 0xf7, 0xf3, 0xc4, 0x59, 0xec,
+	0xbb, 0x1c, 0x1d, 0x86, 0x6f, 0xca, 0xc9, 0xdb, 0x22, 0x39, 0x3f, 0xfc, 0x46, 0x50, 0xae, 0xf0,
+	0xcd, 0x7f, 0xe4, 0x47, 0xa1, 0x5f, 0xce, 0xaf, 0x3f, 0xce, 0xbf, 0x0e, 0xfd, 0x75, 0xfe, 0xba,
+	0x70, 0x1f, 0x43, 0xe4, 0x58, 0xce, 0x6d, 0x94, 0x0b, 0xbf, 0x10, 0x70, 0x91, 0xd8, 0xf9, 0x45,
+	0x81, 0x96, 0x34, 0xf7, 0x3c, 0x62, 0xe3, 0x60, 0x42, 0x9e, 0xc2, 0x76, 0x32, 0x0b, 0x31, 0xcd,
+	0xcd, 0x95, 0x1b, 0xf2, 0x29, 0xec, 0xe7, 0xe7, 0xf3, 0x05, 0x73, 0x52, 0xee, 0x26, 0xdc, 0xe1,
+	0xa9, 0x70, 0xb8, 0x46, 0x75, 0x09, 0xd9, 0x0b, 0x36, 0xcc, 0x00, 0x3b, 0x25, 0xdf, 0x40, 0xab,
+	0xd4, 0xb6, 0x54, 0x18, 0xad, 0xf5, 0x8d, 0x5e, 0xde, 0xf4, 0xde, 0x3b, 0x3d, 0xa5, 0x1b, 0xec,
+	0x4e, 0x0b, 0x80, 0x62, 0x1a, 0x85, 0x73, 0xf4, 0xed, 0xb4, 0x33, 0x83, 0x6d, 0xd9, 0x3b, 0x1d,
+	0xaa, 0x3f, 0xe1, 0xd2, 0x50, 0x8e, 0x95, 0x6e, 0x8b, 0x66, 0xcb, 0xac, 0x56, 0xa1, 0xd3, 0xa8,
+	0x88, 0x98, 0xdc, 0x90, 0xe7, 0xd0, 0x5c, 0x79, 0x63, 0x54, 0x05, 0x50, 0xec, 0x49, 0x17, 0x1a,
+	0x51, 0xec, 0xf0, 0x65, 0x8c, 0xa2, 0x9f, 0x4f, 0xfa, 0xbb, 0x45, 0x4d, 0xd7, 0xb1, 0xbd, 0x8c,
+	0x91, 0xd6, 0x23, 0xf1, 0xdb, 0xf9, 0x11, 0x9a, 0xf6, 0x82, 0xc9, 0x9b, 0x3f, 0x86, 0xba, 0x60,
+	0x49, 0x53, 0xb4, 0xfe, 0x93, 0x4d, 0x21, 0x34, 0x47, 0xc9, 0x11, 0xa8, 0x5e, 0x34, 0x9d, 0x06,
+	0xb9, 0x37, 0x4a, 0xb7, 0x46, 0x9b, 0x32, 0x60, 0xa7, 0xe4, 0x10, 0x9a, 0x85, 0x6f, 0x55, 0x81,
+	0x35, 0x52, 0x69, 0x57, 0x47, 0x03, 0xd5, 0x76, 0x47, 0x21, 0x5a, 0x6c, 0x1c, 0x75, 0xfe, 0x56,
+	0x40, 0x95, 0x76, 0x20, 0xfa, 0xe4, 0x33, 0x80, 0xcc, 0xf1, 0x8d, 0xeb, 0xf7, 0x8a, 0xeb, 0x57,
+	0x15, 0x52, 0x95, 0xe7, 0xab, 0x94, 0x7c, 0x08, 0x5a, 0x92, 0xbb, 0xb7, 0x2e, 0x03, 0x92, 0xc2,
+	0x50, 0xf2, 0x12, 0x76, 0xfc, 0x20, 0x8d, 0xe5, 0xd0, 0x38, 0x81, 0x2f, 0xaa, 0xd1, 0xfa, 0x87,
+	0xbd, 0xd2, 0x24, 0xf6, 0x2e, 0x0a, 0x86, 0x75, 0x41, 0x5b, 0x6b, 0xbe, 0xe5, 0x8b, 0x17, 0xe2,
+	0xf2, 0x20, 0x12, 0x0e, 0x56, 0xa8, 0xdc, 0x90, 0xcf, 0x01, 0x78, 0xa6, 0xc1, 0x09, 0xd8, 0x38,
+	0x12, 0xef, 0x5d, 0xeb, 0x93, 0x75, 0xa1, 0x2b, 0x79, 0x54, 0xe5, 0x85, 0xd2, 0x5f, 0x6b, 0x70,
+	0x48, 0x71, 0x12, 0xa4, 0x1c, 0x93, 0xf5, 0x7d, 0x14, 0xef, 0x66, 0x98, 0xf2, 0xac, 0x4c, 0xef,
+	0xd6, 0x65, 0x13, 0x1c, 0x23, 0xfa, 0x59, 0x99, 0xca, 0xbf, 0x94, 0x79, 0x5e, 0x30, 0xb2, 0x32,
+	0xd7, 0x7c, 0xcb, 0x7f, 0x2c, 0xb3, 0xf2, 0xff, 0x64, 0x7e, 0xb5, 0x12, 0x94, 0xc6, 0x2e, 0xcb,
+	0x3d, 0x7a, 0xb6, 0x91, 0x2c, 0x44, 0x0d, 0x63, 0x97, 0xe5, 0xa2, 0xb2, 0xe5, 0x46, 0x9b, 0x6b,
+	0x1b, 0x6d, 0xce, 0x9e, 0x47, 0x8a, 0xc9, 0x5c, 0x56, 0x23, 0xbf, 0x08, 0x4d, 0x19, 0xb0, 0x7c,
+	0xf2, 0x25, 0x68, 0xae, 0xc7, 0x83, 0x88, 0xc9, 0xd7, 0x59, 0x17, 0xaf, 0x73, 0xbf, 0x30, 0xf0,
+	0x4c, 0x60, 0xe2, 0x85, 0x82, 0x5b, 0xac, 0xc9, 0x0b, 0xd8, 0x19, 0x8b, 0xa9, 0x71, 0x3c, 0x31,
+	0xbe, 0x62, 0xd8, 0xb5, 0xfe, 0x41, 0x91, 0x57, 0x9e, 0x6d, 0xda, 0x1a, 0x97, 0x76, 0xe4, 0x04,
+	0xf6, 0x90, 0x49, 0x85, 0x4b, 0xe6, 0x39, 0x71, 0x14, 0x30, 0x6e, 0x34, 0x8f, 0x95, 0x6e, 0x93,
+	0xee, 0x4a, 0x60, 0xb8, 0x64, 0xde, 0x20, 0x0b, 0x93, 0x0e, 0xec, 0xac, 0x49, 0x99, 0x34, 0x55,
+	0x48, 0xd3, 0xd2, 0x15, 0xc3, 0x4e, 0x49, 0x0f, 0xf6, 0x4b, 0x9c, 0x80, 0x71, 0x4c, 0xe6, 0x6e,
+	0x68, 0x80, 0x60, 0xee, 0x15, 0x4c, 0x2b, 0x07, 0x32, 0xa7, 0x46, 0x7e, 0xe2, 0x4c, 0x23, 0x1f,
+	0x0d, 0x4d, 0x5c, 0xdb, 0x18, 0xf9, 0xc9, 0x65, 0xe4, 0xe3, 0xc9, 0x27, 0x50, 0x97, 0xe3, 0x48,
+	0x76, 0x40, 0x95, 0xab, 0xc1, 0x8c, 0xeb, 0x5b, 0x44, 0x87, 0x96, 0xdc, 0xca, 0xef, 0x98, 0xae,
+	0x9c, 0xfc, 0xa6, 0x00, 0xac, 0xcd, 0x21, 0x47, 0xf0, 0xde, 0xd9, 0xb9, 0x6d, 0x5d, 0x5f, 0x39,
+	0xf6, 0xf7, 0x03, 0xd3, 0xb9, 0xb9, 0x1a, 0x0e, 0xcc, 0x73, 0xeb, 0x95, 0x65, 0x5e, 0xe8, 0x5b,
+	0xc4, 0x80, 0xa7, 0x65, 0x90, 0x9a, 0xdf, 0x59, 0x43, 0xdb, 0xa4, 0xba, 0x42, 0x9e, 0x01, 0xd9,
+	0x44, 0x2e, 0xaf, 0x5f, 0x9b, 0x7a, 0x85, 0x1c, 0xc0, 0x5e, 0x39, 0x3e, 0x38, 0xbb, 0x19, 0x9a,
+	0x7a, 0xf5, 0x31, 0x7d, 0x78, 0x73, 0x69, 0xea, 0xb5, 0x77, 0xe9, 0xd4, 0x1c, 0x9a, 0xb6, 0xbe,
+	0xfd, 0xed, 0x8b, 0xdf, 0xef, 0xdb, 0xca, 0xdb, 0xfb, 0xb6, 0xf2, 0xd7, 0x7d, 0x5b, 0xf9, 0xf9,
+	0xa1, 0xbd, 0xf5, 0xf6, 0xa1, 0xbd, 0xf5, 0xc7, 0x43, 0x7b, 0xeb, 0x87, 0xe3, 0x49, 0xc0, 0x6f,
+	0x67, 0xa3, 0x9e, 0x17, 0x4d, 0x4f, 0xe3, 0x80, 0x4d, 0x3c, 0x37, 0x3e, 0xe5, 0x81, 0xe7, 0x7b,
+	0xa7, 0x79, 0x03, 0x47, 0x75, 0xf1, 0x4f, 0xf8, 0xc5, 0x3f, 0x03, 0x00, 0xd9, 0xee, 0x42, 0x21,
+	0x46, 0x07, 0x00, 0x00,
 }
 
 func (m *EventFilterRule) Marshal() (dAtA []byte, err error) {
@@ -1085,6 +1094,16 @@ func (m *RegisterDispatcherRequest) MarshalToSizedBuffer(dAtA []byte) (int, erro
 	_ = i
 	var l int
 	_ = l
+	if m.BdrMode {
+		i--
+		if m.BdrMode {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
 	if m.SyncPointInterval != 0 {
 		i = encodeVarintEvent(dAtA, i, uint64(m.SyncPointInterval))
 		i--
@@ -1388,6 +1407,9 @@ func (m *RegisterDispatcherRequest) Size() (n int) {
 	if m.SyncPointInterval != 0 {
 		n += 1 + sovEvent(uint64(m.SyncPointInterval))
 	}
+	if m.BdrMode {
+		n += 2
+	}
 	return n
 }
 
@@ -2743,6 +2765,26 @@ func (m *RegisterDispatcherRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BdrMode", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowEvent
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BdrMode = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipEvent(dAtA[iNdEx:])
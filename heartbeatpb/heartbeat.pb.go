@@ -297,8 +297,9 @@ func (m *HeartBeatRequest) GetErr() *RunningError {
 }
 
 type Watermark struct {
-	CheckpointTs uint64 `protobuf:"varint,1,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
-	ResolvedTs   uint64 `protobuf:"varint,2,opt,name=resolvedTs,proto3" json:"resolvedTs,omitempty"`
+	CheckpointTs   uint64 `protobuf:"varint,1,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
+	ResolvedTs     uint64 `protobuf:"varint,2,opt,name=resolvedTs,proto3" json:"resolvedTs,omitempty"`
+	RedoResolvedTs uint64 `protobuf:"varint,3,opt,name=redoResolvedTs,proto3" json:"redoResolvedTs,omitempty"`
 }
 
 func (m *Watermark) Reset()         { *m = Watermark{} }
@@ -348,6 +349,13 @@ func (m *Watermark) GetResolvedTs() uint64 {
 	return 0
 }
 
+func (m *Watermark) GetRedoResolvedTs() uint64 {
+	if m != nil {
+		return m.RedoResolvedTs
+	}
+	return 0
+}
+
 type DispatcherAction struct {
 	Action      Action `protobuf:"varint,1,opt,name=action,proto3,enum=heartbeatpb.Action" json:"action,omitempty"`
 	CommitTs    uint64 `protobuf:"varint,2,opt,name=CommitTs,proto3" json:"CommitTs,omitempty"`
@@ -646,6 +654,8 @@ func (m *HeartBeatResponse) GetDispatcherStatuses() []*DispatcherStatus {
 type CheckpointTsMessage struct {
 	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	CheckpointTs uint64        `protobuf:"varint,2,opt,name=checkpointTs,proto3" json:"checkpointTs,omitempty"`
+	// all the events with commitTs less than or equal to it are flushed to the redo log
+	RedoResolvedTs uint64 `protobuf:"varint,3,opt,name=redoResolvedTs,proto3" json:"redoResolvedTs,omitempty"`
 }
 
 func (m *CheckpointTsMessage) Reset()         { *m = CheckpointTsMessage{} }
@@ -695,6 +705,13 @@ func (m *CheckpointTsMessage) GetCheckpointTs() uint64 {
 	return 0
 }
 
+func (m *CheckpointTsMessage) GetRedoResolvedTs() uint64 {
+	if m != nil {
+		return m.RedoResolvedTs
+	}
+	return 0
+}
+
 type DispatcherConfig struct {
 	Span         *TableSpan    `protobuf:"bytes,1,opt,name=span,proto3" json:"span,omitempty"`
 	StartTs      uint64        `protobuf:"varint,2,opt,name=startTs,proto3" json:"startTs,omitempty"`
@@ -882,6 +899,16 @@ type MaintainerStatus struct {
 	State        ComponentState  `protobuf:"varint,3,opt,name=state,proto3,enum=heartbeatpb.ComponentState" json:"state,omitempty"`
 	CheckpointTs uint64          `protobuf:"varint,4,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	Err          []*RunningError `protobuf:"bytes,5,rep,name=err,proto3" json:"err,omitempty"`
+	// the number of spans scheduled on each node
+	SpanCounts []*NodeSpanCount `protobuf:"bytes,6,rep,name=span_counts,json=spanCounts,proto3" json:"span_counts,omitempty"`
+	// the tables pinned to the target nodes by the move table requests
+	PinnedTables []*PinnedTable `protobuf:"bytes,7,rep,name=pinned_tables,json=pinnedTables,proto3" json:"pinned_tables,omitempty"`
+	// the commit ts of the earliest ddl that blocks the changefeed in the barrier, 0 if there is no blocking ddl
+	BlockingDdlTs uint64 `protobuf:"varint,8,opt,name=blocking_ddl_ts,json=blockingDdlTs,proto3" json:"blocking_ddl_ts,omitempty"`
+	// the version of the latest changefeed config received from the coordinator
+	ConfigVersion uint64 `protobuf:"varint,9,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
+	// all the events with commitTs less than or equal to it are flushed to the redo log
+	RedoResolvedTs uint64 `protobuf:"varint,10,opt,name=redo_resolved_ts,json=redoResolvedTs,proto3" json:"redo_resolved_ts,omitempty"`
 }
 
 func (m *MaintainerStatus) Reset()         { *m = MaintainerStatus{} }
@@ -952,6 +979,155 @@ func (m *MaintainerStatus) GetErr() []*RunningError {
 	return nil
 }
 
+func (m *MaintainerStatus) GetSpanCounts() []*NodeSpanCount {
+	if m != nil {
+		return m.SpanCounts
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetPinnedTables() []*PinnedTable {
+	if m != nil {
+		return m.PinnedTables
+	}
+	return nil
+}
+
+func (m *MaintainerStatus) GetBlockingDdlTs() uint64 {
+	if m != nil {
+		return m.BlockingDdlTs
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetConfigVersion() uint64 {
+	if m != nil {
+		return m.ConfigVersion
+	}
+	return 0
+}
+
+func (m *MaintainerStatus) GetRedoResolvedTs() uint64 {
+	if m != nil {
+		return m.RedoResolvedTs
+	}
+	return 0
+}
+
+type NodeSpanCount struct {
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	Count  uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (m *NodeSpanCount) Reset()         { *m = NodeSpanCount{} }
+func (m *NodeSpanCount) String() string { return proto.CompactTextString(m) }
+func (*NodeSpanCount) ProtoMessage()    {}
+func (*NodeSpanCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{13}
+}
+func (m *NodeSpanCount) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NodeSpanCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NodeSpanCount.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NodeSpanCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NodeSpanCount.Merge(m, src)
+}
+func (m *NodeSpanCount) XXX_Size() int {
+	return m.Size()
+}
+func (m *NodeSpanCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_NodeSpanCount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NodeSpanCount proto.InternalMessageInfo
+
+func (m *NodeSpanCount) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *NodeSpanCount) GetCount() uint32 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+// PinnedTable is a table pinned to the target node by the move table request
+type PinnedTable struct {
+	TableId      int64  `protobuf:"varint,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	TargetNodeId string `protobuf:"bytes,2,opt,name=target_node_id,json=targetNodeId,proto3" json:"target_node_id,omitempty"`
+	// moving is true until all spans of the table are replicating on the target node
+	Moving bool `protobuf:"varint,3,opt,name=moving,proto3" json:"moving,omitempty"`
+}
+
+func (m *PinnedTable) Reset()         { *m = PinnedTable{} }
+func (m *PinnedTable) String() string { return proto.CompactTextString(m) }
+func (*PinnedTable) ProtoMessage()    {}
+func (*PinnedTable) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{14}
+}
+func (m *PinnedTable) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PinnedTable) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PinnedTable.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PinnedTable) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PinnedTable.Merge(m, src)
+}
+func (m *PinnedTable) XXX_Size() int {
+	return m.Size()
+}
+func (m *PinnedTable) XXX_DiscardUnknown() {
+	xxx_messageInfo_PinnedTable.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PinnedTable proto.InternalMessageInfo
+
+func (m *PinnedTable) GetTableId() int64 {
+	if m != nil {
+		return m.TableId
+	}
+	return 0
+}
+
+func (m *PinnedTable) GetTargetNodeId() string {
+	if m != nil {
+		return m.TargetNodeId
+	}
+	return ""
+}
+
+func (m *PinnedTable) GetMoving() bool {
+	if m != nil {
+		return m.Moving
+	}
+	return false
+}
+
 type CoordinatorBootstrapRequest struct {
 	Version int64 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
 }
@@ -960,7 +1136,7 @@ func (m *CoordinatorBootstrapRequest) Reset()         { *m = CoordinatorBootstra
 func (m *CoordinatorBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*CoordinatorBootstrapRequest) ProtoMessage()    {}
 func (*CoordinatorBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{13}
+	return fileDescriptor_6d584080fdadb670, []int{15}
 }
 func (m *CoordinatorBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1004,7 +1180,7 @@ func (m *CoordinatorBootstrapResponse) Reset()         { *m = CoordinatorBootstr
 func (m *CoordinatorBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*CoordinatorBootstrapResponse) ProtoMessage()    {}
 func (*CoordinatorBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{14}
+	return fileDescriptor_6d584080fdadb670, []int{16}
 }
 func (m *CoordinatorBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1044,13 +1220,15 @@ type AddMaintainerRequest struct {
 	Id           *ChangefeedID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Config       []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
 	CheckpointTs uint64        `protobuf:"varint,3,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
+	// the version of the changefeed config, it's increased every time a running changefeed is updated
+	ConfigVersion uint64 `protobuf:"varint,4,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
 }
 
 func (m *AddMaintainerRequest) Reset()         { *m = AddMaintainerRequest{} }
 func (m *AddMaintainerRequest) String() string { return proto.CompactTextString(m) }
 func (*AddMaintainerRequest) ProtoMessage()    {}
 func (*AddMaintainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{15}
+	return fileDescriptor_6d584080fdadb670, []int{17}
 }
 func (m *AddMaintainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1100,6 +1278,13 @@ func (m *AddMaintainerRequest) GetCheckpointTs() uint64 {
 	return 0
 }
 
+func (m *AddMaintainerRequest) GetConfigVersion() uint64 {
+	if m != nil {
+		return m.ConfigVersion
+	}
+	return 0
+}
+
 type RemoveMaintainerRequest struct {
 	Id      *ChangefeedID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Cascade bool          `protobuf:"varint,2,opt,name=cascade,proto3" json:"cascade,omitempty"`
@@ -1110,7 +1295,7 @@ func (m *RemoveMaintainerRequest) Reset()         { *m = RemoveMaintainerRequest
 func (m *RemoveMaintainerRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveMaintainerRequest) ProtoMessage()    {}
 func (*RemoveMaintainerRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{16}
+	return fileDescriptor_6d584080fdadb670, []int{18}
 }
 func (m *RemoveMaintainerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1160,6 +1345,186 @@ func (m *RemoveMaintainerRequest) GetRemoved() bool {
 	return false
 }
 
+// DrainNodeRequest is sent by the coordinator to all nodes,
+// the maintainers stop scheduling spans to the draining node and move the spans out of it.
+type DrainNodeRequest struct {
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	// undrain is true if the node is not draining any more, the tasks can be scheduled to it again
+	Undrain bool `protobuf:"varint,2,opt,name=undrain,proto3" json:"undrain,omitempty"`
+}
+
+func (m *DrainNodeRequest) Reset()         { *m = DrainNodeRequest{} }
+func (m *DrainNodeRequest) String() string { return proto.CompactTextString(m) }
+func (*DrainNodeRequest) ProtoMessage()    {}
+func (*DrainNodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{19}
+}
+func (m *DrainNodeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DrainNodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DrainNodeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DrainNodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DrainNodeRequest.Merge(m, src)
+}
+func (m *DrainNodeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DrainNodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DrainNodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DrainNodeRequest proto.InternalMessageInfo
+
+func (m *DrainNodeRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *DrainNodeRequest) GetUndrain() bool {
+	if m != nil {
+		return m.Undrain
+	}
+	return false
+}
+
+// MoveTableRequest is sent by the coordinator to the maintainer,
+// to move all spans of the table to the target node.
+type MoveTableRequest struct {
+	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	TableId      int64         `protobuf:"varint,2,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	TargetNodeId string        `protobuf:"bytes,3,opt,name=target_node_id,json=targetNodeId,proto3" json:"target_node_id,omitempty"`
+}
+
+func (m *MoveTableRequest) Reset()         { *m = MoveTableRequest{} }
+func (m *MoveTableRequest) String() string { return proto.CompactTextString(m) }
+func (*MoveTableRequest) ProtoMessage()    {}
+func (*MoveTableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{20}
+}
+func (m *MoveTableRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MoveTableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MoveTableRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MoveTableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MoveTableRequest.Merge(m, src)
+}
+func (m *MoveTableRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MoveTableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MoveTableRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MoveTableRequest proto.InternalMessageInfo
+
+func (m *MoveTableRequest) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *MoveTableRequest) GetTableId() int64 {
+	if m != nil {
+		return m.TableId
+	}
+	return 0
+}
+
+func (m *MoveTableRequest) GetTargetNodeId() string {
+	if m != nil {
+		return m.TargetNodeId
+	}
+	return ""
+}
+
+// UpdateMaintainerRequest is sent by the coordinator to the maintainer,
+// to apply the new config to a running changefeed without restarting it.
+type UpdateMaintainerRequest struct {
+	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	Config       []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	// the request is resent until the maintainer reports this version in its status
+	ConfigVersion uint64 `protobuf:"varint,3,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
+}
+
+func (m *UpdateMaintainerRequest) Reset()         { *m = UpdateMaintainerRequest{} }
+func (m *UpdateMaintainerRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateMaintainerRequest) ProtoMessage()    {}
+func (*UpdateMaintainerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{21}
+}
+func (m *UpdateMaintainerRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateMaintainerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateMaintainerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateMaintainerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateMaintainerRequest.Merge(m, src)
+}
+func (m *UpdateMaintainerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateMaintainerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateMaintainerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateMaintainerRequest proto.InternalMessageInfo
+
+func (m *UpdateMaintainerRequest) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *UpdateMaintainerRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *UpdateMaintainerRequest) GetConfigVersion() uint64 {
+	if m != nil {
+		return m.ConfigVersion
+	}
+	return 0
+}
+
 type MaintainerBootstrapRequest struct {
 	ChangefeedID                  *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	Config                        []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
@@ -1171,7 +1536,7 @@ func (m *MaintainerBootstrapRequest) Reset()         { *m = MaintainerBootstrapR
 func (m *MaintainerBootstrapRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerBootstrapRequest) ProtoMessage()    {}
 func (*MaintainerBootstrapRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{17}
+	return fileDescriptor_6d584080fdadb670, []int{22}
 }
 func (m *MaintainerBootstrapRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1246,7 +1611,7 @@ func (m *MaintainerBootstrapResponse) Reset()         { *m = MaintainerBootstrap
 func (m *MaintainerBootstrapResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerBootstrapResponse) ProtoMessage()    {}
 func (*MaintainerBootstrapResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{18}
+	return fileDescriptor_6d584080fdadb670, []int{23}
 }
 func (m *MaintainerBootstrapResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1316,7 +1681,7 @@ func (m *BootstrapTableSpan) Reset()         { *m = BootstrapTableSpan{} }
 func (m *BootstrapTableSpan) String() string { return proto.CompactTextString(m) }
 func (*BootstrapTableSpan) ProtoMessage()    {}
 func (*BootstrapTableSpan) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{19}
+	return fileDescriptor_6d584080fdadb670, []int{24}
 }
 func (m *BootstrapTableSpan) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1397,7 +1762,7 @@ func (m *MaintainerCloseRequest) Reset()         { *m = MaintainerCloseRequest{}
 func (m *MaintainerCloseRequest) String() string { return proto.CompactTextString(m) }
 func (*MaintainerCloseRequest) ProtoMessage()    {}
 func (*MaintainerCloseRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{20}
+	return fileDescriptor_6d584080fdadb670, []int{25}
 }
 func (m *MaintainerCloseRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1449,7 +1814,7 @@ func (m *MaintainerCloseResponse) Reset()         { *m = MaintainerCloseResponse
 func (m *MaintainerCloseResponse) String() string { return proto.CompactTextString(m) }
 func (*MaintainerCloseResponse) ProtoMessage()    {}
 func (*MaintainerCloseResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{21}
+	return fileDescriptor_6d584080fdadb670, []int{26}
 }
 func (m *MaintainerCloseResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1492,26 +1857,148 @@ func (m *MaintainerCloseResponse) GetSuccess() bool {
 	return false
 }
 
-type InfluencedTables struct {
-	InfluenceType InfluenceType `protobuf:"varint,1,opt,name=InfluenceType,proto3,enum=heartbeatpb.InfluenceType" json:"InfluenceType,omitempty"`
-	// only exist when type is normal
-	TableIDs []int64 `protobuf:"varint,2,rep,packed,name=TableIDs,proto3" json:"TableIDs,omitempty"`
-	// only exist when type is DB.
-	SchemaID int64 `protobuf:"varint,3,opt,name=SchemaID,proto3" json:"SchemaID,omitempty"`
+// UpdateDispatcherManagerRequest is sent by the maintainer to the dispatcher managers,
+// to apply the new config in place, the version is used to match the response.
+type UpdateDispatcherManagerRequest struct {
+	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	Config       []byte        `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	Version      uint64        `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (m *InfluencedTables) Reset()         { *m = InfluencedTables{} }
-func (m *InfluencedTables) String() string { return proto.CompactTextString(m) }
-func (*InfluencedTables) ProtoMessage()    {}
-func (*InfluencedTables) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{22}
+func (m *UpdateDispatcherManagerRequest) Reset()         { *m = UpdateDispatcherManagerRequest{} }
+func (m *UpdateDispatcherManagerRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateDispatcherManagerRequest) ProtoMessage()    {}
+func (*UpdateDispatcherManagerRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{27}
 }
-func (m *InfluencedTables) XXX_Unmarshal(b []byte) error {
+func (m *UpdateDispatcherManagerRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *InfluencedTables) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *UpdateDispatcherManagerRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_InfluencedTables.Marshal(b, m, deterministic)
+		return xxx_messageInfo_UpdateDispatcherManagerRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateDispatcherManagerRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDispatcherManagerRequest.Merge(m, src)
+}
+func (m *UpdateDispatcherManagerRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateDispatcherManagerRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDispatcherManagerRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDispatcherManagerRequest proto.InternalMessageInfo
+
+func (m *UpdateDispatcherManagerRequest) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *UpdateDispatcherManagerRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+func (m *UpdateDispatcherManagerRequest) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type UpdateDispatcherManagerResponse struct {
+	ChangefeedID *ChangefeedID `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
+	Version      uint64        `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	Err          *RunningError `protobuf:"bytes,3,opt,name=err,proto3" json:"err,omitempty"`
+}
+
+func (m *UpdateDispatcherManagerResponse) Reset()         { *m = UpdateDispatcherManagerResponse{} }
+func (m *UpdateDispatcherManagerResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateDispatcherManagerResponse) ProtoMessage()    {}
+func (*UpdateDispatcherManagerResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{28}
+}
+func (m *UpdateDispatcherManagerResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdateDispatcherManagerResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdateDispatcherManagerResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdateDispatcherManagerResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateDispatcherManagerResponse.Merge(m, src)
+}
+func (m *UpdateDispatcherManagerResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdateDispatcherManagerResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateDispatcherManagerResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateDispatcherManagerResponse proto.InternalMessageInfo
+
+func (m *UpdateDispatcherManagerResponse) GetChangefeedID() *ChangefeedID {
+	if m != nil {
+		return m.ChangefeedID
+	}
+	return nil
+}
+
+func (m *UpdateDispatcherManagerResponse) GetVersion() uint64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *UpdateDispatcherManagerResponse) GetErr() *RunningError {
+	if m != nil {
+		return m.Err
+	}
+	return nil
+}
+
+type InfluencedTables struct {
+	InfluenceType InfluenceType `protobuf:"varint,1,opt,name=InfluenceType,proto3,enum=heartbeatpb.InfluenceType" json:"InfluenceType,omitempty"`
+	// only exist when type is normal
+	TableIDs []int64 `protobuf:"varint,2,rep,packed,name=TableIDs,proto3" json:"TableIDs,omitempty"`
+	// only exist when type is DB.
+	SchemaID int64 `protobuf:"varint,3,opt,name=SchemaID,proto3" json:"SchemaID,omitempty"`
+}
+
+func (m *InfluencedTables) Reset()         { *m = InfluencedTables{} }
+func (m *InfluencedTables) String() string { return proto.CompactTextString(m) }
+func (*InfluencedTables) ProtoMessage()    {}
+func (*InfluencedTables) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6d584080fdadb670, []int{29}
+}
+func (m *InfluencedTables) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InfluencedTables) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InfluencedTables.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1563,7 +2050,7 @@ func (m *Table) Reset()         { *m = Table{} }
 func (m *Table) String() string { return proto.CompactTextString(m) }
 func (*Table) ProtoMessage()    {}
 func (*Table) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{23}
+	return fileDescriptor_6d584080fdadb670, []int{30}
 }
 func (m *Table) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1616,7 +2103,7 @@ func (m *SchemaIDChange) Reset()         { *m = SchemaIDChange{} }
 func (m *SchemaIDChange) String() string { return proto.CompactTextString(m) }
 func (*SchemaIDChange) ProtoMessage()    {}
 func (*SchemaIDChange) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{24}
+	return fileDescriptor_6d584080fdadb670, []int{31}
 }
 func (m *SchemaIDChange) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1681,7 +2168,7 @@ func (m *State) Reset()         { *m = State{} }
 func (m *State) String() string { return proto.CompactTextString(m) }
 func (*State) ProtoMessage()    {}
 func (*State) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{25}
+	return fileDescriptor_6d584080fdadb670, []int{32}
 }
 func (m *State) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1775,7 +2262,7 @@ func (m *TableSpanBlockStatus) Reset()         { *m = TableSpanBlockStatus{} }
 func (m *TableSpanBlockStatus) String() string { return proto.CompactTextString(m) }
 func (*TableSpanBlockStatus) ProtoMessage()    {}
 func (*TableSpanBlockStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{26}
+	return fileDescriptor_6d584080fdadb670, []int{33}
 }
 func (m *TableSpanBlockStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1823,13 +2310,14 @@ type TableSpanStatus struct {
 	ComponentStatus    ComponentState `protobuf:"varint,2,opt,name=component_status,json=componentStatus,proto3,enum=heartbeatpb.ComponentState" json:"component_status,omitempty"`
 	CheckpointTs       uint64         `protobuf:"varint,3,opt,name=checkpoint_ts,json=checkpointTs,proto3" json:"checkpoint_ts,omitempty"`
 	EventSizePerSecond float32        `protobuf:"fixed32,4,opt,name=event_size_per_second,json=eventSizePerSecond,proto3" json:"event_size_per_second,omitempty"`
+	ResolvedTs         uint64         `protobuf:"varint,5,opt,name=resolved_ts,json=resolvedTs,proto3" json:"resolved_ts,omitempty"`
 }
 
 func (m *TableSpanStatus) Reset()         { *m = TableSpanStatus{} }
 func (m *TableSpanStatus) String() string { return proto.CompactTextString(m) }
 func (*TableSpanStatus) ProtoMessage()    {}
 func (*TableSpanStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{27}
+	return fileDescriptor_6d584080fdadb670, []int{34}
 }
 func (m *TableSpanStatus) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1886,6 +2374,13 @@ func (m *TableSpanStatus) GetEventSizePerSecond() float32 {
 	return 0
 }
 
+func (m *TableSpanStatus) GetResolvedTs() uint64 {
+	if m != nil {
+		return m.ResolvedTs
+	}
+	return 0
+}
+
 type BlockStatusRequest struct {
 	ChangefeedID  *ChangefeedID           `protobuf:"bytes,1,opt,name=changefeedID,proto3" json:"changefeedID,omitempty"`
 	BlockStatuses []*TableSpanBlockStatus `protobuf:"bytes,2,rep,name=blockStatuses,proto3" json:"blockStatuses,omitempty"`
@@ -1895,7 +2390,7 @@ func (m *BlockStatusRequest) Reset()         { *m = BlockStatusRequest{} }
 func (m *BlockStatusRequest) String() string { return proto.CompactTextString(m) }
 func (*BlockStatusRequest) ProtoMessage()    {}
 func (*BlockStatusRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{28}
+	return fileDescriptor_6d584080fdadb670, []int{35}
 }
 func (m *BlockStatusRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -1949,7 +2444,7 @@ func (m *RunningError) Reset()         { *m = RunningError{} }
 func (m *RunningError) String() string { return proto.CompactTextString(m) }
 func (*RunningError) ProtoMessage()    {}
 func (*RunningError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{29}
+	return fileDescriptor_6d584080fdadb670, []int{36}
 }
 func (m *RunningError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2015,7 +2510,7 @@ func (m *DispatcherID) Reset()         { *m = DispatcherID{} }
 func (m *DispatcherID) String() string { return proto.CompactTextString(m) }
 func (*DispatcherID) ProtoMessage()    {}
 func (*DispatcherID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{30}
+	return fileDescriptor_6d584080fdadb670, []int{37}
 }
 func (m *DispatcherID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2069,7 +2564,7 @@ func (m *ChangefeedID) Reset()         { *m = ChangefeedID{} }
 func (m *ChangefeedID) String() string { return proto.CompactTextString(m) }
 func (*ChangefeedID) ProtoMessage()    {}
 func (*ChangefeedID) Descriptor() ([]byte, []int) {
-	return fileDescriptor_6d584080fdadb670, []int{31}
+	return fileDescriptor_6d584080fdadb670, []int{38}
 }
 func (m *ChangefeedID) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -2145,15 +2640,22 @@ func init() {
 	proto.RegisterType((*ScheduleDispatcherRequest)(nil), "heartbeatpb.ScheduleDispatcherRequest")
 	proto.RegisterType((*MaintainerHeartbeat)(nil), "heartbeatpb.MaintainerHeartbeat")
 	proto.RegisterType((*MaintainerStatus)(nil), "heartbeatpb.MaintainerStatus")
+	proto.RegisterType((*NodeSpanCount)(nil), "heartbeatpb.NodeSpanCount")
+	proto.RegisterType((*PinnedTable)(nil), "heartbeatpb.PinnedTable")
 	proto.RegisterType((*CoordinatorBootstrapRequest)(nil), "heartbeatpb.CoordinatorBootstrapRequest")
 	proto.RegisterType((*CoordinatorBootstrapResponse)(nil), "heartbeatpb.CoordinatorBootstrapResponse")
 	proto.RegisterType((*AddMaintainerRequest)(nil), "heartbeatpb.AddMaintainerRequest")
 	proto.RegisterType((*RemoveMaintainerRequest)(nil), "heartbeatpb.RemoveMaintainerRequest")
+	proto.RegisterType((*DrainNodeRequest)(nil), "heartbeatpb.DrainNodeRequest")
+	proto.RegisterType((*MoveTableRequest)(nil), "heartbeatpb.MoveTableRequest")
+	proto.RegisterType((*UpdateMaintainerRequest)(nil), "heartbeatpb.UpdateMaintainerRequest")
 	proto.RegisterType((*MaintainerBootstrapRequest)(nil), "heartbeatpb.MaintainerBootstrapRequest")
 	proto.RegisterType((*MaintainerBootstrapResponse)(nil), "heartbeatpb.MaintainerBootstrapResponse")
 	proto.RegisterType((*BootstrapTableSpan)(nil), "heartbeatpb.BootstrapTableSpan")
 	proto.RegisterType((*MaintainerCloseRequest)(nil), "heartbeatpb.MaintainerCloseRequest")
 	proto.RegisterType((*MaintainerCloseResponse)(nil), "heartbeatpb.MaintainerCloseResponse")
+	proto.RegisterType((*UpdateDispatcherManagerRequest)(nil), "heartbeatpb.UpdateDispatcherManagerRequest")
+	proto.RegisterType((*UpdateDispatcherManagerResponse)(nil), "heartbeatpb.UpdateDispatcherManagerResponse")
 	proto.RegisterType((*InfluencedTables)(nil), "heartbeatpb.InfluencedTables")
 	proto.RegisterType((*Table)(nil), "heartbeatpb.Table")
 	proto.RegisterType((*SchemaIDChange)(nil), "heartbeatpb.SchemaIDChange")
@@ -2169,112 +2671,132 @@ func init() {
 func init() { proto.RegisterFile("heartbeatpb/heartbeat.proto", fileDescriptor_6d584080fdadb670) }
 
 var fileDescriptor_6d584080fdadb670 = []byte{
-	// 1674 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4b, 0x73, 0x1b, 0xc5,
-	0x13, 0xf7, 0xee, 0xca, 0xb2, 0xd4, 0xf2, 0x63, 0x33, 0x4e, 0x1c, 0xc5, 0x8e, 0xfd, 0x77, 0xf6,
-	0xcf, 0xc1, 0x38, 0x60, 0x57, 0x9c, 0xa4, 0x02, 0x14, 0x21, 0xd8, 0xb2, 0x49, 0x54, 0xae, 0x38,
-	0xae, 0x91, 0xa9, 0x10, 0x2e, 0xaa, 0xf5, 0xee, 0x58, 0xde, 0xb2, 0xb4, 0xbb, 0x99, 0x59, 0x39,
-	0x8f, 0x2a, 0xb8, 0x70, 0xe5, 0xc0, 0x91, 0x03, 0x97, 0x1c, 0xf9, 0x24, 0x70, 0xcc, 0x09, 0x38,
-	0x70, 0xa0, 0x92, 0xe2, 0x13, 0x50, 0xdc, 0xa9, 0x99, 0xd9, 0xb7, 0xd6, 0x8f, 0x94, 0x7d, 0xd2,
-	0xf4, 0x4c, 0x77, 0x4f, 0x4f, 0x3f, 0x7e, 0xdd, 0x2b, 0x98, 0xd9, 0x27, 0x26, 0x0d, 0x76, 0x89,
-	0x19, 0xf8, 0xbb, 0xcb, 0xf1, 0x7a, 0xc9, 0xa7, 0x5e, 0xe0, 0xa1, 0x5a, 0xea, 0xd0, 0x78, 0x02,
-	0xd5, 0x1d, 0x73, 0xb7, 0x4b, 0x5a, 0xbe, 0xe9, 0xa2, 0x3a, 0x8c, 0x08, 0xa2, 0xb9, 0x5e, 0x57,
-	0xe6, 0x95, 0x05, 0x0d, 0x47, 0x24, 0x9a, 0x86, 0x4a, 0x2b, 0x30, 0x69, 0xb0, 0x49, 0x5e, 0xd4,
-	0xd5, 0x79, 0x65, 0x61, 0x14, 0xc7, 0x34, 0x9a, 0x82, 0xf2, 0x86, 0x6b, 0xf3, 0x13, 0x4d, 0x9c,
-	0x84, 0x94, 0xf1, 0xa3, 0x0a, 0xfa, 0x03, 0x7e, 0xd5, 0x1a, 0x31, 0x03, 0x4c, 0x9e, 0xf6, 0x09,
-	0x0b, 0xd0, 0x5d, 0x18, 0xb5, 0xf6, 0x4d, 0xb7, 0x43, 0xf6, 0x08, 0xb1, 0xc3, 0x7b, 0x6a, 0x2b,
-	0x57, 0x96, 0x52, 0x36, 0x2d, 0x35, 0x52, 0x0c, 0x38, 0xc3, 0x8e, 0x6e, 0x41, 0xf5, 0x99, 0x19,
-	0x10, 0xda, 0x33, 0xe9, 0x81, 0x30, 0xa4, 0xb6, 0x32, 0x95, 0x91, 0x7d, 0x1c, 0x9d, 0xe2, 0x84,
-	0x11, 0x7d, 0x04, 0x15, 0x16, 0x98, 0x41, 0x9f, 0x11, 0x56, 0xd7, 0xe6, 0xb5, 0x85, 0xda, 0xca,
-	0xd5, 0x8c, 0x50, 0xec, 0x81, 0x96, 0xe0, 0xc2, 0x31, 0x37, 0x5a, 0x80, 0x09, 0xcb, 0xeb, 0xf9,
-	0xa4, 0x4b, 0x02, 0x22, 0x0f, 0xeb, 0xa5, 0x79, 0x65, 0xa1, 0x82, 0xf3, 0xdb, 0xe8, 0x3a, 0x68,
-	0x84, 0xd2, 0xfa, 0x70, 0xc1, 0x7b, 0x70, 0xdf, 0x75, 0x1d, 0xb7, 0xb3, 0x41, 0xa9, 0x47, 0x31,
-	0xe7, 0x32, 0x1e, 0x41, 0x35, 0x36, 0x14, 0x19, 0xdc, 0x25, 0xc4, 0x3a, 0xf0, 0x3d, 0xc7, 0x0d,
-	0x76, 0x98, 0x70, 0x49, 0x09, 0x67, 0xf6, 0xd0, 0x1c, 0x00, 0x25, 0xcc, 0xeb, 0x1e, 0x12, 0x7b,
-	0x87, 0x89, 0x87, 0x97, 0x70, 0x6a, 0xc7, 0xf8, 0x06, 0xf4, 0x75, 0x87, 0xf9, 0x66, 0x60, 0xed,
-	0x13, 0xba, 0x6a, 0x05, 0x8e, 0xe7, 0xa2, 0xeb, 0x50, 0x36, 0xc5, 0x4a, 0x68, 0x1c, 0x5f, 0x99,
-	0xcc, 0x18, 0x25, 0x99, 0x70, 0xc8, 0xc2, 0x03, 0xdc, 0xf0, 0x7a, 0x3d, 0x27, 0x88, 0xd5, 0xc7,
-	0x34, 0x9a, 0x87, 0x5a, 0x93, 0xb5, 0x5e, 0xb8, 0xd6, 0x36, 0xb7, 0x46, 0x44, 0xb9, 0x82, 0xd3,
-	0x5b, 0x46, 0x03, 0xb4, 0xd5, 0xc6, 0x66, 0x46, 0x89, 0x72, 0xbc, 0x12, 0x75, 0x50, 0xc9, 0x77,
-	0x2a, 0x5c, 0x6a, 0xba, 0x7b, 0xdd, 0x3e, 0x71, 0x2d, 0x62, 0x27, 0xcf, 0x61, 0xe8, 0x73, 0x18,
-	0x8b, 0x0f, 0x76, 0x5e, 0xf8, 0x24, 0x7c, 0xd0, 0x74, 0xe6, 0x41, 0x19, 0x0e, 0x9c, 0x15, 0x40,
-	0xf7, 0x60, 0x2c, 0x51, 0xd8, 0x5c, 0xe7, 0x6f, 0xd4, 0x06, 0xe2, 0x94, 0xe6, 0xc0, 0x59, 0x7e,
-	0x51, 0x00, 0xd6, 0x3e, 0xe9, 0x99, 0xcd, 0x75, 0xe1, 0x00, 0x0d, 0xc7, 0x34, 0xda, 0x84, 0x49,
-	0xf2, 0xdc, 0xea, 0xf6, 0x6d, 0x92, 0x92, 0xb1, 0x45, 0xa2, 0x1c, 0x7b, 0x45, 0x91, 0x94, 0xf1,
-	0x8b, 0x92, 0x0e, 0x65, 0x98, 0x5c, 0x5f, 0xc1, 0x25, 0xa7, 0xc8, 0x33, 0x61, 0xf9, 0x18, 0xc5,
-	0x8e, 0x48, 0x73, 0xe2, 0x62, 0x05, 0xe8, 0x76, 0x9c, 0x24, 0xb2, 0x9a, 0x66, 0x8f, 0x30, 0x37,
-	0x97, 0x2e, 0x06, 0x68, 0xa6, 0x75, 0x20, 0x3c, 0x51, 0x5b, 0xd1, 0xb3, 0x89, 0xd5, 0xd8, 0xc4,
-	0xfc, 0xd0, 0x78, 0xa5, 0xc0, 0x85, 0x54, 0xfd, 0x33, 0xdf, 0x73, 0x19, 0x39, 0x2b, 0x00, 0x3c,
-	0x04, 0x64, 0xe7, 0xbc, 0x43, 0xa2, 0x68, 0x1e, 0x65, 0x7b, 0x58, 0xd5, 0x05, 0x82, 0xc6, 0x73,
-	0x98, 0x6c, 0xa4, 0xea, 0xec, 0x21, 0x61, 0xcc, 0xec, 0x9c, 0xd9, 0xc8, 0x7c, 0x45, 0xab, 0x83,
-	0x15, 0x6d, 0xfc, 0x9e, 0x89, 0x73, 0xc3, 0x73, 0xf7, 0x9c, 0x0e, 0x5a, 0x84, 0x12, 0xf3, 0x4d,
-	0x37, 0xbc, 0x6f, 0xaa, 0x18, 0xa4, 0xb0, 0xe0, 0xe1, 0x60, 0xcd, 0x38, 0x04, 0xc7, 0xfa, 0x23,
-	0x92, 0x5b, 0x6f, 0xa7, 0xf2, 0x2c, 0x8c, 0xd2, 0x31, 0x89, 0x98, 0x61, 0xe7, 0xa9, 0xce, 0xa2,
-	0x54, 0x2f, 0xc9, 0x54, 0x8f, 0x68, 0x64, 0xc0, 0x98, 0xd5, 0xa7, 0x94, 0xb8, 0x41, 0xdb, 0xb7,
-	0xdb, 0x01, 0x13, 0x78, 0x57, 0xc2, 0xb5, 0x70, 0x73, 0x9b, 0x63, 0xd1, 0x6f, 0x0a, 0x5c, 0xe1,
-	0xb5, 0x61, 0xf7, 0xbb, 0xa9, 0xd4, 0x3e, 0xa7, 0x06, 0x70, 0x1b, 0xca, 0x96, 0xf0, 0xd5, 0x09,
-	0xf9, 0x2a, 0x1d, 0x8a, 0x43, 0x66, 0xd4, 0x80, 0x71, 0x16, 0x9a, 0x24, 0x33, 0x59, 0x38, 0x65,
-	0x7c, 0x65, 0x26, 0x23, 0xde, 0xca, 0xb0, 0xe0, 0x9c, 0x88, 0xb1, 0x0d, 0x93, 0x0f, 0x4d, 0xc7,
-	0x0d, 0x4c, 0xc7, 0x25, 0xf4, 0x41, 0x24, 0x87, 0x3e, 0x4e, 0x75, 0x17, 0xa5, 0x20, 0x11, 0x13,
-	0x99, 0x7c, 0x7b, 0x31, 0xfe, 0x55, 0x40, 0xcf, 0x1f, 0x9f, 0xd5, 0x43, 0xb3, 0x00, 0x7c, 0xd5,
-	0xe6, 0x97, 0x10, 0xe1, 0xa5, 0x2a, 0xae, 0xf2, 0x1d, 0xae, 0x9e, 0xa0, 0x1b, 0x30, 0x2c, 0x4f,
-	0x8a, 0x1c, 0xd0, 0xf0, 0x7a, 0xbe, 0xe7, 0x12, 0x37, 0x10, 0xbc, 0x58, 0x72, 0xa2, 0xff, 0xc3,
-	0x58, 0x92, 0xba, 0x3c, 0xe8, 0xa5, 0x82, 0x0e, 0x15, 0xf7, 0x3f, 0xed, 0x14, 0xfd, 0xef, 0x0e,
-	0xcc, 0x34, 0x3c, 0x8f, 0xda, 0x8e, 0x6b, 0x06, 0x1e, 0x5d, 0xf3, 0xbc, 0x80, 0x05, 0xd4, 0xf4,
-	0xa3, 0x1c, 0xa9, 0xc3, 0xc8, 0x21, 0xa1, 0x2c, 0x6a, 0x5d, 0x1a, 0x8e, 0x48, 0xe3, 0x09, 0x5c,
-	0x2d, 0x16, 0x0c, 0xd1, 0xe5, 0x0c, 0xb1, 0xf8, 0x16, 0x2e, 0xae, 0xda, 0x76, 0xc2, 0x10, 0x19,
-	0xf3, 0x3e, 0xa8, 0x8e, 0x7d, 0x72, 0x10, 0x54, 0xc7, 0xe6, 0x93, 0x50, 0x2a, 0x39, 0x47, 0xe3,
-	0xec, 0x1b, 0x70, 0xa0, 0x56, 0x00, 0x08, 0xcf, 0xe1, 0x32, 0x26, 0x3d, 0xef, 0x90, 0x9c, 0xc9,
-	0x84, 0x3a, 0x8c, 0x58, 0x26, 0xb3, 0x4c, 0x9b, 0x84, 0x2d, 0x36, 0x22, 0xf9, 0x09, 0x15, 0xfa,
-	0xed, 0xb0, 0x83, 0x47, 0xa4, 0xf1, 0x8f, 0x02, 0xd3, 0xc9, 0xa5, 0x03, 0xd1, 0x38, 0x63, 0x3e,
-	0x1e, 0xe5, 0x94, 0x2b, 0x22, 0x54, 0x34, 0xe5, 0x8f, 0x18, 0xc0, 0x2c, 0xb8, 0x16, 0x70, 0xb4,
-	0x6b, 0x07, 0xd4, 0xe9, 0x74, 0x08, 0x6d, 0x93, 0x43, 0x8e, 0x38, 0x09, 0x4a, 0xb5, 0x9d, 0x53,
-	0xb4, 0xd7, 0x59, 0xa1, 0x63, 0x47, 0xaa, 0xd8, 0xe0, 0x1a, 0x32, 0x8d, 0xf6, 0x6f, 0x05, 0x66,
-	0x0a, 0x5f, 0x7d, 0x3e, 0x8d, 0xea, 0x36, 0x0c, 0x73, 0x98, 0x8e, 0x7a, 0xd3, 0xff, 0x32, 0x72,
-	0xf1, 0x6d, 0x09, 0xa8, 0x4b, 0xee, 0xa8, 0x8c, 0xb4, 0xd3, 0x8c, 0x91, 0xa7, 0x2a, 0x4c, 0xe3,
-	0x67, 0x15, 0xd0, 0xe0, 0x7d, 0x3c, 0xa7, 0x8e, 0x78, 0x54, 0xc6, 0x89, 0x6a, 0x38, 0xfc, 0x47,
-	0x0d, 0x41, 0xcd, 0xcd, 0x3e, 0x51, 0xc7, 0xd2, 0x4e, 0xd1, 0xb1, 0xbe, 0x00, 0xdd, 0x8a, 0x00,
-	0xa6, 0xcd, 0x92, 0x69, 0xfa, 0x04, 0x14, 0x9a, 0xb0, 0xd2, 0x74, 0x9f, 0x0d, 0x3e, 0x7b, 0xb8,
-	0x00, 0x8f, 0x6e, 0x42, 0x6d, 0xb7, 0xeb, 0x59, 0x07, 0x21, 0x0e, 0x96, 0x85, 0x7d, 0x28, 0x0b,
-	0xf7, 0x42, 0x3d, 0x08, 0x36, 0xb1, 0x36, 0x9e, 0xc2, 0x54, 0x92, 0x12, 0x8d, 0xae, 0xc7, 0xc8,
-	0x39, 0x15, 0x41, 0xaa, 0xf8, 0xd4, 0x6c, 0xf1, 0x51, 0xb8, 0x3c, 0x70, 0xe5, 0xf9, 0x64, 0x20,
-	0x1f, 0x10, 0xfa, 0x96, 0x45, 0x18, 0x8b, 0xee, 0x0c, 0x49, 0xe3, 0x7b, 0x05, 0xf4, 0x64, 0x4a,
-	0x14, 0x61, 0x3a, 0x8f, 0x21, 0x7b, 0x1a, 0x2a, 0xe1, 0xf7, 0xa2, 0xcc, 0x7a, 0x0d, 0xc7, 0xf4,
-	0x71, 0xf3, 0xb3, 0x71, 0x17, 0x86, 0x05, 0xdf, 0x09, 0xdf, 0x9f, 0x47, 0xa4, 0xa0, 0xe1, 0xc2,
-	0x78, 0xb4, 0x96, 0xde, 0x38, 0x46, 0xcf, 0x3c, 0xd4, 0x1e, 0x75, 0xed, 0x9c, 0xaa, 0xf4, 0x16,
-	0xe7, 0xd8, 0x22, 0xcf, 0x72, 0xb6, 0xa6, 0xb7, 0x8c, 0x57, 0x1a, 0x0c, 0xcb, 0x5e, 0x7a, 0x15,
-	0xaa, 0x4d, 0xb6, 0xc6, 0xd3, 0x87, 0x48, 0x78, 0xae, 0xe0, 0x64, 0x83, 0x5b, 0x21, 0x96, 0xc9,
-	0x80, 0x16, 0x92, 0xe8, 0x1e, 0xd4, 0xe4, 0x52, 0x78, 0x3e, 0xac, 0x9d, 0xd9, 0x23, 0x86, 0x78,
-	0xc9, 0x84, 0xd3, 0x12, 0x68, 0x13, 0x2e, 0x6c, 0x11, 0x62, 0xaf, 0x53, 0xcf, 0xf7, 0x23, 0x8e,
-	0x10, 0x10, 0x4f, 0x50, 0x33, 0x28, 0x87, 0x3e, 0x85, 0x09, 0xbe, 0xb9, 0x6a, 0xdb, 0xb1, 0x2a,
-	0xd9, 0xc5, 0xd1, 0x60, 0x35, 0xe3, 0x3c, 0x2b, 0x9f, 0xac, 0xbe, 0xf4, 0x6d, 0x33, 0x20, 0xa1,
-	0x0b, 0x59, 0xbd, 0x2c, 0x84, 0x07, 0x27, 0xab, 0x24, 0x40, 0x38, 0x27, 0x92, 0xff, 0x38, 0x1c,
-	0x19, 0xf8, 0x38, 0x44, 0x1f, 0x8a, 0xb1, 0xa5, 0x43, 0xea, 0x15, 0x91, 0x95, 0x97, 0xb3, 0x70,
-	0x1a, 0x56, 0x70, 0x47, 0x8e, 0x2c, 0x1d, 0x62, 0x1c, 0xc0, 0xc5, 0x18, 0x7d, 0xa2, 0x53, 0x0e,
-	0x1d, 0xef, 0x80, 0x7a, 0x0b, 0xd1, 0xa0, 0xa4, 0x1e, 0x09, 0x1d, 0x92, 0xc1, 0xf8, 0x53, 0x81,
-	0x89, 0xdc, 0x5f, 0x08, 0xef, 0x72, 0x51, 0x11, 0x2c, 0xaa, 0xe7, 0x01, 0x8b, 0x05, 0x53, 0x06,
-	0xba, 0x01, 0x97, 0x64, 0x33, 0x65, 0xce, 0x4b, 0xd2, 0xf6, 0x09, 0x6d, 0x33, 0x62, 0x79, 0xae,
-	0x6c, 0xa7, 0x2a, 0x46, 0xe2, 0xb0, 0xe5, 0xbc, 0x24, 0xdb, 0x84, 0xb6, 0xc4, 0x89, 0xf1, 0x93,
-	0x02, 0x28, 0xe5, 0xc3, 0x73, 0x42, 0xc4, 0xfb, 0x30, 0xb6, 0x9b, 0x28, 0x8d, 0xbf, 0xe1, 0xae,
-	0x15, 0x77, 0x90, 0xf4, 0xfd, 0x59, 0x39, 0xc3, 0x86, 0xd1, 0x74, 0x67, 0x44, 0x08, 0x4a, 0x81,
-	0xd3, 0x93, 0xf0, 0x55, 0xc5, 0x62, 0xcd, 0xf7, 0x5c, 0xcf, 0x8e, 0xa6, 0x61, 0xb1, 0xe6, 0x7b,
-	0x16, 0xdf, 0xd3, 0xe4, 0x1e, 0x5f, 0xf3, 0x92, 0xed, 0xc9, 0x4f, 0x40, 0xe1, 0x8f, 0x2a, 0x8e,
-	0x48, 0xe3, 0x16, 0x8c, 0xa6, 0x03, 0xc7, 0xa5, 0xf7, 0x9d, 0xce, 0x7e, 0xf8, 0x37, 0x87, 0x58,
-	0x23, 0x1d, 0xb4, 0xae, 0xf7, 0x2c, 0x2c, 0x76, 0xbe, 0x34, 0xf6, 0x60, 0x34, 0xed, 0x82, 0xd3,
-	0x49, 0x09, 0x6b, 0xcd, 0x5e, 0x6c, 0x19, 0x5f, 0x73, 0xa8, 0xe1, 0xbf, 0xcc, 0x37, 0xad, 0xc8,
-	0xb6, 0x64, 0x63, 0x71, 0x16, 0xca, 0xe1, 0x9f, 0x3e, 0x55, 0x18, 0x7e, 0x4c, 0x9d, 0x80, 0xe8,
-	0x43, 0xa8, 0x02, 0xa5, 0x6d, 0x93, 0x31, 0x5d, 0x59, 0x5c, 0x90, 0x08, 0x99, 0x7c, 0xca, 0x20,
-	0x80, 0x72, 0x83, 0x12, 0x53, 0xf0, 0x01, 0x94, 0xe5, 0xe0, 0xa9, 0x2b, 0x8b, 0x9f, 0x00, 0x24,
-	0xc5, 0xc4, 0x35, 0x6c, 0x3d, 0xda, 0xda, 0xd0, 0x87, 0x50, 0x0d, 0x46, 0x1e, 0xaf, 0x36, 0x77,
-	0x9a, 0x5b, 0xf7, 0x75, 0x45, 0x10, 0x58, 0x12, 0x2a, 0xe7, 0x59, 0xe7, 0x3c, 0xda, 0xe2, 0x07,
-	0xb9, 0x06, 0x82, 0x46, 0x40, 0x5b, 0xed, 0x76, 0xf5, 0x21, 0x54, 0x06, 0x75, 0x7d, 0x4d, 0x57,
-	0xf8, 0x4d, 0x5b, 0x1e, 0xed, 0x99, 0x5d, 0x5d, 0x5d, 0xbc, 0x03, 0xe3, 0xd9, 0x84, 0x16, 0x6a,
-	0x3d, 0x7a, 0xe0, 0xb8, 0x1d, 0x79, 0x61, 0x2b, 0x10, 0x28, 0x25, 0x2f, 0x94, 0x16, 0xda, 0xba,
-	0xba, 0xf6, 0xd9, 0xaf, 0x6f, 0xe6, 0x94, 0xd7, 0x6f, 0xe6, 0x94, 0xbf, 0xde, 0xcc, 0x29, 0x3f,
-	0xbc, 0x9d, 0x1b, 0x7a, 0xfd, 0x76, 0x6e, 0xe8, 0x8f, 0xb7, 0x73, 0x43, 0x5f, 0xbf, 0xd7, 0x71,
-	0x82, 0xfd, 0xfe, 0xee, 0x92, 0xe5, 0xf5, 0x96, 0x7d, 0xc7, 0xed, 0x58, 0xa6, 0xbf, 0x1c, 0x38,
-	0x96, 0x6d, 0x2d, 0xa7, 0x72, 0x6a, 0xb7, 0x2c, 0xfe, 0x05, 0xbd, 0xf9, 0x5f, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x96, 0x00, 0x93, 0xac, 0x24, 0x15, 0x00, 0x00,
+	// 1993 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x19, 0x4b, 0x6f, 0x24, 0x47,
+	0xd9, 0xdd, 0x3d, 0xcf, 0x6f, 0x3c, 0xe3, 0x4e, 0xed, 0xc3, 0xb3, 0x2f, 0xaf, 0x53, 0x04, 0x64,
+	0x1c, 0xd8, 0x55, 0x9c, 0xac, 0xc2, 0x2b, 0x09, 0xf6, 0x8c, 0x49, 0x46, 0x96, 0x1d, 0xab, 0x6c,
+	0x58, 0xc2, 0x65, 0xd4, 0xee, 0x2e, 0x8f, 0x5b, 0x9e, 0xe9, 0xee, 0x74, 0xf5, 0x78, 0xb3, 0x91,
+	0x38, 0x71, 0xe5, 0x00, 0x5c, 0xe0, 0xc0, 0x81, 0x1c, 0x10, 0xe2, 0x97, 0xc0, 0x31, 0x27, 0xe0,
+	0x88, 0x76, 0xc5, 0x85, 0x2b, 0x67, 0xa4, 0xa8, 0x1e, 0xfd, 0x9c, 0xf6, 0x63, 0xe5, 0xc9, 0x69,
+	0xea, 0xab, 0xfe, 0x5e, 0xf5, 0xd5, 0xf7, 0xac, 0x81, 0x7b, 0x27, 0xd4, 0x0a, 0xa3, 0x23, 0x6a,
+	0x45, 0xc1, 0xd1, 0xe3, 0x64, 0xfd, 0x28, 0x08, 0xfd, 0xc8, 0x47, 0xad, 0xcc, 0x47, 0xfc, 0x09,
+	0x34, 0x0f, 0xad, 0xa3, 0x31, 0x3d, 0x08, 0x2c, 0x0f, 0x75, 0xa1, 0x2e, 0x80, 0x41, 0xbf, 0xab,
+	0xad, 0x6a, 0x6b, 0x06, 0x89, 0x41, 0x74, 0x17, 0x1a, 0x07, 0x91, 0x15, 0x46, 0x3b, 0xf4, 0x79,
+	0x57, 0x5f, 0xd5, 0xd6, 0x16, 0x49, 0x02, 0xa3, 0xdb, 0x50, 0xdb, 0xf6, 0x1c, 0xfe, 0xc5, 0x10,
+	0x5f, 0x14, 0x84, 0xff, 0xa0, 0x83, 0xf9, 0x11, 0x17, 0xb5, 0x45, 0xad, 0x88, 0xd0, 0x4f, 0xa7,
+	0x94, 0x45, 0xe8, 0x3d, 0x58, 0xb4, 0x4f, 0x2c, 0x6f, 0x44, 0x8f, 0x29, 0x75, 0x94, 0x9c, 0xd6,
+	0xc6, 0x9d, 0x47, 0x19, 0x9d, 0x1e, 0xf5, 0x32, 0x08, 0x24, 0x87, 0x8e, 0xde, 0x81, 0xe6, 0x33,
+	0x2b, 0xa2, 0xe1, 0xc4, 0x0a, 0x4f, 0x85, 0x22, 0xad, 0x8d, 0xdb, 0x39, 0xda, 0xa7, 0xf1, 0x57,
+	0x92, 0x22, 0xa2, 0xef, 0x41, 0x83, 0x45, 0x56, 0x34, 0x65, 0x94, 0x75, 0x8d, 0x55, 0x63, 0xad,
+	0xb5, 0x71, 0x3f, 0x47, 0x94, 0x58, 0xe0, 0x40, 0x60, 0x91, 0x04, 0x1b, 0xad, 0xc1, 0x92, 0xed,
+	0x4f, 0x02, 0x3a, 0xa6, 0x11, 0x95, 0x1f, 0xbb, 0x95, 0x55, 0x6d, 0xad, 0x41, 0x8a, 0xdb, 0xe8,
+	0x4d, 0x30, 0x68, 0x18, 0x76, 0xab, 0x25, 0xe7, 0x21, 0x53, 0xcf, 0x73, 0xbd, 0xd1, 0x76, 0x18,
+	0xfa, 0x21, 0xe1, 0x58, 0xf8, 0x19, 0x34, 0x13, 0x45, 0x11, 0xe6, 0x26, 0xa1, 0xf6, 0x69, 0xe0,
+	0xbb, 0x5e, 0x74, 0xc8, 0x84, 0x49, 0x2a, 0x24, 0xb7, 0x87, 0x56, 0x00, 0x42, 0xca, 0xfc, 0xf1,
+	0x19, 0x75, 0x0e, 0x99, 0x38, 0x78, 0x85, 0x64, 0x76, 0xd0, 0xb7, 0xa0, 0x13, 0x52, 0xc7, 0x27,
+	0x29, 0x8e, 0x21, 0x70, 0x0a, 0xbb, 0xf8, 0x97, 0x60, 0xf6, 0x5d, 0x16, 0x58, 0x91, 0x7d, 0x42,
+	0xc3, 0x4d, 0x3b, 0x72, 0x7d, 0x0f, 0xbd, 0x09, 0x35, 0x4b, 0xac, 0x84, 0xe4, 0xce, 0xc6, 0x8d,
+	0x9c, 0xf2, 0x12, 0x89, 0x28, 0x14, 0xee, 0x08, 0x3d, 0x7f, 0x32, 0x71, 0xa3, 0x44, 0x8d, 0x04,
+	0x46, 0xab, 0xd0, 0x1a, 0xb0, 0x83, 0xe7, 0x9e, 0xbd, 0xcf, 0xb5, 0x16, 0x1a, 0x34, 0x48, 0x76,
+	0x0b, 0xf7, 0xc0, 0xd8, 0xec, 0xed, 0xe4, 0x98, 0x68, 0x17, 0x33, 0xd1, 0x67, 0x99, 0xfc, 0x4a,
+	0x87, 0x5b, 0x03, 0xef, 0x78, 0x3c, 0xa5, 0x9e, 0x4d, 0x9d, 0xf4, 0x38, 0x0c, 0xfd, 0x18, 0xda,
+	0xc9, 0x87, 0xc3, 0xe7, 0x01, 0x55, 0x07, 0xba, 0x9b, 0x3b, 0x50, 0x0e, 0x83, 0xe4, 0x09, 0xd0,
+	0x07, 0xd0, 0x4e, 0x19, 0x0e, 0xfa, 0xfc, 0x8c, 0xc6, 0xcc, 0x7d, 0x66, 0x31, 0x48, 0x1e, 0x5f,
+	0x04, 0x8a, 0x7d, 0x42, 0x27, 0xd6, 0xa0, 0x2f, 0x0c, 0x60, 0x90, 0x04, 0x46, 0x3b, 0x70, 0x83,
+	0x7e, 0x66, 0x8f, 0xa7, 0x0e, 0xcd, 0xd0, 0x38, 0xc2, 0xa1, 0x2e, 0x14, 0x51, 0x46, 0x85, 0xff,
+	0xa6, 0x65, 0xaf, 0x52, 0x39, 0xe1, 0xcf, 0xe1, 0x96, 0x5b, 0x66, 0x19, 0x15, 0x66, 0xb8, 0xdc,
+	0x10, 0x59, 0x4c, 0x52, 0xce, 0x00, 0x3d, 0x49, 0x9c, 0x44, 0x46, 0xdd, 0x83, 0x73, 0xd4, 0x2d,
+	0xb8, 0x0b, 0x06, 0xc3, 0xb2, 0x4f, 0x85, 0x25, 0x5a, 0x1b, 0x66, 0xde, 0xb1, 0x7a, 0x3b, 0x84,
+	0x7f, 0xc4, 0x5f, 0x68, 0xf0, 0x5a, 0x26, 0x4f, 0xb0, 0xc0, 0xf7, 0x18, 0xbd, 0x6e, 0xa2, 0xd8,
+	0x05, 0xe4, 0x14, 0xac, 0x43, 0xe3, 0xdb, 0x3c, 0x4f, 0x77, 0x15, 0xfd, 0x25, 0x84, 0xf8, 0x4f,
+	0x1a, 0xdc, 0xe8, 0x65, 0x02, 0x72, 0x97, 0x32, 0x66, 0x8d, 0xae, 0xad, 0x65, 0x31, 0xf4, 0xf5,
+	0x92, 0xd0, 0xbf, 0x6a, 0x68, 0xff, 0x33, 0xe7, 0x10, 0x3d, 0xdf, 0x3b, 0x76, 0x47, 0x68, 0x1d,
+	0x2a, 0x2c, 0xb0, 0xbc, 0xae, 0x56, 0x92, 0x2a, 0x93, 0xac, 0x47, 0x2a, 0x4c, 0x65, 0x7f, 0xc6,
+	0x73, 0x7a, 0xa2, 0x47, 0x0c, 0xf2, 0x53, 0x3a, 0x19, 0x87, 0xec, 0x1a, 0x25, 0xa7, 0xcc, 0x79,
+	0x6c, 0x0e, 0x9d, 0xc7, 0x04, 0x8b, 0x63, 0xa2, 0x22, 0x63, 0x22, 0x86, 0x11, 0x86, 0xb6, 0x3d,
+	0x0d, 0x43, 0xea, 0x45, 0xc3, 0xc0, 0x19, 0x46, 0x4c, 0x24, 0xd0, 0x0a, 0x69, 0xa9, 0xcd, 0x7d,
+	0x7e, 0xb2, 0x7f, 0x68, 0x70, 0x87, 0x07, 0x91, 0x33, 0x1d, 0x67, 0x62, 0x60, 0x4e, 0x15, 0xe5,
+	0x09, 0xd4, 0x6c, 0x61, 0xab, 0x4b, 0x1c, 0x5b, 0x1a, 0x94, 0x28, 0x64, 0xd4, 0x83, 0x0e, 0x53,
+	0x2a, 0x49, 0x97, 0x17, 0x46, 0xe9, 0x6c, 0xdc, 0xcb, 0x91, 0x1f, 0xe4, 0x50, 0x48, 0x81, 0x04,
+	0xef, 0xc3, 0x8d, 0x5d, 0xcb, 0xf5, 0x22, 0xcb, 0xf5, 0x68, 0xf8, 0x51, 0x4c, 0x87, 0xbe, 0x9f,
+	0x29, 0x57, 0x5a, 0x89, 0xc7, 0xa6, 0x34, 0xc5, 0x7a, 0x85, 0xff, 0x6b, 0x80, 0x59, 0xfc, 0x7c,
+	0x5d, 0x0b, 0x3d, 0x00, 0xe0, 0xab, 0x21, 0x17, 0x42, 0x85, 0x95, 0x9a, 0xa4, 0xc9, 0x77, 0x38,
+	0x7b, 0x8a, 0xde, 0x82, 0xaa, 0xfc, 0x52, 0x66, 0x80, 0x9e, 0x3f, 0x09, 0x7c, 0x8f, 0x7a, 0x91,
+	0xc0, 0x25, 0x12, 0x13, 0x7d, 0x03, 0xda, 0xa9, 0x8b, 0xf3, 0x4b, 0xaf, 0x94, 0xf8, 0x7d, 0x52,
+	0x50, 0x8d, 0xcb, 0x0b, 0x2a, 0xfa, 0x21, 0xb4, 0xb8, 0x0f, 0x0f, 0x6d, 0x7f, 0xea, 0x45, 0xac,
+	0x5b, 0x13, 0x44, 0xf9, 0xbc, 0xbf, 0xe7, 0x3b, 0xc2, 0xdb, 0x7b, 0x1c, 0x85, 0x00, 0x8b, 0x97,
+	0xdc, 0x3e, 0xed, 0xc0, 0xf5, 0x3c, 0xea, 0x0c, 0x23, 0x1e, 0x12, 0xac, 0x5b, 0x17, 0xe4, 0xdd,
+	0x1c, 0xf9, 0xbe, 0xc0, 0x10, 0x31, 0x43, 0x16, 0x83, 0x14, 0xe0, 0x01, 0xba, 0x74, 0x34, 0xf6,
+	0xed, 0x53, 0xd7, 0x1b, 0x0d, 0x1d, 0x67, 0xcc, 0xcf, 0xd3, 0x10, 0xe7, 0x69, 0xc7, 0xdb, 0x7d,
+	0x67, 0x7c, 0xc8, 0xd0, 0x37, 0xa1, 0x23, 0x9d, 0x67, 0x78, 0x46, 0x43, 0xc6, 0x5d, 0xa6, 0x29,
+	0xd1, 0xe4, 0xee, 0xcf, 0xe4, 0x26, 0x5a, 0x03, 0x93, 0x47, 0xf6, 0x30, 0xae, 0xee, 0x9c, 0x1f,
+	0x94, 0x46, 0xfc, 0xfb, 0xd0, 0xce, 0x1d, 0x0a, 0x2d, 0x43, 0xdd, 0xf3, 0x1d, 0x3a, 0x74, 0x1d,
+	0x71, 0xc7, 0x4d, 0x52, 0xe3, 0xe0, 0xc0, 0x41, 0x37, 0xa1, 0x2a, 0x2c, 0x23, 0x6e, 0xaf, 0x4d,
+	0x24, 0x80, 0x8f, 0xa1, 0x95, 0x39, 0x15, 0xba, 0x03, 0x0d, 0x71, 0xfe, 0x98, 0xdc, 0x20, 0x75,
+	0x01, 0x0f, 0x1c, 0xf4, 0x06, 0x74, 0x22, 0x2b, 0x1c, 0xd1, 0x68, 0x18, 0xf3, 0x97, 0x6e, 0xb0,
+	0x28, 0x77, 0xf7, 0xa4, 0x94, 0xdb, 0x50, 0x9b, 0xf8, 0x67, 0xae, 0x37, 0x52, 0xa5, 0x5f, 0x41,
+	0xf8, 0x5d, 0xb8, 0xd7, 0xf3, 0xfd, 0xd0, 0x71, 0x3d, 0x2b, 0xf2, 0xc3, 0x2d, 0xdf, 0x8f, 0x58,
+	0x14, 0x5a, 0x41, 0x1c, 0xc0, 0x5d, 0xa8, 0xc7, 0x06, 0x51, 0x62, 0x15, 0x88, 0x3f, 0x81, 0xfb,
+	0xe5, 0x84, 0xaa, 0x46, 0x5c, 0x23, 0x50, 0xfe, 0xac, 0xc1, 0xcd, 0x4d, 0xc7, 0x49, 0x31, 0x62,
+	0x6d, 0xbe, 0x0d, 0xba, 0x3a, 0xff, 0x85, 0x21, 0xa2, 0xbb, 0xe2, 0xbc, 0x99, 0xd4, 0xb1, 0x98,
+	0xe4, 0x86, 0x19, 0xf7, 0x36, 0x4a, 0xdc, 0x7b, 0xd6, 0x1b, 0x2a, 0x25, 0xde, 0x80, 0x3f, 0x83,
+	0x65, 0x42, 0x27, 0xfe, 0x19, 0xbd, 0x96, 0xa6, 0x5d, 0xa8, 0xdb, 0x16, 0xb3, 0x2d, 0x87, 0xaa,
+	0x86, 0x2a, 0x06, 0xf9, 0x97, 0x50, 0xf0, 0x77, 0xd4, 0xa5, 0xc5, 0x20, 0xde, 0x06, 0xb3, 0x1f,
+	0x5a, 0xae, 0xc7, 0x2f, 0x37, 0x16, 0x79, 0xae, 0x83, 0x75, 0xa1, 0x3e, 0xf5, 0x1c, 0x8e, 0x1e,
+	0x0b, 0x50, 0x20, 0xfe, 0x9d, 0x06, 0xe6, 0xae, 0x7f, 0x46, 0x65, 0xe4, 0xcc, 0x27, 0x67, 0x67,
+	0x3d, 0x55, 0xbf, 0xcc, 0x53, 0x8d, 0x59, 0x4f, 0xc5, 0xbf, 0xd7, 0x60, 0xf9, 0xa7, 0x81, 0x63,
+	0x45, 0x25, 0x66, 0xbd, 0xa6, 0x6e, 0xe7, 0x39, 0xc5, 0xec, 0x7d, 0x1b, 0x65, 0xf7, 0xfd, 0x3f,
+	0x0d, 0xee, 0xa6, 0x3a, 0xcd, 0xc4, 0xca, 0xd7, 0xa4, 0xdc, 0x1d, 0x11, 0x48, 0x61, 0xc6, 0x59,
+	0x93, 0xda, 0x6f, 0xc3, 0xeb, 0xd2, 0xd6, 0x51, 0xe8, 0x8e, 0x46, 0x34, 0x1c, 0xd2, 0x33, 0x5e,
+	0xac, 0xd3, 0x02, 0xcf, 0x6d, 0x7c, 0x69, 0x0b, 0xfb, 0x40, 0xf0, 0x38, 0x94, 0x2c, 0xb6, 0x39,
+	0x87, 0x5c, 0x33, 0xfb, 0x1f, 0x0d, 0xee, 0x95, 0x9e, 0x7a, 0x3e, 0xcd, 0xe0, 0x13, 0xa8, 0xf2,
+	0x74, 0x1f, 0xf7, 0x7f, 0x0f, 0x73, 0x74, 0x89, 0xb4, 0xb4, 0x1f, 0x92, 0xd8, 0x71, 0x05, 0x32,
+	0xae, 0x32, 0xd2, 0x5d, 0xa9, 0xa6, 0xe1, 0xbf, 0xea, 0x80, 0x66, 0xe5, 0xf1, 0x48, 0x3e, 0xe7,
+	0x50, 0x39, 0x23, 0xea, 0x6a, 0x10, 0x8f, 0x7b, 0x29, 0xbd, 0x30, 0x5f, 0xc4, 0xcd, 0x9e, 0x71,
+	0x85, 0x66, 0xef, 0x27, 0x60, 0xda, 0x71, 0x6d, 0x1e, 0xb2, 0x74, 0xb2, 0xbd, 0xa4, 0x80, 0x2f,
+	0xd9, 0x59, 0x78, 0xca, 0x66, 0x8f, 0x5d, 0x2d, 0xc9, 0x75, 0x6f, 0x43, 0x4b, 0x94, 0x42, 0xd5,
+	0x42, 0xd4, 0x84, 0x7e, 0x28, 0xdf, 0x29, 0x09, 0xf6, 0x20, 0xd0, 0xc4, 0x1a, 0x7f, 0x0a, 0xb7,
+	0x53, 0x97, 0xe8, 0x8d, 0x7d, 0x36, 0xaf, 0xec, 0x91, 0x49, 0x79, 0x7a, 0x3e, 0xe5, 0x85, 0xb0,
+	0x3c, 0x23, 0x72, 0x3e, 0x1e, 0xc8, 0x7b, 0xeb, 0xa9, 0x6d, 0x53, 0xc6, 0x62, 0x99, 0x0a, 0xc4,
+	0xbf, 0xd5, 0x60, 0x45, 0xa6, 0xa2, 0xf4, 0xae, 0x77, 0x2d, 0xcf, 0x1a, 0x7d, 0xed, 0x19, 0x29,
+	0x53, 0x77, 0x55, 0xcc, 0x2b, 0x10, 0xff, 0x45, 0x83, 0x87, 0xe7, 0xea, 0x34, 0x37, 0x83, 0xc4,
+	0xc2, 0xf5, 0x9c, 0xf0, 0x57, 0x8a, 0x3a, 0xfc, 0x6b, 0x0d, 0xcc, 0x74, 0x8e, 0x55, 0x0d, 0xd9,
+	0xf5, 0x9f, 0x01, 0xee, 0x42, 0x43, 0xbd, 0x7c, 0xc9, 0x9c, 0x61, 0x90, 0x04, 0xbe, 0x68, 0xc2,
+	0xc7, 0xef, 0x41, 0x55, 0xe0, 0x5d, 0xf2, 0x92, 0x76, 0x4e, 0x00, 0x63, 0x0f, 0x3a, 0xf1, 0x5a,
+	0x9a, 0xee, 0x02, 0x3e, 0xab, 0xd0, 0xfa, 0x78, 0xec, 0x14, 0x58, 0x65, 0xb7, 0x38, 0xc6, 0x1e,
+	0x7d, 0x56, 0xd0, 0x35, 0xbb, 0x85, 0xbf, 0x30, 0xa0, 0x2a, 0x9b, 0xf8, 0xfb, 0xd0, 0x1c, 0xb0,
+	0x2d, 0x1e, 0x7c, 0x54, 0x96, 0xf6, 0x06, 0x49, 0x37, 0xb8, 0x16, 0x62, 0x99, 0x4e, 0x86, 0x0a,
+	0x44, 0x1f, 0x40, 0x4b, 0x2e, 0x65, 0xe3, 0x6c, 0x94, 0x8c, 0x50, 0xc5, 0xeb, 0x21, 0x59, 0x0a,
+	0xb4, 0x03, 0xaf, 0xed, 0x51, 0xea, 0xf4, 0x43, 0x3f, 0x08, 0x62, 0x8c, 0x6e, 0xe5, 0x2a, 0x6c,
+	0x66, 0xe9, 0xd0, 0x8f, 0x60, 0x89, 0x6f, 0x6e, 0x3a, 0x4e, 0xc2, 0x4a, 0x8e, 0x0f, 0x68, 0x36,
+	0x17, 0x92, 0x22, 0x2a, 0x1f, 0xe9, 0xa4, 0xd3, 0x2b, 0x13, 0xc6, 0x63, 0xc4, 0xec, 0x48, 0x97,
+	0x5e, 0x10, 0x29, 0x90, 0x14, 0x9f, 0xaf, 0xea, 0x33, 0xcf, 0x57, 0xe8, 0xbb, 0x62, 0x5e, 0x1a,
+	0x51, 0x31, 0x24, 0x74, 0x36, 0x96, 0xf3, 0xc5, 0x48, 0xe5, 0xbf, 0x91, 0x9c, 0x95, 0x46, 0x14,
+	0x9f, 0xc2, 0xcd, 0x24, 0x77, 0xc7, 0x5f, 0x79, 0xe2, 0x7d, 0x85, 0x9a, 0xb1, 0x16, 0x4f, 0x68,
+	0xfa, 0xb9, 0x89, 0x57, 0x22, 0xe0, 0xff, 0x6b, 0xb0, 0x54, 0x78, 0x0c, 0x7d, 0x15, 0x41, 0x65,
+	0x45, 0x45, 0x9f, 0x47, 0x51, 0x29, 0x6b, 0xa0, 0xdf, 0x82, 0x5b, 0xb2, 0x15, 0x61, 0xee, 0xe7,
+	0x74, 0x18, 0xd0, 0x70, 0xc8, 0xa8, 0xed, 0x7b, 0xb2, 0x19, 0xd1, 0x09, 0x12, 0x1f, 0x0f, 0xdc,
+	0xcf, 0xe9, 0x3e, 0x0d, 0x0f, 0xc4, 0x17, 0xf4, 0x10, 0x5a, 0xd9, 0xa9, 0xaa, 0x5a, 0x7c, 0x46,
+	0xc5, 0x7f, 0xd4, 0x00, 0x65, 0x8c, 0x3c, 0xa7, 0x04, 0xfc, 0x21, 0xb4, 0x8f, 0x52, 0xa6, 0xc9,
+	0x33, 0xd4, 0xeb, 0xe5, 0x05, 0x3a, 0x2b, 0x3f, 0x4f, 0x87, 0x1d, 0x58, 0xcc, 0xa6, 0x40, 0x84,
+	0xa0, 0x12, 0xb9, 0x13, 0xaa, 0x7a, 0x71, 0xb1, 0xe6, 0x7b, 0xbc, 0xf3, 0x55, 0x03, 0x9a, 0x58,
+	0xf3, 0x3d, 0x9b, 0xef, 0xc9, 0x56, 0x58, 0xac, 0x79, 0x4c, 0x4f, 0xe4, 0x23, 0x96, 0x30, 0x58,
+	0x93, 0xc4, 0x20, 0x7e, 0x07, 0x16, 0xb3, 0x37, 0xcb, 0xa9, 0x4f, 0xdc, 0xd1, 0x89, 0x7a, 0xa9,
+	0x15, 0x6b, 0x64, 0x82, 0x31, 0xf6, 0x9f, 0xa9, 0x6c, 0xc0, 0x97, 0xf8, 0x18, 0x16, 0xb3, 0x26,
+	0xb8, 0x1a, 0x95, 0xd0, 0xd6, 0x9a, 0x24, 0x9a, 0xf1, 0x35, 0xcf, 0x45, 0xfc, 0x97, 0x05, 0x96,
+	0x1d, 0xeb, 0x96, 0x6e, 0xac, 0x3f, 0x80, 0x9a, 0x7a, 0xb7, 0x6e, 0x42, 0xf5, 0x69, 0xe8, 0x46,
+	0xd4, 0x5c, 0x40, 0x0d, 0xa8, 0xec, 0x5b, 0x8c, 0x99, 0xda, 0xfa, 0x9a, 0x4c, 0xa1, 0xe9, 0x23,
+	0x0b, 0x02, 0xa8, 0xf5, 0x42, 0x6a, 0x09, 0x3c, 0x80, 0x9a, 0x9c, 0xa6, 0x4c, 0x6d, 0xfd, 0x07,
+	0x00, 0x69, 0xb4, 0x71, 0x0e, 0x7b, 0x1f, 0xef, 0x6d, 0x9b, 0x0b, 0xa8, 0x05, 0xf5, 0xa7, 0x9b,
+	0x83, 0xc3, 0xc1, 0xde, 0x87, 0xa6, 0x26, 0x00, 0x22, 0x01, 0x9d, 0xe3, 0xf4, 0x39, 0x8e, 0xb1,
+	0xfe, 0x9d, 0x42, 0x85, 0x41, 0x75, 0x30, 0x36, 0xc7, 0x63, 0x73, 0x01, 0xd5, 0x40, 0xef, 0x6f,
+	0x99, 0x1a, 0x97, 0xb4, 0xe7, 0x87, 0x13, 0x6b, 0x6c, 0xea, 0xeb, 0xef, 0x42, 0x27, 0xef, 0xf1,
+	0x82, 0xad, 0x1f, 0xf2, 0xa7, 0x01, 0x29, 0xf0, 0x20, 0x12, 0x69, 0x4c, 0x0a, 0x94, 0x1a, 0x3a,
+	0xa6, 0xbe, 0xf5, 0xfe, 0xdf, 0x5f, 0xac, 0x68, 0x5f, 0xbe, 0x58, 0xd1, 0xfe, 0xfd, 0x62, 0x45,
+	0xfb, 0xcd, 0xcb, 0x95, 0x85, 0x2f, 0x5f, 0xae, 0x2c, 0xfc, 0xeb, 0xe5, 0xca, 0xc2, 0x2f, 0xde,
+	0x18, 0xb9, 0xd1, 0xc9, 0xf4, 0xe8, 0x91, 0xed, 0x4f, 0x1e, 0x07, 0xae, 0x37, 0xb2, 0xad, 0xe0,
+	0x71, 0xe4, 0xda, 0x8e, 0xfd, 0x38, 0xe3, 0x53, 0x47, 0x35, 0xf1, 0x87, 0xcf, 0xdb, 0x5f, 0x0d,
+	0x00, 0x0d, 0x74, 0xe3, 0xee, 0x0f, 0x1a, 0x00, 0x00,
 }
 
 func (m *TableSpan) Marshal() (dAtA []byte, err error) {
@@ -2422,6 +2944,11 @@ func (m *Watermark) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RedoResolvedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.RedoResolvedTs))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.ResolvedTs != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ResolvedTs))
 		i--
@@ -2703,6 +3230,11 @@ func (m *CheckpointTsMessage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RedoResolvedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.RedoResolvedTs))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.CheckpointTs != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointTs))
 		i--
@@ -2894,6 +3426,49 @@ func (m *MaintainerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.RedoResolvedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.RedoResolvedTs))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.ConfigVersion != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ConfigVersion))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.BlockingDdlTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.BlockingDdlTs))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.PinnedTables) > 0 {
+		for iNdEx := len(m.PinnedTables) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.PinnedTables[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.SpanCounts) > 0 {
+		for iNdEx := len(m.SpanCounts) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SpanCounts[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Err) > 0 {
 		for iNdEx := len(m.Err) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2940,6 +3515,86 @@ func (m *MaintainerStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *NodeSpanCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NodeSpanCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NodeSpanCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Count != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.NodeId) > 0 {
+		i -= len(m.NodeId)
+		copy(dAtA[i:], m.NodeId)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.NodeId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PinnedTable) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PinnedTable) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PinnedTable) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Moving {
+		i--
+		if m.Moving {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.TargetNodeId) > 0 {
+		i -= len(m.TargetNodeId)
+		copy(dAtA[i:], m.TargetNodeId)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.TargetNodeId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.TableId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TableId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *CoordinatorBootstrapRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3025,6 +3680,11 @@ func (m *AddMaintainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ConfigVersion != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ConfigVersion))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.CheckpointTs != 0 {
 		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointTs))
 		i--
@@ -3107,7 +3767,7 @@ func (m *RemoveMaintainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
-func (m *MaintainerBootstrapRequest) Marshal() (dAtA []byte, err error) {
+func (m *DrainNodeRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3117,56 +3777,37 @@ func (m *MaintainerBootstrapRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MaintainerBootstrapRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *DrainNodeRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MaintainerBootstrapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *DrainNodeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.TableTriggerEventDispatcherId != nil {
-		{
-			size, err := m.TableTriggerEventDispatcherId.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.StartTs != 0 {
-		i = encodeVarintHeartbeat(dAtA, i, uint64(m.StartTs))
+	if m.Undrain {
 		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.Config) > 0 {
-		i -= len(m.Config)
-		copy(dAtA[i:], m.Config)
-		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Config)))
+		if m.Undrain {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x10
 	}
-	if m.ChangefeedID != nil {
-		{
-			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
-		}
+	if len(m.NodeId) > 0 {
+		i -= len(m.NodeId)
+		copy(dAtA[i:], m.NodeId)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.NodeId)))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *MaintainerBootstrapResponse) Marshal() (dAtA []byte, err error) {
+func (m *MoveTableRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -3176,24 +3817,177 @@ func (m *MaintainerBootstrapResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *MaintainerBootstrapResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *MoveTableRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MaintainerBootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *MoveTableRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.CheckpointTs != 0 {
-		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointTs))
+	if len(m.TargetNodeId) > 0 {
+		i -= len(m.TargetNodeId)
+		copy(dAtA[i:], m.TargetNodeId)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.TargetNodeId)))
 		i--
-		dAtA[i] = 0x20
+		dAtA[i] = 0x1a
 	}
-	if m.Err != nil {
-		{
-			size, err := m.Err.MarshalToSizedBuffer(dAtA[:i])
+	if m.TableId != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.TableId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateMaintainerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateMaintainerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateMaintainerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ConfigVersion != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ConfigVersion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Config) > 0 {
+		i -= len(m.Config)
+		copy(dAtA[i:], m.Config)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Config)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MaintainerBootstrapRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MaintainerBootstrapRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MaintainerBootstrapRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TableTriggerEventDispatcherId != nil {
+		{
+			size, err := m.TableTriggerEventDispatcherId.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.StartTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.StartTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Config) > 0 {
+		i -= len(m.Config)
+		copy(dAtA[i:], m.Config)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Config)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MaintainerBootstrapResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MaintainerBootstrapResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MaintainerBootstrapResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.CheckpointTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.CheckpointTs))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Err != nil {
+		{
+			size, err := m.Err.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -3396,6 +4190,105 @@ func (m *MaintainerCloseResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *UpdateDispatcherManagerRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateDispatcherManagerRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateDispatcherManagerRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Version != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Config) > 0 {
+		i -= len(m.Config)
+		copy(dAtA[i:], m.Config)
+		i = encodeVarintHeartbeat(dAtA, i, uint64(len(m.Config)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdateDispatcherManagerResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdateDispatcherManagerResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdateDispatcherManagerResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Err != nil {
+		{
+			size, err := m.Err.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Version != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.Version))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.ChangefeedID != nil {
+		{
+			size, err := m.ChangefeedID.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintHeartbeat(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *InfluencedTables) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -3422,21 +4315,21 @@ func (m *InfluencedTables) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x18
 	}
 	if len(m.TableIDs) > 0 {
-		dAtA27 := make([]byte, len(m.TableIDs)*10)
-		var j26 int
+		dAtA32 := make([]byte, len(m.TableIDs)*10)
+		var j31 int
 		for _, num1 := range m.TableIDs {
 			num := uint64(num1)
 			for num >= 1<<7 {
-				dAtA27[j26] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA32[j31] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j26++
+				j31++
 			}
-			dAtA27[j26] = uint8(num)
-			j26++
+			dAtA32[j31] = uint8(num)
+			j31++
 		}
-		i -= j26
-		copy(dAtA[i:], dAtA27[:j26])
-		i = encodeVarintHeartbeat(dAtA, i, uint64(j26))
+		i -= j31
+		copy(dAtA[i:], dAtA32[:j31])
+		i = encodeVarintHeartbeat(dAtA, i, uint64(j31))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -3691,6 +4584,11 @@ func (m *TableSpanStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ResolvedTs != 0 {
+		i = encodeVarintHeartbeat(dAtA, i, uint64(m.ResolvedTs))
+		i--
+		dAtA[i] = 0x28
+	}
 	if m.EventSizePerSecond != 0 {
 		i -= 4
 		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.EventSizePerSecond))))
@@ -3975,6 +4873,9 @@ func (m *Watermark) Size() (n int) {
 	if m.ResolvedTs != 0 {
 		n += 1 + sovHeartbeat(uint64(m.ResolvedTs))
 	}
+	if m.RedoResolvedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.RedoResolvedTs))
+	}
 	return n
 }
 
@@ -4089,6 +4990,9 @@ func (m *CheckpointTsMessage) Size() (n int) {
 	if m.CheckpointTs != 0 {
 		n += 1 + sovHeartbeat(uint64(m.CheckpointTs))
 	}
+	if m.RedoResolvedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.RedoResolvedTs))
+	}
 	return n
 }
 
@@ -4179,12 +5083,68 @@ func (m *MaintainerStatus) Size() (n int) {
 			n += 1 + l + sovHeartbeat(uint64(l))
 		}
 	}
-	return n
-}
-
-func (m *CoordinatorBootstrapRequest) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.SpanCounts) > 0 {
+		for _, e := range m.SpanCounts {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if len(m.PinnedTables) > 0 {
+		for _, e := range m.PinnedTables {
+			l = e.Size()
+			n += 1 + l + sovHeartbeat(uint64(l))
+		}
+	}
+	if m.BlockingDdlTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.BlockingDdlTs))
+	}
+	if m.ConfigVersion != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ConfigVersion))
+	}
+	if m.RedoResolvedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.RedoResolvedTs))
+	}
+	return n
+}
+
+func (m *NodeSpanCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeId)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovHeartbeat(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *PinnedTable) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.TableId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TableId))
+	}
+	l = len(m.TargetNodeId)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.Moving {
+		n += 2
+	}
+	return n
+}
+
+func (m *CoordinatorBootstrapRequest) Size() (n int) {
+	if m == nil {
+		return 0
 	}
 	var l int
 	_ = l
@@ -4226,6 +5186,9 @@ func (m *AddMaintainerRequest) Size() (n int) {
 	if m.CheckpointTs != 0 {
 		n += 1 + sovHeartbeat(uint64(m.CheckpointTs))
 	}
+	if m.ConfigVersion != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ConfigVersion))
+	}
 	return n
 }
 
@@ -4248,6 +5211,62 @@ func (m *RemoveMaintainerRequest) Size() (n int) {
 	return n
 }
 
+func (m *DrainNodeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.NodeId)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.Undrain {
+		n += 2
+	}
+	return n
+}
+
+func (m *MoveTableRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.TableId != 0 {
+		n += 1 + sovHeartbeat(uint64(m.TableId))
+	}
+	l = len(m.TargetNodeId)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	return n
+}
+
+func (m *UpdateMaintainerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	l = len(m.Config)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.ConfigVersion != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ConfigVersion))
+	}
+	return n
+}
+
 func (m *MaintainerBootstrapRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4360,6 +5379,46 @@ func (m *MaintainerCloseResponse) Size() (n int) {
 	return n
 }
 
+func (m *UpdateDispatcherManagerRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	l = len(m.Config)
+	if l > 0 {
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovHeartbeat(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *UpdateDispatcherManagerResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChangefeedID != nil {
+		l = m.ChangefeedID.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovHeartbeat(uint64(m.Version))
+	}
+	if m.Err != nil {
+		l = m.Err.Size()
+		n += 1 + l + sovHeartbeat(uint64(l))
+	}
+	return n
+}
+
 func (m *InfluencedTables) Size() (n int) {
 	if m == nil {
 		return 0
@@ -4492,6 +5551,9 @@ func (m *TableSpanStatus) Size() (n int) {
 	if m.EventSizePerSecond != 0 {
 		n += 5
 	}
+	if m.ResolvedTs != 0 {
+		n += 1 + sovHeartbeat(uint64(m.ResolvedTs))
+	}
 	return n
 }
 
@@ -4999,6 +6061,25 @@ func (m *Watermark) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RedoResolvedTs", wireType)
+			}
+			m.RedoResolvedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RedoResolvedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -5737,6 +6818,25 @@ func (m *CheckpointTsMessage) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RedoResolvedTs", wireType)
+			}
+			m.RedoResolvedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RedoResolvedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6331,61 +7431,45 @@ func (m *MaintainerStatus) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeartbeat(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanCounts", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthHeartbeat
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CoordinatorBootstrapRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowHeartbeat
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.SpanCounts = append(m.SpanCounts, &NodeSpanCount{})
+			if err := m.SpanCounts[len(m.SpanCounts)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CoordinatorBootstrapRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CoordinatorBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PinnedTables", wireType)
 			}
-			m.Version = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -6395,33 +7479,764 @@ func (m *CoordinatorBootstrapRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Version |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipHeartbeat(dAtA[iNdEx:])
-			if err != nil {
-				return err
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
+			m.PinnedTables = append(m.PinnedTables, &PinnedTable{})
+			if err := m.PinnedTables[len(m.PinnedTables)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockingDdlTs", wireType)
+			}
+			m.BlockingDdlTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlockingDdlTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigVersion", wireType)
+			}
+			m.ConfigVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfigVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RedoResolvedTs", wireType)
+			}
+			m.RedoResolvedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RedoResolvedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NodeSpanCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NodeSpanCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NodeSpanCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PinnedTable) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PinnedTable: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PinnedTable: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableId", wireType)
+			}
+			m.TableId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableId |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetNodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetNodeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Moving", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Moving = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CoordinatorBootstrapRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CoordinatorBootstrapRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CoordinatorBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CoordinatorBootstrapResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CoordinatorBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Statuses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Statuses = append(m.Statuses, &MaintainerStatus{})
+			if err := m.Statuses[len(m.Statuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AddMaintainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AddMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ChangefeedID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Config = append(m.Config[:0], dAtA[iNdEx:postIndex]...)
+			if m.Config == nil {
+				m.Config = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			}
+			m.CheckpointTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigVersion", wireType)
+			}
+			m.ConfigVersion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ConfigVersion |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RemoveMaintainerRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RemoveMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &ChangefeedID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cascade", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Cascade = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Removed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
+func (m *DrainNodeRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6444,15 +8259,117 @@ func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CoordinatorBootstrapResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DrainNodeRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CoordinatorBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DrainNodeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Statuses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NodeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Undrain", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Undrain = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MoveTableRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MoveTableRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MoveTableRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6479,11 +8396,64 @@ func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Statuses = append(m.Statuses, &MaintainerStatus{})
-			if err := m.Statuses[len(m.Statuses)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
+			}
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableId", wireType)
+			}
+			m.TableId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TableId |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetNodeId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TargetNodeId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6505,7 +8475,7 @@ func (m *CoordinatorBootstrapResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateMaintainerRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6528,15 +8498,15 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AddMaintainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateMaintainerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AddMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6563,10 +8533,10 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ChangefeedID{}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -6606,9 +8576,9 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConfigVersion", wireType)
 			}
-			m.CheckpointTs = 0
+			m.ConfigVersion = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -6618,7 +8588,7 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.CheckpointTs |= uint64(b&0x7F) << shift
+				m.ConfigVersion |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -6644,7 +8614,7 @@ func (m *AddMaintainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
+func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6667,15 +8637,15 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RemoveMaintainerRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: MaintainerBootstrapRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RemoveMaintainerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MaintainerBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6702,18 +8672,18 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Id == nil {
-				m.Id = &ChangefeedID{}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
 			}
-			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cascade", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
 			}
-			var v int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -6723,17 +8693,31 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Cascade = bool(v != 0)
+			if byteLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Config = append(m.Config[:0], dAtA[iNdEx:postIndex]...)
+			if m.Config == nil {
+				m.Config = []byte{}
+			}
+			iNdEx = postIndex
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
 			}
-			var v int
+			m.StartTs = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -6743,12 +8727,47 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.StartTs |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Removed = bool(v != 0)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TableTriggerEventDispatcherId", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TableTriggerEventDispatcherId == nil {
+				m.TableTriggerEventDispatcherId = &DispatcherID{}
+			}
+			if err := m.TableTriggerEventDispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6770,7 +8789,7 @@ func (m *RemoveMaintainerRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
+func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6793,10 +8812,10 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerBootstrapRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: MaintainerBootstrapResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerBootstrapRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MaintainerBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6837,9 +8856,9 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -6849,48 +8868,29 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthHeartbeat
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Config = append(m.Config[:0], dAtA[iNdEx:postIndex]...)
-			if m.Config == nil {
-				m.Config = []byte{}
+			m.Spans = append(m.Spans, &BootstrapTableSpan{})
+			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
-			}
-			m.StartTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.StartTs |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TableTriggerEventDispatcherId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6917,13 +8917,32 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.TableTriggerEventDispatcherId == nil {
-				m.TableTriggerEventDispatcherId = &DispatcherID{}
+			if m.Err == nil {
+				m.Err = &RunningError{}
 			}
-			if err := m.TableTriggerEventDispatcherId.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Err.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
+			}
+			m.CheckpointTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CheckpointTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -6945,7 +8964,7 @@ func (m *MaintainerBootstrapRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
+func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6968,15 +8987,15 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerBootstrapResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: BootstrapTableSpan: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerBootstrapResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BootstrapTableSpan: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7003,18 +9022,18 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ChangefeedID == nil {
-				m.ChangefeedID = &ChangefeedID{}
+			if m.ID == nil {
+				m.ID = &DispatcherID{}
 			}
-			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Spans", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
 			}
-			var msglen int
+			m.SchemaID = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7024,29 +9043,14 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.SchemaID |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Spans = append(m.Spans, &BootstrapTableSpan{})
-			if err := m.Spans[len(m.Spans)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7073,14 +9077,33 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Err == nil {
-				m.Err = &RunningError{}
+			if m.Span == nil {
+				m.Span = &TableSpan{}
 			}
-			if err := m.Err.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ComponentStatus", wireType)
+			}
+			m.ComponentStatus = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ComponentStatus |= ComponentState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
 			}
@@ -7099,6 +9122,42 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockState", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.BlockState == nil {
+				m.BlockState = &State{}
+			}
+			if err := m.BlockState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7120,7 +9179,7 @@ func (m *MaintainerBootstrapResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
+func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7143,15 +9202,15 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: BootstrapTableSpan: wiretype end group for non-group")
+			return fmt.Errorf("proto: MaintainerCloseRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: BootstrapTableSpan: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MaintainerCloseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7178,18 +9237,18 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ID == nil {
-				m.ID = &DispatcherID{}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
 			}
-			if err := m.ID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SchemaID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
 			}
-			m.SchemaID = 0
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7199,14 +9258,65 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.SchemaID |= int64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			m.Removed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipHeartbeat(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowHeartbeat
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MaintainerCloseResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MaintainerCloseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangefeedID", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -7233,56 +9343,18 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Span == nil {
-				m.Span = &TableSpan{}
+			if m.ChangefeedID == nil {
+				m.ChangefeedID = &ChangefeedID{}
 			}
-			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.ChangefeedID.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ComponentStatus", wireType)
-			}
-			m.ComponentStatus = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.ComponentStatus |= ComponentState(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 5:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointTs", wireType)
-			}
-			m.CheckpointTs = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowHeartbeat
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.CheckpointTs |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockState", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7292,28 +9364,12 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthHeartbeat
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.BlockState == nil {
-				m.BlockState = &State{}
-			}
-			if err := m.BlockState.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			m.Success = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7335,7 +9391,7 @@ func (m *BootstrapTableSpan) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
+func (m *UpdateDispatcherManagerRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7358,10 +9414,10 @@ func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerCloseRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateDispatcherManagerRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerCloseRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateDispatcherManagerRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7401,10 +9457,44 @@ func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 			}
 			iNdEx = postIndex
 		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Config = append(m.Config[:0], dAtA[iNdEx:postIndex]...)
+			if m.Config == nil {
+				m.Config = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Removed", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var v int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7414,12 +9504,11 @@ func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Version |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Removed = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -7441,7 +9530,7 @@ func (m *MaintainerCloseRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
+func (m *UpdateDispatcherManagerResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -7464,10 +9553,10 @@ func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: MaintainerCloseResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: UpdateDispatcherManagerResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: MaintainerCloseResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: UpdateDispatcherManagerResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -7508,9 +9597,9 @@ func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var v int
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowHeartbeat
@@ -7520,12 +9609,47 @@ func (m *MaintainerCloseResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.Version |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Success = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Err", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthHeartbeat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Err == nil {
+				m.Err = &RunningError{}
+			}
+			if err := m.Err.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -8410,6 +10534,25 @@ func (m *TableSpanStatus) Unmarshal(dAtA []byte) error {
 			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
 			iNdEx += 4
 			m.EventSizePerSecond = float32(math.Float32frombits(v))
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResolvedTs", wireType)
+			}
+			m.ResolvedTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHeartbeat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResolvedTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHeartbeat(dAtA[iNdEx:])
@@ -15,7 +15,7 @@ package heartbeatpb
 
 import "math"
 
-// UpdateMin updates the watermark with the minimum values of checkpointTs and resolvedTs from another watermark.
+// UpdateMin updates the watermark with the minimum values of checkpointTs, resolvedTs and redoResolvedTs from another watermark.
 func (w *Watermark) UpdateMin(other Watermark) {
 	if w.CheckpointTs > other.CheckpointTs {
 		w.CheckpointTs = other.CheckpointTs
@@ -23,11 +23,15 @@ func (w *Watermark) UpdateMin(other Watermark) {
 	if w.ResolvedTs > other.ResolvedTs {
 		w.ResolvedTs = other.ResolvedTs
 	}
+	if w.RedoResolvedTs > other.RedoResolvedTs {
+		w.RedoResolvedTs = other.RedoResolvedTs
+	}
 }
 
 func NewMaxWatermark() *Watermark {
 	return &Watermark{
-		CheckpointTs: math.MaxUint64,
-		ResolvedTs:   math.MaxUint64,
+		CheckpointTs:   math.MaxUint64,
+		ResolvedTs:     math.MaxUint64,
+		RedoResolvedTs: math.MaxUint64,
 	}
 }
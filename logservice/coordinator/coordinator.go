@@ -14,6 +14,7 @@
 package logcoordinator
 
 import (
+	"bytes"
 	"context"
 	"sort"
 	"sync"
@@ -26,7 +27,6 @@ import (
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
-	"github.com/pingcap/tiflow/pkg/spanz"
 	"go.uber.org/zap"
 
 	"github.com/pingcap/ticdc/pkg/common"
@@ -113,6 +113,8 @@ func (c *logCoordinator) handleMessage(_ context.Context, targetMessage *messagi
 		switch msg.(type) {
 		case *logservicepb.EventStoreState:
 			c.updateEventStoreState(targetMessage.From, msg.(*logservicepb.EventStoreState))
+		case *logservicepb.ReusableEventServiceRequest:
+			c.handleReusableEventServiceRequest(targetMessage.From, msg.(*logservicepb.ReusableEventServiceRequest))
 		default:
 			log.Panic("invalid message type", zap.Any("msg", msg))
 		}
@@ -126,6 +128,10 @@ func (c *logCoordinator) handleNodeChange(allNodes map[node.ID]*node.Info) {
 	for id := range c.nodes.m {
 		if _, ok := allNodes[id]; !ok {
 			delete(c.nodes.m, id)
+			// the event store of the removed node cannot serve any dispatcher
+			c.eventStoreStates.Lock()
+			delete(c.eventStoreStates.m, id)
+			c.eventStoreStates.Unlock()
 			log.Info("log coordinaotr detect node removed", zap.String("nodeId", id.String()))
 		}
 	}
@@ -165,17 +171,47 @@ func (c *logCoordinator) updateEventStoreState(nodeId node.ID, state *logservice
 		zap.Int("subscriptionCount", count))
 }
 
+// handleReusableEventServiceRequest replies the nodes whose event store can serve the dispatcher.
+// If the event store of the request node already holds the data, no candidate is replied,
+// so the dispatcher is registered to the local event service.
+func (c *logCoordinator) handleReusableEventServiceRequest(from node.ID, req *logservicepb.ReusableEventServiceRequest) {
+	var nodes []string
+	if !c.canServe(from, req.GetSpan(), req.GetStartTs()) {
+		for _, nodeID := range c.getCandidateNodes(from, req.GetSpan(), req.GetStartTs()) {
+			nodes = append(nodes, nodeID.String())
+		}
+	}
+	resp := &logservicepb.ReusableEventServiceResponse{
+		Id:    req.GetId(),
+		Nodes: nodes,
+	}
+	err := c.messageCenter.SendCommand(messaging.NewSingleTargetMessage(from, messaging.EventCollectorTopic, resp))
+	if err != nil {
+		log.Warn("send reusable event service response failed",
+			zap.String("nodeId", from.String()), zap.Error(err))
+	}
+}
+
+// canServe returns whether the event store of the node holds the data for `span` from `startTs`.
+func (c *logCoordinator) canServe(nodeID node.ID, span *heartbeatpb.TableSpan, startTs uint64) bool {
+	c.eventStoreStates.RLock()
+	defer c.eventStoreStates.RUnlock()
+	state, ok := c.eventStoreStates.m[nodeID]
+	if !ok {
+		return false
+	}
+	_, ok = state.subscriptionStates[span.GetTableID()].getCoveredResolvedTs(span, startTs)
+	return ok
+}
+
 // getCandidateNode return all nodes(exclude the request node) which may contain data for `span` from `startTs`,
 // and the return slice should be sorted by resolvedTs(largest first).
+// The span can be covered by multiple subscriptions of a node, and the resolvedTs of the node is
+// the minimum resolvedTs of the subscriptions used.
 func (c *logCoordinator) getCandidateNodes(requestNodeID node.ID, span *heartbeatpb.TableSpan, startTs uint64) []node.ID {
 	c.eventStoreStates.RLock()
 	defer c.eventStoreStates.RUnlock()
 
-	// TODO: support incomplete span
-	if !isCompleteSpan(span) {
-		return nil
-	}
-
 	type candidateNode struct {
 		nodeID     node.ID
 		resolvedTs uint64
@@ -189,23 +225,10 @@ func (c *logCoordinator) getCandidateNodes(requestNodeID node.ID, span *heartbea
 		if !ok {
 			continue
 		}
-		// Find the maximum resolvedTs for the current nodeID
-		var maxResolvedTs uint64
-		found := false
-		for _, subscriptionState := range subscriptionStates {
-			if subscriptionState.checkpointTs <= startTs {
-				if !found || subscriptionState.resolvedTs > maxResolvedTs {
-					maxResolvedTs = subscriptionState.resolvedTs
-					found = true
-				}
-			}
-		}
-
-		// If a valid subscription with checkpointTs <= startTs was found, add to candidates
-		if found {
+		if resolvedTs, ok := subscriptionStates.getCoveredResolvedTs(span, startTs); ok {
 			candidates = append(candidates, candidateNode{
 				nodeID:     nodeID,
-				resolvedTs: maxResolvedTs,
+				resolvedTs: resolvedTs,
 			})
 		}
 	}
@@ -222,10 +245,44 @@ func (c *logCoordinator) getCandidateNodes(requestNodeID node.ID, span *heartbea
 	return candidateNodes
 }
 
-func isCompleteSpan(tableSpan *heartbeatpb.TableSpan) bool {
-	startKey, endKey := spanz.GetTableRange(tableSpan.TableID)
-	if spanz.StartCompare(startKey, tableSpan.StartKey) == 0 && spanz.EndCompare(endKey, tableSpan.EndKey) == 0 {
-		return true
+// getCoveredResolvedTs checks whether the subscriptions which contain the data from `startTs`
+// can cover `span` together, it picks the subscription reaching the furthest at each step,
+// and returns the minimum resolvedTs of the picked subscriptions.
+func (states subscriptionStates) getCoveredResolvedTs(span *heartbeatpb.TableSpan, startTs uint64) (uint64, bool) {
+	var candidates []*subscriptionState
+	for _, state := range states {
+		if state.checkpointTs <= startTs && startTs <= state.resolvedTs {
+			candidates = append(candidates, state)
+		}
+	}
+
+	var resolvedTs uint64
+	found := false
+	start := span.GetStartKey()
+	for bytes.Compare(start, span.GetEndKey()) < 0 {
+		var picked *subscriptionState
+		for _, candidate := range candidates {
+			if bytes.Compare(candidate.span.GetStartKey(), start) > 0 ||
+				bytes.Compare(candidate.span.GetEndKey(), start) <= 0 {
+				continue
+			}
+			if picked == nil {
+				picked = candidate
+				continue
+			}
+			cmp := bytes.Compare(candidate.span.GetEndKey(), picked.span.GetEndKey())
+			if cmp > 0 || (cmp == 0 && candidate.resolvedTs > picked.resolvedTs) {
+				picked = candidate
+			}
+		}
+		if picked == nil {
+			return 0, false
+		}
+		if !found || picked.resolvedTs < resolvedTs {
+			resolvedTs = picked.resolvedTs
+			found = true
+		}
+		start = picked.span.GetEndKey()
 	}
-	return false
+	return resolvedTs, found
 }
@@ -183,3 +183,60 @@ func TestGetCandidateNodes(t *testing.T) {
 		assert.Equal(t, []node.ID{nodeID2, nodeID1}, nodes)
 	}
 }
+
+func TestGetCandidateNodesForPartialSpan(t *testing.T) {
+	coordinator := &logCoordinator{}
+
+	nodeID1 := node.ID("node-1")
+	nodeID2 := node.ID("node-2")
+	nodeID3 := node.ID("node-3")
+
+	tableID := int64(100)
+	startKey, endKey := spanz.GetTableRange(tableID)
+	midKey := append(append([]byte{}, startKey...), 'm')
+	tableSpan := &heartbeatpb.TableSpan{TableID: tableID, StartKey: startKey, EndKey: endKey}
+	leftSpan := &heartbeatpb.TableSpan{TableID: tableID, StartKey: startKey, EndKey: midKey}
+	rightSpan := &heartbeatpb.TableSpan{TableID: tableID, StartKey: midKey, EndKey: endKey}
+
+	coordinator.eventStoreStates.m = map[node.ID]*eventStoreState{
+		// node1 holds the whole table
+		nodeID1: {
+			subscriptionStates: map[int64]subscriptionStates{
+				tableID: {{subID: 1, span: tableSpan, checkpointTs: 100, resolvedTs: 200}},
+			},
+		},
+		// node2 holds the table by two sub spans
+		nodeID2: {
+			subscriptionStates: map[int64]subscriptionStates{
+				tableID: {
+					{subID: 1, span: leftSpan, checkpointTs: 100, resolvedTs: 300},
+					{subID: 2, span: rightSpan, checkpointTs: 90, resolvedTs: 250},
+				},
+			},
+		},
+		// node3 only holds the left part
+		nodeID3: {
+			subscriptionStates: map[int64]subscriptionStates{
+				tableID: {{subID: 1, span: leftSpan, checkpointTs: 100, resolvedTs: 400}},
+			},
+		},
+	}
+
+	// the whole table is covered by the two sub spans of node2
+	nodes := coordinator.getCandidateNodes("", tableSpan, 100)
+	assert.Equal(t, []node.ID{nodeID2, nodeID1}, nodes)
+
+	// the right part is not held by node3
+	nodes = coordinator.getCandidateNodes("", rightSpan, 100)
+	assert.Equal(t, []node.ID{nodeID2, nodeID1}, nodes)
+
+	nodes = coordinator.getCandidateNodes("", leftSpan, 100)
+	assert.Equal(t, []node.ID{nodeID3, nodeID2, nodeID1}, nodes)
+
+	// the data before the checkpoint of node2 is deleted
+	nodes = coordinator.getCandidateNodes(nodeID1, tableSpan, 95)
+	assert.Empty(t, nodes)
+
+	assert.True(t, coordinator.canServe(nodeID3, leftSpan, 100))
+	assert.False(t, coordinator.canServe(nodeID3, tableSpan, 100))
+}
@@ -1,9 +1,10 @@
 package eventstore
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"os"
+	"math"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -102,12 +103,30 @@ type dispatcherStat struct {
 	// the max ts of events which is not needed by this dispatcher
 	checkpointTs uint64
 
+	// the subscriptions serving this dispatcher,
+	// their ranges don't overlap and fully cover the table span of the dispatcher
+	subs []subscriptionRange
+
+	resolvedTs struct {
+		sync.Mutex
+		// the last resolved ts notified, it is the min resolved ts of subs
+		ts uint64
+	}
+}
+
+// subscriptionRange is a range of the dispatcher span served by a subscription
+type subscriptionRange struct {
 	subID logpuller.SubscriptionID
+	// the range of the dispatcher span read from the subscription,
+	// nil if it is the same as the span of the subscription
+	span *heartbeatpb.TableSpan
 }
 
 type subscriptionStat struct {
 	// dispatchers depend on this subscription
 	ids map[common.DispatcherID]bool
+	// the span subscribed from the puller
+	tableSpan *heartbeatpb.TableSpan
 	// events of this subsription will be send to the channel identified by chIndex
 	chIndex int
 	// data <= checkpointTs can be deleted
@@ -121,6 +140,19 @@ type subscriptionStat struct {
 	uniqueKeyID uint64
 }
 
+// retainedSubscription is a subscription without any dispatcher,
+// it is recovered from the disk after restart or left by the unregistered dispatchers.
+// Its data can be reused by a dispatcher registered with the same span before it expires.
+type retainedSubscription struct {
+	chIndex      int
+	uniqueKeyID  uint64
+	tableSpan    *heartbeatpb.TableSpan
+	checkpointTs uint64
+	resolvedTs   uint64
+	// the data of the subscription is deleted after retainedSubscriptionTTL since this time
+	retainedTime time.Time
+}
+
 type eventStore struct {
 	pdClock pdutil.Clock
 
@@ -152,6 +184,8 @@ type eventStore struct {
 		// table id -> dispatcher ids
 		// use table id as the key is to share data between spans not completely the same in the future.
 		l map[int64]map[common.DispatcherID]bool
+		// uniqueKeyID -> retained subscriptions
+		r map[uint64]*retainedSubscription
 	}
 
 	encoder *zstd.Encoder
@@ -161,6 +195,8 @@ type eventStore struct {
 const dataDir = "event_store"
 const dbCount = 32
 
+const retainedSubscriptionTTL = 10 * time.Minute
+
 func New(
 	ctx context.Context,
 	root string,
@@ -168,6 +204,7 @@ func New(
 	regionCache *tikv.RegionCache,
 	pdClock pdutil.Clock,
 	kvStorage kv.Storage,
+	credential *security.Credential,
 ) EventStore {
 	clientConfig := &logpuller.SubscriptionClientConfig{
 		RegionRequestWorkerPerStore:   16,
@@ -181,16 +218,11 @@ func New(
 		regionCache,
 		pdClock,
 		txnutil.NewLockerResolver(kvStorage.(tikv.Storage)),
-		&security.Credential{},
+		credential,
 	)
 
 	dbPath := fmt.Sprintf("%s/%s", root, dataDir)
 
-	// FIXME: avoid remove
-	err := os.RemoveAll(dbPath)
-	if err != nil {
-		log.Panic("fail to remove path")
-	}
 	// Create the zstd encoder
 	encoder, err := zstd.NewWriter(nil)
 	if err != nil {
@@ -214,7 +246,6 @@ func New(
 	// TODO: update pebble options
 	for i := 0; i < dbCount; i++ {
 		opts := &pebble.Options{
-			DisableWAL:   true,
 			MemTableSize: 8 << 20,
 		}
 		// opts.Levels = make([]pebble.LevelOptions, 7)
@@ -238,6 +269,22 @@ func New(
 	store.dispatcherStates.m = make(map[common.DispatcherID]*dispatcherStat)
 	store.dispatcherStates.n = make(map[logpuller.SubscriptionID]*subscriptionStat)
 	store.dispatcherStates.l = make(map[int64]map[common.DispatcherID]bool)
+	store.dispatcherStates.r = make(map[uint64]*retainedSubscription)
+
+	// recover the subscriptions persisted in the last run
+	now := time.Now()
+	for i, db := range store.dbs {
+		for _, sub := range loadSubscriptionMetas(db, i) {
+			sub.retainedTime = now
+			store.dispatcherStates.r[sub.uniqueKeyID] = sub
+			if sub.uniqueKeyID > uniqueIDGen {
+				uniqueIDGen = sub.uniqueKeyID
+			}
+		}
+	}
+	log.Info("event store recovered subscriptions",
+		zap.String("path", dbPath),
+		zap.Int("count", len(store.dispatcherStates.r)))
 
 	// start background goroutines to handle events from puller
 	for i := range store.dbs {
@@ -302,18 +349,19 @@ func (e *eventStore) uploadStatePeriodically(ctx context.Context) error {
 				subIDs := make(map[logpuller.SubscriptionID]bool)
 				for dispatcherID := range dispatcherIDs {
 					dispatcherStat := e.dispatcherStates.m[dispatcherID]
-					subID := dispatcherStat.subID
-					subStat := e.dispatcherStates.n[subID]
-					if _, ok := subIDs[subID]; ok {
-						continue
+					for _, sub := range dispatcherStat.subs {
+						if _, ok := subIDs[sub.subID]; ok {
+							continue
+						}
+						subStat := e.dispatcherStates.n[sub.subID]
+						subStates = append(subStates, &logservicepb.SubscriptionState{
+							SubID:        uint64(sub.subID),
+							Span:         subStat.tableSpan,
+							CheckpointTs: subStat.checkpointTs,
+							ResolvedTs:   atomic.LoadUint64(&subStat.resolvedTs),
+						})
+						subIDs[sub.subID] = true
 					}
-					subStates = append(subStates, &logservicepb.SubscriptionState{
-						SubID:        uint64(subID),
-						Span:         dispatcherStat.tableSpan,
-						CheckpointTs: subStat.checkpointTs,
-						ResolvedTs:   subStat.resolvedTs,
-					})
-					subIDs[subID] = true
 				}
 				sort.Slice(subStates, func(i, j int) bool {
 					return subStates[i].SubID < subStates[j].SubID
@@ -357,6 +405,10 @@ func (e *eventStore) Run(ctx context.Context) error {
 		return e.uploadStatePeriodically(ctx)
 	})
 
+	eg.Go(func() error {
+		return e.cleanRetainedSubscriptionsPeriodically(ctx)
+	})
+
 	return eg.Wait()
 }
 
@@ -420,69 +472,80 @@ func (e *eventStore) RegisterDispatcher(
 	}
 
 	e.dispatcherStates.Lock()
-	if candidateIDs, ok := e.dispatcherStates.l[tableSpan.TableID]; ok {
-		for candidateID := range candidateIDs {
-			candidateDispatcher, ok := e.dispatcherStates.m[candidateID]
-			if !ok {
-				log.Panic("should not happen")
-			}
-			if candidateDispatcher.tableSpan.Equal(tableSpan) {
-				subscriptionStat, ok := e.dispatcherStates.n[candidateDispatcher.subID]
-				if !ok {
-					log.Panic("should not happen")
-				}
-				// check whether startTs is in the range [checkpointTs, resolvedTs]
-				// for `[checkpointTs`: because we want data > startTs, so data <= checkpointTs == startTs deleted is ok.
-				// for `resolvedTs]`: startTs == resolvedTs is a special case that no resolved ts has been recieved, so it is ok.
-				if subscriptionStat.checkpointTs <= startTs && startTs <= subscriptionStat.resolvedTs {
-					stat.subID = candidateDispatcher.subID
-					e.dispatcherStates.m[dispatcherID] = stat
-					// add dispatcher to existing subscription and return
-					subscriptionStat.ids[dispatcherID] = true
-					candidateIDs[dispatcherID] = true
-					e.dispatcherStates.Unlock()
-					log.Info("reuse existing subscription",
-						zap.Any("dispatcherID", dispatcherID),
-						zap.Uint64("subID", uint64(stat.subID)),
-						zap.Uint64("checkpointTs", subscriptionStat.checkpointTs),
-						zap.Uint64("startTs", startTs))
-					return nil
-				}
-			}
+	if subs := e.findCoveringSubscriptionsUnlocked(tableSpan, startTs); len(subs) > 0 {
+		stat.subs = subs
+		e.dispatcherStates.m[dispatcherID] = stat
+		// add dispatcher to existing subscriptions and return
+		for _, sub := range subs {
+			e.dispatcherStates.n[sub.subID].ids[dispatcherID] = true
 		}
+		e.dispatcherStates.l[tableSpan.TableID][dispatcherID] = true
+		e.dispatcherStates.Unlock()
+		for _, sub := range subs {
+			log.Info("reuse existing subscription",
+				zap.Any("dispatcherID", dispatcherID),
+				zap.Uint64("subID", uint64(sub.subID)),
+				zap.Bool("partial", sub.span != nil),
+				zap.Uint64("startTs", startTs))
+		}
+		return nil
 	}
+	// try to reuse the data of a retained subscription
+	retained := e.claimRetainedSubscriptionUnlocked(tableSpan, startTs)
 	e.dispatcherStates.Unlock()
 
-	// cannot share data from existing subscription, create a new subscription
-
-	// TODO: hash span is only needed when we need to reuse data after restart
-	// (if we finally decide not to reuse data after restart, use round robin instead)
-	// But if we need to share data for sub span, we need hash table id instead.
-	chIndex := common.HashTableSpan(tableSpan, len(e.eventChs))
-	uniqueKeyID := genUniqueID()
+	newSubStat := &subscriptionStat{
+		ids:              map[common.DispatcherID]bool{dispatcherID: true},
+		tableSpan:        tableSpan,
+		checkpointTs:     startTs,
+		resolvedTs:       startTs,
+		maxEventCommitTs: startTs,
+	}
+	if retained != nil {
+		log.Info("reuse retained subscription",
+			zap.Any("dispatcherID", dispatcherID),
+			zap.Uint64("uniqueKeyID", retained.uniqueKeyID),
+			zap.Uint64("checkpointTs", retained.checkpointTs),
+			zap.Uint64("resolvedTs", retained.resolvedTs),
+			zap.Uint64("startTs", startTs))
+		newSubStat.chIndex = retained.chIndex
+		newSubStat.uniqueKeyID = retained.uniqueKeyID
+		newSubStat.checkpointTs = retained.checkpointTs
+		newSubStat.resolvedTs = retained.resolvedTs
+		// the max commit ts of the retained data is unknown, use resolved ts to make sure the data is scanned
+		newSubStat.maxEventCommitTs = retained.resolvedTs
+	} else {
+		// cannot share data from existing subscription, create a new subscription
+
+		// TODO: hash span is only needed when we need to reuse data after restart
+		// (if we finally decide not to reuse data after restart, use round robin instead)
+		// But if we need to share data for sub span, we need hash table id instead.
+		newSubStat.chIndex = common.HashTableSpan(tableSpan, len(e.eventChs))
+		newSubStat.uniqueKeyID = genUniqueID()
+		// persist the subscription meta before any event of it is written
+		batch := e.dbs[newSubStat.chIndex].NewBatch()
+		writeSubscriptionMetaToBatch(batch, newSubStat.uniqueKeyID, tableSpan, startTs)
+		if err := batch.Commit(pebble.NoSync); err != nil {
+			log.Panic("failed to write subscription meta", zap.Error(err))
+		}
+	}
 	// Note: don't hold any lock when call Subscribe
 	// TODO: if puller event come before we initialize dispatcherStat,
 	// maxEventCommitTs may not be updated correctly and cause data loss.(lost resolved ts is harmless)
 	// To fix it, we need to alloc subID and initialize dispatcherStat before puller may send events.
 	// That is allocate subID in a separate method.
-	stat.subID = e.puller.Subscribe(*tableSpan, startTs, subscriptionTag{
-		chIndex:     chIndex,
+	subID := e.puller.Subscribe(*tableSpan, newSubStat.resolvedTs, subscriptionTag{
+		chIndex:     newSubStat.chIndex,
 		tableID:     tableSpan.TableID,
-		uniqueKeyID: uniqueKeyID,
+		uniqueKeyID: newSubStat.uniqueKeyID,
 	})
 	metrics.EventStoreSubscriptionGauge.Inc()
+	stat.subs = []subscriptionRange{{subID: subID}}
 
 	e.dispatcherStates.Lock()
 	defer e.dispatcherStates.Unlock()
 	e.dispatcherStates.m[dispatcherID] = stat
-	e.dispatcherStates.n[stat.subID] = &subscriptionStat{
-		ids:              map[common.DispatcherID]bool{dispatcherID: true},
-		chIndex:          chIndex,
-		checkpointTs:     startTs,
-		resolvedTs:       startTs,
-		maxEventCommitTs: startTs,
-		uniqueKeyID:      uniqueKeyID,
-	}
+	e.dispatcherStates.n[subID] = newSubStat
 	dispatchersForSameTable, ok := e.dispatcherStates.l[tableSpan.TableID]
 	if !ok {
 		e.dispatcherStates.l[tableSpan.TableID] = map[common.DispatcherID]bool{dispatcherID: true}
@@ -504,21 +567,31 @@ func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) erro
 	if !ok {
 		return nil
 	}
-	subID := stat.subID
 	tableID := stat.tableSpan.TableID
 	delete(e.dispatcherStates.m, dispatcherID)
 
-	// delete the dispatcher from subscription
-	subscriptionStat, ok := e.dispatcherStates.n[subID]
-	if !ok {
-		log.Panic("should not happen")
-	}
-	delete(subscriptionStat.ids, dispatcherID)
-	if len(subscriptionStat.ids) == 0 {
-		delete(e.dispatcherStates.n, subID)
-		// TODO: do we need unlock before puller.Unsubscribe?
-		e.puller.Unsubscribe(subID)
-		metrics.EventStoreSubscriptionGauge.Dec()
+	// delete the dispatcher from subscriptions
+	for _, sub := range stat.subs {
+		subscriptionStat, ok := e.dispatcherStates.n[sub.subID]
+		if !ok {
+			log.Panic("should not happen")
+		}
+		delete(subscriptionStat.ids, dispatcherID)
+		if len(subscriptionStat.ids) == 0 {
+			delete(e.dispatcherStates.n, sub.subID)
+			// TODO: do we need unlock before puller.Unsubscribe?
+			e.puller.Unsubscribe(sub.subID)
+			metrics.EventStoreSubscriptionGauge.Dec()
+			// keep the data for a while, so it can be reused if the span is registered again soon
+			e.dispatcherStates.r[subscriptionStat.uniqueKeyID] = &retainedSubscription{
+				chIndex:      subscriptionStat.chIndex,
+				uniqueKeyID:  subscriptionStat.uniqueKeyID,
+				tableSpan:    subscriptionStat.tableSpan,
+				checkpointTs: subscriptionStat.checkpointTs,
+				resolvedTs:   atomic.LoadUint64(&subscriptionStat.resolvedTs),
+				retainedTime: time.Now(),
+			}
+		}
 	}
 
 	// delete the dispatcher from table subscriptions
@@ -534,6 +607,107 @@ func (e *eventStore) UnregisterDispatcher(dispatcherID common.DispatcherID) erro
 	return nil
 }
 
+// findCoveringSubscriptionsUnlocked returns the existing subscriptions of the same table which
+// contain the data after startTs and fully cover the table span, returns nil if not found.
+// It must be called with the lock of dispatcherStates held.
+func (e *eventStore) findCoveringSubscriptionsUnlocked(tableSpan *heartbeatpb.TableSpan, startTs uint64) []subscriptionRange {
+	var candidates []*subscriptionStat
+	candidateIDs := make(map[*subscriptionStat]logpuller.SubscriptionID)
+	for dispatcherID := range e.dispatcherStates.l[tableSpan.TableID] {
+		for _, sub := range e.dispatcherStates.m[dispatcherID].subs {
+			subStat := e.dispatcherStates.n[sub.subID]
+			if _, ok := candidateIDs[subStat]; ok {
+				continue
+			}
+			// check whether startTs is in the range [checkpointTs, resolvedTs]
+			// for `[checkpointTs`: because we want data > startTs, so data <= checkpointTs == startTs deleted is ok.
+			// for `resolvedTs]`: startTs == resolvedTs is a special case that no resolved ts has been recieved, so it is ok.
+			if subStat.checkpointTs <= startTs && startTs <= atomic.LoadUint64(&subStat.resolvedTs) {
+				candidates = append(candidates, subStat)
+				candidateIDs[subStat] = sub.subID
+			}
+		}
+	}
+
+	// cover the table span from the start key, each step picks the subscription reaching the furthest
+	var result []subscriptionRange
+	start := tableSpan.StartKey
+	for bytes.Compare(start, tableSpan.EndKey) < 0 {
+		var picked *subscriptionStat
+		for _, candidate := range candidates {
+			if bytes.Compare(candidate.tableSpan.StartKey, start) > 0 ||
+				bytes.Compare(candidate.tableSpan.EndKey, start) <= 0 {
+				continue
+			}
+			if picked == nil || bytes.Compare(candidate.tableSpan.EndKey, picked.tableSpan.EndKey) > 0 {
+				picked = candidate
+			}
+		}
+		if picked == nil {
+			return nil
+		}
+		end := picked.tableSpan.EndKey
+		if bytes.Compare(end, tableSpan.EndKey) > 0 {
+			end = tableSpan.EndKey
+		}
+		sub := subscriptionRange{subID: candidateIDs[picked]}
+		if !bytes.Equal(start, picked.tableSpan.StartKey) || !bytes.Equal(end, picked.tableSpan.EndKey) {
+			sub.span = &heartbeatpb.TableSpan{TableID: tableSpan.TableID, StartKey: start, EndKey: end}
+		}
+		result = append(result, sub)
+		start = end
+	}
+	return result
+}
+
+// claimRetainedSubscriptionUnlocked finds a retained subscription which has the same span
+// and contains the data after startTs, and removes it from the retained subscriptions.
+// It must be called with the lock of dispatcherStates held.
+func (e *eventStore) claimRetainedSubscriptionUnlocked(tableSpan *heartbeatpb.TableSpan, startTs uint64) *retainedSubscription {
+	for uniqueKeyID, sub := range e.dispatcherStates.r {
+		if sub.tableSpan.Equal(tableSpan) && sub.checkpointTs <= startTs && startTs <= sub.resolvedTs {
+			delete(e.dispatcherStates.r, uniqueKeyID)
+			return sub
+		}
+	}
+	return nil
+}
+
+// cleanRetainedSubscriptionsPeriodically deletes the data of the expired retained subscriptions
+func (e *eventStore) cleanRetainedSubscriptionsPeriodically(ctx context.Context) error {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			var expired []*retainedSubscription
+			e.dispatcherStates.Lock()
+			for uniqueKeyID, sub := range e.dispatcherStates.r {
+				if time.Since(sub.retainedTime) >= retainedSubscriptionTTL {
+					expired = append(expired, sub)
+					delete(e.dispatcherStates.r, uniqueKeyID)
+				}
+			}
+			e.dispatcherStates.Unlock()
+
+			for _, sub := range expired {
+				batch := e.dbs[sub.chIndex].NewBatch()
+				deleteSubscriptionToBatch(batch, sub.uniqueKeyID)
+				if err := batch.Commit(pebble.NoSync); err != nil {
+					log.Panic("failed to delete retained subscription", zap.Error(err))
+				}
+				log.Info("retained subscription expired",
+					zap.Uint64("uniqueKeyID", sub.uniqueKeyID),
+					zap.String("span", sub.tableSpan.String()),
+					zap.Uint64("checkpointTs", sub.checkpointTs),
+					zap.Uint64("resolvedTs", sub.resolvedTs))
+			}
+		}
+	}
+}
+
 func (e *eventStore) UpdateDispatcherSendTs(
 	dispatcherID common.DispatcherID,
 	sendTs uint64,
@@ -542,37 +716,39 @@ func (e *eventStore) UpdateDispatcherSendTs(
 	defer e.dispatcherStates.RUnlock()
 	if stat, ok := e.dispatcherStates.m[dispatcherID]; ok {
 		stat.checkpointTs = sendTs
-		subscriptionStat := e.dispatcherStates.n[stat.subID]
-		// calculate the new checkpoint ts of the subscription
-		newCheckpointTs := uint64(0)
-		for dispatcherID := range subscriptionStat.ids {
-			dispatcherStat := e.dispatcherStates.m[dispatcherID]
-			if newCheckpointTs == 0 || dispatcherStat.checkpointTs < newCheckpointTs {
-				newCheckpointTs = dispatcherStat.checkpointTs
+		for _, sub := range stat.subs {
+			subscriptionStat := e.dispatcherStates.n[sub.subID]
+			// calculate the new checkpoint ts of the subscription
+			newCheckpointTs := uint64(0)
+			for dispatcherID := range subscriptionStat.ids {
+				dispatcherStat := e.dispatcherStates.m[dispatcherID]
+				if newCheckpointTs == 0 || dispatcherStat.checkpointTs < newCheckpointTs {
+					newCheckpointTs = dispatcherStat.checkpointTs
+				}
+			}
+			if newCheckpointTs == 0 {
+				continue
+			}
+			if newCheckpointTs < subscriptionStat.checkpointTs {
+				log.Panic("should not happen",
+					zap.Uint64("newCheckpointTs", newCheckpointTs),
+					zap.Uint64("oldCheckpointTs", subscriptionStat.checkpointTs))
+			}
+			if subscriptionStat.checkpointTs < newCheckpointTs {
+				e.gcManager.addGCItem(
+					subscriptionStat.chIndex,
+					subscriptionStat.uniqueKeyID,
+					stat.tableSpan.TableID,
+					subscriptionStat.checkpointTs,
+					newCheckpointTs,
+				)
+				log.Debug("update checkpoint ts",
+					zap.Any("dispatcherID", dispatcherID),
+					zap.Uint64("subID", uint64(sub.subID)),
+					zap.Uint64("newCheckpointTs", newCheckpointTs),
+					zap.Uint64("oldCheckpointTs", subscriptionStat.checkpointTs))
+				subscriptionStat.checkpointTs = newCheckpointTs
 			}
-		}
-		if newCheckpointTs == 0 {
-			return nil
-		}
-		if newCheckpointTs < subscriptionStat.checkpointTs {
-			log.Panic("should not happen",
-				zap.Uint64("newCheckpointTs", newCheckpointTs),
-				zap.Uint64("oldCheckpointTs", subscriptionStat.checkpointTs))
-		}
-		if subscriptionStat.checkpointTs < newCheckpointTs {
-			e.gcManager.addGCItem(
-				subscriptionStat.chIndex,
-				subscriptionStat.uniqueKeyID,
-				stat.tableSpan.TableID,
-				subscriptionStat.checkpointTs,
-				newCheckpointTs,
-			)
-			log.Debug("update checkpoint ts",
-				zap.Any("dispatcherID", dispatcherID),
-				zap.Uint64("subID", uint64(stat.subID)),
-				zap.Uint64("newCheckpointTs", newCheckpointTs),
-				zap.Uint64("oldCheckpointTs", subscriptionStat.checkpointTs))
-			subscriptionStat.checkpointTs = newCheckpointTs
 		}
 	}
 	return nil
@@ -585,11 +761,14 @@ func (e *eventStore) GetDispatcherDMLEventState(dispatcherID common.DispatcherID
 	if !ok {
 		log.Panic("fail to find dispatcher", zap.Any("dispatcherID", dispatcherID))
 	}
-	subscriptionStat := e.dispatcherStates.n[stat.subID]
-	return DMLEventState{
-		// ResolvedTs:       subscriptionStat.resolvedTs,
-		MaxEventCommitTs: subscriptionStat.maxEventCommitTs,
+	state := DMLEventState{}
+	for _, sub := range stat.subs {
+		subscriptionStat := e.dispatcherStates.n[sub.subID]
+		if subscriptionStat.maxEventCommitTs > state.MaxEventCommitTs {
+			state.MaxEventCommitTs = subscriptionStat.maxEventCommitTs
+		}
 	}
+	return state
 }
 
 func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange common.DataRange) (EventIterator, error) {
@@ -598,34 +777,54 @@ func (e *eventStore) GetIterator(dispatcherID common.DispatcherID, dataRange com
 	if !ok {
 		log.Panic("fail to find dispatcher", zap.Any("dispatcherID", dispatcherID))
 	}
-	subscriptionStat := e.dispatcherStates.n[stat.subID]
-	if dataRange.StartTs < subscriptionStat.checkpointTs {
-		log.Panic("should not happen",
-			zap.Any("dispatcherID", dispatcherID),
-			zap.Uint64("checkpointTs", subscriptionStat.checkpointTs),
-			zap.Uint64("startTs", dataRange.StartTs))
+	type subscriptionToScan struct {
+		db          *pebble.DB
+		uniqueKeyID uint64
+		span        *heartbeatpb.TableSpan
+	}
+	toScan := make([]subscriptionToScan, 0, len(stat.subs))
+	for _, sub := range stat.subs {
+		subscriptionStat := e.dispatcherStates.n[sub.subID]
+		if dataRange.StartTs < subscriptionStat.checkpointTs {
+			log.Panic("should not happen",
+				zap.Any("dispatcherID", dispatcherID),
+				zap.Uint64("subID", uint64(sub.subID)),
+				zap.Uint64("checkpointTs", subscriptionStat.checkpointTs),
+				zap.Uint64("startTs", dataRange.StartTs))
+		}
+		toScan = append(toScan, subscriptionToScan{
+			db:          e.dbs[subscriptionStat.chIndex],
+			uniqueKeyID: subscriptionStat.uniqueKeyID,
+			span:        sub.span,
+		})
 	}
-	db := e.dbs[subscriptionStat.chIndex]
 	e.dispatcherStates.RUnlock()
 
-	// convert range before pass it to pebble: (startTs, endTs] is equal to [startTs + 1, endTs + 1)
-	start := EncodeKeyPrefix(subscriptionStat.uniqueKeyID, stat.tableSpan.TableID, dataRange.StartTs+1)
-	end := EncodeKeyPrefix(subscriptionStat.uniqueKeyID, stat.tableSpan.TableID, dataRange.EndTs+1)
-	// TODO: optimize read performance
-	iter, err := db.NewIter(&pebble.IterOptions{
-		LowerBound: start,
-		UpperBound: end,
-	})
-	if err != nil {
-		return nil, err
+	innerIters := make([]*spanIter, 0, len(toScan))
+	for _, sub := range toScan {
+		// convert range before pass it to pebble: (startTs, endTs] is equal to [startTs + 1, endTs + 1)
+		start := EncodeKeyPrefix(sub.uniqueKeyID, stat.tableSpan.TableID, dataRange.StartTs+1)
+		end := EncodeKeyPrefix(sub.uniqueKeyID, stat.tableSpan.TableID, dataRange.EndTs+1)
+		// TODO: optimize read performance
+		iter, err := sub.db.NewIter(&pebble.IterOptions{
+			LowerBound: start,
+			UpperBound: end,
+		})
+		if err != nil {
+			for _, innerIter := range innerIters {
+				_ = innerIter.Close()
+			}
+			return nil, err
+		}
+		iter.First()
+		innerIters = append(innerIters, newSpanIter(iter, sub.span))
 	}
-	iter.First()
 
 	metrics.EventStoreScanRequestsCount.Inc()
 
 	return &eventStoreIter{
 		tableID:      stat.tableSpan.TableID,
-		innerIter:    iter,
+		innerIters:   innerIters,
 		prevStartTs:  0,
 		prevCommitTs: 0,
 		iterMounter:  event.NewMounter(time.Local), // FIXME
@@ -725,8 +924,7 @@ func (e *eventStore) batchAndWriteEvents(ctx context.Context, db *pebble.DB, inp
 				}
 				atomic.StoreUint64(&subscriptionStat.resolvedTs, resolvedTs)
 				for dispatcherID := range subscriptionStat.ids {
-					dispatcherStat := e.dispatcherStates.m[dispatcherID]
-					dispatcherStat.notifier(resolvedTs)
+					e.notifyResolvedTs(e.dispatcherStates.m[dispatcherID])
 				}
 			}
 			e.dispatcherStates.RUnlock()
@@ -749,13 +947,29 @@ func (e *eventStore) batchAndWriteEvents(ctx context.Context, db *pebble.DB, inp
 		var batch *pebble.Batch
 		resolvedTsMap := make(map[logpuller.SubscriptionID]uint64)
 		maxEventCommitTsMap := make(map[logpuller.SubscriptionID]uint64)
+		// subscription id -> unique key id, used to persist the resolved ts
+		uniqueKeyIDMap := make(map[logpuller.SubscriptionID]uint64)
+		// persist the resolved ts with the events in the same batch,
+		// so the data in (checkpointTs, resolvedTs] is complete after restart
+		newBatchEvent := func() *DBBatchEvent {
+			if len(resolvedTsMap) > 0 && batch == nil {
+				batch = db.NewBatch()
+			}
+			for subID, resolvedTs := range resolvedTsMap {
+				key := encodeSubscriptionMetaKey(uniqueKeyIDMap[subID], subscriptionMetaResolvedTs)
+				if err := batch.Set(key, encodeTs(resolvedTs), pebble.NoSync); err != nil {
+					log.Panic("failed to update pebble batch", zap.Error(err))
+				}
+			}
+			return &DBBatchEvent{batch, maxEventCommitTsMap, resolvedTsMap}
+		}
 		startToBatch := time.Now()
 		// Note: don't use select here for performance
 		for item := range inputCh {
 			if item.eventType == eventTypeBatchSignal {
 				if time.Since(startToBatch) >= batchCommitInterval {
 					if batch != nil || len(resolvedTsMap) > 0 {
-						return &DBBatchEvent{batch, maxEventCommitTsMap, resolvedTsMap}
+						return newBatchEvent()
 					}
 				}
 				continue
@@ -763,6 +977,7 @@ func (e *eventStore) batchAndWriteEvents(ctx context.Context, db *pebble.DB, inp
 
 			if item.raw.IsResolved() {
 				resolvedTsMap[item.subID] = item.raw.CRTs
+				uniqueKeyIDMap[item.subID] = item.uniqueID
 				continue
 			} else {
 				if batch == nil {
@@ -773,7 +988,7 @@ func (e *eventStore) batchAndWriteEvents(ctx context.Context, db *pebble.DB, inp
 				}
 				addEvent2Batch(batch, item)
 				if batch.Len() >= batchCommitSize {
-					return &DBBatchEvent{batch, maxEventCommitTsMap, resolvedTsMap}
+					return newBatchEvent()
 				}
 			}
 		}
@@ -797,6 +1012,24 @@ func (e *eventStore) batchAndWriteEvents(ctx context.Context, db *pebble.DB, inp
 	}
 }
 
+// notifyResolvedTs notifies the dispatcher with the min resolved ts of its subscriptions if it advances.
+// It must be called with the read lock of dispatcherStates held.
+func (e *eventStore) notifyResolvedTs(stat *dispatcherStat) {
+	stat.resolvedTs.Lock()
+	defer stat.resolvedTs.Unlock()
+	resolvedTs := uint64(math.MaxUint64)
+	for _, sub := range stat.subs {
+		ts := atomic.LoadUint64(&e.dispatcherStates.n[sub.subID].resolvedTs)
+		if ts < resolvedTs {
+			resolvedTs = ts
+		}
+	}
+	if resolvedTs > stat.resolvedTs.ts {
+		stat.resolvedTs.ts = resolvedTs
+		stat.notifier(resolvedTs)
+	}
+}
+
 // TODO: maybe we can remove it and just rely on resolved ts? Do it after we know how to share
 func (e *eventStore) sendBatchSignalPeriodically(ctx context.Context, inputCh chan<- eventWithState) {
 	ticker := time.NewTicker(batchCommitInterval / 2)
@@ -830,12 +1063,53 @@ func (e *eventStore) deleteEvents(dbIndex int, uniqueKeyID uint64, tableID int64
 	start := EncodeKeyPrefix(uniqueKeyID, tableID, startTs)
 	end := EncodeKeyPrefix(uniqueKeyID, tableID, endTs)
 
-	return db.DeleteRange(start, end, pebble.NoSync)
+	// advance the persisted checkpoint ts with the deletion,
+	// so the deleted data is never considered as available after restart
+	batch := db.NewBatch()
+	if err := batch.DeleteRange(start, end, pebble.NoSync); err != nil {
+		return err
+	}
+	if err := batch.Set(encodeSubscriptionMetaKey(uniqueKeyID, subscriptionMetaCheckpointTs), encodeTs(endTs), pebble.NoSync); err != nil {
+		return err
+	}
+	return batch.Commit(pebble.NoSync)
+}
+
+// spanIter iterates the events of a subscription in a range of the dispatcher span
+type spanIter struct {
+	*pebble.Iterator
+	// nil if all the events of the subscription are in the range
+	span *heartbeatpb.TableSpan
+}
+
+func newSpanIter(iter *pebble.Iterator, span *heartbeatpb.TableSpan) *spanIter {
+	it := &spanIter{Iterator: iter, span: span}
+	it.skipOutOfSpan()
+	return it
+}
+
+func (it *spanIter) next() {
+	it.Next()
+	it.skipOutOfSpan()
+}
+
+// skipOutOfSpan moves the iterator to the first event in the span
+func (it *spanIter) skipOutOfSpan() {
+	if it.span == nil {
+		return
+	}
+	for ; it.Valid(); it.Next() {
+		key := common.ToComparableKey(DecodeRawKey(it.Key()))
+		if bytes.Compare(key, it.span.StartKey) >= 0 && bytes.Compare(key, it.span.EndKey) < 0 {
+			return
+		}
+	}
 }
 
 type eventStoreIter struct {
-	tableID      common.TableID
-	innerIter    *pebble.Iterator
+	tableID common.TableID
+	// the events of the inner iterators are merged in the order of commit ts
+	innerIters   []*spanIter
 	prevStartTs  uint64
 	prevCommitTs uint64
 	iterMounter  event.Mounter
@@ -848,15 +1122,25 @@ type eventStoreIter struct {
 }
 
 func (iter *eventStoreIter) Next() (*common.RawKVEntry, bool, error) {
-	if iter.innerIter == nil {
+	if iter.innerIters == nil {
 		log.Panic("iter is nil")
 	}
 
-	if !iter.innerIter.Valid() {
+	// pick the inner iterator with the smallest event
+	var picked *spanIter
+	for _, innerIter := range iter.innerIters {
+		if !innerIter.Valid() {
+			continue
+		}
+		if picked == nil || CompareEventKeyOrder(innerIter.Key(), picked.Key()) < 0 {
+			picked = innerIter
+		}
+	}
+	if picked == nil {
 		return nil, false, nil
 	}
 
-	value := iter.innerIter.Value()
+	value := picked.Value()
 	decompressedValue, err := iter.decoder.DecodeAll(value, nil)
 	if err != nil {
 		log.Panic("failed to decompress value", zap.Error(err))
@@ -871,12 +1155,12 @@ func (iter *eventStoreIter) Next() (*common.RawKVEntry, bool, error) {
 	iter.prevCommitTs = rawKV.CRTs
 	iter.prevStartTs = rawKV.StartTs
 	iter.rowCount++
-	iter.innerIter.Next()
+	picked.next()
 	return rawKV, isNewTxn, nil
 }
 
 func (iter *eventStoreIter) Close() (int64, error) {
-	if iter.innerIter == nil {
+	if iter.innerIters == nil {
 		log.Info("event store close nil iter",
 			zap.Uint64("tableID", uint64(iter.tableID)),
 			zap.Uint64("startTs", iter.startTs),
@@ -885,7 +1169,12 @@ func (iter *eventStoreIter) Close() (int64, error) {
 		return 0, nil
 	}
 
-	err := iter.innerIter.Close()
-	iter.innerIter = nil
+	var err error
+	for _, innerIter := range iter.innerIters {
+		if closeErr := innerIter.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	iter.innerIters = nil
 	return iter.rowCount, err
 }
@@ -0,0 +1,168 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/logservice/logpuller"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newEventStoreForTest(t *testing.T) *eventStore {
+	db, err := pebble.Open(t.TempDir(), &pebble.Options{})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	encoder, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	decoder, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+
+	store := &eventStore{
+		dbs:     []*pebble.DB{db},
+		encoder: encoder,
+		decoder: decoder,
+	}
+	store.dispatcherStates.m = make(map[common.DispatcherID]*dispatcherStat)
+	store.dispatcherStates.n = make(map[logpuller.SubscriptionID]*subscriptionStat)
+	store.dispatcherStates.l = make(map[int64]map[common.DispatcherID]bool)
+	store.dispatcherStates.r = make(map[uint64]*retainedSubscription)
+	return store
+}
+
+func comparableSpan(tableID int64, start, end string) *heartbeatpb.TableSpan {
+	return &heartbeatpb.TableSpan{
+		TableID:  tableID,
+		StartKey: common.ToComparableKey([]byte(start)),
+		EndKey:   common.ToComparableKey([]byte(end)),
+	}
+}
+
+// addSubscriptionForTest adds a subscription with a dispatcher depending on it
+func addSubscriptionForTest(
+	store *eventStore, subID logpuller.SubscriptionID, span *heartbeatpb.TableSpan, checkpointTs, resolvedTs uint64,
+) *dispatcherStat {
+	dispatcherID := common.NewDispatcherID()
+	stat := &dispatcherStat{
+		dispatcherID: dispatcherID,
+		tableSpan:    span,
+		checkpointTs: checkpointTs,
+		subs:         []subscriptionRange{{subID: subID}},
+	}
+	store.dispatcherStates.m[dispatcherID] = stat
+	store.dispatcherStates.n[subID] = &subscriptionStat{
+		ids:          map[common.DispatcherID]bool{dispatcherID: true},
+		tableSpan:    span,
+		checkpointTs: checkpointTs,
+		resolvedTs:   resolvedTs,
+		uniqueKeyID:  uint64(subID),
+	}
+	if _, ok := store.dispatcherStates.l[span.TableID]; !ok {
+		store.dispatcherStates.l[span.TableID] = make(map[common.DispatcherID]bool)
+	}
+	store.dispatcherStates.l[span.TableID][dispatcherID] = true
+	return stat
+}
+
+func TestFindCoveringSubscriptions(t *testing.T) {
+	store := newEventStoreForTest(t)
+	addSubscriptionForTest(store, 1, comparableSpan(1, "a", "c"), 10, 100)
+	addSubscriptionForTest(store, 2, comparableSpan(1, "b", "e"), 10, 100)
+	addSubscriptionForTest(store, 3, comparableSpan(1, "e", "g"), 50, 100)
+
+	// the span is the same as an existing subscription
+	subs := store.findCoveringSubscriptionsUnlocked(comparableSpan(1, "a", "c"), 20)
+	require.Equal(t, []subscriptionRange{{subID: 1}}, subs)
+
+	// the span is covered by two overlapping subscriptions, only a part of the second one is read
+	subs = store.findCoveringSubscriptionsUnlocked(comparableSpan(1, "a", "d"), 20)
+	require.Equal(t, []subscriptionRange{
+		{subID: 1},
+		{subID: 2, span: &heartbeatpb.TableSpan{
+			TableID:  1,
+			StartKey: common.ToComparableKey([]byte("c")),
+			EndKey:   common.ToComparableKey([]byte("d")),
+		}},
+	}, subs)
+
+	// the data of the third subscription before the start ts is deleted
+	require.Nil(t, store.findCoveringSubscriptionsUnlocked(comparableSpan(1, "d", "f"), 20))
+	require.Len(t, store.findCoveringSubscriptionsUnlocked(comparableSpan(1, "d", "f"), 60), 2)
+	// the data after the resolved ts is not available
+	require.Nil(t, store.findCoveringSubscriptionsUnlocked(comparableSpan(1, "a", "c"), 110))
+	// the span is not fully covered
+	require.Nil(t, store.findCoveringSubscriptionsUnlocked(comparableSpan(1, "f", "h"), 60))
+	require.Nil(t, store.findCoveringSubscriptionsUnlocked(comparableSpan(2, "a", "c"), 20))
+}
+
+func TestIteratorMergeSubscriptions(t *testing.T) {
+	store := newEventStoreForTest(t)
+	addSubscriptionForTest(store, 1, comparableSpan(1, "a", "c"), 10, 100)
+	addSubscriptionForTest(store, 2, comparableSpan(1, "b", "e"), 10, 100)
+	writeEvent := func(subID logpuller.SubscriptionID, key string, commitTs uint64) {
+		raw := &common.RawKVEntry{
+			OpType:  common.OpTypePut,
+			Key:     []byte(key),
+			Value:   []byte(key),
+			StartTs: commitTs - 1,
+			CRTs:    commitTs,
+		}
+		value := store.encoder.EncodeAll(raw.Encode(), nil)
+		require.NoError(t, store.dbs[0].Set(EncodeKey(uint64(subID), 1, raw), value, pebble.NoSync))
+	}
+	writeEvent(1, "a1", 20)
+	writeEvent(1, "b1", 30)
+	writeEvent(2, "b1", 30)
+	writeEvent(2, "c1", 20)
+	writeEvent(2, "d1", 40)
+
+	dispatcherID := common.NewDispatcherID()
+	span := comparableSpan(1, "a", "d")
+	stat := &dispatcherStat{
+		dispatcherID: dispatcherID,
+		tableSpan:    span,
+		subs:         store.findCoveringSubscriptionsUnlocked(span, 10),
+	}
+	store.dispatcherStates.m[dispatcherID] = stat
+	iter, err := store.GetIterator(dispatcherID, common.DataRange{StartTs: 10, EndTs: 100})
+	require.NoError(t, err)
+
+	// the events are ordered by commit ts, and b1 is read from the first subscription only
+	expected := []struct {
+		key      string
+		commitTs uint64
+		isNewTxn bool
+	}{
+		{"a1", 20, true},
+		{"c1", 20, false},
+		{"b1", 30, true},
+	}
+	for _, e := range expected {
+		raw, isNewTxn, err := iter.Next()
+		require.NoError(t, err)
+		require.Equal(t, e.key, string(raw.Key))
+		require.Equal(t, e.commitTs, raw.CRTs)
+		require.Equal(t, e.isNewTxn, isNewTxn)
+	}
+	raw, _, err := iter.Next()
+	require.NoError(t, err)
+	require.Nil(t, raw)
+	count, err := iter.Close()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+}
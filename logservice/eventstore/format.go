@@ -1,9 +1,12 @@
 package eventstore
 
 import (
+	"bytes"
 	"encoding/binary"
 
+	"github.com/cockroachdb/pebble"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
 	"go.uber.org/zap"
 )
@@ -74,6 +77,27 @@ func EncodeKey(uniqueID uint64, tableID int64, event *common.RawKVEntry) []byte
 	return append(buf, event.Key...)
 }
 
+// eventKeyOrderOffset is the offset of CRTs in the key encoded by EncodeKey,
+// the keys of the same table are ordered by the bytes after it.
+const eventKeyOrderOffset = 8 + 8
+
+// eventKeyRawKeyOffset is the offset of the raw key in the key encoded by EncodeKey.
+const eventKeyRawKeyOffset = 8 + 8 + 8 + 8 + 2
+
+// CompareEventKeyOrder compares the order of two keys encoded by EncodeKey,
+// the uniqueID and tableID are ignored.
+func CompareEventKeyOrder(lhs, rhs []byte) int {
+	return bytes.Compare(lhs[eventKeyOrderOffset:], rhs[eventKeyOrderOffset:])
+}
+
+// DecodeRawKey returns the raw key of the event from the key encoded by EncodeKey.
+func DecodeRawKey(key []byte) []byte {
+	if len(key) < eventKeyRawKeyOffset {
+		log.Panic("invalid event key", zap.Binary("key", key))
+	}
+	return key[eventKeyRawKeyOffset:]
+}
+
 // getDMLOrder returns the order of the dml types: delete<update<insert
 func getDMLOrder(rowKV *common.RawKVEntry) uint16 {
 	if rowKV.OpType == common.OpTypeDelete {
@@ -83,3 +107,135 @@ func getDMLOrder(rowKV *common.RawKVEntry) uint16 {
 	}
 	return typeInsert
 }
+
+// Subscription metadata format:
+//
+//	{0}{uniqueKeyID}{subscriptionMetaSpan} -> table span
+//	{0}{uniqueKeyID}{subscriptionMetaCheckpointTs} -> checkpoint ts
+//	{0}{uniqueKeyID}{subscriptionMetaResolvedTs} -> resolved ts
+//
+// uniqueKeyID starts from 1, so the metadata never overlaps with the event keys.
+// The data of a subscription on disk is complete in the range (checkpointTs, resolvedTs].
+const (
+	subscriptionMetaSpan byte = iota + 1
+	subscriptionMetaCheckpointTs
+	subscriptionMetaResolvedTs
+)
+
+const subscriptionMetaUniqueID uint64 = 0
+
+func encodeSubscriptionMetaKey(uniqueKeyID uint64, metaType byte) []byte {
+	buf := make([]byte, 0, 8+8+1)
+	buf = binary.BigEndian.AppendUint64(buf, subscriptionMetaUniqueID)
+	buf = binary.BigEndian.AppendUint64(buf, uniqueKeyID)
+	return append(buf, metaType)
+}
+
+func decodeSubscriptionMetaKey(key []byte) (uint64, byte) {
+	if len(key) != 8+8+1 {
+		log.Panic("invalid subscription meta key", zap.Binary("key", key))
+	}
+	return binary.BigEndian.Uint64(key[8:16]), key[16]
+}
+
+func encodeTs(ts uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, ts)
+}
+
+func decodeTs(value []byte) uint64 {
+	if len(value) != 8 {
+		log.Panic("invalid ts value", zap.Binary("value", value))
+	}
+	return binary.BigEndian.Uint64(value)
+}
+
+// writeSubscriptionMetaToBatch writes the span and the initial ts range of a subscription to the batch
+func writeSubscriptionMetaToBatch(batch *pebble.Batch, uniqueKeyID uint64, span *heartbeatpb.TableSpan, startTs uint64) {
+	spanValue, err := span.Marshal()
+	if err != nil {
+		log.Panic("marshal table span failed", zap.Error(err))
+	}
+	if err := batch.Set(encodeSubscriptionMetaKey(uniqueKeyID, subscriptionMetaSpan), spanValue, pebble.NoSync); err != nil {
+		log.Panic("failed to update pebble batch", zap.Error(err))
+	}
+	if err := batch.Set(encodeSubscriptionMetaKey(uniqueKeyID, subscriptionMetaCheckpointTs), encodeTs(startTs), pebble.NoSync); err != nil {
+		log.Panic("failed to update pebble batch", zap.Error(err))
+	}
+	if err := batch.Set(encodeSubscriptionMetaKey(uniqueKeyID, subscriptionMetaResolvedTs), encodeTs(startTs), pebble.NoSync); err != nil {
+		log.Panic("failed to update pebble batch", zap.Error(err))
+	}
+}
+
+// deleteSubscriptionToBatch deletes all the events and the metadata of a subscription
+func deleteSubscriptionToBatch(batch *pebble.Batch, uniqueKeyID uint64) {
+	if err := batch.DeleteRange(
+		binary.BigEndian.AppendUint64(nil, uniqueKeyID),
+		binary.BigEndian.AppendUint64(nil, uniqueKeyID+1),
+		pebble.NoSync); err != nil {
+		log.Panic("failed to update pebble batch", zap.Error(err))
+	}
+	if err := batch.DeleteRange(
+		encodeSubscriptionMetaKey(uniqueKeyID, 0),
+		encodeSubscriptionMetaKey(uniqueKeyID+1, 0),
+		pebble.NoSync); err != nil {
+		log.Panic("failed to update pebble batch", zap.Error(err))
+	}
+}
+
+// loadSubscriptionMetas reads the metadata of all subscriptions persisted in the db,
+// the subscriptions without a complete metadata are deleted.
+func loadSubscriptionMetas(db *pebble.DB, chIndex int) []*retainedSubscription {
+	iter, err := db.NewIter(&pebble.IterOptions{
+		LowerBound: binary.BigEndian.AppendUint64(nil, subscriptionMetaUniqueID),
+		UpperBound: binary.BigEndian.AppendUint64(nil, subscriptionMetaUniqueID+1),
+	})
+	if err != nil {
+		log.Panic("create iterator failed", zap.Error(err))
+	}
+	defer iter.Close()
+
+	subs := make(map[uint64]*retainedSubscription)
+	// the metadata keys of a subscription are adjacent and in order
+	var uniqueKeyIDs []uint64
+	for iter.First(); iter.Valid(); iter.Next() {
+		uniqueKeyID, metaType := decodeSubscriptionMetaKey(iter.Key())
+		sub, ok := subs[uniqueKeyID]
+		if !ok {
+			sub = &retainedSubscription{chIndex: chIndex, uniqueKeyID: uniqueKeyID}
+			subs[uniqueKeyID] = sub
+			uniqueKeyIDs = append(uniqueKeyIDs, uniqueKeyID)
+		}
+		switch metaType {
+		case subscriptionMetaSpan:
+			span := &heartbeatpb.TableSpan{}
+			if err := span.Unmarshal(iter.Value()); err != nil {
+				log.Panic("unmarshal table span failed", zap.Error(err))
+			}
+			sub.tableSpan = span
+		case subscriptionMetaCheckpointTs:
+			sub.checkpointTs = decodeTs(iter.Value())
+		case subscriptionMetaResolvedTs:
+			sub.resolvedTs = decodeTs(iter.Value())
+		default:
+			log.Panic("unknown subscription meta type", zap.Uint8("type", metaType))
+		}
+	}
+
+	result := make([]*retainedSubscription, 0, len(uniqueKeyIDs))
+	batch := db.NewBatch()
+	for _, uniqueKeyID := range uniqueKeyIDs {
+		sub := subs[uniqueKeyID]
+		// the resolved ts may be written by an in-flight batch after the subscription is deleted
+		if sub.tableSpan == nil || sub.checkpointTs > sub.resolvedTs {
+			deleteSubscriptionToBatch(batch, uniqueKeyID)
+			continue
+		}
+		result = append(result, sub)
+	}
+	if !batch.Empty() {
+		if err := batch.Commit(pebble.NoSync); err != nil {
+			log.Panic("delete incomplete subscriptions failed", zap.Error(err))
+		}
+	}
+	return result
+}
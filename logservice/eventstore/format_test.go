@@ -0,0 +1,62 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSubscriptionMetas(t *testing.T) {
+	db, err := pebble.Open(t.TempDir(), &pebble.Options{})
+	require.NoError(t, err)
+	defer db.Close()
+
+	span := &heartbeatpb.TableSpan{TableID: 100, StartKey: []byte("a"), EndKey: []byte("b")}
+	batch := db.NewBatch()
+	writeSubscriptionMetaToBatch(batch, 1, span, 10)
+	require.NoError(t, batch.Set(encodeSubscriptionMetaKey(1, subscriptionMetaResolvedTs), encodeTs(20), pebble.NoSync))
+	require.NoError(t, batch.Set(EncodeKey(1, 100, &common.RawKVEntry{
+		OpType: common.OpTypePut, Key: []byte("a1"), CRTs: 15, StartTs: 14,
+	}), []byte("v"), pebble.NoSync))
+	// an incomplete subscription left by an in-flight batch
+	require.NoError(t, batch.Set(encodeSubscriptionMetaKey(2, subscriptionMetaResolvedTs), encodeTs(30), pebble.NoSync))
+	require.NoError(t, batch.Commit(pebble.NoSync))
+
+	subs := loadSubscriptionMetas(db, 3)
+	require.Len(t, subs, 1)
+	require.Equal(t, 3, subs[0].chIndex)
+	require.Equal(t, uint64(1), subs[0].uniqueKeyID)
+	require.True(t, span.Equal(subs[0].tableSpan))
+	require.Equal(t, uint64(10), subs[0].checkpointTs)
+	require.Equal(t, uint64(20), subs[0].resolvedTs)
+
+	// the incomplete subscription is deleted
+	_, _, err = db.Get(encodeSubscriptionMetaKey(2, subscriptionMetaResolvedTs))
+	require.ErrorIs(t, err, pebble.ErrNotFound)
+
+	// delete the subscription with all its events
+	batch = db.NewBatch()
+	deleteSubscriptionToBatch(batch, 1)
+	require.NoError(t, batch.Commit(pebble.NoSync))
+	require.Len(t, loadSubscriptionMetas(db, 3), 0)
+	iter, err := db.NewIter(&pebble.IterOptions{})
+	require.NoError(t, err)
+	require.False(t, iter.First())
+	require.NoError(t, iter.Close())
+}
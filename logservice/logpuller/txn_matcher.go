@@ -70,6 +70,7 @@ func (m *matcher) matchRow(row *cdcpb.Event_Row, initialized bool) bool {
 		}
 		row.Value = value.GetValue()
 		row.OldValue = value.GetOldValue()
+		row.TxnSource = value.GetTxnSource()
 		delete(m.unmatchedValue, newMatchKey(row))
 		return true
 	}
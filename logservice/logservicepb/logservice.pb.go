@@ -179,11 +179,125 @@ func (m *EventStoreState) GetSubscriptions() map[int64]*SubscriptionStates {
 	return nil
 }
 
+type ReusableEventServiceRequest struct {
+	Id      *heartbeatpb.DispatcherID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Span    *heartbeatpb.TableSpan    `protobuf:"bytes,2,opt,name=span,proto3" json:"span,omitempty"`
+	StartTs uint64                    `protobuf:"varint,3,opt,name=startTs,proto3" json:"startTs,omitempty"`
+}
+
+func (m *ReusableEventServiceRequest) Reset()         { *m = ReusableEventServiceRequest{} }
+func (m *ReusableEventServiceRequest) String() string { return proto.CompactTextString(m) }
+func (*ReusableEventServiceRequest) ProtoMessage()    {}
+func (*ReusableEventServiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{3}
+}
+func (m *ReusableEventServiceRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReusableEventServiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReusableEventServiceRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReusableEventServiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReusableEventServiceRequest.Merge(m, src)
+}
+func (m *ReusableEventServiceRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReusableEventServiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReusableEventServiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReusableEventServiceRequest proto.InternalMessageInfo
+
+func (m *ReusableEventServiceRequest) GetId() *heartbeatpb.DispatcherID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ReusableEventServiceRequest) GetSpan() *heartbeatpb.TableSpan {
+	if m != nil {
+		return m.Span
+	}
+	return nil
+}
+
+func (m *ReusableEventServiceRequest) GetStartTs() uint64 {
+	if m != nil {
+		return m.StartTs
+	}
+	return 0
+}
+
+type ReusableEventServiceResponse struct {
+	Id    *heartbeatpb.DispatcherID `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Nodes []string                  `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (m *ReusableEventServiceResponse) Reset()         { *m = ReusableEventServiceResponse{} }
+func (m *ReusableEventServiceResponse) String() string { return proto.CompactTextString(m) }
+func (*ReusableEventServiceResponse) ProtoMessage()    {}
+func (*ReusableEventServiceResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a1db670929506a40, []int{4}
+}
+func (m *ReusableEventServiceResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReusableEventServiceResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReusableEventServiceResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReusableEventServiceResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReusableEventServiceResponse.Merge(m, src)
+}
+func (m *ReusableEventServiceResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReusableEventServiceResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReusableEventServiceResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReusableEventServiceResponse proto.InternalMessageInfo
+
+func (m *ReusableEventServiceResponse) GetId() *heartbeatpb.DispatcherID {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *ReusableEventServiceResponse) GetNodes() []string {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SubscriptionState)(nil), "logservicepb.SubscriptionState")
 	proto.RegisterType((*SubscriptionStates)(nil), "logservicepb.SubscriptionStates")
 	proto.RegisterType((*EventStoreState)(nil), "logservicepb.EventStoreState")
 	proto.RegisterMapType((map[int64]*SubscriptionStates)(nil), "logservicepb.EventStoreState.SubscriptionsEntry")
+	proto.RegisterType((*ReusableEventServiceRequest)(nil), "logservicepb.ReusableEventServiceRequest")
+	proto.RegisterType((*ReusableEventServiceResponse)(nil), "logservicepb.ReusableEventServiceResponse")
 }
 
 func init() {
@@ -191,29 +305,34 @@ func init() {
 }
 
 var fileDescriptor_a1db670929506a40 = []byte{
-	// 350 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0xc1, 0x4a, 0xeb, 0x40,
-	0x14, 0xed, 0x34, 0xed, 0x5b, 0x4c, 0xfb, 0x78, 0xcf, 0x41, 0x24, 0x54, 0x18, 0x43, 0x40, 0x08,
-	0x2e, 0x12, 0xa9, 0x20, 0xe2, 0x46, 0x50, 0xbb, 0x70, 0x9b, 0x14, 0x17, 0xba, 0x9a, 0x99, 0x0e,
-	0xed, 0xd0, 0x38, 0x33, 0x64, 0x26, 0x81, 0xfe, 0x85, 0x5b, 0xff, 0xc8, 0x8d, 0xd0, 0xa5, 0x4b,
-	0x69, 0x7f, 0x44, 0x4c, 0x44, 0x93, 0x56, 0x70, 0x77, 0xef, 0x99, 0x73, 0xce, 0x9d, 0x73, 0xb9,
-	0x30, 0x48, 0xd5, 0xd4, 0xf0, 0xac, 0x10, 0x8c, 0x47, 0xdf, 0xa5, 0xa6, 0xb5, 0x26, 0xd4, 0x99,
-	0xb2, 0x0a, 0xf5, 0xeb, 0xcf, 0x83, 0xfd, 0x19, 0x27, 0x99, 0xa5, 0x9c, 0x58, 0x4d, 0xa3, 0xaf,
-	0xba, 0xa2, 0xfa, 0x4f, 0x00, 0xee, 0x24, 0x39, 0x35, 0x2c, 0x13, 0xda, 0x0a, 0x25, 0x13, 0x4b,
-	0x2c, 0x47, 0xbb, 0xb0, 0x9b, 0xe4, 0xf4, 0xe6, 0xda, 0x05, 0x1e, 0x08, 0x3a, 0x71, 0xd5, 0xa0,
-	0x23, 0xd8, 0x31, 0x9a, 0x48, 0xb7, 0xed, 0x81, 0xa0, 0x37, 0xdc, 0x0b, 0x6b, 0xbe, 0xe1, 0x98,
-	0xd0, 0x94, 0x27, 0x9a, 0xc8, 0xb8, 0xe4, 0x20, 0x1f, 0xf6, 0xaf, 0x66, 0x9c, 0xcd, 0xb5, 0x12,
-	0xd2, 0x8e, 0x8d, 0xeb, 0x94, 0x46, 0x0d, 0x0c, 0x61, 0x08, 0x63, 0x6e, 0x54, 0x5a, 0xf0, 0xc9,
-	0xd8, 0xb8, 0x9d, 0x92, 0x51, 0x43, 0xfc, 0x7b, 0x88, 0xb6, 0xbe, 0x66, 0xd0, 0x08, 0xfe, 0x35,
-	0x35, 0xd4, 0xb8, 0xc0, 0x73, 0x82, 0xde, 0xf0, 0x20, 0xac, 0x87, 0x0e, 0xb7, 0x84, 0x71, 0x53,
-	0xe5, 0xbf, 0x00, 0xf8, 0x6f, 0x54, 0x70, 0x69, 0x13, 0xab, 0x32, 0x5e, 0xc5, 0xbe, 0xfd, 0xd9,
-	0xfa, 0xb8, 0x69, 0xbd, 0xa1, 0x6a, 0x8c, 0x32, 0x23, 0x69, 0xb3, 0xc5, 0xc6, 0xac, 0x01, 0x6d,
-	0x06, 0xa9, 0x48, 0xe8, 0x3f, 0x74, 0xe6, 0x7c, 0x51, 0xae, 0xd8, 0x89, 0x3f, 0x4a, 0x74, 0x0a,
-	0xbb, 0x05, 0x49, 0x73, 0xfe, 0xb9, 0x61, 0xef, 0x97, 0x48, 0x26, 0xae, 0xe8, 0xe7, 0xed, 0x33,
-	0x70, 0x79, 0xf1, 0xbc, 0xc2, 0x60, 0xb9, 0xc2, 0xe0, 0x6d, 0x85, 0xc1, 0xe3, 0x1a, 0xb7, 0x96,
-	0x6b, 0xdc, 0x7a, 0x5d, 0xe3, 0xd6, 0xdd, 0xe1, 0x54, 0xd8, 0x59, 0x4e, 0x43, 0xa6, 0x1e, 0x22,
-	0x2d, 0xe4, 0x94, 0x11, 0x1d, 0x59, 0xc1, 0x26, 0xac, 0x71, 0x45, 0xf4, 0x4f, 0x79, 0x10, 0x27,
-	0xef, 0x01, 0x00, 0x00, 0xff, 0xff, 0x82, 0x2e, 0xc5, 0x14, 0x67, 0x02, 0x00, 0x00,
+	// 430 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x53, 0xc1, 0x8a, 0xd4, 0x40,
+	0x10, 0xdd, 0x4e, 0x66, 0x14, 0x6b, 0x56, 0xd4, 0x66, 0x91, 0xb8, 0x2b, 0x31, 0x04, 0x84, 0xe8,
+	0x21, 0x23, 0x23, 0x88, 0x78, 0x11, 0x74, 0xe6, 0xb0, 0xd7, 0xce, 0xe0, 0x41, 0x0f, 0xd2, 0x49,
+	0x8a, 0x99, 0x66, 0xc7, 0xee, 0x36, 0xd5, 0x19, 0xd8, 0x9f, 0x10, 0xaf, 0xfe, 0x91, 0x17, 0x61,
+	0x8f, 0x1e, 0x65, 0xe6, 0x47, 0x64, 0x13, 0x75, 0x93, 0x9d, 0x05, 0xd9, 0x5b, 0x55, 0xf5, 0xab,
+	0x57, 0xaf, 0xfa, 0x51, 0x90, 0xac, 0xcc, 0x82, 0xb0, 0x5a, 0xab, 0x02, 0xc7, 0x17, 0xa1, 0xcd,
+	0x3b, 0x49, 0x6a, 0x2b, 0xe3, 0x0c, 0xdf, 0xef, 0x3e, 0x1f, 0x1e, 0x2d, 0x51, 0x56, 0x2e, 0x47,
+	0xe9, 0x6c, 0x3e, 0xfe, 0x17, 0xb7, 0xd0, 0xf8, 0x1b, 0x83, 0x7b, 0x59, 0x9d, 0x53, 0x51, 0x29,
+	0xeb, 0x94, 0xd1, 0x99, 0x93, 0x0e, 0xf9, 0x01, 0x0c, 0xb3, 0x3a, 0x3f, 0x9e, 0x06, 0x2c, 0x62,
+	0xc9, 0x40, 0xb4, 0x09, 0x7f, 0x0a, 0x03, 0xb2, 0x52, 0x07, 0x5e, 0xc4, 0x92, 0xd1, 0xe4, 0x7e,
+	0xda, 0xe1, 0x4d, 0xe7, 0x32, 0x5f, 0x61, 0x66, 0xa5, 0x16, 0x0d, 0x86, 0xc7, 0xb0, 0xff, 0x76,
+	0x89, 0xc5, 0x89, 0x35, 0x4a, 0xbb, 0x39, 0x05, 0x7e, 0x43, 0xd4, 0xab, 0xf1, 0x10, 0x40, 0x20,
+	0x99, 0xd5, 0x1a, 0xcb, 0x39, 0x05, 0x83, 0x06, 0xd1, 0xa9, 0xc4, 0x1f, 0x80, 0xef, 0x48, 0x23,
+	0x3e, 0x83, 0xdb, 0xd4, 0xa9, 0x52, 0xc0, 0x22, 0x3f, 0x19, 0x4d, 0x1e, 0xa5, 0xdd, 0xa5, 0xd3,
+	0x9d, 0x46, 0xd1, 0xef, 0x8a, 0x7f, 0x30, 0xb8, 0x33, 0x5b, 0xa3, 0x76, 0x99, 0x33, 0x15, 0xb6,
+	0x6b, 0xbf, 0xbb, 0x9a, 0xfa, 0x59, 0x9f, 0xfa, 0x52, 0x57, 0x6f, 0x14, 0xcd, 0xb4, 0xab, 0x4e,
+	0x2f, 0xcd, 0x3a, 0xcc, 0x81, 0xef, 0x82, 0xf8, 0x5d, 0xf0, 0x4f, 0xf0, 0xb4, 0xf9, 0x62, 0x5f,
+	0x9c, 0x87, 0xfc, 0x05, 0x0c, 0xd7, 0x72, 0x55, 0xe3, 0x9f, 0x1f, 0x8e, 0xfe, 0xb3, 0x12, 0x89,
+	0x16, 0xfe, 0xca, 0x7b, 0xc9, 0xe2, 0x2f, 0x0c, 0x8e, 0x04, 0xd6, 0x74, 0xee, 0x43, 0xab, 0xb0,
+	0x6d, 0x14, 0xf8, 0xb9, 0x46, 0x72, 0xfc, 0x09, 0x78, 0xaa, 0x6c, 0x86, 0x8d, 0x26, 0x0f, 0x7a,
+	0xd6, 0x4d, 0x15, 0x59, 0xe9, 0x8a, 0x25, 0x56, 0xc7, 0x53, 0xe1, 0xa9, 0xf2, 0x5a, 0x3e, 0x07,
+	0x70, 0x93, 0x9c, 0xac, 0x2e, 0x2c, 0xfe, 0x9b, 0xc6, 0x1f, 0xe1, 0xe1, 0xd5, 0x7a, 0xc8, 0x1a,
+	0x4d, 0x78, 0x1d, 0x41, 0x07, 0x30, 0xd4, 0xa6, 0x44, 0x0a, 0xbc, 0xc8, 0x4f, 0x6e, 0x89, 0x36,
+	0x79, 0xf3, 0xfa, 0xfb, 0x26, 0x64, 0x67, 0x9b, 0x90, 0xfd, 0xda, 0x84, 0xec, 0xeb, 0x36, 0xdc,
+	0x3b, 0xdb, 0x86, 0x7b, 0x3f, 0xb7, 0xe1, 0xde, 0xfb, 0xc7, 0x0b, 0xe5, 0x96, 0x75, 0x9e, 0x16,
+	0xe6, 0xd3, 0xd8, 0x2a, 0xbd, 0x28, 0xa4, 0x1d, 0x3b, 0x55, 0x94, 0x45, 0xef, 0x6e, 0xf2, 0x1b,
+	0xcd, 0x09, 0x3c, 0xff, 0x3d, 0x00, 0xbd, 0x0c, 0x44, 0xe5, 0x59, 0x03, 0x00, 0x00,
 }
 
 func (m *SubscriptionState) Marshal() (dAtA []byte, err error) {
@@ -350,6 +469,102 @@ func (m *EventStoreState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *ReusableEventServiceRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReusableEventServiceRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReusableEventServiceRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.StartTs != 0 {
+		i = encodeVarintLogservice(dAtA, i, uint64(m.StartTs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Span != nil {
+		{
+			size, err := m.Span.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogservice(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogservice(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReusableEventServiceResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReusableEventServiceResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReusableEventServiceResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Nodes[iNdEx])
+			copy(dAtA[i:], m.Nodes[iNdEx])
+			i = encodeVarintLogservice(dAtA, i, uint64(len(m.Nodes[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Id != nil {
+		{
+			size, err := m.Id.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintLogservice(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintLogservice(dAtA []byte, offset int, v uint64) int {
 	offset -= sovLogservice(v)
 	base := offset
@@ -420,6 +635,45 @@ func (m *EventStoreState) Size() (n int) {
 	return n
 }
 
+func (m *ReusableEventServiceRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if m.Span != nil {
+		l = m.Span.Size()
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if m.StartTs != 0 {
+		n += 1 + sovLogservice(uint64(m.StartTs))
+	}
+	return n
+}
+
+func (m *ReusableEventServiceResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Id != nil {
+		l = m.Id.Size()
+		n += 1 + l + sovLogservice(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, s := range m.Nodes {
+			l = len(s)
+			n += 1 + l + sovLogservice(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovLogservice(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -818,6 +1072,265 @@ func (m *EventStoreState) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ReusableEventServiceRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReusableEventServiceRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReusableEventServiceRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &heartbeatpb.DispatcherID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Span", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Span == nil {
+				m.Span = &heartbeatpb.TableSpan{}
+			}
+			if err := m.Span.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTs", wireType)
+			}
+			m.StartTs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTs |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ReusableEventServiceResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLogservice
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ReusableEventServiceResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ReusableEventServiceResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Id == nil {
+				m.Id = &heartbeatpb.DispatcherID{}
+			}
+			if err := m.Id.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLogservice
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLogservice(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLogservice
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipLogservice(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/pebble"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/logservice/logpuller"
 	"github.com/pingcap/ticdc/pkg/common"
@@ -29,6 +30,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"go.uber.org/zap"
 )
 
@@ -545,21 +547,22 @@ func cleanObsoleteData(db *pebble.DB, oldGcTs uint64, gcTs uint64) {
 	}
 }
 
-func loadAllPhysicalTablesAtTs(
+// loadTablesAtTs loads the databases and tables at snapVersion,
+// applyDDL is called with every ddl job in range (gcTs, snapVersion] if it's not nil.
+func loadTablesAtTs(
 	storageSnap *pebble.Snapshot,
 	gcTs uint64,
 	snapVersion uint64,
-	tableFilter filter.Filter,
-) ([]commonEvent.Table, error) {
-	// TODO: respect tableFilter(filter table in kv snap is easy, filter ddl jobs need more attention)
+	applyDDL func(ddlEvent *PersistedDDLEvent),
+) (map[int64]*BasicDatabaseInfo, map[int64]*BasicTableInfo, map[int64]BasicPartitionInfo, error) {
 	databaseMap, err := loadDatabasesInKVSnap(storageSnap, gcTs)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	tableMap, partitionMap, err := loadTablesInKVSnap(storageSnap, gcTs, databaseMap)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	log.Info("after load tables in kv snap",
 		zap.Int("tableMapLen", len(tableMap)),
@@ -587,10 +590,27 @@ func loadAllPhysicalTablesAtTs(
 		if err := updateDatabaseInfoAndTableInfo(&ddlEvent, databaseMap, tableMap, partitionMap); err != nil {
 			log.Panic("updateDatabaseInfo error", zap.Error(err))
 		}
+		if applyDDL != nil {
+			applyDDL(&ddlEvent)
+		}
 	}
 	log.Info("after load tables from ddl",
 		zap.Int("tableMapLen", len(tableMap)),
 		zap.Int("partitionMapLen", len(partitionMap)))
+	return databaseMap, tableMap, partitionMap, nil
+}
+
+func loadAllPhysicalTablesAtTs(
+	storageSnap *pebble.Snapshot,
+	gcTs uint64,
+	snapVersion uint64,
+	tableFilter filter.Filter,
+) ([]commonEvent.Table, error) {
+	// TODO: respect tableFilter(filter table in kv snap is easy, filter ddl jobs need more attention)
+	databaseMap, tableMap, partitionMap, err := loadTablesAtTs(storageSnap, gcTs, snapVersion, nil)
+	if err != nil {
+		return nil, err
+	}
 	tables := make([]commonEvent.Table, 0)
 	for tableID, tableInfo := range tableMap {
 		if _, ok := databaseMap[tableInfo.SchemaID]; !ok {
@@ -621,3 +641,87 @@ func loadAllPhysicalTablesAtTs(
 		zap.Int("tableLen", len(tables)))
 	return tables, nil
 }
+
+// loadTableInfosInKVSnap loads the table infos in the kv snapshot at gcTs
+func loadTableInfosInKVSnap(snap *pebble.Snapshot, gcTs uint64) map[int64]*model.TableInfo {
+	tableInfos := make(map[int64]*model.TableInfo)
+	startKey, err := tableInfoKey(gcTs, 0)
+	if err != nil {
+		log.Fatal("generate lower bound failed", zap.Error(err))
+	}
+	endKey, err := tableInfoKey(gcTs, math.MaxInt64)
+	if err != nil {
+		log.Fatal("generate upper bound failed", zap.Error(err))
+	}
+	snapIter, err := snap.NewIter(&pebble.IterOptions{
+		LowerBound: startKey,
+		UpperBound: endKey,
+	})
+	if err != nil {
+		log.Fatal("new iterator failed", zap.Error(err))
+	}
+	defer snapIter.Close()
+	for snapIter.First(); snapIter.Valid(); snapIter.Next() {
+		var entry PersistedTableInfoEntry
+		if _, err := entry.UnmarshalMsg(snapIter.Value()); err != nil {
+			log.Fatal("unmarshal table info entry failed", zap.Error(err))
+		}
+		tableInfo := &model.TableInfo{}
+		if err := json.Unmarshal(entry.TableInfoValue, tableInfo); err != nil {
+			log.Fatal("unmarshal table info failed", zap.Error(err))
+		}
+		tableInfos[tableInfo.ID] = tableInfo
+	}
+	return tableInfos
+}
+
+// loadVerifiedTablesAtTs returns all the tables at snapVersion with whether they can be replicated,
+// the table infos are loaded in a single pass of the kv snapshot and the ddl jobs.
+func loadVerifiedTablesAtTs(
+	storageSnap *pebble.Snapshot,
+	gcTs uint64,
+	snapVersion uint64,
+	tableFilter filter.Filter,
+) ([]VerifiedTable, error) {
+	tableInfos := loadTableInfosInKVSnap(storageSnap, gcTs)
+	updateTableInfo := func(tableInfo *model.TableInfo) {
+		if tableInfo != nil && tableInfo.ID != 0 {
+			tableInfos[tableInfo.ID] = tableInfo
+		}
+	}
+	databaseMap, tableMap, partitionMap, err := loadTablesAtTs(storageSnap, gcTs, snapVersion,
+		func(ddlEvent *PersistedDDLEvent) {
+			updateTableInfo(ddlEvent.TableInfo)
+			for _, tableInfo := range ddlEvent.MultipleTableInfos {
+				updateTableInfo(tableInfo)
+			}
+		})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	tables := make([]VerifiedTable, 0, len(tableMap))
+	for tableID, basicInfo := range tableMap {
+		databaseInfo, ok := databaseMap[basicInfo.SchemaID]
+		if !ok {
+			return nil, cerror.ErrSnapshotSchemaNotFound.GenWithStackByArgs(basicInfo.SchemaID)
+		}
+		tableInfo, ok := tableInfos[tableID]
+		if !ok {
+			return nil, cerror.ErrSnapshotTableNotFound.GenWithStackByArgs(tableID)
+		}
+		_, isPartition := partitionMap[tableID]
+		table := VerifiedTable{
+			SchemaName:  databaseInfo.Name,
+			TableName:   basicInfo.Name,
+			TableID:     tableID,
+			IsPartition: isPartition,
+			Filtered:    tableFilter != nil && tableFilter.ShouldIgnoreTable(databaseInfo.Name, basicInfo.Name),
+		}
+		if !table.Filtered {
+			table.Eligible = common.WrapTableInfo(basicInfo.SchemaID, databaseInfo.Name, tableInfo).IsEligible(false /* forceReplicate */)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/cockroachdb/pebble"
 	"github.com/cockroachdb/pebble/bloom"
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/pkg/common"
@@ -31,6 +32,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	cerror "github.com/pingcap/tiflow/pkg/errors"
 	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
 )
@@ -269,6 +271,22 @@ func (p *persistentStorage) getAllPhysicalTables(snapTs uint64, tableFilter filt
 	return loadAllPhysicalTablesAtTs(storageSnap, gcTs, snapTs, tableFilter)
 }
 
+// verifyTables returns all tables in the snapshot with whether they can be replicated
+// caller must ensure current resolve ts is larger than snapTs
+func (p *persistentStorage) verifyTables(snapTs uint64, tableFilter filter.Filter) ([]VerifiedTable, error) {
+	storageSnap := p.db.NewSnapshot()
+	defer storageSnap.Close()
+
+	p.mu.Lock()
+	gcTs := p.gcTs
+	p.mu.Unlock()
+	if snapTs < gcTs {
+		return nil, cerror.ErrSchemaStorageGCed.GenWithStackByArgs(snapTs, gcTs)
+	}
+	tables, err := loadVerifiedTablesAtTs(storageSnap, gcTs, snapTs, tableFilter)
+	return tables, errors.Trace(err)
+}
+
 // only return when table info is initialized
 func (p *persistentStorage) registerTable(tableID int64, startTs uint64) error {
 	p.mu.Lock()
@@ -1390,6 +1408,7 @@ func buildDDLEvent(rawEvent *PersistedDDLEvent, tableFilter filter.Filter) commo
 		TableInfo:  wrapTableInfo,
 		FinishedTs: rawEvent.FinishedTs,
 		TiDBOnly:   false,
+		BDRRole:    rawEvent.BDRRole,
 	}
 
 	switch model.ActionType(rawEvent.Type) {
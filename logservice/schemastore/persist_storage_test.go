@@ -25,6 +25,8 @@ import (
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tiflow/pkg/config"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -2362,3 +2364,90 @@ func TestGetAllPhysicalTables(t *testing.T) {
 		require.Equal(t, 2, len(allPhysicalTables))
 	}
 }
+
+func TestVerifyTables(t *testing.T) {
+	dbPath := fmt.Sprintf("/tmp/testdb-%s", t.Name())
+	err := os.RemoveAll(dbPath)
+	require.Nil(t, err)
+
+	newTableInfo := func(tableID int64, name string, hasPK bool) *model.TableInfo {
+		col := &model.ColumnInfo{
+			ID:        1,
+			Name:      model.NewCIStr("a"),
+			Offset:    0,
+			State:     model.StatePublic,
+			FieldType: *types.NewFieldType(mysql.TypeLong),
+		}
+		if hasPK {
+			col.AddFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+		}
+		return &model.TableInfo{
+			ID:         tableID,
+			Name:       model.NewCIStr(name),
+			PKIsHandle: hasPK,
+			Columns:    []*model.ColumnInfo{col},
+		}
+	}
+
+	schemaID := int64(300)
+	gcTs := uint64(600)
+	tableID1 := int64(100)
+	tableID2 := tableID1 + 100
+	tableID3 := tableID2 + 100
+
+	databaseInfo := make(map[int64]*model.DBInfo)
+	databaseInfo[schemaID] = &model.DBInfo{
+		ID:   schemaID,
+		Name: model.NewCIStr("test"),
+		Tables: []*model.TableInfo{
+			newTableInfo(tableID1, "t1", true),
+			newTableInfo(tableID2, "t2", false),
+		},
+	}
+	pStorage := newPersistentStorageForTest(dbPath, gcTs, databaseInfo)
+
+	// create table t3
+	{
+		job := &model.Job{
+			Type:     model.ActionCreateTable,
+			SchemaID: schemaID,
+			TableID:  tableID3,
+			BinlogInfo: &model.HistoryInfo{
+				SchemaVersion: 501,
+				TableInfo:     newTableInfo(tableID3, "t3", true),
+				FinishedTS:    601,
+			},
+		}
+		pStorage.handleDDLJob(job)
+	}
+
+	filterConfig := &config.FilterConfig{
+		Rules: []string{"test.*", "!test.t3"},
+	}
+	tableFilter, err := filter.NewFilter(filterConfig, "", false)
+	require.Nil(t, err)
+
+	{
+		tables, err := pStorage.verifyTables(600, tableFilter)
+		require.Nil(t, err)
+		require.ElementsMatch(t, []VerifiedTable{
+			{SchemaName: "test", TableName: "t1", TableID: tableID1, Eligible: true},
+			{SchemaName: "test", TableName: "t2", TableID: tableID2},
+		}, tables)
+	}
+
+	{
+		tables, err := pStorage.verifyTables(601, tableFilter)
+		require.Nil(t, err)
+		require.ElementsMatch(t, []VerifiedTable{
+			{SchemaName: "test", TableName: "t1", TableID: tableID1, Eligible: true},
+			{SchemaName: "test", TableName: "t2", TableID: tableID2},
+			{SchemaName: "test", TableName: "t3", TableID: tableID3, Filtered: true},
+		}, tables)
+	}
+
+	{
+		_, err := pStorage.verifyTables(599, tableFilter)
+		require.NotNil(t, err)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/security"
 	"github.com/tikv/client-go/v2/oracle"
 	"github.com/tikv/client-go/v2/tikv"
 	pd "github.com/tikv/pd/client"
@@ -25,6 +26,11 @@ type SchemaStore interface {
 
 	GetAllPhysicalTables(snapTs uint64, filter filter.Filter) ([]commonEvent.Table, error)
 
+	// VerifyTables returns all the tables at snapTs with whether they can be replicated,
+	// the tables ignored by the filter are returned with Filtered set.
+	// A partition table is returned once, it's used to check the tables before creating a changefeed.
+	VerifyTables(snapTs uint64, filter filter.Filter) ([]VerifiedTable, error)
+
 	RegisterTable(tableID int64, startTs uint64) error
 
 	UnregisterTable(tableID int64) error
@@ -48,6 +54,18 @@ type DDLEventState struct {
 	MaxEventCommitTs uint64
 }
 
+// VerifiedTable is a table with whether it can be replicated
+type VerifiedTable struct {
+	SchemaName  string
+	TableName   string
+	TableID     int64
+	IsPartition bool
+	// Eligible is true if the table has a primary key or a not null unique key
+	Eligible bool
+	// Filtered is true if the table is ignored by the filter
+	Filtered bool
+}
+
 type schemaStore struct {
 	pdClock pdutil.Clock
 
@@ -83,6 +101,7 @@ func New(
 	regionCache *tikv.RegionCache,
 	pdClock pdutil.Clock,
 	kvStorage kv.Storage,
+	credential *security.Credential,
 ) SchemaStore {
 	dataStorage := newPersistentStorage(ctx, root, pdCli, kvStorage)
 	upperBound := dataStorage.getUpperBound()
@@ -107,6 +126,7 @@ func New(
 		regionCache,
 		pdClock,
 		kvStorage,
+		credential,
 		upperBound.ResolvedTs,
 		s.writeDDLEvent,
 		s.advanceResolvedTs)
@@ -211,6 +231,11 @@ func (s *schemaStore) GetAllPhysicalTables(snapTs uint64, filter filter.Filter)
 	return s.dataStorage.getAllPhysicalTables(snapTs, filter)
 }
 
+func (s *schemaStore) VerifyTables(snapTs uint64, filter filter.Filter) ([]VerifiedTable, error) {
+	s.waitResolvedTs(0, snapTs, 10*time.Second)
+	return s.dataStorage.verifyTables(snapTs, filter)
+}
+
 func (s *schemaStore) RegisterTable(tableID int64, startTs uint64) error {
 	metrics.SchemaStoreResolvedRegisterTableGauge.Inc()
 	s.waitResolvedTs(tableID, startTs, 5*time.Second)
@@ -39,7 +39,7 @@ import (
 // 3. begin; select @@tidb_current_ts; and set it to snapTs;
 func TestBasicDDLJob(t *testing.T) {
 	ctx := context.Background()
-	upstreamManager := upstream.NewManager(ctx)
+	upstreamManager := upstream.NewManager(ctx, &security.Credential{})
 	pdEndpoints := []string{"http://127.0.0.1:2379"}
 	pdClient, err := pd.NewClientWithContext(
 		ctx, pdEndpoints, pd.SecurityOption{},
@@ -65,7 +65,7 @@ func TestBasicDDLJob(t *testing.T) {
 	upstream, err := upstreamManager.AddDefaultUpstream(pdEndpoints, &security.Credential{}, pdClient, etcdCli)
 	require.Nil(t, err)
 
-	schemaStore := New(ctx, "/tmp/cdc", upstream.PDClient, upstream.RegionCache, upstream.PDClock, upstream.KVStorage)
+	schemaStore := New(ctx, "/tmp/cdc", upstream.PDClient, upstream.RegionCache, upstream.PDClock, upstream.KVStorage, &security.Credential{})
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	go schemaStore.Run(ctx)
@@ -39,18 +39,26 @@ type Manager struct {
 	mu sync.Mutex
 
 	defaultUpstream *Upstream
+	// security is the credential of the cdc server, it is used by the
+	// upstreams which don't carry their own credential.
+	security *security.Credential
 
 	initUpstreamFunc func(context.Context, *Upstream) error
 }
 
 // NewManager creates a new Manager.
 // ctx will be used to initialize upstream spawned by this Manager.
-func NewManager(ctx context.Context) *Manager {
+// credential is the security config of the cdc server.
+func NewManager(ctx context.Context, credential *security.Credential) *Manager {
 	ctx, cancel := context.WithCancel(ctx)
+	if credential == nil {
+		credential = &security.Credential{}
+	}
 	return &Manager{
 		ups:              new(sync.Map),
 		ctx:              ctx,
 		cancel:           cancel,
+		security:         credential,
 		initUpstreamFunc: initUpstream,
 	}
 }
@@ -102,14 +110,15 @@ func (m *Manager) add(upstreamID uint64,
 		up.resetIdleTime()
 		return up
 	}
-	securityConf := &security.Credential{}
-	if conf != nil {
-		securityConf = &security.Credential{
-			CAPath:        conf.CAPath,
-			CertPath:      conf.CertPath,
-			KeyPath:       conf.KeyPath,
-			CertAllowedCN: conf.CertAllowedCN,
-		}
+	// fallback to the server credential if the upstream has no tls config
+	if conf == nil || conf.IsEmpty() {
+		conf = m.security
+	}
+	securityConf := &security.Credential{
+		CAPath:        conf.CAPath,
+		CertPath:      conf.CertPath,
+		KeyPath:       conf.KeyPath,
+		CertAllowedCN: conf.CertAllowedCN,
 	}
 	up := newUpstream(pdEndpoints, securityConf)
 	m.ups.Store(upstreamID, up)
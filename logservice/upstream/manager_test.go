@@ -0,0 +1,66 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package upstream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddUpstreamCredential(t *testing.T) {
+	serverCredential := &security.Credential{
+		CAPath:        "ca.pem",
+		CertPath:      "server.pem",
+		KeyPath:       "server-key.pem",
+		CertAllowedCN: []string{"server"},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the upstreams are not initialized, so they are not closed by the manager
+	m := NewManager(ctx, serverCredential)
+	m.initUpstreamFunc = func(context.Context, *Upstream) error { return nil }
+
+	// the upstream without credential uses the server credential
+	up := m.add(1, []string{"127.0.0.1:2379"}, nil)
+	require.Equal(t, serverCredential, up.SecurityConfig)
+	require.NotSame(t, serverCredential, up.SecurityConfig)
+	up = m.AddUpstream(&UpstreamInfo{ID: 2, PDEndpoints: "127.0.0.1:2379"})
+	require.Equal(t, serverCredential, up.SecurityConfig)
+
+	// the upstream credential is used if it's not empty
+	up = m.AddUpstream(&UpstreamInfo{
+		ID:          3,
+		PDEndpoints: "127.0.0.1:2379",
+		CAPath:      "upstream-ca.pem",
+		CertPath:    "upstream.pem",
+		KeyPath:     "upstream-key.pem",
+	})
+	require.Equal(t, &security.Credential{
+		CAPath:   "upstream-ca.pem",
+		CertPath: "upstream.pem",
+		KeyPath:  "upstream-key.pem",
+	}, up.SecurityConfig)
+
+	// the existing upstream is returned
+	require.Same(t, up, m.add(3, []string{"127.0.0.1:2379"}, nil))
+
+	// the manager without server credential creates insecure upstreams
+	m2 := NewManager(ctx, nil)
+	m2.initUpstreamFunc = m.initUpstreamFunc
+	up = m2.add(1, []string{"127.0.0.1:2379"}, nil)
+	require.True(t, up.SecurityConfig.IsEmpty())
+}
@@ -14,9 +14,12 @@
 package maintainer
 
 import (
+	"sync"
+
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/range_checker"
+	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
@@ -34,6 +37,8 @@ import (
 // 6. maintainer wait for all dispatchers reporting event(pass) done message
 // 7. maintainer clear the event
 type Barrier struct {
+	// mutex protects the blockedTs, it's read by the open api to inspect the pending block events
+	mutex             sync.Mutex
 	blockedTs         map[eventKey]*BarrierEvent
 	controller        *Controller
 	splitTableEnabled bool
@@ -58,6 +63,8 @@ func NewBarrier(controller *Controller, splitTableEnabled bool) *Barrier {
 // HandleStatus handle the block status from dispatcher manager
 func (b *Barrier) HandleStatus(from node.ID,
 	request *heartbeatpb.BlockStatusRequest) *messaging.TargetMessage {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
 	eventMap := make(map[*BarrierEvent][]*heartbeatpb.DispatcherID)
 	var dispatcherStatus []*heartbeatpb.DispatcherStatus
 	for _, status := range request.BlockStatuses {
@@ -94,6 +101,8 @@ func (b *Barrier) HandleStatus(from node.ID,
 
 // HandleBootstrapResponse rebuild the block event from the bootstrap response
 func (b *Barrier) HandleBootstrapResponse(bootstrapRespMap map[node.ID]*heartbeatpb.MaintainerBootstrapResponse) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
 	for _, resp := range bootstrapRespMap {
 		for _, span := range resp.Spans {
 			// we only care about the WAITING, WRITING and DONE stage
@@ -128,6 +137,8 @@ func (b *Barrier) HandleBootstrapResponse(bootstrapRespMap map[node.ID]*heartbea
 
 // Resend resends the message to the dispatcher manger, the pass action is handle here
 func (b *Barrier) Resend() []*messaging.TargetMessage {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
 	var msgs []*messaging.TargetMessage
 	for _, event := range b.blockedTs {
 		//todo: we can limit the number of messages to send in one round here
@@ -136,6 +147,43 @@ func (b *Barrier) Resend() []*messaging.TargetMessage {
 	return msgs
 }
 
+// GetBlockingDDLTs returns the commit ts of the earliest pending ddl event,
+// it returns 0 if there is no ddl event blocks the changefeed
+func (b *Barrier) GetBlockingDDLTs() uint64 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	blockingTs := uint64(0)
+	for key := range b.blockedTs {
+		if key.isSyncPoint {
+			continue
+		}
+		if blockingTs == 0 || key.blockTs < blockingTs {
+			blockingTs = key.blockTs
+		}
+	}
+	return blockingTs
+}
+
+// GetPendingEvents returns the earliest pending block event of each span,
+// the spans that are not blocked by any event are not in the result
+func (b *Barrier) GetPendingEvents(spans []*replica.SpanReplication) map[common.DispatcherID]*PendingBlockEvent {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	result := make(map[common.DispatcherID]*PendingBlockEvent)
+	for _, event := range b.blockedTs {
+		for _, span := range spans {
+			if !event.isBlocking(span) {
+				continue
+			}
+			if pending, ok := result[span.ID]; ok && pending.BlockTs <= event.commitTs {
+				continue
+			}
+			result[span.ID] = event.pendingEvent(span.ID)
+		}
+	}
+	return result
+}
+
 func (b *Barrier) handleOneStatus(changefeedID *heartbeatpb.ChangefeedID, status *heartbeatpb.TableSpanBlockStatus) *BarrierEvent {
 	cfID := common.NewChangefeedIDFromPB(changefeedID)
 	dispatcherID := common.NewDispatcherIDFromPB(status.ID)
@@ -14,6 +14,7 @@
 package maintainer
 
 import (
+	"slices"
 	"time"
 
 	"github.com/pingcap/log"
@@ -27,6 +28,22 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	// BlockEventStageWaiting means the maintainer is waiting for all influenced dispatchers to report the event
+	BlockEventStageWaiting = "waiting"
+	// BlockEventStageWriting means the selected dispatcher is writing the event to downstream
+	BlockEventStageWriting = "writing"
+	// BlockEventStagePassing means the event is written, the other dispatchers are passing it
+	BlockEventStagePassing = "passing"
+)
+
+// PendingBlockEvent is the snapshot of a block event that blocks a span
+type PendingBlockEvent struct {
+	BlockTs     uint64
+	IsSyncPoint bool
+	Stage       string
+}
+
 // BarrierEvent is a barrier event that reported by dispatchers, note is a block multiple dispatchers
 // all of these dispatchers should report the same event
 type BarrierEvent struct {
@@ -221,6 +238,39 @@ func (be *BarrierEvent) allDispatcherReported() bool {
 	return be.rangeChecker.IsFullyCovered()
 }
 
+// isBlocking checks if the span is influenced by the block event
+func (be *BarrierEvent) isBlocking(span *replica.SpanReplication) bool {
+	if be.blockedDispatchers == nil {
+		return false
+	}
+	switch be.blockedDispatchers.InfluenceType {
+	case heartbeatpb.InfluenceType_Normal:
+		return slices.Contains(be.blockedDispatchers.TableIDs, span.Span.TableID)
+	case heartbeatpb.InfluenceType_DB:
+		// the table trigger event dispatcher is always blocked by the db level event
+		return span.GetSchemaID() == be.blockedDispatchers.SchemaID ||
+			span.Span.Equal(heartbeatpb.DDLSpan)
+	default:
+		return true
+	}
+}
+
+// pendingEvent returns the snapshot of the event for the dispatcher
+func (be *BarrierEvent) pendingEvent(dispatcherID common.DispatcherID) *PendingBlockEvent {
+	stage := BlockEventStageWaiting
+	if be.selected {
+		stage = BlockEventStagePassing
+		if be.writerDispatcher == dispatcherID && !be.writerDispatcherAdvanced {
+			stage = BlockEventStageWriting
+		}
+	}
+	return &PendingBlockEvent{
+		BlockTs:     be.commitTs,
+		IsSyncPoint: be.isSyncPoint,
+		Stage:       stage,
+	}
+}
+
 func (be *BarrierEvent) sendPassAction() []*messaging.TargetMessage {
 	if be.blockedDispatchers == nil {
 		return []*messaging.TargetMessage{}
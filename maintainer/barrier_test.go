@@ -927,6 +927,70 @@ func TestNonBlocked(t *testing.T) {
 	require.Equal(t, 2, barrier.controller.replicationDB.GetAbsentSize(), 2)
 }
 
+func TestGetPendingEvents(t *testing.T) {
+	setNodeManagerAndMessageCenter()
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &mockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient, heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	controller := NewController(cfID, 1, nil, tsoClient,
+		nil, nil, nil, ddlSpan, 1000, 0)
+	controller.AddNewTable(commonEvent.Table{SchemaID: 1, TableID: 1}, 1)
+	controller.AddNewTable(commonEvent.Table{SchemaID: 2, TableID: 2}, 1)
+	absent := controller.replicationDB.GetAbsent(make([]*replica.SpanReplication, 0), 10)
+	for _, stm := range absent {
+		controller.replicationDB.BindSpanToNode("", "node1", stm)
+		controller.replicationDB.MarkSpanReplicating(stm)
+	}
+	stm1 := controller.GetTasksByTableIDs(1)[0]
+	stm2 := controller.GetTasksByTableIDs(2)[0]
+
+	barrier := NewBarrier(controller, false)
+	require.Empty(t, barrier.GetPendingEvents(controller.GetAllTasks()))
+	require.Zero(t, barrier.GetBlockingDDLTs())
+
+	newBlockStatus := func(id common.DispatcherID) *heartbeatpb.TableSpanBlockStatus {
+		return &heartbeatpb.TableSpanBlockStatus{
+			ID: id.ToPB(),
+			State: &heartbeatpb.State{
+				IsBlocked: true,
+				BlockTs:   10,
+				BlockTables: &heartbeatpb.InfluencedTables{
+					InfluenceType: heartbeatpb.InfluenceType_DB,
+					SchemaID:      1,
+				},
+			},
+		}
+	}
+	// the table 1 reported the db level event, waiting for the table trigger event dispatcher
+	barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
+		ChangefeedID:  cfID.ToPB(),
+		BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{newBlockStatus(stm1.ID)},
+	})
+	pending := barrier.GetPendingEvents(controller.GetAllTasks())
+	require.Len(t, pending, 2)
+	require.Equal(t, &PendingBlockEvent{BlockTs: 10, Stage: BlockEventStageWaiting}, pending[stm1.ID])
+	require.Equal(t, &PendingBlockEvent{BlockTs: 10, Stage: BlockEventStageWaiting}, pending[tableTriggerEventDispatcherID])
+	require.Nil(t, pending[stm2.ID])
+	require.Equal(t, uint64(10), barrier.GetBlockingDDLTs())
+
+	// all dispatchers reported, the table trigger event dispatcher is selected to write the event
+	barrier.HandleStatus("node1", &heartbeatpb.BlockStatusRequest{
+		ChangefeedID:  cfID.ToPB(),
+		BlockStatuses: []*heartbeatpb.TableSpanBlockStatus{newBlockStatus(tableTriggerEventDispatcherID)},
+	})
+	pending = barrier.GetPendingEvents(controller.GetAllTasks())
+	require.Len(t, pending, 2)
+	require.Equal(t, BlockEventStagePassing, pending[stm1.ID].Stage)
+	require.Equal(t, BlockEventStageWriting, pending[tableTriggerEventDispatcherID].Stage)
+}
+
 func TestUpdateCheckpointTs(t *testing.T) {
 	setNodeManagerAndMessageCenter()
 	tableTriggerEventDispatcherID := common.NewDispatcherID()
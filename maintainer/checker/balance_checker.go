@@ -14,37 +14,318 @@
 package checker
 
 import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/server/watcher"
+	"go.uber.org/zap"
 )
 
-// BalanceChecker is used to check the balance status of all spans among all nodes
+// BalanceChecker is used to check the balance status of all spans among all nodes.
+// It balances the span count first, and then the write throughput of the nodes,
+// spans are moved gradually, the moves in flight are limited by maxMovingSpans,
+// and a span can't be moved again until the cool down duration is passed.
 type BalanceChecker struct {
 	changefeedID       common.ChangeFeedID
 	operatorController *operator.Controller
 	replicationDB      *replica.ReplicationDB
 	nodeManager        *watcher.NodeManager
+	// isTablePinned returns true if the table is pinned to a node by the move table requests,
+	// the spans of the pinned tables are not moved by the checker
+	isTablePinned func(tableID int64) bool
+
+	checkInterval time.Duration
+	lastCheckTime time.Time
+
+	// maxMovingSpans is the max number of moves issued by the checker running at the same time
+	maxMovingSpans int
+	// coolDown is the min interval between two moves of the same span
+	coolDown time.Duration
+	// throughputTolerance is the ratio a node's write throughput can exceed the average
+	// before it's considered as overloaded
+	throughputTolerance float64
+
+	// moving holds the spans moved by the checker and not finished yet, the value is the dest node
+	moving map[common.DispatcherID]node.ID
+	// lastMoved holds the last time the span is moved by the checker
+	lastMoved map[common.DispatcherID]time.Time
 }
 
 func NewBalanceChecker(
 	changefeedID common.ChangeFeedID,
 	oc *operator.Controller,
 	db *replica.ReplicationDB,
-	nodeManager *watcher.NodeManager) *BalanceChecker {
+	nodeManager *watcher.NodeManager,
+	isTablePinned func(tableID int64) bool) *BalanceChecker {
 	return &BalanceChecker{
 		changefeedID:       changefeedID,
 		operatorController: oc,
 		replicationDB:      db,
 		nodeManager:        nodeManager,
+		isTablePinned:      isTablePinned,
+
+		checkInterval:       time.Second * 30,
+		maxMovingSpans:      8,
+		coolDown:            time.Minute * 10,
+		throughputTolerance: 0.2,
+		moving:              make(map[common.DispatcherID]node.ID),
+		lastMoved:           make(map[common.DispatcherID]time.Time),
 	}
 }
 
+func (b *BalanceChecker) Name() string {
+	return "balance-checker"
+}
+
 func (b *BalanceChecker) Check() {
+	if b.operatorController == nil || b.replicationDB == nil || b.nodeManager == nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(b.lastCheckTime) < b.checkInterval {
+		return
+	}
+	b.lastCheckTime = now
+	b.cleanup(now)
+
+	// the absent spans are handled by the scheduler first
+	if b.replicationDB.GetAbsentSize() > 0 {
+		return
+	}
+	// other operators are running, not in stable schedule state, skip balance
+	if b.operatorController.OperatorSize() > len(b.moving) {
+		return
+	}
+	quota := b.maxMovingSpans - len(b.moving)
+	if quota <= 0 {
+		return
+	}
+	loads := b.collectNodeLoads(now)
+	if len(loads) < 2 {
+		return
+	}
 
+	for quota > 0 {
+		src, dst := pickBySpanCount(loads)
+		if src.spanCount-dst.spanCount > 1 {
+			idx := src.pickCandidate(math.Max(0, (src.throughput-dst.throughput)/2), math.Inf(1))
+			if idx < 0 || !b.move(src, dst, idx, now) {
+				// don't balance the throughput when the span count is not balanced,
+				// or the span count can be skewed more
+				return
+			}
+			quota--
+			continue
+		}
+		moved := b.balanceThroughput(loads, quota, now)
+		if moved == 0 {
+			return
+		}
+		quota -= moved
+	}
 }
 
-func (b *BalanceChecker) Name() string {
-	return "balance-checker"
+// balanceThroughput moves the spans from the node with the highest write throughput to
+// the node with the lowest write throughput, and keeps the span count balanced.
+// It returns the number of the moves issued.
+func (b *BalanceChecker) balanceThroughput(loads []*nodeLoad, quota int, now time.Time) int {
+	src, dst := pickByThroughput(loads)
+	total := 0.0
+	for _, load := range loads {
+		total += load.throughput
+	}
+	avg := total / float64(len(loads))
+	if avg <= 0 || src.throughput <= avg*(1+b.throughputTolerance) {
+		return 0
+	}
+	gap := src.throughput - dst.throughput
+	// the src node has more spans, move one span to the dst node
+	if src.spanCount > dst.spanCount {
+		idx := src.pickCandidate(gap/2, gap)
+		if idx < 0 || !b.move(src, dst, idx, now) {
+			return 0
+		}
+		return 1
+	}
+	// swap a heavy span of the src node with the lightest span of the dst node,
+	// so the span count is not changed
+	if quota < 2 {
+		return 0
+	}
+	lightIdx := dst.pickCandidate(0, math.Inf(1))
+	if lightIdx < 0 {
+		return 0
+	}
+	light := dst.candidates[lightIdx]
+	lightThroughput := getThroughput(light)
+	heavyIdx := src.pickCandidate(lightThroughput+gap/2, lightThroughput+gap)
+	if heavyIdx < 0 || getThroughput(src.candidates[heavyIdx]) <= lightThroughput {
+		return 0
+	}
+	if !b.move(src, dst, heavyIdx, now) {
+		return 0
+	}
+	if !b.move(dst, src, lightIdx, now) {
+		return 1
+	}
+	return 2
+}
+
+// move adds a move operator to move the candidate span at idx from the src node to the dst node
+func (b *BalanceChecker) move(src, dst *nodeLoad, idx int, now time.Time) bool {
+	span := src.candidates[idx]
+	if !b.operatorController.AddOperator(
+		operator.NewMoveDispatcherOperator(b.replicationDB, span, src.id, dst.id)) {
+		return false
+	}
+	throughput := getThroughput(span)
+	log.Info("balance checker moves span",
+		zap.String("changefeed", b.changefeedID.Name()),
+		zap.String("span", span.ID.String()),
+		zap.String("origin", src.id.String()),
+		zap.String("dest", dst.id.String()),
+		zap.Int("originSpanCount", src.spanCount),
+		zap.Int("destSpanCount", dst.spanCount),
+		zap.Float64("spanThroughput", throughput))
+
+	b.moving[span.ID] = dst.id
+	b.lastMoved[span.ID] = now
+	src.candidates = append(src.candidates[:idx], src.candidates[idx+1:]...)
+	src.spanCount--
+	src.throughput -= throughput
+	dst.spanCount++
+	dst.throughput += throughput
+	return true
+}
+
+// cleanup removes the finished moves and the expired cool down records
+func (b *BalanceChecker) cleanup(now time.Time) {
+	for id := range b.moving {
+		if b.operatorController.GetOperator(id) == nil {
+			delete(b.moving, id)
+		}
+	}
+	for id, movedTime := range b.lastMoved {
+		if now.Sub(movedTime) >= b.coolDown {
+			delete(b.lastMoved, id)
+		}
+	}
+}
+
+// collectNodeLoads returns the loads of all schedulable nodes, the spans being moved
+// by the checker are counted to the dest node. The draining nodes are skipped,
+// their spans are moved out by the scheduler.
+func (b *BalanceChecker) collectNodeLoads(now time.Time) []*nodeLoad {
+	loadMap := make(map[node.ID]*nodeLoad)
+	for id := range b.nodeManager.GetSchedulableNodes() {
+		loadMap[id] = &nodeLoad{id: id}
+	}
+	for _, span := range b.replicationDB.GetReplicating() {
+		load, ok := loadMap[span.GetNodeID()]
+		if !ok {
+			continue
+		}
+		load.spanCount++
+		load.throughput += getThroughput(span)
+		if b.canMove(span, now) {
+			load.candidates = append(load.candidates, span)
+		}
+	}
+	for id, dest := range b.moving {
+		span := b.replicationDB.GetTaskByID(id)
+		load, ok := loadMap[dest]
+		if span == nil || !ok {
+			continue
+		}
+		load.spanCount++
+		load.throughput += getThroughput(span)
+	}
+
+	loads := make([]*nodeLoad, 0, len(loadMap))
+	for _, load := range loadMap {
+		loads = append(loads, load)
+	}
+	sort.Slice(loads, func(i, j int) bool {
+		return loads[i].id < loads[j].id
+	})
+	return loads
+}
+
+func (b *BalanceChecker) canMove(span *replica.SpanReplication, now time.Time) bool {
+	if b.operatorController.GetOperator(span.ID) != nil {
+		return false
+	}
+	if b.isTablePinned != nil && b.isTablePinned(span.Span.TableID) {
+		return false
+	}
+	movedTime, ok := b.lastMoved[span.ID]
+	return !ok || now.Sub(movedTime) >= b.coolDown
+}
+
+// nodeLoad is the load of a node
+type nodeLoad struct {
+	id         node.ID
+	spanCount  int
+	throughput float64
+	// candidates are the spans can be moved to other nodes
+	candidates []*replica.SpanReplication
+}
+
+// pickCandidate returns the index of the candidate whose throughput is closest to the target
+// and less than the upper bound, returns -1 if no candidate is found
+func (l *nodeLoad) pickCandidate(target, upper float64) int {
+	picked := -1
+	minDiff := math.Inf(1)
+	for i, span := range l.candidates {
+		throughput := getThroughput(span)
+		if throughput >= upper {
+			continue
+		}
+		if diff := math.Abs(throughput - target); diff < minDiff {
+			picked = i
+			minDiff = diff
+		}
+	}
+	return picked
+}
+
+// pickBySpanCount returns the nodes with the most and the least spans,
+// the write throughput is used to break the tie
+func pickBySpanCount(loads []*nodeLoad) (*nodeLoad, *nodeLoad) {
+	src, dst := loads[0], loads[0]
+	for _, load := range loads[1:] {
+		if load.spanCount > src.spanCount ||
+			(load.spanCount == src.spanCount && load.throughput > src.throughput) {
+			src = load
+		}
+		if load.spanCount < dst.spanCount ||
+			(load.spanCount == dst.spanCount && load.throughput < dst.throughput) {
+			dst = load
+		}
+	}
+	return src, dst
+}
+
+// pickByThroughput returns the nodes with the highest and the lowest write throughput
+func pickByThroughput(loads []*nodeLoad) (*nodeLoad, *nodeLoad) {
+	src, dst := loads[0], loads[0]
+	for _, load := range loads[1:] {
+		if load.throughput > src.throughput {
+			src = load
+		}
+		if load.throughput < dst.throughput {
+			dst = load
+		}
+	}
+	return src, dst
+}
+
+func getThroughput(span *replica.SpanReplication) float64 {
+	return float64(span.GetStatus().GetEventSizePerSecond())
 }
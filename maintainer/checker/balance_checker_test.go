@@ -0,0 +1,214 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/stretchr/testify/require"
+)
+
+func newBalanceCheckerForTest(nodes ...node.ID) *BalanceChecker {
+	cfID := common.NewChangeFeedIDWithName("test")
+	ddlDispatcherID := common.NewDispatcherID()
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, ddlDispatcherID, nil,
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan,
+		&heartbeatpb.TableSpanStatus{
+			ID:              ddlDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db := replica.NewReplicaSetDB(cfID, ddlSpan)
+	oc := operator.NewOperatorController(cfID, nil, db, 1000)
+	nodeManager := watcher.NewNodeManager(nil, nil)
+	for _, id := range nodes {
+		nodeManager.GetAliveNodes()[id] = &node.Info{ID: id}
+	}
+	b := NewBalanceChecker(cfID, oc, db, nodeManager, nil)
+	b.checkInterval = 0
+	return b
+}
+
+func addReplicatingSpan(b *BalanceChecker, tableID int64, nodeID node.ID, throughput float32) *replica.SpanReplication {
+	id := common.NewDispatcherID()
+	span := replica.NewWorkingReplicaSet(b.changefeedID, id, &mockTsoClient{}, 1,
+		&heartbeatpb.TableSpan{TableID: tableID},
+		&heartbeatpb.TableSpanStatus{
+			ID:                 id.ToPB(),
+			ComponentStatus:    heartbeatpb.ComponentState_Working,
+			CheckpointTs:       1,
+			EventSizePerSecond: throughput,
+		}, nodeID)
+	b.replicationDB.AddReplicatingSpan(span)
+	return span
+}
+
+type mockTsoClient struct{}
+
+func (m *mockTsoClient) GetTS(_ context.Context) (int64, int64, error) {
+	return 0, 0, nil
+}
+
+// finishMoves finishes all the moves issued by the checker
+func finishMoves(t *testing.T, b *BalanceChecker) {
+	for id, dest := range b.moving {
+		span := b.replicationDB.GetTaskByID(id)
+		op := b.operatorController.GetOperator(id)
+		require.NotNil(t, op)
+		op.Check(span.GetNodeID(), &heartbeatpb.TableSpanStatus{
+			ID:              id.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Stopped,
+		})
+		op.Schedule()
+		op.Check(dest, &heartbeatpb.TableSpanStatus{
+			ID:              id.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+		})
+		require.True(t, op.IsFinished())
+	}
+	b.operatorController.Execute()
+	require.Equal(t, 0, b.operatorController.OperatorSize())
+}
+
+func TestBalanceCheckerBalanceSpanCount(t *testing.T) {
+	b := newBalanceCheckerForTest("node1", "node2")
+	b.maxMovingSpans = 3
+	for i := 0; i < 10; i++ {
+		addReplicatingSpan(b, int64(i), "node1", 0)
+	}
+
+	// the moves are limited by the max moving spans
+	b.Check()
+	require.Equal(t, 3, b.operatorController.OperatorSize())
+	require.Len(t, b.moving, 3)
+	b.Check()
+	require.Equal(t, 3, b.operatorController.OperatorSize())
+
+	finishMoves(t, b)
+	require.Equal(t, 7, b.replicationDB.GetTaskSizeByNodeID("node1"))
+	require.Equal(t, 3, b.replicationDB.GetTaskSizeByNodeID("node2"))
+
+	b.Check()
+	require.Equal(t, 2, b.operatorController.OperatorSize())
+	finishMoves(t, b)
+	require.Equal(t, 5, b.replicationDB.GetTaskSizeByNodeID("node1"))
+	require.Equal(t, 5, b.replicationDB.GetTaskSizeByNodeID("node2"))
+
+	// balanced, no more moves
+	b.Check()
+	require.Equal(t, 0, b.operatorController.OperatorSize())
+}
+
+func TestBalanceCheckerBalanceThroughput(t *testing.T) {
+	b := newBalanceCheckerForTest("node1", "node2")
+	hot := addReplicatingSpan(b, 1, "node1", 80)
+	addReplicatingSpan(b, 2, "node1", 150)
+	addReplicatingSpan(b, 3, "node1", 10)
+	light := addReplicatingSpan(b, 4, "node2", 10)
+	addReplicatingSpan(b, 5, "node2", 20)
+	addReplicatingSpan(b, 6, "node2", 20)
+
+	// the span count is balanced, swap a hot span with a light span
+	b.Check()
+	require.Equal(t, 2, b.operatorController.OperatorSize())
+	require.Equal(t, node.ID("node2"), b.moving[hot.ID])
+	require.Equal(t, node.ID("node1"), b.moving[light.ID])
+	finishMoves(t, b)
+	require.Equal(t, 3, b.replicationDB.GetTaskSizeByNodeID("node1"))
+	require.Equal(t, 3, b.replicationDB.GetTaskSizeByNodeID("node2"))
+
+	// node1: 150+10+10, node2: 80+20+20, within the tolerance
+	b.Check()
+	require.Equal(t, 0, b.operatorController.OperatorSize())
+}
+
+func TestBalanceCheckerBalanceThroughputWithTies(t *testing.T) {
+	// the spans with the same throughput are picked in the span order,
+	// so the checker issues the same moves every time
+	for i := 0; i < 10; i++ {
+		b := newBalanceCheckerForTest("node1", "node2")
+		hot := addReplicatingSpan(b, 1, "node1", 100)
+		addReplicatingSpan(b, 2, "node1", 100)
+		addReplicatingSpan(b, 3, "node1", 10)
+		light := addReplicatingSpan(b, 4, "node2", 10)
+		addReplicatingSpan(b, 5, "node2", 10)
+		addReplicatingSpan(b, 6, "node2", 10)
+
+		b.Check()
+		require.Equal(t, 2, b.operatorController.OperatorSize())
+		require.Equal(t, node.ID("node2"), b.moving[hot.ID])
+		require.Equal(t, node.ID("node1"), b.moving[light.ID])
+		finishMoves(t, b)
+
+		// node1: 100+10+10, node2: 100+10+10, balanced
+		b.Check()
+		require.Equal(t, 0, b.operatorController.OperatorSize())
+	}
+}
+
+func TestBalanceCheckerCoolDown(t *testing.T) {
+	b := newBalanceCheckerForTest("node1", "node2")
+	span1 := addReplicatingSpan(b, 1, "node1", 0)
+	span2 := addReplicatingSpan(b, 2, "node1", 0)
+	b.lastMoved[span1.ID] = time.Now()
+	b.lastMoved[span2.ID] = time.Now()
+
+	// all spans are in the cool down duration
+	b.Check()
+	require.Equal(t, 0, b.operatorController.OperatorSize())
+
+	// the cool down duration of span2 is passed
+	b.lastMoved[span2.ID] = time.Now().Add(-b.coolDown)
+	b.Check()
+	require.Equal(t, 1, b.operatorController.OperatorSize())
+	require.Nil(t, b.operatorController.GetOperator(span1.ID))
+	require.Equal(t, node.ID("node2"), b.moving[span2.ID])
+}
+
+func TestBalanceCheckerSkipDrainingNodesAndPinnedTables(t *testing.T) {
+	b := newBalanceCheckerForTest("node1", "node2", "node3")
+	for i := 0; i < 6; i++ {
+		addReplicatingSpan(b, int64(i), "node1", 0)
+	}
+	// table 0 and 1 are pinned to node1 by the move table requests
+	b.isTablePinned = func(tableID int64) bool {
+		return tableID < 2
+	}
+	require.True(t, b.nodeManager.DrainNode("node3"))
+
+	// the draining node gets no spans, and the pinned tables are not moved
+	b.Check()
+	finishMoves(t, b)
+	require.Equal(t, 3, b.replicationDB.GetTaskSizeByNodeID("node1"))
+	require.Equal(t, 3, b.replicationDB.GetTaskSizeByNodeID("node2"))
+	require.Equal(t, 0, b.replicationDB.GetTaskSizeByNodeID("node3"))
+	for _, span := range b.replicationDB.GetTaskByNodeID("node2") {
+		require.GreaterOrEqual(t, span.Span.TableID, int64(2))
+	}
+
+	// the spans on the draining node are not moved by the checker
+	for i := 6; i < 12; i++ {
+		addReplicatingSpan(b, int64(i), "node3", 0)
+	}
+	b.Check()
+	require.Equal(t, 0, b.operatorController.OperatorSize())
+}
@@ -14,6 +14,7 @@
 package checker
 
 import (
+	"context"
 	"time"
 
 	"github.com/pingcap/ticdc/maintainer/operator"
@@ -31,6 +32,10 @@ type Controller struct {
 	replicationDB      *replica.ReplicationDB
 	nodeManager        *watcher.NodeManager
 
+	// ctx is canceled when the controller is stopped, so the checkers stop the running region scans
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	maxTimePerRound time.Duration
 	checkers        []Checker
 	checkedIndex    int
@@ -40,17 +45,22 @@ func NewController(changefeedID common.ChangeFeedID,
 	splitter *split.Splitter,
 	oc *operator.Controller,
 	db *replica.ReplicationDB,
-	nodeManager *watcher.NodeManager) *Controller {
+	nodeManager *watcher.NodeManager,
+	isTablePinned func(tableID int64) bool) *Controller {
+	ctx, cancel := context.WithCancel(context.Background())
 	c := &Controller{
 		changefeedID:       changefeedID,
 		operatorController: oc,
 		replicationDB:      db,
 		nodeManager:        nodeManager,
+		ctx:                ctx,
+		cancel:             cancel,
 		maxTimePerRound:    time.Second * 5,
 	}
 	c.checkers = []Checker{
 		NewSplitChecker(changefeedID, splitter, oc, db, nodeManager),
-		NewBalanceChecker(changefeedID, oc, db, nodeManager),
+		NewBalanceChecker(changefeedID, oc, db, nodeManager, isTablePinned),
+		NewMergeChecker(ctx, changefeedID, splitter, oc, db, nodeManager),
 	}
 	return c
 }
@@ -74,3 +84,8 @@ func (ctl *Controller) Execute() time.Time {
 	}
 	return time.Now().Add(time.Second * 5)
 }
+
+// Stop cancels the running checks, it's called when the maintainer is closed
+func (ctl *Controller) Stop() {
+	ctl.cancel()
+}
@@ -22,8 +22,8 @@ import (
 )
 
 func TestControllerExecute(t *testing.T) {
-	ctl := NewController(common.NewChangeFeedIDWithName("test"), nil, nil, nil, nil)
-	require.Equal(t, 2, len(ctl.checkers))
+	ctl := NewController(common.NewChangeFeedIDWithName("test"), nil, nil, nil, nil, nil)
+	require.Equal(t, 3, len(ctl.checkers))
 	ctl.maxTimePerRound = time.Hour
 	ctl.Execute()
 	require.Equal(t, 0, ctl.checkedIndex)
@@ -32,5 +32,11 @@ func TestControllerExecute(t *testing.T) {
 	ctl.Execute()
 	require.Equal(t, 1, ctl.checkedIndex)
 	ctl.Execute()
+	require.Equal(t, 2, ctl.checkedIndex)
+	ctl.Execute()
 	require.Equal(t, 0, ctl.checkedIndex)
+
+	// the running checks are canceled after the controller is stopped
+	ctl.Stop()
+	require.Error(t, ctl.checkers[2].(*MergeChecker).ctx.Err())
 }
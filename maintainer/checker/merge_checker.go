@@ -0,0 +1,186 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/operator"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/maintainer/split"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/server/watcher"
+	"github.com/pingcap/ticdc/utils"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"go.uber.org/zap"
+)
+
+// MergeChecker is used to merge the contiguous low traffic spans of a table into one span
+type MergeChecker struct {
+	ctx          context.Context
+	changefeedID common.ChangeFeedID
+	splitter     *split.Splitter
+	opController *operator.Controller
+	db           *replica.ReplicationDB
+	nodeManager  *watcher.NodeManager
+
+	checkInterval time.Duration
+	lastCheckTime time.Time
+
+	// mergeThreshold is the max write throughput(bytes/s) of the merged span
+	mergeThreshold float64
+	// maxSpansPerMerge is the max number of spans merged by one operator
+	maxSpansPerMerge int
+}
+
+func NewMergeChecker(
+	ctx context.Context,
+	changefeedID common.ChangeFeedID,
+	splitter *split.Splitter,
+	opController *operator.Controller,
+	db *replica.ReplicationDB,
+	nodeManager *watcher.NodeManager) *MergeChecker {
+	return &MergeChecker{
+		ctx:          ctx,
+		changefeedID: changefeedID,
+		splitter:     splitter,
+		opController: opController,
+		db:           db,
+		nodeManager:  nodeManager,
+
+		checkInterval:    time.Second * 120,
+		mergeThreshold:   1024 * 1024,
+		maxSpansPerMerge: 32,
+	}
+}
+
+func (m *MergeChecker) Name() string {
+	return "merge-checker"
+}
+
+func (m *MergeChecker) Check() {
+	if m.splitter == nil {
+		return
+	}
+	if time.Since(m.lastCheckTime) < m.checkInterval {
+		return
+	}
+	m.lastCheckTime = time.Now()
+
+	tables := make(map[int64][]*replica.SpanReplication)
+	for _, span := range m.db.GetReplicating() {
+		tables[span.Span.TableID] = append(tables[span.Span.TableID], span)
+	}
+	for tableID, spans := range tables {
+		if len(spans) < 2 {
+			continue
+		}
+		// some spans of the table are not replicating, wait for the next round
+		if len(m.db.GetTasksByTableIDs(tableID)) != len(spans) {
+			continue
+		}
+		tableMap := utils.NewBtreeMap[*heartbeatpb.TableSpan, *replica.SpanReplication](heartbeatpb.LessTableSpan)
+		for _, span := range spans {
+			tableMap.ReplaceOrInsert(span.Span, span)
+		}
+		totalSpan := spanz.TableIDToComparableSpan(tableID)
+		tableSpan := &heartbeatpb.TableSpan{
+			TableID:  tableID,
+			StartKey: totalSpan.StartKey,
+			EndKey:   totalSpan.EndKey,
+		}
+		// the table is not fully covered, don't merge it
+		if holes := split.FindHoles(tableMap, tableSpan); len(holes) > 0 {
+			log.Warn("table is not fully covered by spans, skip merge",
+				zap.String("changefeed", m.changefeedID.Name()),
+				zap.Int64("table", tableID),
+				zap.Int("holes", len(holes)))
+			continue
+		}
+		sort.Slice(spans, func(i, j int) bool {
+			return bytes.Compare(spans[i].Span.StartKey, spans[j].Span.StartKey) < 0
+		})
+		for _, group := range m.findMergeGroups(spans) {
+			m.merge(group)
+		}
+	}
+}
+
+// findMergeGroups returns the contiguous spans can be merged, the spans must be sorted by the start key
+func (m *MergeChecker) findMergeGroups(spans []*replica.SpanReplication) [][]*replica.SpanReplication {
+	var (
+		groups     [][]*replica.SpanReplication
+		group      []*replica.SpanReplication
+		throughput float64
+	)
+	flush := func() {
+		if len(group) > 1 {
+			groups = append(groups, group)
+		}
+		group = nil
+		throughput = 0
+	}
+	for _, span := range spans {
+		spanThroughput := getThroughput(span)
+		if m.opController.GetOperator(span.ID) != nil || spanThroughput >= m.mergeThreshold {
+			flush()
+			continue
+		}
+		if len(group) >= m.maxSpansPerMerge || throughput+spanThroughput >= m.mergeThreshold ||
+			(len(group) > 0 && !bytes.Equal(group[len(group)-1].Span.EndKey, span.Span.StartKey)) {
+			flush()
+		}
+		group = append(group, span)
+		throughput += spanThroughput
+	}
+	flush()
+	return groups
+}
+
+// merge adds the operators to merge the spans in the group
+func (m *MergeChecker) merge(group []*replica.SpanReplication) {
+	mergedSpan := &heartbeatpb.TableSpan{
+		TableID:  group[0].Span.TableID,
+		StartKey: group[0].Span.StartKey,
+		EndKey:   group[len(group)-1].Span.EndKey,
+	}
+	// the merged span will be split again by the split checker, skip it
+	if len(m.splitter.SplitSpans(m.ctx, mergedSpan, len(m.nodeManager.GetAliveNodes()))) > 1 {
+		return
+	}
+	op := operator.NewMergeDispatcherOperator(m.db, group)
+	// occupy the spans first, so no other operator can be added to them
+	occupied := 0
+	for _, occupy := range op.GetOccupyOperators() {
+		if !m.opController.AddOperator(occupy) {
+			break
+		}
+		occupied++
+	}
+	if occupied != len(group)-1 || !m.opController.AddOperator(op) {
+		// the added occupy operators will be finished with the merge operator
+		op.Cancel()
+		return
+	}
+	log.Info("merge spans",
+		zap.String("changefeed", m.changefeedID.Name()),
+		zap.Int64("table", mergedSpan.TableID),
+		zap.String("span", op.ID().String()),
+		zap.Int("spanSize", len(group)))
+}
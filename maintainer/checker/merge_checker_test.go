@@ -0,0 +1,165 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/maintainer/split"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/config"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/pkg/spanz"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikv"
+)
+
+// mockRegionCache reports one region for any key range, so no span is split by region count
+type mockRegionCache struct{}
+
+func (m *mockRegionCache) ListRegionIDsInKeyRange(
+	_ *tikv.Backoffer, _, _ []byte,
+) ([]uint64, error) {
+	return []uint64{1}, nil
+}
+
+func (m *mockRegionCache) LocateRegionByID(_ *tikv.Backoffer, _ uint64) (*tikv.KeyLocation, error) {
+	return nil, nil
+}
+
+func newMergeCheckerForTest(nodes ...node.ID) *MergeChecker {
+	b := newBalanceCheckerForTest(nodes...)
+	splitter := split.NewSplitter(b.changefeedID, nil, &mockRegionCache{},
+		&config.ChangefeedSchedulerConfig{EnableTableAcrossNodes: true, RegionThreshold: 10})
+	m := NewMergeChecker(context.Background(), b.changefeedID, splitter, b.operatorController, b.replicationDB, b.nodeManager)
+	m.checkInterval = 0
+	return m
+}
+
+// addTableSpans splits the table into the spans at the given keys, and adds them as replicating spans
+func addTableSpans(m *MergeChecker, tableID int64, keys []byte, nodes []node.ID, throughputs []float32) []*replica.SpanReplication {
+	totalSpan := spanz.TableIDToComparableSpan(tableID)
+	start := totalSpan.StartKey
+	spans := make([]*replica.SpanReplication, 0, len(nodes))
+	for i := range nodes {
+		end := totalSpan.EndKey
+		if i < len(keys) {
+			end = append(bytes.Clone(totalSpan.StartKey), keys[i])
+		}
+		id := common.NewDispatcherID()
+		span := replica.NewWorkingReplicaSet(m.changefeedID, id, &mockTsoClient{}, 1,
+			&heartbeatpb.TableSpan{TableID: tableID, StartKey: start, EndKey: end},
+			&heartbeatpb.TableSpanStatus{
+				ID:                 id.ToPB(),
+				ComponentStatus:    heartbeatpb.ComponentState_Working,
+				CheckpointTs:       1,
+				EventSizePerSecond: throughputs[i],
+			}, nodes[i])
+		m.db.AddReplicatingSpan(span)
+		spans = append(spans, span)
+		start = end
+	}
+	return spans
+}
+
+func TestMergeCheckerMergeSpans(t *testing.T) {
+	m := newMergeCheckerForTest("node1", "node2")
+	spans := addTableSpans(m, 1, []byte{'a', 'b'},
+		[]node.ID{"node1", "node2", "node1"}, []float32{10, 10, 10})
+	// the second span of table 2 is hot, can't be merged
+	addTableSpans(m, 2, []byte{'a'},
+		[]node.ID{"node1", "node2"}, []float32{10, 10 * 1024 * 1024})
+
+	m.Check()
+	// one merge operator and two occupy operators
+	require.Equal(t, 3, m.opController.OperatorSize())
+	require.Equal(t, 3, m.db.GetSchedulingSize())
+	op := m.opController.GetOperator(spans[0].ID)
+	require.Equal(t, "merge", op.Type())
+	require.Equal(t, "occupy", m.opController.GetOperator(spans[1].ID).Type())
+
+	// remove the dispatchers one by one
+	for _, span := range spans {
+		msg := op.Schedule()
+		require.Equal(t, span.GetNodeID(), msg.To)
+		req := msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
+		require.Equal(t, heartbeatpb.ScheduleAction_Remove, req.ScheduleAction)
+		require.Equal(t, span.ID.ToPB(), req.Config.DispatcherID)
+	}
+
+	checkpoints := []uint64{10, 8, 12}
+	for i, span := range spans {
+		require.False(t, op.IsFinished())
+		m.opController.UpdateOperatorStatus(span.ID, span.GetNodeID(), &heartbeatpb.TableSpanStatus{
+			ID:              span.ID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Stopped,
+			CheckpointTs:    checkpoints[i],
+		})
+	}
+	require.True(t, op.IsFinished())
+	require.Nil(t, op.Schedule())
+	m.opController.Execute()
+	require.Equal(t, 0, m.opController.OperatorSize())
+
+	// the merged span covers the whole table, and starts from the min checkpointTs
+	tasks := m.db.GetTasksByTableIDs(1)
+	require.Len(t, tasks, 1)
+	totalSpan := spanz.TableIDToComparableSpan(1)
+	require.Equal(t, []byte(totalSpan.StartKey), tasks[0].Span.StartKey)
+	require.Equal(t, []byte(totalSpan.EndKey), tasks[0].Span.EndKey)
+	require.Equal(t, uint64(8), tasks[0].GetStatus().CheckpointTs)
+	require.Equal(t, 1, m.db.GetAbsentSize())
+	require.Len(t, m.db.GetTasksByTableIDs(2), 2)
+}
+
+func TestMergeCheckerNodeRemoved(t *testing.T) {
+	m := newMergeCheckerForTest("node1", "node2")
+	spans := addTableSpans(m, 1, []byte{'a'},
+		[]node.ID{"node1", "node2"}, []float32{0, 0})
+	m.Check()
+	require.Equal(t, 2, m.opController.OperatorSize())
+	op := m.opController.GetOperator(spans[0].ID)
+
+	// the occupied span on the removed node is not marked as absent
+	m.opController.OnNodeRemoved("node2")
+	require.Equal(t, 0, m.db.GetAbsentSize())
+	require.False(t, op.IsFinished())
+	m.opController.UpdateOperatorStatus(spans[0].ID, "node1", &heartbeatpb.TableSpanStatus{
+		ID:              spans[0].ID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Stopped,
+		CheckpointTs:    10,
+	})
+	require.True(t, op.IsFinished())
+	m.opController.Execute()
+
+	// the last reported checkpointTs of the span on the removed node is used
+	tasks := m.db.GetTasksByTableIDs(1)
+	require.Len(t, tasks, 1)
+	require.Equal(t, uint64(1), tasks[0].GetStatus().CheckpointTs)
+	require.Equal(t, 1, m.db.GetAbsentSize())
+}
+
+func TestMergeCheckerTableNotCovered(t *testing.T) {
+	m := newMergeCheckerForTest("node1", "node2")
+	spans := addTableSpans(m, 1, []byte{'a', 'b'},
+		[]node.ID{"node1", "node2", "node1"}, []float32{0, 0, 0})
+	// the table has a hole after the second span is removed
+	m.db.ForceRemove(spans[1].ID)
+	m.Check()
+	require.Equal(t, 0, m.opController.OperatorSize())
+}
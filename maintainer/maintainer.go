@@ -16,6 +16,9 @@ package maintainer
 import (
 	"encoding/json"
 	"math"
+	"net/url"
+	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -36,6 +39,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/pdutil"
+	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/atomic"
@@ -58,7 +62,8 @@ type Maintainer struct {
 	config     *config.ChangeFeedInfo
 	selfNode   *node.Info
 	controller *Controller
-	barrier    *Barrier
+	// barrier is created when the maintainer is bootstrapped, it's also read by the open api
+	barrier atomic.Pointer[Barrier]
 
 	stream        dynstream.DynamicStream[int, common.GID, *Event, *Maintainer, *StreamHandler]
 	taskScheduler threadpool.ThreadPool
@@ -103,6 +108,20 @@ type Maintainer struct {
 	errLock       sync.Mutex
 	runningErrors map[node.ID]*heartbeatpb.RunningError
 
+	// pendingConfig is the changefeed info updated by the coordinator, it's applied
+	// when the maintainer is bootstrapped and there is no ddl in flight
+	pendingConfig *config.ChangeFeedInfo
+	// coordinatorConfigVersion is the version of the latest changefeed info received from the coordinator,
+	// it's reported in the status to ack the update maintainer request
+	coordinatorConfigVersion atomic.Uint64
+	// configVersion increases every time a new config is sent to the dispatcher managers
+	configVersion uint64
+	// configUpdatingNodes are the nodes that have not acked the current config version
+	configUpdatingNodes map[node.ID]struct{}
+	// filterChanged is true if the current config update changes the table filter,
+	// the tables are reloaded after all dispatcher managers applied the new filter
+	filterChanged bool
+
 	changefeedCheckpointTsGauge    prometheus.Gauge
 	changefeedCheckpointTsLagGauge prometheus.Gauge
 	changefeedResolvedTsGauge      prometheus.Gauge
@@ -157,8 +176,9 @@ func NewMaintainer(cfID common.ChangeFeedID,
 		tableTriggerEventDispatcherID: tableTriggerEventDispatcherID,
 
 		watermark: &heartbeatpb.Watermark{
-			CheckpointTs: checkpointTs,
-			ResolvedTs:   checkpointTs,
+			CheckpointTs:   checkpointTs,
+			ResolvedTs:     checkpointTs,
+			RedoResolvedTs: checkpointTs,
 		},
 		checkpointTsByCapture: make(map[node.ID]heartbeatpb.Watermark),
 		runningErrors:         map[node.ID]*heartbeatpb.RunningError{},
@@ -263,15 +283,58 @@ func (m *Maintainer) GetMaintainerStatus() *heartbeatpb.MaintainerStatus {
 		clear(m.runningErrors)
 	}
 	status := &heartbeatpb.MaintainerStatus{
-		ChangefeedID: m.id.ToPB(),
-		FeedState:    string(m.changefeedSate),
-		State:        m.state,
-		CheckpointTs: m.watermark.CheckpointTs,
-		Err:          runningErrors,
+		ChangefeedID:   m.id.ToPB(),
+		FeedState:      string(m.changefeedSate),
+		State:          m.state,
+		CheckpointTs:   m.watermark.CheckpointTs,
+		Err:            runningErrors,
+		SpanCounts:     m.controller.GetSpanCountPerNode(),
+		PinnedTables:   m.controller.GetPinnedTables(),
+		ConfigVersion:  m.coordinatorConfigVersion.Load(),
+		RedoResolvedTs: m.watermark.RedoResolvedTs,
+	}
+	if barrier := m.barrier.Load(); barrier != nil {
+		status.BlockingDdlTs = barrier.GetBlockingDDLTs()
 	}
 	return status
 }
 
+// SpanReplicationInfo is the snapshot of a span replication of the changefeed
+type SpanReplicationInfo struct {
+	ID       common.DispatcherID
+	Span     *heartbeatpb.TableSpan
+	SchemaID int64
+	NodeID   node.ID
+	Status   *heartbeatpb.TableSpanStatus
+	// BlockEvent is the earliest block event that blocks the span, nil if the span is not blocked
+	BlockEvent *PendingBlockEvent
+}
+
+// GetSpanReplications returns the snapshot of all spans of the changefeed, sorted by the span,
+// it's used by the open api to find out which span holds back the checkpoint ts
+func (m *Maintainer) GetSpanReplications() []*SpanReplicationInfo {
+	spans := m.controller.GetAllTasks()
+	var pendingEvents map[common.DispatcherID]*PendingBlockEvent
+	if barrier := m.barrier.Load(); barrier != nil {
+		pendingEvents = barrier.GetPendingEvents(spans)
+	}
+	infos := make([]*SpanReplicationInfo, 0, len(spans))
+	for _, span := range spans {
+		infos = append(infos, &SpanReplicationInfo{
+			ID:         span.ID,
+			Span:       span.Span,
+			SchemaID:   span.GetSchemaID(),
+			NodeID:     span.GetNodeID(),
+			Status:     span.GetStatus(),
+			BlockEvent: pendingEvents[span.ID],
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Span.Less(infos[j].Span)
+	})
+	return infos
+}
+
 func (m *Maintainer) initialize() error {
 	start := time.Now()
 	log.Info("start to initialize changefeed maintainer",
@@ -281,7 +344,7 @@ func (m *Maintainer) initialize() error {
 	m.nodeManager.RegisterNodeChangeHandler(node.ID("maintainer-"+m.id.Name()), func(allNodes map[node.ID]*node.Info) {
 		m.nodeChanged.Store(true)
 	})
-	// init bootstrapper nodes
+	// init bootstrapper nodes, the draining nodes are included, since they may still hold dispatchers
 	nodes := m.nodeManager.GetAliveNodes()
 	log.Info("changefeed bootstrap initial nodes",
 		zap.Int("nodes", len(nodes)))
@@ -336,6 +399,12 @@ func (m *Maintainer) onMessage(msg *messaging.TargetMessage) {
 		m.onRemoveMaintainer(req.Cascade, req.Removed)
 	case messaging.TypeCheckpointTsMessage:
 		m.onCheckpointTsPersisted(msg.Message[0].(*heartbeatpb.CheckpointTsMessage))
+	case messaging.TypeMoveTableRequest:
+		m.onMoveTableRequest(msg.Message[0].(*heartbeatpb.MoveTableRequest))
+	case messaging.TypeUpdateMaintainerRequest:
+		m.onUpdateMaintainerRequest(msg.Message[0].(*heartbeatpb.UpdateMaintainerRequest))
+	case messaging.TypeUpdateDispatcherManagerResponse:
+		m.onUpdateDispatcherManagerResponse(msg.From, msg.Message[0].(*heartbeatpb.UpdateDispatcherManagerResponse))
 	default:
 		log.Panic("unexpected message type",
 			zap.String("changefeed", m.id.Name()),
@@ -362,9 +431,156 @@ func (m *Maintainer) onCheckpointTsPersisted(msg *heartbeatpb.CheckpointTsMessag
 	})
 }
 
+func (m *Maintainer) onMoveTableRequest(req *heartbeatpb.MoveTableRequest) {
+	if !m.bootstrapped {
+		log.Warn("maintainer is not bootstrapped, ignore move table request",
+			zap.String("changefeed", m.id.Name()),
+			zap.Int64("table", req.TableId))
+		return
+	}
+	if err := m.controller.MoveTable(req.TableId, node.ID(req.TargetNodeId)); err != nil {
+		log.Warn("move table failed",
+			zap.String("changefeed", m.id.Name()),
+			zap.Int64("table", req.TableId),
+			zap.String("target", req.TargetNodeId),
+			zap.Error(err))
+		return
+	}
+	m.statusChanged.Store(true)
+}
+
+func (m *Maintainer) onUpdateMaintainerRequest(req *heartbeatpb.UpdateMaintainerRequest) {
+	// the request is resent until it's acked, ignore the duplicated and the stale ones
+	if req.ConfigVersion <= m.coordinatorConfigVersion.Load() {
+		return
+	}
+	info, err := unmarshalChangefeedInfo(req.Config)
+	if err != nil {
+		// the version is not acked, the error is reported to the coordinator instead
+		m.handleError(err)
+		return
+	}
+	m.coordinatorConfigVersion.Store(req.ConfigVersion)
+	log.Info("received update maintainer request",
+		zap.String("changefeed", m.id.Name()),
+		zap.Uint64("version", req.ConfigVersion))
+	m.pendingConfig = info
+	m.tryApplyPendingConfig()
+}
+
+// unmarshalChangefeedInfo decodes the changefeed info of the update request,
+// fills the missing configs with the default values and validates it
+func unmarshalChangefeedInfo(data []byte) (*config.ChangeFeedInfo, error) {
+	info := &config.ChangeFeedInfo{}
+	if err := info.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	if info.Config == nil {
+		return nil, errors.ErrChangefeedUpdateRefused.GenWithStackByArgs("the changefeed config is empty")
+	}
+	info.VerifyAndComplete()
+	sinkURI, err := url.Parse(info.SinkURI)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrSinkURIInvalid, err)
+	}
+	if err := info.Config.ValidateAndAdjust(sinkURI); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// tryApplyPendingConfig sends the pending config to all dispatcher managers, the tables are not
+// changed during a ddl, so the config is applied only if there is no ddl in flight
+func (m *Maintainer) tryApplyPendingConfig() {
+	if m.pendingConfig == nil || !m.bootstrapped || m.removing || len(m.configUpdatingNodes) > 0 {
+		return
+	}
+	if m.barrier.Load().GetBlockingDDLTs() != 0 {
+		return
+	}
+	info := m.pendingConfig
+	m.pendingConfig = nil
+	// the source id is not persisted, keep the one assigned when the changefeed is created
+	if info.Config.Sink != nil && m.config.Config.Sink != nil {
+		info.Config.Sink.TiDBSourceID = m.config.Config.Sink.TiDBSourceID
+	}
+	m.filterChanged = !reflect.DeepEqual(m.config.Config.Filter, info.Config.Filter)
+	m.config = info
+	m.configVersion++
+	m.configUpdatingNodes = make(map[node.ID]struct{})
+	for id := range m.bootstrapper.GetAllNodes() {
+		m.configUpdatingNodes[id] = struct{}{}
+	}
+	log.Info("apply changefeed config update",
+		zap.String("changefeed", m.id.Name()),
+		zap.Uint64("version", m.configVersion),
+		zap.Bool("filterChanged", m.filterChanged),
+		zap.Int("nodes", len(m.configUpdatingNodes)))
+	m.sendUpdateDispatcherManagerRequests()
+}
+
+func (m *Maintainer) sendUpdateDispatcherManagerRequests() {
+	if len(m.configUpdatingNodes) == 0 {
+		return
+	}
+	cfgBytes := m.marshalChangefeedConfig()
+	msgs := make([]*messaging.TargetMessage, 0, len(m.configUpdatingNodes))
+	for id := range m.configUpdatingNodes {
+		msgs = append(msgs, messaging.NewSingleTargetMessage(
+			id,
+			messaging.DispatcherManagerManagerTopic,
+			&heartbeatpb.UpdateDispatcherManagerRequest{
+				ChangefeedID: m.id.ToPB(),
+				Config:       cfgBytes,
+				Version:      m.configVersion,
+			}))
+	}
+	m.sendMessages(msgs)
+}
+
+func (m *Maintainer) onUpdateDispatcherManagerResponse(from node.ID, resp *heartbeatpb.UpdateDispatcherManagerResponse) {
+	if resp.Version != m.configVersion {
+		return
+	}
+	if _, ok := m.configUpdatingNodes[from]; !ok {
+		return
+	}
+	if resp.Err != nil {
+		log.Warn("dispatcher manager update config failed",
+			zap.String("changefeed", m.id.Name()),
+			zap.String("error", resp.Err.Message))
+		m.errLock.Lock()
+		m.statusChanged.Store(true)
+		m.runningErrors[from] = resp.Err
+		resp.Err.Node = from.String()
+		if info, ok := m.nodeManager.GetAliveNodes()[from]; ok {
+			resp.Err.Node = info.AdvertiseAddr
+		}
+		m.errLock.Unlock()
+	}
+	delete(m.configUpdatingNodes, from)
+	m.onConfigUpdated()
+}
+
+// onConfigUpdated reloads the tables once all dispatcher managers applied the new filter,
+// so the new dispatchers never subscribe the events with the stale filter
+func (m *Maintainer) onConfigUpdated() {
+	if len(m.configUpdatingNodes) > 0 || !m.filterChanged {
+		return
+	}
+	m.filterChanged = false
+	if err := m.controller.UpdateFilter(m.config.Config, m.watermark.CheckpointTs); err != nil {
+		m.handleError(err)
+		return
+	}
+	m.statusChanged.Store(true)
+}
+
 func (m *Maintainer) onNodeChanged() {
 	currentNodes := m.bootstrapper.GetAllNodes()
 
+	// the draining nodes still hold dispatchers until they are drained, so they are not removed here,
+	// the scheduler only picks the schedulable nodes as the destinations
 	activeNodes := m.nodeManager.GetAliveNodes()
 	var newNodes = make([]*node.Info, 0, len(activeNodes))
 	for id, n := range activeNodes {
@@ -377,6 +593,7 @@ func (m *Maintainer) onNodeChanged() {
 		if _, ok := activeNodes[id]; !ok {
 			removedNodes = append(removedNodes, id)
 			delete(m.checkpointTsByCapture, id)
+			delete(m.configUpdatingNodes, id)
 			m.controller.RemoveNode(id)
 		}
 	}
@@ -389,6 +606,9 @@ func (m *Maintainer) onNodeChanged() {
 		log.Info("bootstrap done after removed some nodes", zap.String("id", m.id.String()))
 		m.onBootstrapDone(cachedResponse)
 	}
+	if len(removedNodes) > 0 {
+		m.onConfigUpdated()
+	}
 }
 
 func (m *Maintainer) calCheckpointTs() {
@@ -426,6 +646,9 @@ func (m *Maintainer) calCheckpointTs() {
 	if newWatermark.ResolvedTs != math.MaxUint64 {
 		m.watermark.ResolvedTs = newWatermark.ResolvedTs
 	}
+	if newWatermark.RedoResolvedTs != math.MaxUint64 {
+		m.watermark.RedoResolvedTs = newWatermark.RedoResolvedTs
+	}
 }
 
 func (m *Maintainer) updateMetrics() {
@@ -486,7 +709,7 @@ func (m *Maintainer) onBlockStateRequest(msg *messaging.TargetMessage) {
 		return
 	}
 	req := msg.Message[0].(*heartbeatpb.BlockStatusRequest)
-	ackMsg := m.barrier.HandleStatus(msg.From, req)
+	ackMsg := m.barrier.Load().HandleStatus(msg.From, req)
 	m.sendMessages([]*messaging.TargetMessage{ackMsg})
 }
 
@@ -522,7 +745,7 @@ func (m *Maintainer) onBootstrapDone(cachedResp map[node.ID]*heartbeatpb.Maintai
 		m.handleError(err)
 		return
 	}
-	m.barrier = barrier
+	m.barrier.Store(barrier)
 	m.bootstrapped = true
 }
 
@@ -542,10 +765,12 @@ func (m *Maintainer) handleResendMessage() {
 	}
 	// resend bootstrap message
 	m.sendMessages(m.bootstrapper.ResendBootstrapMessage())
-	if m.barrier != nil {
+	if barrier := m.barrier.Load(); barrier != nil {
 		// resend barrier ack messages
-		m.sendMessages(m.barrier.Resend())
+		m.sendMessages(barrier.Resend())
 	}
+	// resend the config update until all dispatcher managers ack
+	m.sendUpdateDispatcherManagerRequests()
 }
 
 func (m *Maintainer) tryCloseChangefeed() bool {
@@ -560,6 +785,7 @@ func (m *Maintainer) tryCloseChangefeed() bool {
 
 func (m *Maintainer) sendMaintainerCloseRequestToAllNode() bool {
 	msgs := make([]*messaging.TargetMessage, 0)
+	// the draining nodes must close their dispatchers too
 	for n := range m.nodeManager.GetAliveNodes() {
 		if _, ok := m.nodesClosed[n]; !ok {
 			msgs = append(msgs, messaging.NewSingleTargetMessage(
@@ -605,28 +831,6 @@ func (m *Maintainer) handleError(err error) {
 // getNewBootstrapFn returns a function that creates a new bootstrap message to initialize
 // a changefeed dispatcher manager.
 func (m *Maintainer) getNewBootstrapFn() bootstrap.NewBootstrapMessageFn {
-	cfg := m.config
-	changefeedConfig := config.ChangefeedConfig{
-		ChangefeedID:       cfg.ChangefeedID,
-		StartTS:            cfg.StartTs,
-		TargetTS:           cfg.TargetTs,
-		SinkURI:            cfg.SinkURI,
-		ForceReplicate:     cfg.Config.ForceReplicate,
-		SinkConfig:         cfg.Config.Sink,
-		Filter:             cfg.Config.Filter,
-		EnableSyncPoint:    *cfg.Config.EnableSyncPoint,
-		SyncPointInterval:  cfg.Config.SyncPointInterval,
-		SyncPointRetention: cfg.Config.SyncPointRetention,
-		MemoryQuota:        cfg.Config.MemoryQuota,
-		// other fields are not necessary for maintainer
-	}
-	// cfgBytes only holds necessary fields to initialize a changefeed dispatcher.
-	cfgBytes, err := json.Marshal(changefeedConfig)
-	if err != nil {
-		log.Panic("marshal changefeed config failed",
-			zap.String("changefeed", m.id.Name()),
-			zap.Error(err))
-	}
 	return func(id node.ID) *messaging.TargetMessage {
 		// only send dispatcher id to dispatcher manager on the same node
 		if id == m.selfNode.ID {
@@ -642,17 +846,52 @@ func (m *Maintainer) getNewBootstrapFn() bootstrap.NewBootstrapMessageFn {
 			id,
 			messaging.DispatcherManagerManagerTopic,
 			&heartbeatpb.MaintainerBootstrapRequest{
-				ChangefeedID:                  m.id.ToPB(),
-				Config:                        cfgBytes,
+				ChangefeedID: m.id.ToPB(),
+				// the config may be updated, build it from the current one
+				Config:                        m.marshalChangefeedConfig(),
 				StartTs:                       m.startCheckpointTs,
 				TableTriggerEventDispatcherId: m.tableTriggerEventDispatcherID.ToPB(),
 			})
 	}
 }
 
+// marshalChangefeedConfig builds the config sent to the dispatcher managers
+func (m *Maintainer) marshalChangefeedConfig() []byte {
+	cfg := m.config
+	changefeedConfig := config.ChangefeedConfig{
+		ChangefeedID:           cfg.ChangefeedID,
+		StartTS:                cfg.StartTs,
+		TargetTS:               cfg.TargetTs,
+		SinkURI:                cfg.SinkURI,
+		ForceReplicate:         cfg.Config.ForceReplicate,
+		SinkConfig:             cfg.Config.Sink,
+		Filter:                 cfg.Config.Filter,
+		EnableSyncPoint:        util.GetOrZero(cfg.Config.EnableSyncPoint),
+		SyncPointInterval:      cfg.Config.SyncPointInterval,
+		SyncPointRetention:     cfg.Config.SyncPointRetention,
+		MemoryQuota:            cfg.Config.MemoryQuota,
+		Consistent:             cfg.Config.Consistent,
+		BDRMode:                util.GetOrZero(cfg.Config.BDRMode),
+		EnableTableAcrossNodes: cfg.Config.Scheduler != nil && cfg.Config.Scheduler.EnableTableAcrossNodes,
+		// other fields are not necessary for maintainer
+	}
+	if cfg.Config.Sink != nil {
+		changefeedConfig.TiDBSourceID = cfg.Config.Sink.TiDBSourceID
+	}
+	// cfgBytes only holds necessary fields to initialize a changefeed dispatcher.
+	cfgBytes, err := json.Marshal(changefeedConfig)
+	if err != nil {
+		log.Panic("marshal changefeed config failed",
+			zap.String("changefeed", m.id.Name()),
+			zap.Error(err))
+	}
+	return cfgBytes
+}
+
 func (m *Maintainer) onPeriodTask() {
 	// send scheduling messages
 	m.handleResendMessage()
+	m.tryApplyPendingConfig()
 	m.collectMetrics()
 	m.calCheckpointTs()
 	SubmitScheduledEvent(m.taskScheduler, m.stream, &Event{
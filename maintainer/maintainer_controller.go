@@ -15,6 +15,7 @@ package maintainer
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pingcap/log"
@@ -102,7 +103,7 @@ func NewController(changefeedID common.ChangeFeedID,
 		s.splitter = split.NewSplitter(changefeedID, pdapi, regionCache, cfConfig.Scheduler)
 		s.spanReplicationEnabled = true
 	}
-	s.checkController = checker.NewController(changefeedID, s.splitter, oc, replicaSetDB, nodeManager)
+	s.checkController = checker.NewController(changefeedID, s.splitter, oc, replicaSetDB, nodeManager, s.spanScheduler.IsTablePinned)
 	return s
 }
 
@@ -295,6 +296,48 @@ func (c *Controller) FinishBootstrap(cachedResp map[node.ID]*heartbeatpb.Maintai
 	return barrier, nil
 }
 
+// UpdateFilter applies the new filter of the changefeed, the tables newly matched by the filter
+// are added from the startTs, and the tables no longer matched are removed
+func (c *Controller) UpdateFilter(cfConfig *config.ReplicaConfig, startTs uint64) error {
+	c.cfConfig = cfConfig
+	tables, err := c.loadTables(startTs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	matched := make(map[int64]struct{}, len(tables))
+	added := 0
+	for _, table := range tables {
+		matched[table.TableID] = struct{}{}
+		if !c.replicationDB.IsTableExists(table.TableID) {
+			c.AddNewTable(table, startTs)
+			added++
+		}
+	}
+	removed := make(map[int64]struct{})
+	for _, task := range c.replicationDB.GetAllTasks() {
+		tableID := task.Span.TableID
+		if tableID == heartbeatpb.DDLSpan.TableID {
+			continue
+		}
+		if _, ok := matched[tableID]; !ok {
+			removed[tableID] = struct{}{}
+		}
+	}
+	if len(removed) > 0 {
+		tableIDs := make([]int64, 0, len(removed))
+		for tableID := range removed {
+			tableIDs = append(tableIDs, tableID)
+		}
+		c.RemoveTasksByTableIDs(tableIDs...)
+	}
+	log.Info("changefeed filter updated",
+		zap.String("changefeed", c.changefeedID.Name()),
+		zap.Uint64("startTs", startTs),
+		zap.Int("added", added),
+		zap.Int("removed", len(removed)))
+	return nil
+}
+
 func (c *Controller) Stop() {
 	if c.operatorControllerHandle != nil {
 		c.operatorControllerHandle.Cancel()
@@ -305,6 +348,7 @@ func (c *Controller) Stop() {
 	if c.checkerHandle != nil {
 		c.checkerHandle.Cancel()
 	}
+	c.checkController.Stop()
 }
 
 // GetTask queries a task by dispatcherID, return nil if not found
@@ -343,6 +387,36 @@ func (c *Controller) UpdateSchemaID(tableID, newSchemaID int64) {
 	c.replicationDB.UpdateSchemaID(tableID, newSchemaID)
 }
 
+// MoveTable moves all spans of the table to the target node
+func (c *Controller) MoveTable(tableID int64, target node.ID) error {
+	if !c.replicationDB.IsTableExists(tableID) {
+		return errors.ErrSchedulerRequestFailed.GenWithStackByArgs(fmt.Sprintf("table %d not found", tableID))
+	}
+	if _, ok := c.nodeManager.GetSchedulableNodes()[target]; !ok {
+		return errors.ErrCaptureNotExist.GenWithStackByArgs(target)
+	}
+	c.spanScheduler.MoveTable(tableID, target)
+	return nil
+}
+
+// GetPinnedTables returns the tables pinned to the target nodes by the move table requests
+func (c *Controller) GetPinnedTables() []*heartbeatpb.PinnedTable {
+	return c.spanScheduler.GetPinnedTables()
+}
+
+// GetSpanCountPerNode returns the number of spans scheduled on each node
+func (c *Controller) GetSpanCountPerNode() []*heartbeatpb.NodeSpanCount {
+	sizeMap := c.replicationDB.GetTaskSizePerNode()
+	counts := make([]*heartbeatpb.NodeSpanCount, 0, len(sizeMap))
+	for id, size := range sizeMap {
+		counts = append(counts, &heartbeatpb.NodeSpanCount{
+			NodeId: id.String(),
+			Count:  uint32(size),
+		})
+	}
+	return counts
+}
+
 // RemoveNode is called when a node is removed
 func (c *Controller) RemoveNode(id node.ID) {
 	c.operatorController.OnNodeRemoved(id)
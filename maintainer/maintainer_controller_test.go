@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/pingcap/tiflow/pkg/spanz"
 	"github.com/stretchr/testify/require"
+	pd "github.com/tikv/pd/client"
 )
 
 func TestSchedule(t *testing.T) {
@@ -484,3 +485,174 @@ type mockTsoClient struct {
 func (m *mockTsoClient) GetTS(_ context.Context) (int64, int64, error) {
 	return m.phy, m.logic, m.err
 }
+
+// mockPDClient is the pd client used by the maintainer manager
+type mockPDClient struct {
+	pd.Client
+	mockTsoClient
+}
+
+func (m *mockPDClient) GetTS(ctx context.Context) (int64, int64, error) {
+	return m.mockTsoClient.GetTS(ctx)
+}
+
+func (m *mockPDClient) LoadGlobalConfig(
+	_ context.Context, _ []string, _ string,
+) ([]pd.GlobalConfigItem, int64, error) {
+	return nil, 0, nil
+}
+
+// newMoveTestController creates a controller with the tables replicating on the nodes, the table id starts from 1, and table i is on nodes[(i-1)%len(nodes)]
+func newMoveTestController(t *testing.T, tableSize int, nodes ...node.ID) (*Controller, *watcher.NodeManager, map[int64]*replica.SpanReplication) {
+	nodeManager := setNodeManagerAndMessageCenter()
+	for _, id := range nodes {
+		nodeManager.GetAliveNodes()[id] = &node.Info{ID: id}
+	}
+	tableTriggerEventDispatcherID := common.NewDispatcherID()
+	cfID := common.NewChangeFeedIDWithName("test")
+	tsoClient := &mockTsoClient{}
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, tableTriggerEventDispatcherID,
+		tsoClient,
+		heartbeatpb.DDLSpanSchemaID,
+		heartbeatpb.DDLSpan, &heartbeatpb.TableSpanStatus{
+			ID:              tableTriggerEventDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, nodes[0])
+	s := NewController(cfID, 1, nil, tsoClient, nil, nil, nil, ddlSpan, 1000, 0)
+	spans := make(map[int64]*replica.SpanReplication, tableSize)
+	for i := 1; i <= tableSize; i++ {
+		span := &heartbeatpb.TableSpan{TableID: int64(i)}
+		spanReplica := replica.NewReplicaSet(cfID, common.NewDispatcherID(), tsoClient, 1, span, 1)
+		spanReplica.SetNodeID(nodes[(i-1)%len(nodes)])
+		s.replicationDB.AddReplicatingSpan(spanReplica)
+		spans[int64(i)] = spanReplica
+	}
+	require.Equal(t, tableSize, s.replicationDB.GetReplicatingSize())
+	return s, nodeManager, spans
+}
+
+// finishMoveOperators finishes all move operators, the spans are replicating on the dest nodes
+func finishMoveOperators(t *testing.T, s *Controller, spans map[int64]*replica.SpanReplication) {
+	for _, span := range spans {
+		op := s.operatorController.GetOperator(span.ID)
+		if op == nil {
+			continue
+		}
+		_, ok := op.(*operator.MoveDispatcherOperator)
+		require.True(t, ok)
+		op.Check(span.GetNodeID(), &heartbeatpb.TableSpanStatus{
+			ID:              span.ID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Stopped,
+		})
+		msg := op.Schedule()
+		op.Check(msg.To, &heartbeatpb.TableSpanStatus{
+			ID:              span.ID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+		})
+		require.True(t, op.IsFinished())
+	}
+	s.operatorController.Execute()
+	require.Equal(t, 0, s.operatorController.OperatorSize())
+}
+
+func TestMoveTable(t *testing.T) {
+	s, _, spans := newMoveTestController(t, 4, "node1", "node2")
+
+	// the table or the target node doesn't exist
+	require.Error(t, s.MoveTable(100, "node2"))
+	require.Error(t, s.MoveTable(1, "node3"))
+	require.Empty(t, s.GetPinnedTables())
+
+	require.NoError(t, s.MoveTable(1, "node2"))
+	expected := []*heartbeatpb.PinnedTable{{TableId: 1, TargetNodeId: "node2", Moving: true}}
+	require.Equal(t, expected, s.GetPinnedTables())
+	// getting the pinned tables doesn't change them
+	require.Equal(t, expected, s.GetPinnedTables())
+
+	s.spanScheduler.Execute()
+	require.Equal(t, 1, s.operatorController.OperatorSize())
+	require.NotNil(t, s.operatorController.GetOperator(spans[1].ID))
+	finishMoveOperators(t, s, spans)
+	require.Equal(t, node.ID("node2"), spans[1].GetNodeID())
+	require.True(t, s.replicationDB.IsReplicating(spans[1]))
+
+	// the table is moved, but it's still pinned to the target node
+	s.spanScheduler.Execute()
+	require.Equal(t, []*heartbeatpb.PinnedTable{{TableId: 1, TargetNodeId: "node2"}}, s.GetPinnedTables())
+	// node2 has 3 tables, the balance moves a table out of it, but not the pinned one.
+	// the balance picks the tables randomly, it may pick the pinned one and skip the round.
+	for i := 0; i < 50 && s.operatorController.OperatorSize() == 0; i++ {
+		s.spanScheduler.Execute()
+	}
+	require.Equal(t, 1, s.operatorController.OperatorSize())
+	require.Nil(t, s.operatorController.GetOperator(spans[1].ID))
+	finishMoveOperators(t, s, spans)
+	require.Equal(t, 2, s.replicationDB.GetTaskSizeByNodeID("node1"))
+	require.Equal(t, 2, s.replicationDB.GetTaskSizeByNodeID("node2"))
+	require.Equal(t, node.ID("node2"), spans[1].GetNodeID())
+
+	// the table is unpinned after it's removed
+	s.RemoveTasksByTableIDs(1)
+	s.spanScheduler.Execute()
+	require.Empty(t, s.GetPinnedTables())
+}
+
+func TestDrainNode(t *testing.T) {
+	s, nodeManager, spans := newMoveTestController(t, 4, "node1", "node2")
+	require.NoError(t, s.MoveTable(1, "node1"))
+
+	require.True(t, nodeManager.DrainNode("node1"))
+	s.spanScheduler.Execute()
+	// the table pinned to the draining node is unpinned, and all spans are moved out of the draining node
+	require.Empty(t, s.GetPinnedTables())
+	require.Equal(t, 2, s.operatorController.OperatorSize())
+	finishMoveOperators(t, s, spans)
+	require.Equal(t, 0, s.replicationDB.GetTaskSizeByNodeID("node1"))
+	require.Equal(t, 4, s.replicationDB.GetTaskSizeByNodeID("node2"))
+	// the draining node can't be the target of the move table requests
+	require.Error(t, s.MoveTable(2, "node1"))
+
+	// the tables are balanced again after the node is undrained
+	require.True(t, nodeManager.UndrainNode("node1"))
+	require.False(t, nodeManager.UndrainNode("node1"))
+	s.spanScheduler.Execute()
+	require.Equal(t, 2, s.operatorController.OperatorSize())
+	finishMoveOperators(t, s, spans)
+	require.Equal(t, 2, s.replicationDB.GetTaskSizeByNodeID("node1"))
+	require.Equal(t, 2, s.replicationDB.GetTaskSizeByNodeID("node2"))
+}
+
+func TestUpdateFilter(t *testing.T) {
+	s, _, spans := newMoveTestController(t, 4, "node1", "node2")
+	// table 1 is no longer matched by the new filter, and table 5 is newly matched
+	schemaStore := &mockSchemaStore{tables: []commonEvent.Table{
+		{TableID: 2, SchemaID: 1}, {TableID: 3, SchemaID: 1}, {TableID: 4, SchemaID: 1}, {TableID: 5, SchemaID: 1},
+	}}
+	appcontext.SetService(appcontext.SchemaStore, schemaStore)
+
+	cfConfig := config.GetDefaultReplicaConfig()
+	cfConfig.Filter.Rules = []string{"test.*"}
+	require.NoError(t, s.UpdateFilter(cfConfig, 10))
+	require.Equal(t, cfConfig, s.cfConfig)
+
+	// the new table is added from the checkpoint
+	require.True(t, s.replicationDB.IsTableExists(5))
+	require.Equal(t, 1, s.replicationDB.GetAbsentSize())
+	require.Equal(t, uint64(10), s.GetTasksByTableIDs(5)[0].GetStatus().CheckpointTs)
+	// the dispatcher of the unmatched table is removed
+	op := s.operatorController.GetOperator(spans[1].ID)
+	require.NotNil(t, op)
+	_, ok := op.(*operator.RemoveDispatcherOperator)
+	require.True(t, ok)
+	for tableID := int64(2); tableID <= 4; tableID++ {
+		require.Nil(t, s.operatorController.GetOperator(spans[tableID].ID))
+	}
+	// the table trigger event dispatcher is never removed
+	require.NotNil(t, s.replicationDB.GetTaskByID(s.ddlDispatcherID))
+
+	// nothing changes if the filter result is the same
+	require.NoError(t, s.UpdateFilter(cfConfig, 20))
+	require.Equal(t, 1, s.replicationDB.GetAbsentSize())
+	require.Equal(t, 1, s.operatorController.OperatorSize())
+}
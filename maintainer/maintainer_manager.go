@@ -27,13 +27,18 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/ticdc/server/watcher"
 	"github.com/pingcap/ticdc/utils/dynstream"
 	"github.com/pingcap/ticdc/utils/threadpool"
 	"github.com/pingcap/tiflow/pkg/pdutil"
 	"github.com/tikv/client-go/v2/tikv"
+	pd "github.com/tikv/pd/client"
 	"go.uber.org/zap"
 )
 
+// ManagerName is the name of the maintainer manager service
+const ManagerName = "maintainer-manager"
+
 // Manager is the manager of all changefeed maintainer in a ticdc watcher, each ticdc watcher will
 // start a Manager when the watcher is startup. the Manager should:
 // 1. handle bootstrap command from coordinator and return all changefeed maintainer status
@@ -51,6 +56,7 @@ type Manager struct {
 
 	selfNode    *node.Info
 	pdAPI       pdutil.PDAPIClient
+	pdClient    pd.Client
 	tsoClient   replica.TSOClient
 	regionCache *tikv.RegionCache
 
@@ -67,7 +73,7 @@ type Manager struct {
 func NewMaintainerManager(selfNode *node.Info,
 	conf *config.SchedulerConfig,
 	pdAPI pdutil.PDAPIClient,
-	pdClient replica.TSOClient,
+	pdClient pd.Client,
 	regionCache *tikv.RegionCache,
 ) *Manager {
 	mc := appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter)
@@ -79,6 +85,7 @@ func NewMaintainerManager(selfNode *node.Info,
 		msgCh:         make(chan *messaging.TargetMessage, 1024),
 		taskScheduler: threadpool.NewThreadPoolDefault(),
 		pdAPI:         pdAPI,
+		pdClient:      pdClient,
 		tsoClient:     pdClient,
 		regionCache:   regionCache,
 	}
@@ -97,7 +104,7 @@ func NewMaintainerManager(selfNode *node.Info,
 func (m *Manager) recvMessages(ctx context.Context, msg *messaging.TargetMessage) error {
 	switch msg.Type {
 	// receive message from coordinator
-	case messaging.TypeAddMaintainerRequest, messaging.TypeRemoveMaintainerRequest:
+	case messaging.TypeAddMaintainerRequest, messaging.TypeRemoveMaintainerRequest, messaging.TypeDrainNodeRequest:
 		fallthrough
 	case messaging.TypeCoordinatorBootstrapRequest:
 		select {
@@ -120,6 +127,16 @@ func (m *Manager) recvMessages(ctx context.Context, msg *messaging.TargetMessage
 	case messaging.TypeCheckpointTsMessage:
 		req := msg.Message[0].(*heartbeatpb.CheckpointTsMessage)
 		return m.dispatcherMaintainerMessage(ctx, common.NewChangefeedIDFromPB(req.ChangefeedID), msg)
+	case messaging.TypeMoveTableRequest:
+		req := msg.Message[0].(*heartbeatpb.MoveTableRequest)
+		return m.dispatcherMaintainerMessage(ctx, common.NewChangefeedIDFromPB(req.ChangefeedID), msg)
+	case messaging.TypeUpdateMaintainerRequest:
+		req := msg.Message[0].(*heartbeatpb.UpdateMaintainerRequest)
+		return m.dispatcherMaintainerMessage(ctx, common.NewChangefeedIDFromPB(req.ChangefeedID), msg)
+	// receive the config update response from the dispatcher manager
+	case messaging.TypeUpdateDispatcherManagerResponse:
+		req := msg.Message[0].(*heartbeatpb.UpdateDispatcherManagerResponse)
+		return m.dispatcherMaintainerMessage(ctx, common.NewChangefeedIDFromPB(req.ChangefeedID), msg)
 	default:
 		log.Panic("unknown message type", zap.Any("message", msg.Message))
 	}
@@ -127,7 +144,20 @@ func (m *Manager) recvMessages(ctx context.Context, msg *messaging.TargetMessage
 }
 
 func (m *Manager) Name() string {
-	return "maintainer-manager"
+	return ManagerName
+}
+
+// GetMaintainer returns the maintainer of the changefeed if it's running on this node
+func (m *Manager) GetMaintainer(changefeedDisplayName common.ChangeFeedDisplayName) (*Maintainer, bool) {
+	var result *Maintainer
+	m.maintainers.Range(func(key, value interface{}) bool {
+		if key.(common.ChangeFeedID).DisplayName == changefeedDisplayName {
+			result = value.(*Maintainer)
+			return false
+		}
+		return true
+	})
+	return result, result != nil
 }
 
 func (m *Manager) Run(ctx context.Context) error {
@@ -227,9 +257,23 @@ func (m *Manager) onAddMaintainerRequest(req *heartbeatpb.AddMaintainerRequest)
 	if err != nil {
 		log.Panic("decode changefeed fail", zap.Error(err))
 	}
+	// The source ID is not persisted, it's loaded from the upstream every time the maintainer is added,
+	// so the changes of the source ID take effect after the changefeed is restarted.
+	// The request is handled in the message loop of the manager, so the pd request must not block it.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	sourceID, err := pdutil.GetSourceID(ctx, m.pdClient)
+	cancel()
+	if err != nil {
+		log.Warn("get source id failed, coordinator will retry later",
+			zap.String("changefeed", cfID.String()), zap.Error(err))
+		return
+	}
+	cfConfig.Config.Sink.TiDBSourceID = sourceID
 	cf = NewMaintainer(cfID, m.conf, cfConfig, m.selfNode, m.stream, m.taskScheduler,
 		m.pdAPI, m.tsoClient, m.regionCache,
 		req.CheckpointTs)
+	// the maintainer is created with the latest changefeed info
+	cf.(*Maintainer).coordinatorConfigVersion.Store(req.ConfigVersion)
 	err = m.stream.AddPath(cfID.Id, cf.(*Maintainer))
 	if err != nil {
 		log.Warn("add path to dynstream failed, coordinator will retry later", zap.Error(err))
@@ -291,11 +335,28 @@ func (m *Manager) onDispatchMaintainerRequest(
 		m.onAddMaintainerRequest(req)
 	case messaging.TypeRemoveMaintainerRequest:
 		return m.onRemoveMaintainerRequest(msg)
+	case messaging.TypeDrainNodeRequest:
+		m.onDrainNodeRequest(msg.Message[0].(*heartbeatpb.DrainNodeRequest))
 	default:
 	}
 	return nil
 }
 
+// onDrainNodeRequest marks the node as draining, so all maintainers in this node
+// stop scheduling spans to the node and move the spans out of it.
+// If the request is an undrain request, the node is schedulable again.
+func (m *Manager) onDrainNodeRequest(req *heartbeatpb.DrainNodeRequest) {
+	nodeManager := appcontext.GetService[*watcher.NodeManager](watcher.NodeManagerName)
+	if req.Undrain {
+		nodeManager.UndrainNode(node.ID(req.NodeId))
+		return
+	}
+	if !nodeManager.DrainNode(node.ID(req.NodeId)) {
+		log.Warn("ignore drain node request, since the node is not alive",
+			zap.String("node", req.NodeId))
+	}
+}
+
 func (m *Manager) sendHeartbeat() {
 	if m.coordinatorVersion > 0 {
 		response := &heartbeatpb.MaintainerHeartbeat{}
@@ -319,7 +380,7 @@ func (m *Manager) handleMessage(msg *messaging.TargetMessage) {
 	case messaging.TypeCoordinatorBootstrapRequest:
 		log.Info("received coordinator bootstrap request", zap.String("from", msg.From.String()))
 		m.onCoordinatorBootstrapRequest(msg)
-	case messaging.TypeAddMaintainerRequest, messaging.TypeRemoveMaintainerRequest:
+	case messaging.TypeAddMaintainerRequest, messaging.TypeRemoveMaintainerRequest, messaging.TypeDrainNodeRequest:
 		if m.coordinatorVersion > 0 {
 			status := m.onDispatchMaintainerRequest(msg)
 			if status == nil {
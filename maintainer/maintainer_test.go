@@ -24,6 +24,8 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/bootstrap"
 	"github.com/pingcap/ticdc/pkg/common"
 	appcontext "github.com/pingcap/ticdc/pkg/common/context"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
@@ -39,6 +41,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -261,7 +264,7 @@ func TestMaintainerSchedule(t *testing.T) {
 
 	n := node.NewInfo("", "")
 	appcontext.SetService(appcontext.MessageCenter, messaging.NewMessageCenter(ctx,
-		n.ID, 100, config.NewDefaultMessageCenterConfig()))
+		n.ID, 100, config.NewDefaultMessageCenterConfig(), nil))
 	nodeManager := watcher.NewNodeManager(nil, nil)
 	appcontext.SetService(watcher.NodeManagerName, nodeManager)
 	nodeManager.GetAliveNodes()[n.ID] = n
@@ -311,3 +314,225 @@ func TestMaintainerSchedule(t *testing.T) {
 	require.Equal(t, tableSize,
 		maintainer.controller.GetTaskSizeByNodeID(n.ID))
 }
+
+func TestGetSpanReplicationsConcurrently(t *testing.T) {
+	s, _, spans := newMoveTestController(t, 4, "node1", "node2")
+	m := &Maintainer{controller: s}
+	m.barrier.Store(NewBarrier(s, false))
+
+	status := &heartbeatpb.TableSpanStatus{
+		ID:              spans[1].ID.ToPB(),
+		ComponentStatus: heartbeatpb.ComponentState_Working,
+		CheckpointTs:    10,
+		ResolvedTs:      20,
+	}
+	spans[1].UpdateStatus(status)
+
+	done := make(chan struct{})
+	spanSizes := make([]int, 0, 100)
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			spanSizes = append(spanSizes, len(m.GetSpanReplications()))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		// the status forwarded by the block event carries no resolved ts
+		newStatus := &heartbeatpb.TableSpanStatus{
+			ID:              spans[1].ID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    uint64(10 + i),
+		}
+		spans[1].UpdateStatus(newStatus)
+		// the status of the caller is not modified
+		require.Equal(t, uint64(0), newStatus.ResolvedTs)
+		require.Equal(t, uint64(20), spans[1].GetStatus().ResolvedTs)
+		spans[2].SetNodeID(node.ID("node" + strconv.Itoa(i%2+1)))
+	}
+	<-done
+	// the table trigger event dispatcher and the 4 tables
+	for _, size := range spanSizes {
+		require.Equal(t, 5, size)
+	}
+}
+
+// newConfigTestMaintainer creates a bootstrapped maintainer with 4 tables replicating on the nodes
+func newConfigTestMaintainer(t *testing.T, nodes ...node.ID) (*Maintainer, *watcher.NodeManager, map[int64]*replica.SpanReplication) {
+	s, nodeManager, spans := newMoveTestController(t, 4, nodes...)
+	cfConfig := config.GetDefaultReplicaConfig()
+	s.cfConfig = cfConfig
+	m := &Maintainer{
+		id:                    s.changefeedID,
+		config:                &config.ChangeFeedInfo{ChangefeedID: s.changefeedID, Config: cfConfig},
+		controller:            s,
+		mc:                    appcontext.GetService[messaging.MessageCenter](appcontext.MessageCenter),
+		nodeManager:           nodeManager,
+		selfNode:              nodeManager.GetAliveNodes()[nodes[0]],
+		bootstrapped:          true,
+		statusChanged:         atomic.NewBool(false),
+		watermark:             &heartbeatpb.Watermark{CheckpointTs: 10, ResolvedTs: 10},
+		checkpointTsByCapture: make(map[node.ID]heartbeatpb.Watermark),
+		runningErrors:         make(map[node.ID]*heartbeatpb.RunningError),
+	}
+	m.barrier.Store(NewBarrier(s, false))
+	m.bootstrapper = bootstrap.NewBootstrapper[heartbeatpb.MaintainerBootstrapResponse](m.id.Name(), m.getNewBootstrapFn())
+	infos := make([]*node.Info, 0, len(nodes))
+	for _, id := range nodes {
+		infos = append(infos, nodeManager.GetAliveNodes()[id])
+	}
+	m.bootstrapper.HandleNewNodes(infos)
+	// the tables matched by the current filter
+	appcontext.SetService(appcontext.SchemaStore, &mockSchemaStore{tables: []commonEvent.Table{
+		{TableID: 1, SchemaID: 1}, {TableID: 2, SchemaID: 1}, {TableID: 3, SchemaID: 1}, {TableID: 4, SchemaID: 1},
+	}})
+	return m, nodeManager, spans
+}
+
+func newUpdateMaintainerRequest(t *testing.T, m *Maintainer, version uint64, rules ...string) *heartbeatpb.UpdateMaintainerRequest {
+	info, err := m.config.Clone()
+	require.NoError(t, err)
+	info.Config.Filter.Rules = rules
+	data, err := info.Marshal()
+	require.NoError(t, err)
+	return &heartbeatpb.UpdateMaintainerRequest{
+		ChangefeedID:  m.id.ToPB(),
+		Config:        []byte(data),
+		ConfigVersion: version,
+	}
+}
+
+func TestMaintainerUpdateConfig(t *testing.T) {
+	m, _, spans := newConfigTestMaintainer(t, "node1", "node2")
+
+	m.onUpdateMaintainerRequest(newUpdateMaintainerRequest(t, m, 1, "test.*"))
+	require.Equal(t, uint64(1), m.GetMaintainerStatus().ConfigVersion)
+	require.Nil(t, m.pendingConfig)
+	require.Equal(t, []string{"test.*"}, m.config.Config.Filter.Rules)
+	require.Equal(t, uint64(1), m.configVersion)
+	require.Len(t, m.configUpdatingNodes, 2)
+	require.True(t, m.filterChanged)
+
+	// the resent request is ignored
+	m.onUpdateMaintainerRequest(newUpdateMaintainerRequest(t, m, 1, "test.*"))
+	require.Nil(t, m.pendingConfig)
+	require.Equal(t, uint64(1), m.configVersion)
+
+	// the new request waits for the current update
+	m.onUpdateMaintainerRequest(newUpdateMaintainerRequest(t, m, 2, "test.*", "test2.*"))
+	require.Equal(t, uint64(2), m.GetMaintainerStatus().ConfigVersion)
+	require.NotNil(t, m.pendingConfig)
+	require.Equal(t, uint64(1), m.configVersion)
+
+	// table 1 is no longer matched by the new filter, and table 5 is newly matched
+	appcontext.SetService(appcontext.SchemaStore, &mockSchemaStore{tables: []commonEvent.Table{
+		{TableID: 2, SchemaID: 1}, {TableID: 3, SchemaID: 1}, {TableID: 4, SchemaID: 1}, {TableID: 5, SchemaID: 1},
+	}})
+	// the response of a stale version is ignored
+	m.onUpdateDispatcherManagerResponse("node1", &heartbeatpb.UpdateDispatcherManagerResponse{Version: 0})
+	require.Len(t, m.configUpdatingNodes, 2)
+	m.onUpdateDispatcherManagerResponse("node1", &heartbeatpb.UpdateDispatcherManagerResponse{Version: 1})
+	require.Len(t, m.configUpdatingNodes, 1)
+	// the tables are not reloaded until all nodes applied the new filter
+	require.False(t, m.controller.replicationDB.IsTableExists(5))
+
+	m.onUpdateDispatcherManagerResponse("node2", &heartbeatpb.UpdateDispatcherManagerResponse{
+		Version: 1,
+		Err:     &heartbeatpb.RunningError{Message: "update failed"},
+	})
+	require.Empty(t, m.configUpdatingNodes)
+	require.Equal(t, "update failed", m.runningErrors["node2"].Message)
+	require.False(t, m.filterChanged)
+	require.True(t, m.controller.replicationDB.IsTableExists(5))
+	require.NotNil(t, m.controller.operatorController.GetOperator(spans[1].ID))
+
+	// the pending config is applied in the period task
+	m.tryApplyPendingConfig()
+	require.Nil(t, m.pendingConfig)
+	require.Equal(t, uint64(2), m.configVersion)
+	require.Len(t, m.configUpdatingNodes, 2)
+	require.Equal(t, []string{"test.*", "test2.*"}, m.config.Config.Filter.Rules)
+}
+
+func TestMaintainerUpdateConfigWhenNodeRemoved(t *testing.T) {
+	m, nodeManager, _ := newConfigTestMaintainer(t, "node1", "node2")
+	m.onUpdateMaintainerRequest(newUpdateMaintainerRequest(t, m, 1, "test.*"))
+	require.Len(t, m.configUpdatingNodes, 2)
+	appcontext.SetService(appcontext.SchemaStore, &mockSchemaStore{tables: []commonEvent.Table{
+		{TableID: 1, SchemaID: 1}, {TableID: 2, SchemaID: 1}, {TableID: 3, SchemaID: 1}, {TableID: 4, SchemaID: 1}, {TableID: 5, SchemaID: 1},
+	}})
+
+	m.onUpdateDispatcherManagerResponse("node1", &heartbeatpb.UpdateDispatcherManagerResponse{Version: 1})
+	require.False(t, m.controller.replicationDB.IsTableExists(5))
+
+	// the removed node never acks, the tables are reloaded after it's removed
+	delete(nodeManager.GetAliveNodes(), "node2")
+	m.onNodeChanged()
+	require.Empty(t, m.configUpdatingNodes)
+	require.False(t, m.filterChanged)
+	require.True(t, m.controller.replicationDB.IsTableExists(5))
+
+	// the config update without filter changes doesn't reload the tables
+	appcontext.SetService(appcontext.SchemaStore, &mockSchemaStore{})
+	req := newUpdateMaintainerRequest(t, m, 2, "test.*")
+	m.onUpdateMaintainerRequest(req)
+	require.Len(t, m.configUpdatingNodes, 1)
+	require.False(t, m.filterChanged)
+	m.onUpdateDispatcherManagerResponse("node1", &heartbeatpb.UpdateDispatcherManagerResponse{Version: 2})
+	require.Empty(t, m.configUpdatingNodes)
+	require.True(t, m.controller.replicationDB.IsTableExists(5))
+}
+
+func TestMaintainerUpdateInvalidConfig(t *testing.T) {
+	m, _, _ := newConfigTestMaintainer(t, "node1", "node2")
+
+	// the invalid config is not acked, the error is reported to the coordinator
+	m.onUpdateMaintainerRequest(&heartbeatpb.UpdateMaintainerRequest{
+		ChangefeedID:  m.id.ToPB(),
+		Config:        []byte("invalid"),
+		ConfigVersion: 1,
+	})
+	require.Nil(t, m.pendingConfig)
+	status := m.GetMaintainerStatus()
+	require.Equal(t, uint64(0), status.ConfigVersion)
+	require.Len(t, status.Err, 1)
+
+	// the missing sink config is filled with the default one
+	info, err := m.config.Clone()
+	require.NoError(t, err)
+	info.Config.Sink = nil
+	data, err := info.Marshal()
+	require.NoError(t, err)
+	m.onUpdateMaintainerRequest(&heartbeatpb.UpdateMaintainerRequest{
+		ChangefeedID:  m.id.ToPB(),
+		Config:        []byte(data),
+		ConfigVersion: 2,
+	})
+	require.Equal(t, uint64(2), m.GetMaintainerStatus().ConfigVersion)
+	require.NotNil(t, m.config.Config.Sink)
+
+	// the config without sink config can still be sent to the dispatcher managers
+	m.config.Config.Sink = nil
+	require.NotEmpty(t, m.marshalChangefeedConfig())
+}
+
+func TestMaintainerRedoResolvedTs(t *testing.T) {
+	m, _, _ := newConfigTestMaintainer(t, "node1", "node2")
+	m.watermark.RedoResolvedTs = 10
+	m.changefeedCheckpointTsGauge = metrics.ChangefeedCheckpointTsGauge.WithLabelValues(m.id.Namespace(), m.id.Name())
+	m.changefeedCheckpointTsLagGauge = metrics.ChangefeedCheckpointTsLagGauge.WithLabelValues(m.id.Namespace(), m.id.Name())
+	m.changefeedResolvedTsGauge = metrics.ChangefeedResolvedTsGauge.WithLabelValues(m.id.Namespace(), m.id.Name())
+	m.changefeedResolvedTsLagGauge = metrics.ChangefeedResolvedTsLagGauge.WithLabelValues(m.id.Namespace(), m.id.Name())
+	m.changefeedStatusGauge = metrics.ChangefeedStatusGauge.WithLabelValues(m.id.Namespace(), m.id.Name())
+
+	m.checkpointTsByCapture["node1"] = heartbeatpb.Watermark{CheckpointTs: 20, ResolvedTs: 40, RedoResolvedTs: 30}
+	m.calCheckpointTs()
+	// node2 is not reported yet
+	require.Equal(t, uint64(10), m.GetMaintainerStatus().RedoResolvedTs)
+
+	// the redo resolvedTs is the min of all the nodes
+	m.checkpointTsByCapture["node2"] = heartbeatpb.Watermark{CheckpointTs: 15, ResolvedTs: 40, RedoResolvedTs: 25}
+	m.calCheckpointTs()
+	status := m.GetMaintainerStatus()
+	require.Equal(t, uint64(15), status.CheckpointTs)
+	require.Equal(t, uint64(25), status.RedoResolvedTs)
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+	"go.uber.org/zap"
+)
+
+// MergeDispatcherOperator is an operator to remove some contiguous table spans of a table
+// from the dispatchers, and then add a merged span to the replication db.
+// The operator is identified by the first span, the other spans are occupied by
+// the OccupyDispatcherOperator, which forwards the status of the spans to this operator.
+//
+// The merged span starts from the min checkpointTs of the removed spans, so no event is lost,
+// the events between the min checkpointTs and the checkpointTs of a span are replicated again
+// by the new dispatcher, they are written in the safe mode since the safeModeTs of the merged
+// span is set to the max checkpointTs of the removed spans.
+// If the merged span can't be created, the removed spans are marked absent and scheduled
+// again from their own checkpointTs.
+type MergeDispatcherOperator struct {
+	db          *replica.ReplicationDB
+	replicaSets []*replica.SpanReplication
+	originNodes []node.ID
+	mergedSpan  *heartbeatpb.TableSpan
+
+	// stopped[i] is true if the dispatcher of replicaSets[i] is removed
+	stopped       []bool
+	checkpointTs  []uint64
+	scheduleIndex int
+
+	finished atomic.Bool
+	removed  bool
+	canceled bool
+
+	lck sync.Mutex
+}
+
+// NewMergeDispatcherOperator creates a new MergeDispatcherOperator,
+// the replicaSets must be sorted by the start key and contiguous
+func NewMergeDispatcherOperator(db *replica.ReplicationDB,
+	replicaSets []*replica.SpanReplication) *MergeDispatcherOperator {
+	first, last := replicaSets[0], replicaSets[len(replicaSets)-1]
+	op := &MergeDispatcherOperator{
+		db:          db,
+		replicaSets: replicaSets,
+		originNodes: make([]node.ID, len(replicaSets)),
+		mergedSpan: &heartbeatpb.TableSpan{
+			TableID:  first.Span.TableID,
+			StartKey: first.Span.StartKey,
+			EndKey:   last.Span.EndKey,
+		},
+		stopped:      make([]bool, len(replicaSets)),
+		checkpointTs: make([]uint64, len(replicaSets)),
+	}
+	for i, replicaSet := range replicaSets {
+		op.originNodes[i] = replicaSet.GetNodeID()
+		op.checkpointTs[i] = replicaSet.GetStatus().GetCheckpointTs()
+	}
+	return op
+}
+
+func (m *MergeDispatcherOperator) Start() {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	for _, replicaSet := range m.replicaSets {
+		m.db.MarkSpanScheduling(replicaSet)
+	}
+}
+
+// Cancel marks the operator finished without touching the replica sets,
+// it's used when the operator or the occupy operators can't be added to the controller.
+func (m *MergeDispatcherOperator) Cancel() {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	m.canceled = true
+	m.finished.Store(true)
+}
+
+func (m *MergeDispatcherOperator) OnNodeRemove(n node.ID) {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	// the dispatchers on the removed node are stopped,
+	// the last reported checkpointTs is used to start the merged span
+	for i, origin := range m.originNodes {
+		if origin == n && !m.stopped[i] {
+			log.Info("origin node is removed",
+				zap.String("replicaSet", m.replicaSets[i].ID.String()),
+				zap.String("origin", n.String()))
+			m.stopped[i] = true
+		}
+	}
+	m.checkAllStopped()
+}
+
+func (m *MergeDispatcherOperator) ID() common.DispatcherID {
+	return m.replicaSets[0].ID
+}
+
+func (m *MergeDispatcherOperator) IsFinished() bool {
+	return m.finished.Load()
+}
+
+// Check is called with the status of all replica sets merged by the operator
+func (m *MergeDispatcherOperator) Check(from node.ID, status *heartbeatpb.TableSpanStatus) {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	id := common.NewDispatcherIDFromPB(status.ID)
+	for i, replicaSet := range m.replicaSets {
+		if replicaSet.ID != id {
+			continue
+		}
+		if from == m.originNodes[i] && status.ComponentStatus != heartbeatpb.ComponentState_Working {
+			if status.CheckpointTs > m.checkpointTs[i] {
+				m.checkpointTs[i] = status.CheckpointTs
+			}
+			if !m.stopped[i] {
+				log.Info("replica set removed from origin node",
+					zap.Uint64("checkpointTs", m.checkpointTs[i]),
+					zap.String("replicaSet", replicaSet.ID.String()))
+			}
+			m.stopped[i] = true
+		}
+		break
+	}
+	m.checkAllStopped()
+}
+
+// Schedule sends the remove message to the dispatchers not stopped one by one
+func (m *MergeDispatcherOperator) Schedule() *messaging.TargetMessage {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	if m.finished.Load() {
+		return nil
+	}
+	for range m.replicaSets {
+		i := m.scheduleIndex
+		m.scheduleIndex = (m.scheduleIndex + 1) % len(m.replicaSets)
+		if !m.stopped[i] {
+			return m.replicaSets[i].NewRemoveDispatcherMessage(m.originNodes[i])
+		}
+	}
+	return nil
+}
+
+// OnTaskRemoved is called when the task is removed by ddl
+func (m *MergeDispatcherOperator) OnTaskRemoved() {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	log.Info("task removed", zap.String("replicaSet", m.replicaSets[0].ID.String()))
+	m.removed = true
+	m.finished.Store(true)
+}
+
+func (m *MergeDispatcherOperator) PostFinish() {
+	m.lck.Lock()
+	defer m.lck.Unlock()
+
+	if m.removed || m.canceled {
+		return
+	}
+	checkpointTs, safeModeTs := uint64(math.MaxUint64), uint64(0)
+	for _, ts := range m.checkpointTs {
+		if ts < checkpointTs {
+			checkpointTs = ts
+		}
+		if ts > safeModeTs {
+			safeModeTs = ts
+		}
+	}
+	merged := m.db.MergeReplicaSet(m.replicaSets, m.mergedSpan, checkpointTs, safeModeTs)
+	if merged == nil {
+		m.rollback()
+		return
+	}
+	log.Info("merge dispatcher operator finished",
+		zap.String("id", m.replicaSets[0].ID.String()),
+		zap.String("merged", merged.ID.String()),
+		zap.Uint64("checkpointTs", checkpointTs),
+		zap.Uint64("safeModeTs", safeModeTs))
+}
+
+// rollback marks the replica sets still in the db absent, the dispatchers of them are
+// removed already, so they are scheduled again from the checkpointTs reported by the dispatchers
+func (m *MergeDispatcherOperator) rollback() {
+	log.Warn("merge dispatcher operator failed, mark the spans absent",
+		zap.String("id", m.replicaSets[0].ID.String()))
+	for i, replicaSet := range m.replicaSets {
+		if m.db.GetTaskByID(replicaSet.ID) == nil {
+			continue
+		}
+		replicaSet.UpdateStatus(&heartbeatpb.TableSpanStatus{
+			ID:           replicaSet.ID.ToPB(),
+			CheckpointTs: m.checkpointTs[i],
+		})
+		m.db.MarkSpanAbsent(replicaSet)
+	}
+}
+
+func (m *MergeDispatcherOperator) String() string {
+	return fmt.Sprintf("merge dispatcher operator: %s, spans:%d, mergedSpan:[%s,%s]",
+		m.replicaSets[0].ID, len(m.replicaSets),
+		hex.EncodeToString(m.mergedSpan.StartKey), hex.EncodeToString(m.mergedSpan.EndKey))
+}
+
+func (m *MergeDispatcherOperator) Type() string {
+	return "merge"
+}
+
+// GetOccupyOperators returns the operators to occupy the replica sets merged by the operator
+// except the first one, which is identified by the operator itself
+func (m *MergeDispatcherOperator) GetOccupyOperators() []*OccupyDispatcherOperator {
+	ops := make([]*OccupyDispatcherOperator, 0, len(m.replicaSets)-1)
+	for _, replicaSet := range m.replicaSets[1:] {
+		ops = append(ops, NewOccupyDispatcherOperator(replicaSet, m))
+	}
+	return ops
+}
+
+func (m *MergeDispatcherOperator) checkAllStopped() {
+	for _, stopped := range m.stopped {
+		if !stopped {
+			return
+		}
+	}
+	m.finished.Store(true)
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTsoClient struct{}
+
+func (m *mockTsoClient) GetTS(_ context.Context) (int64, int64, error) {
+	return 0, 0, nil
+}
+
+func newMergeTestDB(t *testing.T) (*replica.ReplicationDB, []*replica.SpanReplication) {
+	cfID := common.NewChangeFeedIDWithName("test")
+	ddlDispatcherID := common.NewDispatcherID()
+	ddlSpan := replica.NewWorkingReplicaSet(cfID, ddlDispatcherID, nil,
+		heartbeatpb.DDLSpanSchemaID, heartbeatpb.DDLSpan,
+		&heartbeatpb.TableSpanStatus{
+			ID:              ddlDispatcherID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Working,
+			CheckpointTs:    1,
+		}, "node1")
+	db := replica.NewReplicaSetDB(cfID, ddlSpan)
+
+	keys := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	spans := make([]*replica.SpanReplication, 0, len(keys)-1)
+	for i := 0; i < len(keys)-1; i++ {
+		id := common.NewDispatcherID()
+		span := replica.NewWorkingReplicaSet(cfID, id, &mockTsoClient{}, 1,
+			&heartbeatpb.TableSpan{TableID: 1, StartKey: keys[i], EndKey: keys[i+1]},
+			&heartbeatpb.TableSpanStatus{
+				ID:              id.ToPB(),
+				ComponentStatus: heartbeatpb.ComponentState_Working,
+				CheckpointTs:    10,
+			}, node.ID("node1"))
+		db.AddReplicatingSpan(span)
+		spans = append(spans, span)
+	}
+	require.Equal(t, 2, db.GetReplicatingSize())
+	return db, spans
+}
+
+// stopSpans reports the dispatchers of the spans are stopped with the checkpointTs
+func stopSpans(op *MergeDispatcherOperator, spans []*replica.SpanReplication, checkpointTs ...uint64) {
+	for i, span := range spans {
+		op.Check("node1", &heartbeatpb.TableSpanStatus{
+			ID:              span.ID.ToPB(),
+			ComponentStatus: heartbeatpb.ComponentState_Stopped,
+			CheckpointTs:    checkpointTs[i],
+		})
+	}
+}
+
+func TestMergeDispatcherOperator(t *testing.T) {
+	db, spans := newMergeTestDB(t)
+	op := NewMergeDispatcherOperator(db, spans)
+	op.Start()
+	require.Equal(t, 2, db.GetSchedulingSize())
+
+	stopSpans(op, spans, 20, 30)
+	require.True(t, op.IsFinished())
+	op.PostFinish()
+
+	// the merged span starts from the min checkpointTs of the spans
+	require.Nil(t, db.GetTaskByID(spans[0].ID))
+	require.Nil(t, db.GetTaskByID(spans[1].ID))
+	require.Equal(t, 1, db.GetAbsentSize())
+	merged := db.GetAbsent(nil, 1)[0]
+	require.Equal(t, []byte("a"), merged.Span.StartKey)
+	require.Equal(t, []byte("c"), merged.Span.EndKey)
+	require.Equal(t, uint64(20), merged.GetStatus().CheckpointTs)
+
+	// the events replicated again are written in the safe mode
+	msg, err := merged.NewAddDispatcherMessage("node2")
+	require.NoError(t, err)
+	req := msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
+	require.Equal(t, uint64(20), req.Config.StartTs)
+	require.GreaterOrEqual(t, req.Config.CurrentPdTs, uint64(30))
+}
+
+func TestMergeDispatcherOperatorMergeFailed(t *testing.T) {
+	db, spans := newMergeTestDB(t)
+	op := NewMergeDispatcherOperator(db, spans)
+	op.Start()
+
+	stopSpans(op, spans, 20, 30)
+	require.True(t, op.IsFinished())
+	// the second span is removed from the db, the merged span can't be created
+	db.ForceRemove(spans[1].ID)
+	op.PostFinish()
+
+	// the first span is scheduled again from its own checkpointTs
+	require.Equal(t, 1, db.GetAbsentSize())
+	require.True(t, db.IsAbsent(spans[0]))
+	require.Equal(t, 0, db.GetSchedulingSize())
+	require.Equal(t, uint64(20), spans[0].GetStatus().CheckpointTs)
+	msg, err := spans[0].NewAddDispatcherMessage("node2")
+	require.NoError(t, err)
+	req := msg.Message[0].(*heartbeatpb.ScheduleDispatcherRequest)
+	require.Equal(t, uint64(20), req.Config.StartTs)
+}
@@ -0,0 +1,78 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package operator
+
+import (
+	"fmt"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/maintainer/replica"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/messaging"
+	"github.com/pingcap/ticdc/pkg/node"
+)
+
+// OccupyDispatcherOperator occupies a span handled by another operator, like the MergeDispatcherOperator,
+// so no other operator can be added to the span, and the status of the span is forwarded to the owner operator.
+type OccupyDispatcherOperator struct {
+	replicaSet *replica.SpanReplication
+	owner      *MergeDispatcherOperator
+}
+
+func NewOccupyDispatcherOperator(replicaSet *replica.SpanReplication,
+	owner *MergeDispatcherOperator) *OccupyDispatcherOperator {
+	return &OccupyDispatcherOperator{
+		replicaSet: replicaSet,
+		owner:      owner,
+	}
+}
+
+// Start does nothing, the span is marked as scheduling by the owner
+func (m *OccupyDispatcherOperator) Start() {}
+
+// OnNodeRemove does nothing, the owner is notified by the controller too
+func (m *OccupyDispatcherOperator) OnNodeRemove(_ node.ID) {}
+
+func (m *OccupyDispatcherOperator) ID() common.DispatcherID {
+	return m.replicaSet.ID
+}
+
+func (m *OccupyDispatcherOperator) IsFinished() bool {
+	return m.owner.IsFinished()
+}
+
+func (m *OccupyDispatcherOperator) Check(from node.ID, status *heartbeatpb.TableSpanStatus) {
+	m.owner.Check(from, status)
+}
+
+// Schedule returns nil, the messages are sent by the owner
+func (m *OccupyDispatcherOperator) Schedule() *messaging.TargetMessage {
+	return nil
+}
+
+func (m *OccupyDispatcherOperator) OnTaskRemoved() {
+	m.owner.OnTaskRemoved()
+}
+
+// PostFinish does nothing, the replication db is updated by the owner
+func (m *OccupyDispatcherOperator) PostFinish() {}
+
+func (m *OccupyDispatcherOperator) String() string {
+	return fmt.Sprintf("occupy dispatcher operator: %s, owner:%s",
+		m.replicaSet.ID, m.owner.ID())
+}
+
+func (m *OccupyDispatcherOperator) Type() string {
+	return "occupy"
+}
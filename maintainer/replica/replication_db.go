@@ -14,6 +14,8 @@
 package replica
 
 import (
+	"bytes"
+	"sort"
 	"sync"
 
 	"github.com/pingcap/log"
@@ -148,7 +150,27 @@ func (db *ReplicationDB) GetReplicatingSize() int {
 	return len(db.replicating)
 }
 
-// GetReplicating returns the replicating spans
+// IsReplicating returns true if the span is in the replicating map
+func (db *ReplicationDB) IsReplicating(task *SpanReplication) bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	_, ok := db.replicating[task.ID]
+	return ok
+}
+
+// IsAbsent returns true if the span is in the absent map
+func (db *ReplicationDB) IsAbsent(task *SpanReplication) bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	_, ok := db.absent[task.ID]
+	return ok
+}
+
+// GetReplicating returns the replicating spans, ordered by the table id,
+// the start key of the span and the dispatcher id, so the callers picking
+// spans from the result get the same spans every time.
 func (db *ReplicationDB) GetReplicating() []*SpanReplication {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -157,9 +179,22 @@ func (db *ReplicationDB) GetReplicating() []*SpanReplication {
 	for _, stm := range db.replicating {
 		replicating = append(replicating, stm)
 	}
+	sort.Slice(replicating, func(i, j int) bool {
+		return lessSpanReplication(replicating[i], replicating[j])
+	})
 	return replicating
 }
 
+func lessSpanReplication(a, b *SpanReplication) bool {
+	if a.Span.TableID != b.Span.TableID {
+		return a.Span.TableID < b.Span.TableID
+	}
+	if c := bytes.Compare(a.Span.StartKey, b.Span.StartKey); c != 0 {
+		return c < 0
+	}
+	return a.ID.Less(b.ID)
+}
+
 // GetAbsent returns the absent spans with the maxSize, push the spans to the buffer
 func (db *ReplicationDB) GetAbsent(buffer []*SpanReplication, maxSize int) []*SpanReplication {
 	db.lock.RLock()
@@ -320,6 +355,38 @@ func (db *ReplicationDB) ReplaceReplicaSet(old *SpanReplication, newSpans []*hea
 	return true
 }
 
+// MergeReplicaSet replaces the old replica sets with one new span,
+// the new span is added to the absent map and starts from the checkpointTs,
+// the events with commitTs not larger than the safeModeTs are written in the safe mode
+func (db *ReplicationDB) MergeReplicaSet(olds []*SpanReplication, newSpan *heartbeatpb.TableSpan, checkpointTs, safeModeTs uint64) *SpanReplication {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	// first check all the old replica sets exist, if not, return nil
+	for _, old := range olds {
+		if _, ok := db.allTasks[old.ID]; !ok {
+			log.Warn("old replica set not found, skip",
+				zap.String("changefeed", db.changefeedID.Name()),
+				zap.String("span", old.ID.String()))
+			return nil
+		}
+	}
+
+	primary := olds[0]
+	merged := NewReplicaSet(
+		primary.ChangefeedID,
+		common.NewDispatcherID(),
+		primary.GetTsoClient(),
+		primary.GetSchemaID(),
+		newSpan, checkpointTs)
+	merged.safeModeTs = safeModeTs
+
+	// remove and insert the new replica set
+	db.removeSpanUnLock(olds...)
+	db.addAbsentReplicaSetUnLock(merged)
+	return merged
+}
+
 // AddReplicatingSpan adds a replicating the replicating map, that means the task is already scheduled to a dispatcher
 func (db *ReplicationDB) AddReplicatingSpan(task *SpanReplication) {
 	db.lock.Lock()
@@ -16,6 +16,7 @@ package replica
 import (
 	"context"
 	"encoding/hex"
+	"sync"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -37,10 +38,17 @@ type SpanReplication struct {
 	Span         *heartbeatpb.TableSpan
 	ChangefeedID common.ChangeFeedID
 
+	// lock protects the schemaID, nodeID and status, they are read by the open api
+	lock     sync.RWMutex
 	schemaID int64
 	nodeID   node.ID
 	status   *heartbeatpb.TableSpanStatus
 
+	// safeModeTs is the max commitTs of the events which may be written to the downstream
+	// before the dispatcher is created, these events are written in the safe mode
+	// by the dispatcher, it's set when the span is merged from the spans at different checkpoints.
+	safeModeTs uint64
+
 	tsoClient TSOClient
 }
 
@@ -104,13 +112,32 @@ func NewWorkingReplicaSet(
 
 func (r *SpanReplication) UpdateStatus(newStatus *heartbeatpb.TableSpanStatus) {
 	if newStatus != nil {
+		r.lock.Lock()
+		defer r.lock.Unlock()
 		if newStatus.CheckpointTs >= r.status.CheckpointTs {
+			// the status forwarded by the block event carries no resolved ts, keep the reported one,
+			// the status is copied since it's owned by the caller.
+			if newStatus.ResolvedTs < r.status.ResolvedTs {
+				status := *newStatus
+				status.ResolvedTs = r.status.ResolvedTs
+				newStatus = &status
+			}
 			r.status = newStatus
 		}
 	}
 }
 
+// GetStatus returns the latest status reported by the dispatcher of the span,
+// the returned status must not be modified.
+func (r *SpanReplication) GetStatus() *heartbeatpb.TableSpanStatus {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.status
+}
+
 func (r *SpanReplication) GetSchemaID() int64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
 	return r.schemaID
 }
 
@@ -119,14 +146,20 @@ func (r *SpanReplication) GetTsoClient() TSOClient {
 }
 
 func (r *SpanReplication) SetSchemaID(schemaID int64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
 	r.schemaID = schemaID
 }
 
 func (r *SpanReplication) SetNodeID(n node.ID) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
 	r.nodeID = n
 }
 
 func (r *SpanReplication) GetNodeID() node.ID {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
 	return r.nodeID
 }
 
@@ -135,6 +168,10 @@ func (r *SpanReplication) NewAddDispatcherMessage(server node.ID) (*messaging.Ta
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	// the events with commitTs not larger than the current pd ts are written in the safe mode
+	if ts < r.safeModeTs {
+		ts = r.safeModeTs
+	}
 	return messaging.NewSingleTargetMessage(server,
 		messaging.HeartbeatCollectorTopic,
 		&heartbeatpb.ScheduleDispatcherRequest{
@@ -142,7 +179,7 @@ func (r *SpanReplication) NewAddDispatcherMessage(server node.ID) (*messaging.Ta
 			Config: &heartbeatpb.DispatcherConfig{
 				DispatcherID: r.ID.ToPB(),
 				Span:         r.Span,
-				StartTs:      r.status.CheckpointTs,
+				StartTs:      r.GetStatus().CheckpointTs,
 				CurrentPdTs:  ts,
 			},
 			ScheduleAction: heartbeatpb.ScheduleAction_Create,
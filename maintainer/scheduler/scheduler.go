@@ -15,19 +15,25 @@ package scheduler
 
 import (
 	"math/rand"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/heartbeatpb"
 	"github.com/pingcap/ticdc/maintainer/operator"
 	"github.com/pingcap/ticdc/maintainer/replica"
 	"github.com/pingcap/ticdc/pkg/common"
 	"github.com/pingcap/ticdc/pkg/node"
 	"github.com/pingcap/ticdc/pkg/scheduler"
 	"github.com/pingcap/ticdc/server/watcher"
+	"go.uber.org/zap"
 )
 
 // Scheduler generates operators for the spans, and push them to the operator controller
 // it generates add operator for the absent spans, and move operator for the unbalanced replicating spans
 // currently, it only supports balance the spans by size
+// it also moves the spans out of the draining nodes, and moves the tables to the nodes requested by the move table requests
 type Scheduler struct {
 	batchSize            int
 	changefeedID         common.ChangeFeedID
@@ -40,6 +46,18 @@ type Scheduler struct {
 
 	// buffer for the absent spans
 	absent []*replica.SpanReplication
+
+	// tables moved by the move table requests, they are pinned to the target node and skipped by the balance
+	pinnedTables struct {
+		sync.Mutex
+		m map[int64]*pinnedTable
+	}
+}
+
+type pinnedTable struct {
+	target node.ID
+	// moving is true until all spans of the table are replicating on the target node
+	moving bool
 }
 
 func NewScheduler(changefeedID common.ChangeFeedID,
@@ -48,7 +66,7 @@ func NewScheduler(changefeedID common.ChangeFeedID,
 	db *replica.ReplicationDB,
 	nodeManager *watcher.NodeManager,
 	balanceInterval time.Duration) *Scheduler {
-	return &Scheduler{
+	s := &Scheduler{
 		batchSize:            batchSize,
 		random:               rand.New(rand.NewSource(time.Now().UnixNano())),
 		changefeedID:         changefeedID,
@@ -59,6 +77,8 @@ func NewScheduler(changefeedID common.ChangeFeedID,
 		lastRebalanceTime:    time.Now(),
 		absent:               make([]*replica.SpanReplication, 0, batchSize),
 	}
+	s.pinnedTables.m = make(map[int64]*pinnedTable)
+	return s
 }
 
 // Execute periodically execute the operator
@@ -73,24 +93,155 @@ func (s *Scheduler) Execute() time.Time {
 			return time.Now().Add(time.Millisecond * 100)
 		}
 		absent := s.replicationDB.GetAbsent(s.absent, availableSize)
-		nodeSize := s.replicationDB.GetTaskSizePerNode()
-		// add the absent node to the node size map
-		// todo: use the bootstrap nodes
-		for id, _ := range s.nodeManager.GetAliveNodes() {
-			if _, ok := nodeSize[id]; !ok {
-				nodeSize[id] = 0
-			}
-		}
+		nodeSize := s.getSchedulableNodeSize()
 		scheduler.BasicSchedule(availableSize, absent, nodeSize, func(replication *replica.SpanReplication, id node.ID) bool {
 			return s.operatorController.AddOperator(operator.NewAddDispatcherOperator(s.replicationDB, replication, id))
 		})
 		s.absent = absent[:0]
 	} else {
-		s.balance()
+		s.moveTables()
+		if !s.drain() {
+			s.balance()
+		}
 	}
 	return time.Now().Add(time.Millisecond * 500)
 }
 
+// MoveTable moves all spans of the table to the target node, and pins the table to the target node
+func (s *Scheduler) MoveTable(tableID int64, target node.ID) {
+	s.pinnedTables.Lock()
+	defer s.pinnedTables.Unlock()
+
+	log.Info("move table to the target node",
+		zap.String("changefeed", s.changefeedID.Name()),
+		zap.Int64("table", tableID),
+		zap.Stringer("target", target))
+	s.pinnedTables.m[tableID] = &pinnedTable{target: target, moving: true}
+}
+
+// GetPinnedTables returns the tables pinned by the move table requests, sorted by the table id
+func (s *Scheduler) GetPinnedTables() []*heartbeatpb.PinnedTable {
+	s.pinnedTables.Lock()
+	defer s.pinnedTables.Unlock()
+
+	tables := make([]*heartbeatpb.PinnedTable, 0, len(s.pinnedTables.m))
+	for tableID, t := range s.pinnedTables.m {
+		tables = append(tables, &heartbeatpb.PinnedTable{
+			TableId:      tableID,
+			TargetNodeId: t.target.String(),
+			Moving:       t.moving,
+		})
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].TableId < tables[j].TableId })
+	return tables
+}
+
+// moveTables generates operators to move the spans of the pinned tables to the target node
+func (s *Scheduler) moveTables() {
+	s.pinnedTables.Lock()
+	defer s.pinnedTables.Unlock()
+
+	if len(s.pinnedTables.m) == 0 {
+		return
+	}
+	schedulableNodes := s.nodeManager.GetSchedulableNodes()
+	for tableID, t := range s.pinnedTables.m {
+		spans := s.replicationDB.GetTasksByTableIDs(tableID)
+		if _, ok := schedulableNodes[t.target]; !ok || len(spans) == 0 {
+			log.Info("table is unpinned, since the target node is unavailable or the table is removed",
+				zap.String("changefeed", s.changefeedID.Name()),
+				zap.Int64("table", tableID),
+				zap.Stringer("target", t.target))
+			delete(s.pinnedTables.m, tableID)
+			continue
+		}
+		if !t.moving {
+			continue
+		}
+		finished := true
+		for _, span := range spans {
+			replicating := s.replicationDB.IsReplicating(span)
+			if replicating && span.GetNodeID() == t.target {
+				continue
+			}
+			finished = false
+			if s.operatorController.GetOperator(span.ID) != nil {
+				continue
+			}
+			if replicating {
+				s.operatorController.AddOperator(operator.NewMoveDispatcherOperator(s.replicationDB, span, span.GetNodeID(), t.target))
+			} else if s.replicationDB.IsAbsent(span) {
+				s.operatorController.AddOperator(operator.NewAddDispatcherOperator(s.replicationDB, span, t.target))
+			}
+		}
+		if finished {
+			log.Info("table is moved to the target node",
+				zap.String("changefeed", s.changefeedID.Name()),
+				zap.Int64("table", tableID),
+				zap.Stringer("target", t.target))
+			t.moving = false
+		}
+	}
+}
+
+// drain moves the spans out of the draining nodes, it returns false if there is no draining node
+func (s *Scheduler) drain() bool {
+	drainingNodes := s.nodeManager.GetDrainingNodes()
+	if len(drainingNodes) == 0 {
+		return false
+	}
+	availableSize := s.batchSize - s.operatorController.OperatorSize()
+	if availableSize <= 0 {
+		return true
+	}
+	var victims []*replica.SpanReplication
+	for _, id := range drainingNodes {
+		if s.replicationDB.GetTaskSizeByNodeID(id) == 0 {
+			continue
+		}
+		for _, span := range s.replicationDB.GetTaskByNodeID(id) {
+			if s.replicationDB.IsReplicating(span) {
+				victims = append(victims, span)
+			}
+		}
+	}
+	if len(victims) == 0 {
+		return true
+	}
+	scheduler.BasicSchedule(availableSize, victims, s.getSchedulableNodeSize(), func(replication *replica.SpanReplication, id node.ID) bool {
+		return s.operatorController.AddOperator(operator.NewMoveDispatcherOperator(s.replicationDB, replication, replication.GetNodeID(), id))
+	})
+	return true
+}
+
+// getSchedulableNodeSize returns the task size of the schedulable nodes, including the nodes without tasks
+func (s *Scheduler) getSchedulableNodeSize() map[node.ID]int {
+	nodeSize := s.replicationDB.GetTaskSizePerNode()
+	schedulableNodes := s.nodeManager.GetSchedulableNodes()
+	for id := range nodeSize {
+		if _, ok := schedulableNodes[id]; !ok {
+			delete(nodeSize, id)
+		}
+	}
+	// add the absent node to the node size map
+	// todo: use the bootstrap nodes
+	for id := range schedulableNodes {
+		if _, ok := nodeSize[id]; !ok {
+			nodeSize[id] = 0
+		}
+	}
+	return nodeSize
+}
+
+// IsTablePinned returns true if the table is pinned by the move table requests
+func (s *Scheduler) IsTablePinned(tableID int64) bool {
+	s.pinnedTables.Lock()
+	defer s.pinnedTables.Unlock()
+
+	_, ok := s.pinnedTables.m[tableID]
+	return ok
+}
+
 // balance balances the spans by size
 func (s *Scheduler) balance() {
 	if time.Since(s.lastRebalanceTime) < s.checkBalanceInterval {
@@ -106,13 +257,25 @@ func (s *Scheduler) balance() {
 		return
 	}
 
-	// check the balance status
-	moveSize := scheduler.CheckBalanceStatus(s.replicationDB.GetTaskSizePerNode(), s.nodeManager.GetAliveNodes())
+	// check the balance status, the draining nodes are neither the source nor the dest of the balance
+	moveSize := scheduler.CheckBalanceStatus(s.getSchedulableNodeSize(), s.nodeManager.GetSchedulableNodes())
 	if moveSize <= 0 {
 		// fast check the balance status, no need to do the balance,skip
 		return
 	}
-	scheduler.Balance(s.batchSize, s.random, s.nodeManager.GetAliveNodes(), s.replicationDB.GetReplicating(), func(replication *replica.SpanReplication, id node.ID) bool {
+	schedulableNodes := s.nodeManager.GetSchedulableNodes()
+	replicating := s.replicationDB.GetReplicating()
+	spans := make([]*replica.SpanReplication, 0, len(replicating))
+	for _, span := range replicating {
+		if _, ok := schedulableNodes[span.GetNodeID()]; ok {
+			spans = append(spans, span)
+		}
+	}
+	// the pinned tables are counted in the node size, but they are not moved by the balance
+	scheduler.Balance(s.batchSize, s.random, schedulableNodes, spans, func(replication *replica.SpanReplication, id node.ID) bool {
+		if s.IsTablePinned(replication.Span.TableID) {
+			return false
+		}
 		return s.operatorController.AddOperator(operator.NewMoveDispatcherOperator(s.replicationDB, replication, replication.GetNodeID(), id))
 	})
 	s.lastRebalanceTime = now
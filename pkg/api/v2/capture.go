@@ -15,6 +15,7 @@ package v2
 
 import (
 	"context"
+	"fmt"
 
 	v2 "github.com/pingcap/ticdc/api/v2"
 	"github.com/pingcap/ticdc/pkg/api/internal/rest"
@@ -29,6 +30,8 @@ type CapturesGetter interface {
 // We can also mock the capture operations by implement this interface.
 type CaptureInterface interface {
 	List(ctx context.Context) ([]v2.Capture, error)
+	Drain(ctx context.Context, captureID string) (*v2.DrainCaptureResp, error)
+	Undrain(ctx context.Context, captureID string) error
 }
 
 // captures implements CaptureInterface
@@ -52,3 +55,23 @@ func (c *captures) List(ctx context.Context) ([]v2.Capture, error) {
 		Into(result)
 	return result.Items, err
 }
+
+// Drain drains the capture, it returns the number of maintainers and tables
+// still running on the capture
+func (c *captures) Drain(ctx context.Context, captureID string) (*v2.DrainCaptureResp, error) {
+	result := &v2.DrainCaptureResp{}
+	u := fmt.Sprintf("captures/%s/drain", captureID)
+	err := c.client.Post().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// Undrain cancels the draining of the capture
+func (c *captures) Undrain(ctx context.Context, captureID string) error {
+	u := fmt.Sprintf("captures/%s/drain", captureID)
+	return c.client.Delete().
+		WithURI(u).
+		Do(ctx).Error()
+}
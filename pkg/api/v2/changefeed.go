@@ -47,6 +47,10 @@ type ChangefeedInterface interface {
 	Get(ctx context.Context, namespace string, name string) (*v2.ChangeFeedInfo, error)
 	// List lists all changefeeds
 	List(ctx context.Context, namespace string, state string) ([]v2.ChangefeedCommonInfo, error)
+	// ListSpans lists all span replications of the changefeed
+	ListSpans(ctx context.Context, namespace string, name string) ([]v2.SpanReplication, error)
+	// MoveTable moves a table of the changefeed to the target capture
+	MoveTable(ctx context.Context, namespace string, name string, cfg *v2.MoveTableConfig) (*v2.MoveTableResp, error)
 }
 
 // changefeeds implements ChangefeedInterface
@@ -157,3 +161,30 @@ func (c *changefeeds) List(ctx context.Context,
 		Into(result)
 	return result.Items, err
 }
+
+// MoveTable moves a table of the changefeed to the target capture
+func (c *changefeeds) MoveTable(ctx context.Context,
+	namespace string, name string, cfg *v2.MoveTableConfig,
+) (*v2.MoveTableResp, error) {
+	result := &v2.MoveTableResp{}
+	u := fmt.Sprintf("changefeeds/%s/tables/move?namespace=%s", name, namespace)
+	err := c.client.Post().
+		WithURI(u).
+		WithBody(cfg).
+		Do(ctx).
+		Into(result)
+	return result, err
+}
+
+// ListSpans lists all span replications of the changefeed
+func (c *changefeeds) ListSpans(ctx context.Context,
+	namespace string, name string,
+) ([]v2.SpanReplication, error) {
+	result := &v2.ListResponse[v2.SpanReplication]{}
+	u := fmt.Sprintf("changefeeds/%s/spans?namespace=%s", name, namespace)
+	err := c.client.Get().
+		WithURI(u).
+		Do(ctx).
+		Into(result)
+	return result.Items, err
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	timodel "github.com/pingcap/tiflow/cdc/model"
+	"go.uber.org/zap"
+)
+
+// OnConflictResolved is called when the transaction leaves the conflict detector.
+func (t *DMLEvent) OnConflictResolved() {}
+
+// ConflictKeys returns the hashes of the primary key and unique key values of all rows
+// in the transaction, the transactions with a same hash can't be executed concurrently.
+// If a row has no available key, the table id is used, so the rows of the table are executed in order.
+func (t *DMLEvent) ConflictKeys() []uint64 {
+	if t.Len() == 0 || t.Rows == nil {
+		return nil
+	}
+
+	indexes := uniqueIndexColumnsOffset(t.TableInfo)
+	hashRes := make(map[uint64]struct{}, len(t.RowTypes))
+	hasher := fnv.New32a()
+	addKey := func(key []byte) {
+		if n, err := hasher.Write(key); n != len(key) || err != nil {
+			log.Panic("transaction key hash fail", zap.Error(err))
+		}
+		hashRes[uint64(hasher.Sum32())] = struct{}{}
+		hasher.Reset()
+	}
+	for i := 0; i < t.Rows.NumRows(); i++ {
+		row := t.Rows.GetRow(i)
+		hasKey := false
+		for iIdx, colOffsets := range indexes {
+			key := genKeyList(&row, t.TableInfo, iIdx, colOffsets, t.PhysicalTableID)
+			if len(key) == 0 {
+				continue
+			}
+			addKey(key)
+			hasKey = true
+		}
+		if !hasKey {
+			// use table ID as key if no key generated (no PK/UK),
+			// no concurrence for rows in the same table.
+			tableKey := make([]byte, 8)
+			binary.BigEndian.PutUint64(tableKey, uint64(t.PhysicalTableID))
+			addKey(tableKey)
+		}
+	}
+
+	keys := make([]uint64, 0, len(hashRes))
+	for key := range hashRes {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// uniqueIndexColumnsOffset returns the offsets of the columns of the primary key and unique indexes,
+// the offsets are the positions of the columns in the rows of DMLEvent.
+// The indexes containing generated columns are ignored, because the generated values can't be
+// specified by the DML.
+func uniqueIndexColumnsOffset(tableInfo *common.TableInfo) [][]int {
+	var result [][]int
+	if tableInfo.PKIsHandle {
+		for i, col := range tableInfo.Columns {
+			if mysql.HasPriKeyFlag(col.GetFlag()) {
+				result = append(result, []int{i})
+				break
+			}
+		}
+	}
+	for _, idx := range tableInfo.Indices {
+		if !idx.Primary && !idx.Unique {
+			continue
+		}
+		offsets := make([]int, 0, len(idx.Columns))
+		for _, idxCol := range idx.Columns {
+			if tableInfo.Columns[idxCol.Offset].IsGenerated() {
+				offsets = nil
+				break
+			}
+			offsets = append(offsets, idxCol.Offset)
+		}
+		if len(offsets) > 0 {
+			result = append(result, offsets)
+		}
+	}
+	return result
+}
+
+func genKeyList(row *chunk.Row, tableInfo *common.TableInfo, iIdx int, colOffsets []int, tableID int64) []byte {
+	var key []byte
+	for _, i := range colOffsets {
+		// if a column value is null, we can ignore this index
+		if row.IsNull(i) {
+			return nil
+		}
+		col := tableInfo.Columns[i]
+		value, err := common.FormatColVal(row, col, i)
+		if err != nil {
+			log.Panic("FormatColVal failed", zap.Error(err))
+		}
+		if value == nil {
+			return nil
+		}
+		val := timodel.ColumnValueString(value)
+		if columnNeeds2LowerCase(col.GetType(), col.GetCollate()) {
+			val = strings.ToLower(val)
+		}
+		key = append(key, []byte(val)...)
+		key = append(key, 0)
+	}
+	if len(key) == 0 {
+		return nil
+	}
+	tableKey := make([]byte, 16)
+	binary.BigEndian.PutUint64(tableKey[:8], uint64(iIdx))
+	binary.BigEndian.PutUint64(tableKey[8:], uint64(tableID))
+	return append(key, tableKey...)
+}
+
+func columnNeeds2LowerCase(mysqlType byte, collation string) bool {
+	switch mysqlType {
+	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+		return strings.HasSuffix(collation, "_ci")
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func hasConflict(a, b []uint64) bool {
+	keys := make(map[uint64]struct{}, len(a))
+	for _, k := range a {
+		keys[k] = struct{}{}
+	}
+	for _, k := range b {
+		if _, ok := keys[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDMLEventConflictKeys(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	helper.DDL2Job("create table t1 (id int primary key, name varchar(32) collate utf8mb4_general_ci, unique key uk(name))")
+	helper.DDL2Job("create table t2 (id int, name varchar(32))")
+
+	event1 := helper.DML2Event("test", "t1", "insert into t1 values (1, 'a')")
+	event2 := helper.DML2Event("test", "t1", "insert into t1 values (2, 'c')")
+	keys1 := event1.ConflictKeys()
+	require.Len(t, keys1, 2)
+	require.False(t, hasConflict(keys1, event2.ConflictKeys()))
+
+	// the update event contains the keys of both the pre and post rows
+	// DML2RawKv returns the last row of the table, so the updated row must be the last one
+	oldValue := helper.DML2RawKv("test", "t1", "insert into t1 values (3, 'b')")[0].Value
+	rawKv3 := helper.DML2RawKv("test", "t1", "update t1 set name = 'd' where id = 3")[0]
+	rawKv3.OldValue = oldValue
+	event3 := NewDMLEvent(common.NewDispatcherID(), event1.PhysicalTableID, event1.StartTs, event1.CommitTs, event1.TableInfo)
+	require.NoError(t, event3.AppendRow(rawKv3, helper.mounter.DecodeToChunk, nil))
+	require.Len(t, event3.ConflictKeys(), 3)
+	require.False(t, hasConflict(keys1, event3.ConflictKeys()))
+	require.False(t, hasConflict(event2.ConflictKeys(), event3.ConflictKeys()))
+
+	// conflict with event1 by the case insensitive unique key
+	helper.tk.MustExec("delete from t1 where id = 1")
+	event4 := helper.DML2Event("test", "t1", "insert into t1 values (4, 'A')")
+	require.True(t, hasConflict(keys1, event4.ConflictKeys()))
+
+	// the null unique key is ignored
+	event5 := helper.DML2Event("test", "t1", "insert into t1 values (5, null)")
+	require.Len(t, event5.ConflictKeys(), 1)
+
+	// the table without primary key and unique key uses the table id as the key
+	event6 := helper.DML2Event("test", "t2", "insert into t2 values (1, 'a')")
+	event7 := helper.DML2Event("test", "t2", "insert into t2 values (2, 'b')")
+	require.Len(t, event6.ConflictKeys(), 1)
+	require.Equal(t, event6.ConflictKeys(), event7.ConflictKeys())
+}
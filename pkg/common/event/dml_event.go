@@ -81,10 +81,13 @@ func NewDMLEvent(
 	}
 }
 
+// AppendRow decodes the raw kv entry and appends the row change to the event.
+// If filter is not nil, the row change is discarded when the filter returns true.
 func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
 	decode func(
 		rawKv *common.RawKVEntry,
 		tableInfo *common.TableInfo, chk *chunk.Chunk) (int, error),
+	filter func(rowType RowType, preRow, row chunk.Row) (bool, error),
 ) error {
 	RowType := RowTypeInsert
 	if raw.OpType == common.OpTypeDelete {
@@ -93,10 +96,30 @@ func (t *DMLEvent) AppendRow(raw *common.RawKVEntry,
 	if len(raw.Value) != 0 && len(raw.OldValue) != 0 {
 		RowType = RowTypeUpdate
 	}
+	numRows := t.Rows.NumRows()
 	count, err := decode(raw, t.TableInfo, t.Rows)
 	if err != nil {
 		return err
 	}
+	if filter != nil && count > 0 {
+		var preRow, row chunk.Row
+		switch {
+		case count == 2:
+			preRow, row = t.Rows.GetRow(numRows), t.Rows.GetRow(numRows+1)
+		case RowType == RowTypeDelete:
+			preRow = t.Rows.GetRow(numRows)
+		default:
+			row = t.Rows.GetRow(numRows)
+		}
+		ignore, err := filter(RowType, preRow, row)
+		if err != nil {
+			return err
+		}
+		if ignore {
+			t.Rows.TruncateTo(numRows)
+			return nil
+		}
+	}
 	if count == 1 {
 		t.RowTypes = append(t.RowTypes, RowType)
 	} else if count == 2 {
@@ -175,6 +198,13 @@ func (t *DMLEvent) GetNextRow() (RowChange, bool) {
 	return RowChange{}, false
 }
 
+// Rewind resets the row iterator, so the next GetNextRow returns the first row again.
+// It is used when the rows of a transaction need to be consumed more than once,
+// for example, written to the redo log before being written to the sink.
+func (t *DMLEvent) Rewind() {
+	t.offset = 0
+}
+
 // Len returns the number of row change events in the transaction.
 // Note: An update event is counted as 1 row.
 func (t *DMLEvent) Len() int32 {
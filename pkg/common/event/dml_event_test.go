@@ -3,6 +3,7 @@ package event
 import (
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/stretchr/testify/require"
 )
 
@@ -78,3 +79,31 @@ func TestEncodeAndDecodeV0(t *testing.T) {
 	reverseEvent.eventSize = 0
 	require.Equal(t, dmlEvent, reverseEvent)
 }
+
+func TestDMLEventAppendRowWithFilter(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	helper.DDL2Job("create table t (id int primary key, name varchar(32))")
+	rawKvs := helper.DML2RawKv("test", "t",
+		"insert into t values (1, 'a')",
+		"insert into t values (2, 'b')",
+		"insert into t values (3, 'c')")
+
+	event := helper.DML2Event("test", "t")
+	// ignore the row with id 2
+	filter := func(rowType RowType, preRow, row chunk.Row) (bool, error) {
+		require.Equal(t, RowTypeInsert, rowType)
+		require.True(t, preRow.IsEmpty())
+		return row.GetInt64(0) == 2, nil
+	}
+	for _, rawKv := range rawKvs {
+		require.NoError(t, event.AppendRow(rawKv, helper.mounter.DecodeToChunk, filter))
+	}
+	require.Equal(t, int32(2), event.Len())
+	require.Equal(t, 2, event.Rows.NumRows())
+	require.Equal(t, []RowType{RowTypeInsert, RowTypeInsert}, event.RowTypes)
+	require.Equal(t, int64(1), event.Rows.GetRow(0).GetInt64(0))
+	require.Equal(t, int64(3), event.Rows.GetRow(1).GetInt64(0))
+}
@@ -0,0 +1,117 @@
+package event
+
+import (
+	"encoding/binary"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"go.uber.org/zap"
+)
+
+const (
+	ErrorEventVersion = 0
+)
+
+// ErrorEvent is sent by the event service to the dispatcher when the events of
+// the dispatcher can't be scanned, the dispatcher reports the error to the maintainer
+// as a running error of the changefeed.
+type ErrorEvent struct {
+	// Version is the version of the ErrorEvent struct.
+	Version      byte
+	DispatcherID common.DispatcherID
+	// CommitTs is the commitTs of the transaction which can't be scanned.
+	CommitTs uint64
+	// Message is the message of the error.
+	Message string
+}
+
+func NewErrorEvent(dispatcherID common.DispatcherID, commitTs common.Ts, err error) *ErrorEvent {
+	return &ErrorEvent{
+		Version:      ErrorEventVersion,
+		DispatcherID: dispatcherID,
+		CommitTs:     uint64(commitTs),
+		Message:      err.Error(),
+	}
+}
+
+// GetType returns the event type
+func (e *ErrorEvent) GetType() int {
+	return TypeErrorEvent
+}
+
+// GetSeq returns 0, the error event is not in the sequence of the events.
+func (e *ErrorEvent) GetSeq() uint64 {
+	return 0
+}
+
+// GetDispatcherID returns the dispatcher ID
+func (e *ErrorEvent) GetDispatcherID() common.DispatcherID {
+	return e.DispatcherID
+}
+
+// GetCommitTs returns the commit timestamp
+func (e *ErrorEvent) GetCommitTs() common.Ts {
+	return e.CommitTs
+}
+
+// GetStartTs returns the start timestamp
+func (e *ErrorEvent) GetStartTs() common.Ts {
+	return e.CommitTs
+}
+
+// GetSize returns the approximate size of the event in bytes
+func (e *ErrorEvent) GetSize() int64 {
+	return int64(1 + e.DispatcherID.GetSize() + 8 + len(e.Message))
+}
+
+func (e *ErrorEvent) IsPaused() bool {
+	return false
+}
+
+func (e ErrorEvent) Marshal() ([]byte, error) {
+	return e.encode()
+}
+
+func (e *ErrorEvent) Unmarshal(data []byte) error {
+	return e.decode(data)
+}
+
+func (e ErrorEvent) encode() ([]byte, error) {
+	if e.Version != 0 {
+		log.Panic("ErrorEvent: invalid version, expect 0, got ", zap.Uint8("version", e.Version))
+	}
+	return e.encodeV0()
+}
+
+func (e *ErrorEvent) decode(data []byte) error {
+	version := data[0]
+	if version != 0 {
+		log.Panic("ErrorEvent: invalid version, expect 0, got ", zap.Uint8("version", version))
+	}
+	return e.decodeV0(data)
+}
+
+func (e ErrorEvent) encodeV0() ([]byte, error) {
+	data := make([]byte, e.GetSize())
+	offset := 0
+	data[offset] = e.Version
+	offset += 1
+	copy(data[offset:], e.DispatcherID.Marshal())
+	offset += e.DispatcherID.GetSize()
+	binary.BigEndian.PutUint64(data[offset:], e.CommitTs)
+	offset += 8
+	copy(data[offset:], e.Message)
+	return data, nil
+}
+
+func (e *ErrorEvent) decodeV0(data []byte) error {
+	offset := 0
+	e.Version = data[offset]
+	offset += 1
+	e.DispatcherID.Unmarshal(data[offset:])
+	offset += e.DispatcherID.GetSize()
+	e.CommitTs = binary.BigEndian.Uint64(data[offset:])
+	offset += 8
+	e.Message = string(data[offset:])
+	return nil
+}
@@ -0,0 +1,24 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorEvent(t *testing.T) {
+	e := NewErrorEvent(common.NewDispatcherID(), 456, errors.New("evaluate filter expression failed"))
+	require.Equal(t, TypeErrorEvent, e.GetType())
+	require.Equal(t, uint64(456), e.GetCommitTs())
+
+	data, err := e.Marshal()
+	require.NoError(t, err)
+	require.Len(t, data, int(e.GetSize()))
+
+	e2 := &ErrorEvent{}
+	err = e2.Unmarshal(data)
+	require.NoError(t, err)
+	require.Equal(t, e, e2)
+}
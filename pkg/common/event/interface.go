@@ -49,6 +49,8 @@ const (
 	TypeSyncPointEvent
 	// HandshakeEvent is the event type of a handshake.
 	TypeHandshakeEvent
+	// ErrorEvent is the event type of an error met by the event service.
+	TypeErrorEvent
 )
 
 // fakeDispatcherID is a fake dispatcherID for batch resolvedTs.
@@ -0,0 +1,163 @@
+package event
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/ticdc/pkg/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/cdc/model"
+)
+
+// The redo log keeps the same format as the redo log of TiCDC(tiflow),
+// so the logs can be consumed by the existing redo reader and applier.
+// The events of the new architecture are converted to the redo format here.
+
+// RedoRowEvent is a single row of a DMLEvent which can be written into the redo log.
+type RedoRowEvent struct {
+	row *model.RowChangedEventInRedoLog
+}
+
+// ToRedoLog implements the redo event interface.
+func (r *RedoRowEvent) ToRedoLog() *model.RedoLog {
+	return &model.RedoLog{
+		RedoRow: model.RedoRowChangedEvent{Row: r.row},
+		Type:    model.RedoLogTypeRow,
+	}
+}
+
+// GetCommitTs returns the commit ts of the row.
+func (r *RedoRowEvent) GetCommitTs() uint64 {
+	return r.row.CommitTs
+}
+
+// ToRedoRowEvents converts all rows of the DMLEvent to redo row events.
+// The row iterator of the DMLEvent is rewound after the conversion,
+// so the event can still be consumed by the sink.
+func (t *DMLEvent) ToRedoRowEvents() ([]*RedoRowEvent, error) {
+	defer t.Rewind()
+	tableInfo := t.TableInfo
+	indexColumns := toRedoIndexColumns(tableInfo)
+	events := make([]*RedoRowEvent, 0, t.Len())
+	for {
+		row, ok := t.GetNextRow()
+		if !ok {
+			break
+		}
+		redoRow := &model.RowChangedEventInRedoLog{
+			StartTs:  t.StartTs,
+			CommitTs: t.CommitTs,
+			Table: &model.TableName{
+				Schema:      tableInfo.GetSchemaName(),
+				Table:       tableInfo.GetTableName(),
+				TableID:     t.PhysicalTableID,
+				IsPartition: tableInfo.IsPartitionTable(),
+			},
+			IndexColumns: indexColumns,
+		}
+		var err error
+		if row.RowType != RowTypeDelete {
+			redoRow.Columns, err = toRedoColumns(tableInfo, &row.Row)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if row.RowType != RowTypeInsert {
+			redoRow.PreColumns, err = toRedoColumns(tableInfo, &row.PreRow)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		events = append(events, &RedoRowEvent{row: redoRow})
+	}
+	return events, nil
+}
+
+// toRedoIndexColumns returns the offsets of the primary key and unique index columns
+// in the redo columns. The offsets are counted over the CDC visible columns only,
+// since the invisible columns, such as virtual generated columns, are skipped by toRedoColumns.
+func toRedoIndexColumns(tableInfo *common.TableInfo) [][]int {
+	visibleOffsets := make(map[int64]int, len(tableInfo.Columns))
+	for _, col := range tableInfo.Columns {
+		if common.IsColCDCVisible(col) {
+			visibleOffsets[col.ID] = len(visibleOffsets)
+		}
+	}
+	var indexColumns [][]int
+	if tableInfo.PKIsHandle {
+		for _, col := range tableInfo.Columns {
+			offset, ok := visibleOffsets[col.ID]
+			if ok && mysql.HasPriKeyFlag(col.GetFlag()) {
+				indexColumns = append(indexColumns, []int{offset})
+			}
+		}
+	}
+	for _, idx := range tableInfo.Indices {
+		if !idx.Primary && !idx.Unique {
+			continue
+		}
+		offsets := make([]int, 0, len(idx.Columns))
+		for _, idxCol := range idx.Columns {
+			if offset, ok := visibleOffsets[tableInfo.Columns[idxCol.Offset].ID]; ok {
+				offsets = append(offsets, offset)
+			}
+		}
+		if len(offsets) > 0 {
+			indexColumns = append(indexColumns, offsets)
+		}
+	}
+	return indexColumns
+}
+
+// toRedoColumns converts the CDC visible columns of the row to redo columns,
+// the invisible columns are skipped, so the offsets of the columns are
+// the ones returned by toRedoIndexColumns.
+func toRedoColumns(tableInfo *common.TableInfo, row *chunk.Row) ([]*model.Column, error) {
+	columns := make([]*model.Column, 0, len(tableInfo.Columns))
+	for i, col := range tableInfo.Columns {
+		if !common.IsColCDCVisible(col) {
+			continue
+		}
+		value, err := common.FormatColVal(row, col, i)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var flag model.ColumnFlagType
+		if f, ok := tableInfo.ColumnsFlag[col.ID]; ok {
+			flag = model.ColumnFlagType(*f)
+		}
+		columns = append(columns, &model.Column{
+			Name:      col.Name.O,
+			Type:      col.GetType(),
+			Charset:   col.GetCharset(),
+			Collation: col.GetCollate(),
+			Flag:      flag,
+			Value:     value,
+		})
+	}
+	return columns, nil
+}
+
+// ToRedoLog implements the redo event interface.
+func (d *DDLEvent) ToRedoLog() *model.RedoLog {
+	ddl := &model.DDLEvent{
+		StartTs:  d.GetStartTs(),
+		CommitTs: d.GetCommitTs(),
+		Query:    d.Query,
+		Type:     timodel.ActionType(d.Type),
+		TableInfo: &model.TableInfo{
+			TableName: model.TableName{
+				Schema:  d.SchemaName,
+				Table:   d.TableName,
+				TableID: d.TableID,
+			},
+		},
+	}
+	if d.TableInfo != nil {
+		ddl.TableInfo.TableName.IsPartition = d.TableInfo.IsPartitionTable()
+	}
+	return &model.RedoLog{
+		RedoDDL: model.RedoDDLEvent{DDL: ddl},
+		Type:    model.RedoLogTypeDDL,
+	}
+}
@@ -0,0 +1,116 @@
+package event
+
+import (
+	"testing"
+
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/cdc/model/codec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDMLEventToRedoRowEvents(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, name varchar(32), age int, unique key uk(name))")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t",
+		"insert into t values (1, 'alice', 18)",
+		"insert into t values (2, 'bob', null)")
+	dmlEvent.StartTs = 10
+	dmlEvent.CommitTs = 11
+
+	events, err := dmlEvent.ToRedoRowEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	// The row iterator is rewound, so the event can still be consumed by the sink.
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	require.Equal(t, RowTypeInsert, row.RowType)
+
+	redoLog := events[0].ToRedoLog()
+	require.Equal(t, model.RedoLogTypeRow, redoLog.Type)
+	redoRow := redoLog.RedoRow.Row
+	require.Equal(t, uint64(10), redoRow.StartTs)
+	require.Equal(t, uint64(11), redoRow.CommitTs)
+	require.Equal(t, uint64(11), events[0].GetCommitTs())
+	require.Equal(t, "test", redoRow.Table.Schema)
+	require.Equal(t, "t", redoRow.Table.Table)
+	require.Equal(t, dmlEvent.PhysicalTableID, redoRow.Table.TableID)
+	require.Nil(t, redoRow.PreColumns)
+	require.Len(t, redoRow.Columns, 3)
+	require.Equal(t, "id", redoRow.Columns[0].Name)
+	require.Equal(t, mysql.TypeLong, redoRow.Columns[0].Type)
+	require.True(t, redoRow.Columns[0].Flag.IsPrimaryKey())
+	require.Equal(t, int64(1), redoRow.Columns[0].Value)
+	require.Equal(t, "alice", redoRow.Columns[1].Value)
+	require.Equal(t, int64(18), redoRow.Columns[2].Value)
+	require.Equal(t, [][]int{{0}, {1}}, redoRow.IndexColumns)
+
+	// The redo log can be decoded by the redo reader.
+	data, err := codec.MarshalRedoLog(events[1].ToRedoLog(), nil)
+	require.NoError(t, err)
+	decoded, _, err := codec.UnmarshalRedoLog(data)
+	require.NoError(t, err)
+	decodedRow := decoded.RedoRow.Row.ToRowChangedEvent()
+	require.Equal(t, uint64(11), decodedRow.CommitTs)
+	require.Equal(t, "t", decodedRow.TableInfo.GetTableName())
+	require.True(t, decodedRow.IsInsert())
+	require.Nil(t, decoded.RedoRow.Row.Columns[2].Value)
+}
+
+func TestDMLEventToRedoRowEventsWithVirtualColumn(t *testing.T) {
+	helper := NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.tk.MustExec("use test")
+	job := helper.DDL2Job("create table t (id int primary key, a int, b int as (a + 1) virtual, c int, unique key uk(c))")
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t (id, a, c) values (1, 2, 3)")
+	events, err := dmlEvent.ToRedoRowEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	// The virtual generated column is skipped, and the index offsets
+	// point to the columns in the redo row.
+	redoRow := events[0].ToRedoLog().RedoRow.Row
+	require.Len(t, redoRow.Columns, 3)
+	require.Equal(t, "id", redoRow.Columns[0].Name)
+	require.Equal(t, "a", redoRow.Columns[1].Name)
+	require.Equal(t, "c", redoRow.Columns[2].Name)
+	require.Equal(t, [][]int{{0}, {2}}, redoRow.IndexColumns)
+	for _, offsets := range redoRow.IndexColumns {
+		for _, offset := range offsets {
+			require.Less(t, offset, len(redoRow.Columns))
+		}
+	}
+}
+
+func TestDDLEventToRedoLog(t *testing.T) {
+	ddlEvent := &DDLEvent{
+		Type:       byte(timodel.ActionCreateTable),
+		SchemaName: "test",
+		TableName:  "t",
+		TableID:    100,
+		Query:      "create table t (id int primary key)",
+		FinishedTs: 20,
+	}
+
+	data, err := codec.MarshalRedoLog(ddlEvent.ToRedoLog(), nil)
+	require.NoError(t, err)
+	decoded, _, err := codec.UnmarshalRedoLog(data)
+	require.NoError(t, err)
+	require.Equal(t, model.RedoLogTypeDDL, decoded.Type)
+	require.Equal(t, uint64(20), decoded.GetCommitTs())
+	require.Equal(t, "create table t (id int primary key)", decoded.RedoDDL.DDL.Query)
+	require.Equal(t, timodel.ActionCreateTable, decoded.RedoDDL.DDL.Type)
+	require.Equal(t, "test", decoded.RedoDDL.DDL.TableInfo.TableName.Schema)
+	require.Equal(t, "t", decoded.RedoDDL.DDL.TableInfo.TableName.Table)
+	require.Equal(t, int64(100), decoded.RedoDDL.DDL.TableInfo.TableName.TableID)
+}
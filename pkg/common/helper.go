@@ -26,7 +26,7 @@ func FormatColVal(row *chunk.Row, col *model.ColumnInfo, idx int) (
 	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeNewDate, mysql.TypeTimestamp:
 		v = row.GetTime(idx).String()
 	case mysql.TypeDuration:
-		v = row.GetDuration(idx, 0).String()
+		v = row.GetDuration(idx, col.GetDecimal()).String()
 	case mysql.TypeJSON:
 		v = row.GetJSON(idx).String()
 	case mysql.TypeNewDecimal:
@@ -57,6 +57,18 @@ type RawKVEntry struct {
 	Value []byte `msg:"value"`
 	// nil for insert type
 	OldValue []byte `msg:"old_value"`
+	// TxnSource is the source of the transaction, the lowest 8 bits are set
+	// when the transaction is written by TiCDC.
+	TxnSource uint64 `msg:"txn_source"`
+}
+
+// cdcWriteSourceMask is the mask of the bits of TxnSource used by TiCDC to mark its writes,
+// see SetCDCWriteSource in github.com/pingcap/tidb/pkg/kv.
+const cdcWriteSourceMask = (1 << 8) - 1
+
+// IsWrittenByCDC returns whether the transaction of the entry is written by TiCDC.
+func (v *RawKVEntry) IsWrittenByCDC() bool {
+	return v.TxnSource&cdcWriteSourceMask != 0
 }
 
 func (v *RawKVEntry) IsResolved() bool {
@@ -84,7 +96,7 @@ func (v *RawKVEntry) ApproximateDataSize() int64 {
 // Encode serializes the RawKVEntry into a byte slice
 func (v *RawKVEntry) Encode() []byte {
 	// Calculate total size
-	totalSize := 4*5 + 8*3 + len(v.Key) + len(v.Value) + len(v.OldValue)
+	totalSize := 4*4 + 8*4 + len(v.Key) + len(v.Value) + len(v.OldValue)
 	buf := make([]byte, 0, totalSize)
 	// Use binary.LittleEndian.PutUint32/64 to write directly to the buffer
 	buf = binary.LittleEndian.AppendUint32(buf, uint32(v.OpType))
@@ -103,6 +115,8 @@ func (v *RawKVEntry) Encode() []byte {
 	buf = append(buf, v.Key...)
 	buf = append(buf, v.Value...)
 	buf = append(buf, v.OldValue...)
+	// TxnSource is appended at the end, so the entries encoded without it can still be decoded.
+	buf = binary.LittleEndian.AppendUint64(buf, v.TxnSource)
 
 	return buf
 }
@@ -142,6 +156,10 @@ func (v *RawKVEntry) Decode(data []byte) error {
 	offset += int(v.ValueLen)
 
 	v.OldValue = data[offset : offset+int(v.OldValueLen)]
+	offset += int(v.OldValueLen)
 
+	if len(data[offset:]) >= 8 {
+		v.TxnSource = binary.LittleEndian.Uint64(data[offset : offset+8])
+	}
 	return nil
 }
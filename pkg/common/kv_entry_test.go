@@ -80,3 +80,32 @@ func TestCompareEncodedSize(t *testing.T) {
 
 	require.Less(t, len(encoded), len(jsonEncoded))
 }
+
+func TestRawKVEntryTxnSource(t *testing.T) {
+	original := RawKVEntry{
+		OpType:    OpTypePut,
+		CRTs:      5555555555,
+		StartTs:   6666666666,
+		RegionID:  7,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		OldValue:  make([]byte, 0),
+		TxnSource: 1,
+	}
+	require.True(t, original.IsWrittenByCDC())
+
+	encoded := original.Encode()
+	var decoded RawKVEntry
+	require.NoError(t, decoded.Decode(encoded))
+	require.Equal(t, original, decoded)
+
+	// the entry encoded without the txn source
+	var legacy RawKVEntry
+	require.NoError(t, legacy.Decode(encoded[:len(encoded)-8]))
+	require.Equal(t, uint64(0), legacy.TxnSource)
+	require.False(t, legacy.IsWrittenByCDC())
+
+	// the lossy DDL reorg source is not written by TiCDC
+	original.TxnSource = 1 << 8
+	require.False(t, original.IsWrittenByCDC())
+}
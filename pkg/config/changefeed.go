@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"math"
 	"net/url"
+	"reflect"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -35,6 +36,15 @@ type ChangefeedConfig struct {
 	SyncPointInterval  *time.Duration `json:"sync_point_interval" default:"1m"`
 	SyncPointRetention *time.Duration `json:"sync_point_retention" default:"24h"`
 	SinkConfig         *SinkConfig    `json:"sink_config"`
+	// Consistent is the redo log config, redo log is written ahead of the sink if it is enabled.
+	Consistent *ConsistentConfig `json:"consistent"`
+	// BDRMode is true if the changefeed is a part of the bidirectional replication,
+	// the changes written by TiCDC are not replicated in BDR mode.
+	BDRMode bool `json:"bdr_mode" default:"false"`
+	// TiDBSourceID is the source ID of the upstream TiDB, it's used to mark the writes to the downstream.
+	TiDBSourceID uint64 `json:"tidb_source_id"`
+	// EnableTableAcrossNodes is true if the tables may be split into multiple spans.
+	EnableTableAcrossNodes bool `json:"enable_table_across_nodes" default:"false"`
 }
 
 // ChangeFeedInfo describes the detail of a ChangeFeed
@@ -167,6 +177,35 @@ func (info *ChangeFeedInfo) Clone() (*ChangeFeedInfo, error) {
 	return cloned, err
 }
 
+// IsLiveUpdatable returns true if the changes between oldInfo and newInfo can be applied to
+// a running changefeed without restarting it. Only the table filter rules, the memory
+// quota, the worker count of the mysql sink and the dispatch rules of the MQ sink
+// can be updated in place.
+func IsLiveUpdatable(oldInfo, newInfo *ChangeFeedInfo) bool {
+	if oldInfo.SinkURI != newInfo.SinkURI || oldInfo.TargetTs != newInfo.TargetTs ||
+		oldInfo.Config == nil || newInfo.Config == nil {
+		return false
+	}
+	oldCfg, newCfg := oldInfo.Config.Clone(), newInfo.Config.Clone()
+	for _, cfg := range []*ReplicaConfig{oldCfg, newCfg} {
+		cfg.MemoryQuota = 0
+		if cfg.Filter != nil {
+			cfg.Filter.Rules = nil
+		}
+		if cfg.Sink != nil {
+			cfg.Sink.DispatchRules = nil
+			if cfg.Sink.MySQLConfig != nil {
+				cfg.Sink.MySQLConfig.WorkerCount = nil
+				// the mysql config only setting the worker count equals to an unset one
+				if *cfg.Sink.MySQLConfig == (MySQLConfig{}) {
+					cfg.Sink.MySQLConfig = nil
+				}
+			}
+		}
+	}
+	return reflect.DeepEqual(oldCfg, newCfg)
+}
+
 // VerifyAndComplete verifies changefeed info and may fill in some fields.
 // If a required field is not provided, return an error.
 // If some necessary filed is missing but can use a default value, fill in it.
@@ -447,12 +486,42 @@ var (
 	ProgressStopping Progress = 3
 )
 
+// maxResumeHistoryCount is the max number of resume records kept in the changefeed status
+const maxResumeHistoryCount = 10
+
+// ResumeRecord records a resume that overwrites the checkpoint of a changefeed
+type ResumeRecord struct {
+	ResumeTime      time.Time `json:"resume-time"`
+	OldCheckpointTs uint64    `json:"old-checkpoint-ts"`
+	NewCheckpointTs uint64    `json:"new-checkpoint-ts"`
+	// SkippedDDLTs is the commit ts of the blocking ddl skipped by the resume, 0 if no ddl is skipped
+	SkippedDDLTs uint64 `json:"skipped-ddl-ts,omitempty"`
+}
+
 // ChangeFeedStatus stores information about a ChangeFeed
 // It is stored in etcd.
 type ChangeFeedStatus struct {
 	CheckpointTs uint64 `json:"checkpoint-ts"`
 	// Progress indicates changefeed progress status
 	Progress Progress `json:"progress"`
+	// ResumeHistory is the audit trail of the resumes that overwrite the checkpoint,
+	// only the latest maxResumeHistoryCount records are kept
+	ResumeHistory []*ResumeRecord `json:"resume-history,omitempty"`
+	// BlockingDDLTs is the commit ts of the ddl that blocks the changefeed,
+	// it's reported by the maintainer and not stored in etcd
+	BlockingDDLTs uint64 `json:"-"`
+}
+
+// AppendResumeHistory returns a new resume history with the record appended,
+// the oldest records are dropped if the history is too long
+func AppendResumeHistory(history []*ResumeRecord, record *ResumeRecord) []*ResumeRecord {
+	newHistory := make([]*ResumeRecord, 0, len(history)+1)
+	newHistory = append(newHistory, history...)
+	newHistory = append(newHistory, record)
+	if len(newHistory) > maxResumeHistoryCount {
+		newHistory = newHistory[len(newHistory)-maxResumeHistoryCount:]
+	}
+	return newHistory
 }
 
 // Marshal returns json encoded string of ChangeFeedStatus, only contains necessary fields stored in storage
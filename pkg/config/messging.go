@@ -8,10 +8,14 @@ const (
 type MessageCenterConfig struct {
 	// The size of the channel for pending messages to be sent and received.
 	CacheChannelSize int
+	// The compression used to send messages to the remote message centers, "none", "zstd" or "snappy".
+	// It's only used if the remote supports it, otherwise the messages are sent uncompressed.
+	Compression string
 }
 
 func NewDefaultMessageCenterConfig() *MessageCenterConfig {
 	return &MessageCenterConfig{
 		CacheChannelSize: defaultCacheSize,
+		Compression:      "zstd",
 	}
 }
@@ -79,6 +79,7 @@ var defaultReplicaConfig = &ReplicaConfig{
 		SendBootstrapInMsgCount:          util.AddressOf(DefaultSendBootstrapInMsgCount),
 		SendBootstrapToAllPartition:      util.AddressOf(DefaultSendBootstrapToAllPartition),
 		SendAllBootstrapAtStart:          util.AddressOf(DefaultSendAllBootstrapAtStart),
+		SendSyncPointMarker:              util.AddressOf(DefaultSendSyncPointMarker),
 		DebeziumDisableSchema:            util.AddressOf(false),
 		OpenProtocol:                     &OpenProtocolConfig{OutputOldValue: true},
 		Debezium:                         &DebeziumConfig{OutputOldValue: true},
@@ -292,6 +293,18 @@ func (c *ReplicaConfig) ValidateAndAdjust(sinkURI *url.URL) error { // check sin
 	if !isSinkCompatibleWithSpanReplication(sinkURI) {
 		c.Scheduler.EnableTableAcrossNodes = false
 	}
+	if c.Scheduler.EnableTableAcrossNodes && c.Sink != nil {
+		exactlyOnce, err := c.Sink.isKafkaExactlyOnce(sinkURI)
+		if err != nil {
+			return err
+		}
+		// the exactly-once kafka sink records the committed ts by table,
+		// it can't tell the progress of the spans of a split table apart.
+		if exactlyOnce {
+			return cerror.ErrInvalidReplicaConfig.GenWithStack(
+				"exactly-once kafka sink can't be enabled with enable-table-across-nodes")
+		}
+	}
 
 	if c.Integrity != nil {
 		switch strings.ToLower(sinkURI.Scheme) {
@@ -92,6 +92,9 @@ const (
 	// DefaultSendAllBootstrapAtStart is the default value of whether
 	// to send all tables bootstrap message at changefeed start.
 	DefaultSendAllBootstrapAtStart = false
+	// DefaultSendSyncPointMarker is the default value of whether
+	// to send the sync point marker messages.
+	DefaultSendSyncPointMarker = false
 
 	// DefaultMaxReconnectToPulsarBroker is the default max reconnect times to pulsar broker.
 	// The pulsar client uses an exponential backoff with jitter to reconnect to the broker.
@@ -194,6 +197,10 @@ type SinkConfig struct {
 	SendBootstrapToAllPartition *bool `toml:"send-bootstrap-to-all-partition" json:"send-bootstrap-to-all-partition,omitempty"`
 	// SendAllBootstrapAtStart determines whether to send all tables bootstrap message at changefeed start.
 	SendAllBootstrapAtStart *bool `toml:"send-all-bootstrap-at-start" json:"send-all-bootstrap-at-start,omitempty"`
+	// SendSyncPointMarker determines whether to send a marker message of each sync point to
+	// all the partitions of the active topics, it's only available when the downstream is MQ.
+	// It's disabled by default, since the consumers of the old versions don't recognize the message.
+	SendSyncPointMarker *bool `toml:"send-sync-point-marker" json:"send-sync-point-marker,omitempty"`
 	// Debezium only. Whether schema should be excluded in the output.
 	DebeziumDisableSchema *bool `toml:"debezium-disable-schema" json:"debezium-disable-schema,omitempty"`
 
@@ -247,6 +254,11 @@ func (s *SinkConfig) ShouldSendAllBootstrapAtStart() bool {
 	return should
 }
 
+// ShouldSendSyncPointMarker returns whether the MQ sink should send the sync point marker messages.
+func (s *SinkConfig) ShouldSendSyncPointMarker() bool {
+	return s != nil && util.GetOrZero(s.SendSyncPointMarker)
+}
+
 // CSVConfig defines a series of configuration items for csv codec.
 type CSVConfig struct {
 	// delimiter between fields, it can be 1 character or at most 2 characters
@@ -456,6 +468,11 @@ type KafkaConfig struct {
 
 	// OutputRawChangeEvent controls whether to split the update pk/uk events.
 	OutputRawChangeEvent *bool `toml:"output-raw-change-event" json:"output-raw-change-event,omitempty"`
+	// ExactlyOnce controls whether to send the DML messages in kafka transactions,
+	// so the messages are not duplicated after the changefeed restarts.
+	// It can't be enabled with enable-table-across-nodes, since the committed ts is recorded by table.
+	// The transactions are always sent by the sarama client, even if enable-kafka-sink-v2 is set.
+	ExactlyOnce *bool `toml:"exactly-once" json:"exactly-once,omitempty"`
 }
 
 // GetOutputRawChangeEvent returns the value of OutputRawChangeEvent
@@ -827,6 +844,27 @@ func (s *SinkConfig) validateAndAdjust(sinkURI *url.URL) error {
 }
 
 // validateAndAdjustSinkURI validate and adjust `Protocol` and `TxnAtomicity` by sinkURI.
+// isKafkaExactlyOnce returns whether the kafka sink sends the DML messages in transactions,
+// the exactly-once parameter in the sink uri overrides the one in the kafka config.
+func (s *SinkConfig) isKafkaExactlyOnce(sinkURI *url.URL) (bool, error) {
+	if sinkURI == nil {
+		return false, nil
+	}
+	switch strings.ToLower(sinkURI.Scheme) {
+	case sink.KafkaScheme, sink.KafkaSSLScheme:
+	default:
+		return false, nil
+	}
+	if v := sinkURI.Query().Get("exactly-once"); v != "" {
+		exactlyOnce, err := strconv.ParseBool(v)
+		if err != nil {
+			return false, cerror.WrapError(cerror.ErrSinkURIInvalid, err)
+		}
+		return exactlyOnce, nil
+	}
+	return s.KafkaConfig != nil && util.GetOrZero(s.KafkaConfig.ExactlyOnce), nil
+}
+
 func (s *SinkConfig) validateAndAdjustSinkURI(sinkURI *url.URL) error {
 	if sinkURI == nil {
 		return nil
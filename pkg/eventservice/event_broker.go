@@ -19,6 +19,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/filter"
 	"github.com/pingcap/ticdc/pkg/messaging"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/oracle"
@@ -251,6 +252,11 @@ func (c *eventBroker) wakeDispatcher(dispatcherID common.DispatcherID) {
 func (c *eventBroker) checkNeedScan(task scanTask) (bool, common.DataRange) {
 	c.checkAndInitDispatcher(task)
 
+	// The error is reported, wait for the dispatcher to be reset or removed.
+	if task.dispatcherStat.failed.Load() {
+		return false, common.DataRange{}
+	}
+
 	dataRange, needScan := task.dispatcherStat.getDataRange()
 	if !needScan {
 		return false, dataRange
@@ -353,9 +359,15 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 		log.Panic("get ddl events failed", zap.Error(err))
 	}
 
+	// failed is set when the events can't be scanned, the watermark of the dispatcher
+	// must not be advanced, otherwise the events after the error are lost.
+	failed := false
 	// After all the events are sent, we need to
 	// drain the ddlEvents and wake up the dispatcher.
 	defer func() {
+		if failed {
+			return
+		}
 		for _, e := range ddlEvents {
 			c.sendDDL(ctx, remoteID, e, task.dispatcherStat)
 		}
@@ -380,7 +392,8 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 	}()
 
 	sendDML := func(dml *pevent.DMLEvent) {
-		if dml == nil {
+		// All rows of the transaction may be filtered out.
+		if dml == nil || dml.Len() == 0 {
 			return
 		}
 
@@ -396,6 +409,11 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 
 	// 3. Send the events to the dispatcher.
 	var dml *pevent.DMLEvent
+	// filterDML applies the event filters of the changefeed to the row changes,
+	// the rows filtered out are never sent to the dispatcher.
+	filterDML := func(rowType pevent.RowType, preRow, row chunk.Row) (bool, error) {
+		return task.dispatcherStat.filter.ShouldIgnoreDMLEvent(rowType, preRow, row, dml.TableInfo, dml.StartTs)
+	}
 	for {
 		//Node: The first event of the txn must return isNewTxn as true.
 		e, isNewTxn, err := iter.Next()
@@ -423,7 +441,31 @@ func (c *eventBroker) doScan(ctx context.Context, task scanTask) {
 			}
 			dml = pevent.NewDMLEvent(dispatcherID, tableID, e.StartTs, e.CRTs, tableInfo)
 		}
-		dml.AppendRow(e, c.mounter.DecodeToChunk)
+		// The rows of a transaction share the same txn source,
+		// so the dml event of the transaction written by TiCDC stays empty and is not sent.
+		if task.dispatcherStat.bdrMode && e.IsWrittenByCDC() {
+			continue
+		}
+		err = dml.AppendRow(e, c.mounter.DecodeToChunk, filterDML)
+		if err != nil {
+			// The error is reported to the dispatcher, which reports it to the maintainer
+			// as a running error of the changefeed.
+			log.Error("append row failed", zap.Error(err), zap.Stringer("dispatcher", dispatcherID),
+				zap.Uint64("startTs", e.StartTs), zap.Uint64("commitTs", e.CRTs))
+			failed = true
+			task.dispatcherStat.failed.Store(true)
+			c.sendError(remoteID, task.dispatcherStat, e.CRTs, err)
+			return
+		}
+	}
+}
+
+// sendError sends the error met when scanning the events of the dispatcher to the dispatcher.
+func (c *eventBroker) sendError(remoteID node.ID, d *dispatcherStat, commitTs uint64, err error) {
+	c.messageCh <- wrapEvent{
+		serverID: remoteID,
+		e:        pevent.NewErrorEvent(d.info.GetID(), commitTs, err),
+		msgType:  pevent.TypeErrorEvent,
 	}
 }
 
@@ -727,6 +769,9 @@ type dispatcherStat struct {
 	// startTableInfo is the table info of the dispatcher when it is registered or reset.
 	startTableInfo atomic.Pointer[common.TableInfo]
 	filter         filter.Filter
+	// bdrMode is true if the changefeed is in BDR mode,
+	// the rows written by TiCDC are not sent to the dispatcher to avoid replication loops.
+	bdrMode bool
 	// The start ts of the dispatcher
 	startTs atomic.Uint64
 	// The max resolved ts received from event store.
@@ -746,6 +791,10 @@ type dispatcherStat struct {
 	// It will be set to true, after it sends the handshake event to the dispatcher.
 	// It will be set to false, after it receives the reset event from the dispatcher.
 	isInitialized atomic.Bool
+	// failed is used to indicate whether the events of the dispatcher can't be scanned.
+	// It will be set to true, after an error event is sent to the dispatcher,
+	// the dispatcher is not scanned anymore until it's reset.
+	failed atomic.Bool
 
 	// syncpoint related
 	enableSyncPoint   bool
@@ -767,6 +816,7 @@ func newDispatcherStat(
 	dispStat := &dispatcherStat{
 		info:                                  info,
 		filter:                                filter,
+		bdrMode:                               info.GetBDRMode(),
 		metricSorterOutputEventCountKV:        metrics.SorterOutputEventCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "kv"),
 		metricEventServiceSendKvCount:         metrics.EventServiceSendEventCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "kv"),
 		metricEventServiceSendDDLCount:        metrics.EventServiceSendEventCount.WithLabelValues(changefeedID.Namespace(), changefeedID.Name(), "ddl"),
@@ -13,6 +13,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/common"
 	pevent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/messaging"
+	tconfig "github.com/pingcap/tiflow/pkg/config"
 	"github.com/stretchr/testify/require"
 )
 
@@ -201,3 +202,72 @@ func TestSendEvents(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestScanEventsFilterFailed(t *testing.T) {
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	ddlEvent, kvEvents := genEvents(helper, t, `create table test.t(id int primary key, c char(50))`, []string{
+		`insert into test.t(id,c) values (0, "c0")`,
+	}...)
+	require.NotNil(t, kvEvents)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventStore := newMockEventStore(100)
+	schemaStore := newMockSchemaStore()
+	msgCh := make(chan *messaging.TargetMessage, 1024)
+	mc := &mockMessageCenter{messageCh: msgCh}
+
+	s := newEventBroker(ctx, 1, eventStore, schemaStore, mc, time.Local)
+	defer s.close()
+
+	// The expression refers to an unknown column, so the filter evaluation fails.
+	tableID := ddlEvent.TableID
+	info := newMockDispatcherInfo(common.NewDispatcherID(), tableID, eventpb.ActionType_ACTION_TYPE_REGISTER)
+	info.filterConfig = &tconfig.FilterConfig{
+		Rules: []string{"*.*"},
+		EventFilters: []*tconfig.EventFilterRule{{
+			Matcher:               []string{"test.t"},
+			IgnoreInsertValueExpr: "unknown_column = 1",
+		}},
+	}
+	s.addDispatcher(info)
+	schemaStore.AppendDDLEvent(tableID, ddlEvent)
+
+	v, ok := eventStore.spansMap.Load(tableID)
+	require.True(t, ok)
+	span := v.(*mockSpanStats)
+	span.update(kvEvents[0].CRTs+1, kvEvents...)
+
+	// The error is sent to the dispatcher instead of the dml event.
+	timer := time.NewTimer(time.Second * 10)
+	defer timer.Stop()
+	var errorEvent *pevent.ErrorEvent
+	for errorEvent == nil {
+		select {
+		case <-timer.C:
+			require.FailNow(t, "error event is not received")
+		case msgs := <-msgCh:
+			for _, msg := range msgs.Message {
+				switch e := msg.(type) {
+				case *pevent.DMLEvent:
+					require.FailNow(t, "unexpected dml event", "event: %+v", e)
+				case *pevent.ErrorEvent:
+					errorEvent = e
+				}
+			}
+		}
+	}
+	require.Equal(t, info.GetID(), errorEvent.GetDispatcherID())
+	require.Equal(t, kvEvents[0].CRTs, errorEvent.GetCommitTs())
+	require.Contains(t, errorEvent.Message, "unknown_column")
+
+	// The watermark is not advanced, and the dispatcher is not scanned anymore.
+	stat, ok := s.getDispatcher(info.GetID())
+	require.True(t, ok)
+	require.True(t, stat.failed.Load())
+	require.Less(t, stat.watermark.Load(), kvEvents[0].CRTs)
+}
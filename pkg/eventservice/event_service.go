@@ -34,6 +34,9 @@ type DispatcherInfo interface {
 	GetActionType() eventpb.ActionType
 	GetChangefeedID() common.ChangeFeedID
 	GetFilterConfig() *config.FilterConfig
+	// GetBDRMode returns whether the changefeed is in BDR mode,
+	// the events written by TiCDC are not sent to the dispatcher in BDR mode.
+	GetBDRMode() bool
 
 	// sync point related
 	SyncPointEnabled() bool
@@ -260,6 +260,10 @@ func (m *mockSchemaStore) GetAllPhysicalTables(snapTs uint64, filter filter.Filt
 	return nil, nil
 }
 
+func (m *mockSchemaStore) VerifyTables(snapTs uint64, filter filter.Filter) ([]schemastore.VerifiedTable, error) {
+	return nil, nil
+}
+
 func (m *mockSchemaStore) GetTableDDLEventState(tableID int64) schemastore.DDLEventState {
 	return schemastore.DDLEventState{
 		ResolvedTs:       m.resolvedTs,
@@ -329,6 +333,8 @@ type mockDispatcherInfo struct {
 	span       *heartbeatpb.TableSpan
 	startTs    uint64
 	actionType eventpb.ActionType
+	// filterConfig is used instead of the default filter config if it's set
+	filterConfig *tconfig.FilterConfig
 }
 
 func newMockDispatcherInfo(dispatcherID common.DispatcherID, tableID int64, actionType eventpb.ActionType) *mockDispatcherInfo {
@@ -380,11 +386,18 @@ func (m *mockDispatcherInfo) GetChangefeedID() (namespace, id string) {
 }
 
 func (m *mockDispatcherInfo) GetFilterConfig() *tconfig.FilterConfig {
+	if m.filterConfig != nil {
+		return m.filterConfig
+	}
 	return &tconfig.FilterConfig{
 		Rules: []string{"*.*"},
 	}
 }
 
+func (m *mockDispatcherInfo) GetBDRMode() bool {
+	return false
+}
+
 func (m *mockDispatcherInfo) SyncPointEnabled() bool {
 	return false
 }
@@ -19,15 +19,15 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/sessionctx"
-	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
-	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/dm/pkg/utils"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"go.uber.org/zap"
@@ -39,7 +39,7 @@ import (
 type dmlExprFilterRule struct {
 	mu sync.Mutex
 	// Cache tableInfos to check if the table was changed.
-	tables map[string]*model.TableInfo
+	tables map[string]*common.TableInfo
 
 	insertExprs    map[string]expression.Expression // tableName -> expr
 	updateOldExprs map[string]expression.Expression // tableName -> expr
@@ -63,7 +63,7 @@ func newExprFilterRule(
 	}
 
 	ret := &dmlExprFilterRule{
-		tables:         make(map[string]*model.TableInfo),
+		tables:         make(map[string]*common.TableInfo),
 		insertExprs:    make(map[string]expression.Expression),
 		updateOldExprs: make(map[string]expression.Expression),
 		updateNewExprs: make(map[string]expression.Expression),
@@ -79,7 +79,7 @@ func newExprFilterRule(
 // It should only be called in dmlExprFilter's verify method.
 // We ask users to set these expr only in default sql mode,
 // so we just need to  verify each expr in default sql mode
-func (r *dmlExprFilterRule) verify(tableInfos []*model.TableInfo) error {
+func (r *dmlExprFilterRule) verify(tableInfos []*common.TableInfo) error {
 	// verify expression filter rule syntax.
 	p := parser.New()
 	_, _, err := p.ParseSQL(completeExpression(r.config.IgnoreInsertValueExpr))
@@ -154,7 +154,7 @@ func (r *dmlExprFilterRule) resetExpr(tableName string) {
 
 // getInsertExprs returns the expression filter to filter INSERT events.
 // This function will lazy calculate expressions if not initialized.
-func (r *dmlExprFilterRule) getInsertExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getInsertExpr(ti *common.TableInfo) (
 	expression.Expression, error,
 ) {
 	tableName := ti.TableName.String()
@@ -171,7 +171,7 @@ func (r *dmlExprFilterRule) getInsertExpr(ti *model.TableInfo) (
 	return r.insertExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getUpdateOldExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getUpdateOldExpr(ti *common.TableInfo) (
 	expression.Expression, error,
 ) {
 	tableName := ti.TableName.String()
@@ -189,7 +189,7 @@ func (r *dmlExprFilterRule) getUpdateOldExpr(ti *model.TableInfo) (
 	return r.updateOldExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getUpdateNewExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getUpdateNewExpr(ti *common.TableInfo) (
 	expression.Expression, error,
 ) {
 	tableName := ti.TableName.String()
@@ -207,7 +207,7 @@ func (r *dmlExprFilterRule) getUpdateNewExpr(ti *model.TableInfo) (
 	return r.updateNewExprs[tableName], nil
 }
 
-func (r *dmlExprFilterRule) getDeleteExpr(ti *model.TableInfo) (
+func (r *dmlExprFilterRule) getDeleteExpr(ti *common.TableInfo) (
 	expression.Expression, error,
 ) {
 	tableName := ti.TableName.String()
@@ -227,7 +227,7 @@ func (r *dmlExprFilterRule) getDeleteExpr(ti *model.TableInfo) (
 
 func (r *dmlExprFilterRule) getSimpleExprOfTable(
 	expr string,
-	ti *model.TableInfo,
+	ti *common.TableInfo,
 ) (expression.Expression, error) {
 	e, err := expression.ParseSimpleExprWithTableInfo(r.sessCtx.GetExprCtx(), expr, ti.TableInfo)
 	if err != nil {
@@ -247,9 +247,9 @@ func (r *dmlExprFilterRule) getSimpleExprOfTable(
 }
 
 func (r *dmlExprFilterRule) shouldSkipDML(
-	row *model.RowChangedEvent,
-	rawRow model.RowChangedDatums,
-	ti *model.TableInfo,
+	dmlType commonEvent.RowType,
+	preRow, row chunk.Row,
+	ti *common.TableInfo,
 ) (bool, error) {
 	tableName := ti.TableName.String()
 
@@ -259,7 +259,7 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 	if oldTi, ok := r.tables[tableName]; ok {
 		// If one table's tableInfo was updated, we need to reset this rule
 		// and update the tableInfo in the cache.
-		if ti.Version != oldTi.Version {
+		if ti.UpdateTS != oldTi.UpdateTS {
 			r.tables[tableName] = ti.Clone()
 			r.resetExpr(ti.TableName.String())
 		}
@@ -267,17 +267,17 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 		r.tables[tableName] = ti.Clone()
 	}
 
-	switch {
-	case row.IsInsert():
+	switch dmlType {
+	case commonEvent.RowTypeInsert:
 		exprs, err := r.getInsertExpr(ti)
 		if err != nil {
 			return false, err
 		}
 		return r.skipDMLByExpression(
-			rawRow.RowDatums,
+			row,
 			exprs,
 		)
-	case row.IsUpdate():
+	case commonEvent.RowTypeUpdate:
 		oldExprs, err := r.getUpdateOldExpr(ti)
 		if err != nil {
 			return false, err
@@ -287,27 +287,27 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 			return false, err
 		}
 		ignoreOld, err := r.skipDMLByExpression(
-			rawRow.PreRowDatums,
+			preRow,
 			oldExprs,
 		)
 		if err != nil {
 			return false, err
 		}
 		ignoreNew, err := r.skipDMLByExpression(
-			rawRow.RowDatums,
+			row,
 			newExprs,
 		)
 		if err != nil {
 			return false, err
 		}
 		return ignoreOld || ignoreNew, nil
-	case row.IsDelete():
+	case commonEvent.RowTypeDelete:
 		exprs, err := r.getDeleteExpr(ti)
 		if err != nil {
 			return false, err
 		}
 		return r.skipDMLByExpression(
-			rawRow.PreRowDatums,
+			preRow,
 			exprs,
 		)
 	default:
@@ -316,16 +316,17 @@ func (r *dmlExprFilterRule) shouldSkipDML(
 	}
 }
 
+// skipDMLByExpression evaluates the expression on the row, the columns of the row
+// are in the same order as the columns of the table info, so the column offsets
+// in the expression can be used directly.
 func (r *dmlExprFilterRule) skipDMLByExpression(
-	rowData []types.Datum,
+	row chunk.Row,
 	expr expression.Expression,
 ) (bool, error) {
-	if len(rowData) == 0 || expr == nil {
+	if row.IsEmpty() || expr == nil {
 		return false, nil
 	}
 
-	row := chunk.MutRowFromDatums(rowData).ToRow()
-
 	d, err := expr.Eval(r.sessCtx.GetExprCtx().GetEvalCtx(), row)
 	if err != nil {
 		log.Error("failed to eval expression", zap.Error(err))
@@ -382,7 +383,7 @@ func (f *dmlExprFilter) addRule(
 }
 
 // verify checks if all rules in this filter is valid.
-func (f *dmlExprFilter) verify(tableInfos []*model.TableInfo) error {
+func (f *dmlExprFilter) verify(tableInfos []*common.TableInfo) error {
 	for _, rule := range f.rules {
 		err := rule.verify(tableInfos)
 		if err != nil {
@@ -405,25 +406,25 @@ func (f *dmlExprFilter) getRules(schema, table string) []*dmlExprFilterRule {
 
 // shouldSkipDML skips dml event by sql expression.
 func (f *dmlExprFilter) shouldSkipDML(
-	row *model.RowChangedEvent,
-	rawRow model.RowChangedDatums,
-	ti *model.TableInfo,
+	dmlType commonEvent.RowType,
+	preRow, row chunk.Row,
+	ti *common.TableInfo,
 ) (bool, error) {
 	if len(f.rules) == 0 {
 		return false, nil
 	}
-	// for defense purpose, normally the row and ti should not be nil.
-	if ti == nil || row == nil || rawRow.IsEmpty() {
+	// for defense purpose, normally the ti should not be nil.
+	if ti == nil || (preRow.IsEmpty() && row.IsEmpty()) {
 		return false, nil
 	}
-	rules := f.getRules(row.TableInfo.GetSchemaName(), row.TableInfo.GetTableName())
+	rules := f.getRules(ti.GetSchemaName(), ti.GetTableName())
 	for _, rule := range rules {
-		ignore, err := rule.shouldSkipDML(row, rawRow, ti)
+		ignore, err := rule.shouldSkipDML(dmlType, preRow, row, ti)
 		if err != nil {
 			if cerror.ShouldFailChangefeed(err) {
 				return false, err
 			}
-			return false, cerror.WrapError(cerror.ErrFailedToFilterDML, err, row)
+			return false, cerror.WrapError(cerror.ErrFailedToFilterDML, err, ti.TableName.String())
 		}
 		if ignore {
 			return true, nil
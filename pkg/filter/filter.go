@@ -18,9 +18,11 @@ import (
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/apperror"
+	"github.com/pingcap/ticdc/pkg/common"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/ticdc/pkg/config"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	tfilter "github.com/pingcap/tidb/pkg/util/table-filter"
 	"github.com/pingcap/tiflow/cdc/model"
 	"go.uber.org/zap"
@@ -41,8 +43,9 @@ const (
 // Filter are safe for concurrent use.
 // TODO: find a better way to abstract this interface.
 type Filter interface {
-	// ShouldIgnoreDMLEvent returns true if the DML event should not be sent to downstream.
-	ShouldIgnoreDMLEvent(dml *model.RowChangedEvent, rawRow model.RowChangedDatums, tableInfo *model.TableInfo) (bool, error)
+	// ShouldIgnoreDMLEvent returns true if the row change should not be sent to downstream.
+	// The preRow is empty for insert and the row is empty for delete.
+	ShouldIgnoreDMLEvent(dmlType commonEvent.RowType, preRow, row chunk.Row, tableInfo *common.TableInfo, startTs uint64) (bool, error)
 	// ShouldIgnoreDDLEvent returns true if the DDL event should not be sent to downstream.
 	ShouldIgnoreDDLEvent(ddl *model.DDLEvent) (bool, error)
 	// ShouldDiscardDDL returns true if this DDL should be discarded.
@@ -55,7 +58,7 @@ type Filter interface {
 	ShouldIgnoreSchema(schema string) bool
 	// Verify should only be called by create changefeed OpenAPI.
 	// Its purpose is to verify the expression filter config.
-	Verify(tableInfos []*model.TableInfo) error
+	Verify(tableInfos []*common.TableInfo) error
 
 	// filter ddl event to update query and influenced table spans
 	FilterDDLEvent(ddl *commonEvent.DDLEvent) error
@@ -128,32 +131,33 @@ func (f *filter) FilterDDLEvent(ddl *commonEvent.DDLEvent) error {
 	return nil
 }
 
-// ShouldIgnoreDMLEvent checks if a DML event should be ignore by conditions below:
+// ShouldIgnoreDMLEvent checks if a row change should be ignore by conditions below:
 // 0. By startTs.
 // 1. By table name.
 // 2. By type.
 // 3. By columns value.
 func (f *filter) ShouldIgnoreDMLEvent(
-	dml *model.RowChangedEvent,
-	rawRow model.RowChangedDatums,
-	ti *model.TableInfo,
+	dmlType commonEvent.RowType,
+	preRow, row chunk.Row,
+	ti *common.TableInfo,
+	startTs uint64,
 ) (bool, error) {
-	if f.shouldIgnoreStartTs(dml.StartTs) {
+	if f.shouldIgnoreStartTs(startTs) {
 		return true, nil
 	}
 
-	if f.ShouldIgnoreTable(dml.TableInfo.GetSchemaName(), dml.TableInfo.GetTableName()) {
+	if f.ShouldIgnoreTable(ti.GetSchemaName(), ti.GetTableName()) {
 		return true, nil
 	}
 
-	ignoreByEventType, err := f.sqlEventFilter.shouldSkipDML(dml)
+	ignoreByEventType, err := f.sqlEventFilter.shouldSkipDML(ti.GetSchemaName(), ti.GetTableName(), dmlType)
 	if err != nil {
 		return false, err
 	}
 	if ignoreByEventType {
 		return true, nil
 	}
-	return f.dmlExprFilter.shouldSkipDML(dml, rawRow, ti)
+	return f.dmlExprFilter.shouldSkipDML(dmlType, preRow, row, ti)
 }
 
 // ShouldDiscardDDL checks if a DDL should be discarded by conditions below:
@@ -206,7 +210,7 @@ func (f *filter) ShouldIgnoreSchema(schema string) bool {
 	return IsSysSchema(schema) || !f.tableFilter.MatchSchema(schema)
 }
 
-func (f *filter) Verify(tableInfos []*model.TableInfo) error {
+func (f *filter) Verify(tableInfos []*common.TableInfo) error {
 	return f.dmlExprFilter.verify(tableInfos)
 }
 
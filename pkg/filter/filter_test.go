@@ -0,0 +1,71 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"testing"
+
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	bf "github.com/pingcap/tiflow/pkg/binlog-filter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldIgnoreDMLEvent(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.DDL2Job("create table test.t1 (id int primary key, name varchar(32))")
+	helper.DDL2Job("create table test.t2 (id int primary key, name varchar(32))")
+
+	f, err := NewFilter(&config.FilterConfig{
+		Rules:            []string{"*.*"},
+		IgnoreTxnStartTs: []uint64{100},
+		EventFilters: []*config.EventFilterRule{
+			{
+				Matcher:               []string{"test.t1"},
+				IgnoreInsertValueExpr: "id > 10 and name = 'ignored'",
+			},
+			{
+				Matcher:     []string{"test.t2"},
+				IgnoreEvent: []bf.EventType{bf.InsertEvent},
+			},
+		},
+	}, "", false)
+	require.NoError(t, err)
+
+	shouldIgnore := func(event *commonEvent.DMLEvent, startTs uint64) bool {
+		row, ok := event.GetNextRow()
+		require.True(t, ok)
+		ignore, err := f.ShouldIgnoreDMLEvent(row.RowType, row.PreRow, row.Row, event.TableInfo, startTs)
+		require.NoError(t, err)
+		return ignore
+	}
+
+	// filtered by the column values
+	event := helper.DML2Event("test", "t1", "insert into test.t1 values (1, 'ignored')")
+	require.False(t, shouldIgnore(event, 1))
+	event = helper.DML2Event("test", "t1", "insert into test.t1 values (11, 'ignored')")
+	require.True(t, shouldIgnore(event, 1))
+	event = helper.DML2Event("test", "t1", "insert into test.t1 values (12, 'kept')")
+	require.False(t, shouldIgnore(event, 1))
+
+	// filtered by the start ts of the transaction
+	event = helper.DML2Event("test", "t1", "insert into test.t1 values (13, 'kept')")
+	require.True(t, shouldIgnore(event, 100))
+
+	// filtered by the event type
+	event = helper.DML2Event("test", "t2", "insert into test.t2 values (1, 'a')")
+	require.True(t, shouldIgnore(event, 1))
+}
@@ -0,0 +1,183 @@
+package messaging
+
+import (
+	"sync"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/messaging/proto"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// CompressionNone means the messages are sent without compression.
+	CompressionNone = "none"
+	// CompressionZstd compresses the messages by zstd.
+	CompressionZstd = "zstd"
+	// CompressionSnappy compresses the messages by snappy.
+	CompressionSnappy = "snappy"
+
+	// minCompressSize is the min size of the payloads of a message to be compressed,
+	// small messages like heartbeats are not worth compressing.
+	minCompressSize = 1024
+	// maxPooledBufferSize is the max capacity of the buffers kept in the pool,
+	// to avoid holding too much memory after sending a few large messages.
+	maxPooledBufferSize = 4 * 1024 * 1024
+)
+
+// acceptCompressions are the compressions this message center can decompress,
+// they are sent to the remote message center in the handshake message.
+var acceptCompressions = []string{CompressionZstd, CompressionSnappy}
+
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+func initZstd() {
+	zstdOnce.Do(func() {
+		var err error
+		zstdEncoder, err = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+		if err != nil {
+			log.Panic("failed to create zstd encoder", zap.Error(err))
+		}
+		zstdDecoder, err = zstd.NewReader(nil)
+		if err != nil {
+			log.Panic("failed to create zstd decoder", zap.Error(err))
+		}
+	})
+}
+
+// negotiateCompression returns the compression used to send messages to the remote message center,
+// it's the preferred one if the remote accepts it, otherwise the messages are not compressed.
+// The remote of an old version doesn't send the accepted compressions, so it always gets uncompressed messages.
+func negotiateCompression(preferred string, accepted []string) string {
+	if preferred == "" || preferred == CompressionNone {
+		return ""
+	}
+	for _, compression := range accepted {
+		if compression == preferred {
+			return compression
+		}
+	}
+	return ""
+}
+
+func compress(compression string, data []byte) []byte {
+	switch compression {
+	case CompressionZstd:
+		initZstd()
+		return zstdEncoder.EncodeAll(data, nil)
+	case CompressionSnappy:
+		return s2.EncodeSnappy(nil, data)
+	default:
+		log.Panic("unsupported compression", zap.String("compression", compression))
+	}
+	return nil
+}
+
+func decompress(compression string, data []byte) ([]byte, error) {
+	switch compression {
+	case CompressionZstd:
+		initZstd()
+		return zstdDecoder.DecodeAll(data, nil)
+	case CompressionSnappy:
+		return s2.Decode(nil, data)
+	default:
+		return nil, errors.Errorf("unsupported compression %s", compression)
+	}
+}
+
+// compressMessage compresses the payloads of the message if they are large enough.
+// The compressed payloads are stored in a new slice, so the raw payloads
+// can be released by releasePayloads before the message is sent.
+func compressMessage(message *proto.Message, compression string) {
+	if compression == "" || payloadSize(message) < minCompressSize {
+		return
+	}
+	payloads := make([][]byte, 0, len(message.Payload))
+	for _, payload := range message.Payload {
+		payloads = append(payloads, compress(compression, payload))
+	}
+	message.Payload = payloads
+	message.Compression = compression
+}
+
+// decompressMessage decompresses the payloads of the message in place.
+func decompressMessage(message *proto.Message) error {
+	if message.Compression == "" {
+		return nil
+	}
+	for i, payload := range message.Payload {
+		data, err := decompress(message.Compression, payload)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		message.Payload[i] = data
+	}
+	message.Compression = ""
+	return nil
+}
+
+func payloadSize(message *proto.Message) int {
+	size := 0
+	for _, payload := range message.Payload {
+		size += len(payload)
+	}
+	return size
+}
+
+// sizedMarshaler is implemented by the gogo protobuf messages,
+// they can be marshaled into a buffer from the pool.
+type sizedMarshaler interface {
+	Size() int
+	MarshalTo(data []byte) (int, error)
+}
+
+// marshalPayload marshals the message into a buffer from the pool if possible.
+// The buffer is put back to the pool by releasePayloads only if it's not sent,
+// i.e. it's replaced by the compressed payload.
+func marshalPayload(m IOTypeT) ([]byte, error) {
+	sm, ok := m.(sizedMarshaler)
+	if !ok {
+		return m.Marshal()
+	}
+	buf := getBuffer(sm.Size())
+	n, err := sm.MarshalTo(buf)
+	if err != nil {
+		putBuffer(buf)
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// releasePayloads puts the raw payloads of a compressed message back to the pool,
+// the payloads referenced by the sent message must not be released.
+func releasePayloads(payloads [][]byte) {
+	for _, payload := range payloads {
+		putBuffer(payload)
+	}
+}
+
+var bufferPool sync.Pool
+
+func getBuffer(size int) []byte {
+	if v := bufferPool.Get(); v != nil {
+		buf := *(v.(*[]byte))
+		if cap(buf) >= size {
+			return buf[:size]
+		}
+	}
+	return make([]byte, size)
+}
+
+func putBuffer(buf []byte) {
+	if cap(buf) == 0 || cap(buf) > maxPooledBufferSize {
+		return
+	}
+	buf = buf[:0]
+	bufferPool.Put(&buf)
+}
@@ -0,0 +1,147 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/heartbeatpb"
+	"github.com/pingcap/ticdc/pkg/messaging/proto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	require.Equal(t, CompressionZstd, negotiateCompression(CompressionZstd, acceptCompressions))
+	require.Equal(t, CompressionSnappy, negotiateCompression(CompressionSnappy, acceptCompressions))
+	require.Equal(t, "", negotiateCompression(CompressionNone, acceptCompressions))
+	require.Equal(t, "", negotiateCompression("", acceptCompressions))
+	// The remote of an old version doesn't send the accepted compressions.
+	require.Equal(t, "", negotiateCompression(CompressionZstd, nil))
+	require.Equal(t, "", negotiateCompression(CompressionZstd, []string{CompressionSnappy}))
+}
+
+func TestCompressMessage(t *testing.T) {
+	large := bytes.Repeat([]byte("ticdc"), minCompressSize)
+	small := []byte("heartbeat")
+
+	for _, compression := range acceptCompressions {
+		message := &proto.Message{Payload: [][]byte{append([]byte{}, large...), append([]byte{}, small...)}}
+		rawPayloads := message.Payload
+		compressMessage(message, compression)
+		require.Equal(t, compression, message.Compression)
+		require.Less(t, payloadSize(message), len(large)+len(small))
+		// the raw payloads are kept to be released before the message is sent
+		require.Equal(t, [][]byte{large, small}, rawPayloads)
+
+		require.NoError(t, decompressMessage(message))
+		require.Equal(t, "", message.Compression)
+		require.Equal(t, [][]byte{large, small}, message.Payload)
+	}
+
+	// Small messages are not compressed.
+	message := &proto.Message{Payload: [][]byte{small}}
+	compressMessage(message, CompressionZstd)
+	require.Equal(t, "", message.Compression)
+	require.Equal(t, [][]byte{small}, message.Payload)
+
+	// No compression is negotiated.
+	message = &proto.Message{Payload: [][]byte{large}}
+	compressMessage(message, "")
+	require.Equal(t, "", message.Compression)
+	require.Equal(t, [][]byte{large}, message.Payload)
+
+	message = &proto.Message{Payload: [][]byte{large}, Compression: "unknown"}
+	require.Error(t, decompressMessage(message))
+}
+
+func TestMarshalPayload(t *testing.T) {
+	span := &heartbeatpb.TableSpan{
+		TableID:  1,
+		StartKey: bytes.Repeat([]byte{'a'}, 2048),
+		EndKey:   bytes.Repeat([]byte{'b'}, 2048),
+	}
+	for i := 0; i < 3; i++ {
+		buf, err := marshalPayload(span)
+		require.NoError(t, err)
+		expected, err := span.Marshal()
+		require.NoError(t, err)
+		require.Equal(t, expected, buf)
+
+		message := &proto.Message{Payload: [][]byte{buf}}
+		compressMessage(message, CompressionZstd)
+		// the buffer is reused by the next marshal
+		releasePayloads([][]byte{buf})
+		require.NoError(t, decompressMessage(message))
+
+		decoded := &heartbeatpb.TableSpan{}
+		require.NoError(t, decoded.Unmarshal(message.Payload[0]))
+		require.Equal(t, span, decoded)
+	}
+}
+
+// recordSender keeps the sent messages without copying them,
+// they are decoded concurrently with the next messages being marshaled.
+type recordSender struct {
+	messages chan *proto.Message
+}
+
+func (s *recordSender) Send(message *proto.Message) error {
+	s.messages <- message
+	return nil
+}
+
+// Run it with -race to check the payloads referenced by the sent messages are not reused.
+func TestSendReleasedPayloads(t *testing.T) {
+	rt := newRemoteMessageTargetForTest()
+	defer rt.close()
+	large := &heartbeatpb.TableSpan{TableID: 1, StartKey: bytes.Repeat([]byte{'a'}, 2048)}
+	small := &heartbeatpb.TableSpan{TableID: 2}
+	spans := make([]*heartbeatpb.TableSpan, 0, 40)
+	for i := 0; i < 20; i++ {
+		spans = append(spans, large, small)
+	}
+
+	for _, compression := range []string{"", CompressionZstd} {
+		ctx, cancel := context.WithCancel(context.Background())
+		sender := &recordSender{messages: make(chan *proto.Message, 1)}
+		sendCh := make(chan *proto.Message, 1)
+		done := make(chan error)
+		go func() {
+			done <- rt.runSendMessages(ctx, sender, sendCh, compression)
+		}()
+		// the sent messages are decoded while the next ones are marshaled,
+		// and decoded again after all messages are sent
+		sent := make(chan *proto.Message, len(spans))
+		decoded := make(chan struct{})
+		go func() {
+			defer close(decoded)
+			for message := range sent {
+				for _, payload := range message.Payload {
+					_ = bytes.Count(payload, []byte{'a'})
+				}
+			}
+		}()
+		messages := make([]*proto.Message, 0, len(spans))
+		for _, span := range spans {
+			sendCh <- rt.newMessage(&TargetMessage{Type: TypeMessageHandShake, Message: []IOTypeT{span}})
+			message := <-sender.messages
+			sent <- message
+			messages = append(messages, message)
+		}
+		close(sent)
+		<-decoded
+		for i, message := range messages {
+			if compression != "" && spans[i] == large {
+				require.Equal(t, compression, message.Compression)
+			} else {
+				require.Equal(t, "", message.Compression)
+			}
+			require.NoError(t, decompressMessage(message))
+			span := &heartbeatpb.TableSpan{}
+			require.NoError(t, span.Unmarshal(message.Payload[0]))
+			require.Equal(t, spans[i], span)
+		}
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+	}
+}
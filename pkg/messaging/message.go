@@ -52,6 +52,15 @@ const (
 
 	TypeMessageError
 	TypeMessageHandShake
+
+	TypeErrorEvent
+	TypeDrainNodeRequest
+	TypeMoveTableRequest
+	TypeUpdateMaintainerRequest
+	TypeUpdateDispatcherManagerRequest
+	TypeUpdateDispatcherManagerResponse
+	TypeReusableEventServiceRequest
+	TypeReusableEventServiceResponse
 )
 
 func (t IOType) String() string {
@@ -66,10 +75,16 @@ func (t IOType) String() string {
 		return "BatchResolvedTs"
 	case TypeHandshakeEvent:
 		return "HandshakeEvent"
+	case TypeErrorEvent:
+		return "ErrorEvent"
 	case TypeLogCoordinatorBroadcastRequest:
 		return "TypeLogCoordinatorBroadcastRequest"
 	case TypeEventStoreState:
 		return "TypeEventStoreState"
+	case TypeReusableEventServiceRequest:
+		return "TypeReusableEventServiceRequest"
+	case TypeReusableEventServiceResponse:
+		return "TypeReusableEventServiceResponse"
 	case TypeHeartBeatRequest:
 		return "HeartBeatRequest"
 	case TypeHeartBeatResponse:
@@ -98,6 +113,16 @@ func (t IOType) String() string {
 		return "MaintainerCloseRequest"
 	case TypeMaintainerCloseResponse:
 		return "MaintainerCloseResponse"
+	case TypeDrainNodeRequest:
+		return "DrainNodeRequest"
+	case TypeMoveTableRequest:
+		return "MoveTableRequest"
+	case TypeUpdateMaintainerRequest:
+		return "UpdateMaintainerRequest"
+	case TypeUpdateDispatcherManagerRequest:
+		return "UpdateDispatcherManagerRequest"
+	case TypeUpdateDispatcherManagerResponse:
+		return "UpdateDispatcherManagerResponse"
 	case TypeMessageError:
 		return "MessageError"
 	case TypeMessageHandShake:
@@ -176,6 +201,10 @@ func (r RegisterDispatcherRequest) GetFilterConfig() *config.FilterConfig {
 	return filterCfg
 }
 
+func (r RegisterDispatcherRequest) GetBDRMode() bool {
+	return r.BdrMode
+}
+
 func (r RegisterDispatcherRequest) SyncPointEnabled() bool {
 	return r.EnableSyncPoint
 }
@@ -206,10 +235,16 @@ func decodeIOType(ioType IOType, value []byte) (IOTypeT, error) {
 		m = &commonEvent.BatchResolvedEvent{}
 	case TypeHandshakeEvent:
 		m = &commonEvent.HandshakeEvent{}
+	case TypeErrorEvent:
+		m = &commonEvent.ErrorEvent{}
 	case TypeLogCoordinatorBroadcastRequest:
 		m = &common.LogCoordinatorBroadcastRequest{}
 	case TypeEventStoreState:
 		m = &logservicepb.EventStoreState{}
+	case TypeReusableEventServiceRequest:
+		m = &logservicepb.ReusableEventServiceRequest{}
+	case TypeReusableEventServiceResponse:
+		m = &logservicepb.ReusableEventServiceResponse{}
 	case TypeHeartBeatRequest:
 		m = &heartbeatpb.HeartBeatRequest{}
 	case TypeHeartBeatResponse:
@@ -238,6 +273,16 @@ func decodeIOType(ioType IOType, value []byte) (IOTypeT, error) {
 		m = &heartbeatpb.MaintainerCloseResponse{}
 	case TypeMaintainerBootstrapRequest:
 		m = &heartbeatpb.MaintainerBootstrapRequest{}
+	case TypeDrainNodeRequest:
+		m = &heartbeatpb.DrainNodeRequest{}
+	case TypeMoveTableRequest:
+		m = &heartbeatpb.MoveTableRequest{}
+	case TypeUpdateMaintainerRequest:
+		m = &heartbeatpb.UpdateMaintainerRequest{}
+	case TypeUpdateDispatcherManagerRequest:
+		m = &heartbeatpb.UpdateDispatcherManagerRequest{}
+	case TypeUpdateDispatcherManagerResponse:
+		m = &heartbeatpb.UpdateDispatcherManagerResponse{}
 	case TypeCheckpointTsMessage:
 		m = &heartbeatpb.CheckpointTsMessage{}
 	case TypeMessageError:
@@ -276,10 +321,16 @@ func NewSingleTargetMessage(To node.ID, Topic string, Message IOTypeT) *TargetMe
 		ioType = TypeBatchResolvedTs
 	case *commonEvent.HandshakeEvent:
 		ioType = TypeHandshakeEvent
+	case *commonEvent.ErrorEvent:
+		ioType = TypeErrorEvent
 	case *common.LogCoordinatorBroadcastRequest:
 		ioType = TypeLogCoordinatorBroadcastRequest
 	case *logservicepb.EventStoreState:
 		ioType = TypeEventStoreState
+	case *logservicepb.ReusableEventServiceRequest:
+		ioType = TypeReusableEventServiceRequest
+	case *logservicepb.ReusableEventServiceResponse:
+		ioType = TypeReusableEventServiceResponse
 	case *heartbeatpb.HeartBeatRequest:
 		ioType = TypeHeartBeatRequest
 	case *heartbeatpb.BlockStatusRequest:
@@ -312,6 +363,16 @@ func NewSingleTargetMessage(To node.ID, Topic string, Message IOTypeT) *TargetMe
 		ioType = TypeMaintainerCloseResponse
 	case *heartbeatpb.CheckpointTsMessage:
 		ioType = TypeCheckpointTsMessage
+	case *heartbeatpb.DrainNodeRequest:
+		ioType = TypeDrainNodeRequest
+	case *heartbeatpb.MoveTableRequest:
+		ioType = TypeMoveTableRequest
+	case *heartbeatpb.UpdateMaintainerRequest:
+		ioType = TypeUpdateMaintainerRequest
+	case *heartbeatpb.UpdateDispatcherManagerRequest:
+		ioType = TypeUpdateDispatcherManagerRequest
+	case *heartbeatpb.UpdateDispatcherManagerResponse:
+		ioType = TypeUpdateDispatcherManagerResponse
 	default:
 		panic("unknown io type")
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/config"
 	"github.com/pingcap/ticdc/pkg/messaging/proto"
 	"github.com/pingcap/ticdc/pkg/metrics"
+	"github.com/pingcap/tiflow/pkg/security"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -71,6 +72,8 @@ type messageCenter struct {
 	// when every time the message center is restarted, the epoch will be increased by 1.
 	epoch uint64
 	cfg   *config.MessageCenterConfig
+	// The credential used to connect to the remote message centers.
+	security *security.Credential
 	// The local target, which is the message center itself.
 	localTarget *localMessageTarget
 	// The remote targets, which are the other message centers in remote servers.
@@ -89,7 +92,13 @@ type messageCenter struct {
 	cancel         context.CancelFunc
 }
 
-func NewMessageCenter(ctx context.Context, id node.ID, epoch uint64, cfg *config.MessageCenterConfig) *messageCenter {
+func NewMessageCenter(
+	ctx context.Context, id node.ID, epoch uint64,
+	cfg *config.MessageCenterConfig, credential *security.Credential,
+) *messageCenter {
+	if credential == nil {
+		credential = &security.Credential{}
+	}
 	receiveEventCh := make(chan *TargetMessage, cfg.CacheChannelSize)
 	receiveCmdCh := make(chan *TargetMessage, cfg.CacheChannelSize)
 	ctx, cancel := context.WithCancel(ctx)
@@ -97,6 +106,7 @@ func NewMessageCenter(ctx context.Context, id node.ID, epoch uint64, cfg *config
 		id:             id,
 		epoch:          epoch,
 		cfg:            cfg,
+		security:       credential,
 		localTarget:    newLocalMessageTarget(id, receiveEventCh, receiveCmdCh),
 		receiveEventCh: receiveEventCh,
 		receiveCmdCh:   receiveCmdCh,
@@ -256,7 +266,7 @@ func (mc *messageCenter) touchRemoteTarget(id node.ID, epoch uint64, addr string
 		target = newRemoteMessageTarget(
 			mc.id, id, mc.epoch,
 			epoch, addr, mc.receiveEventCh,
-			mc.receiveCmdCh, mc.cfg)
+			mc.receiveCmdCh, mc.cfg, mc.security)
 		mc.remoteTargets.m[id] = target
 		return target
 	}
@@ -285,7 +295,7 @@ func (mc *messageCenter) touchRemoteTarget(id node.ID, epoch uint64, addr string
 	newTarget := newRemoteMessageTarget(
 		mc.id, id, mc.epoch,
 		epoch, addr, mc.receiveEventCh,
-		mc.receiveCmdCh, mc.cfg)
+		mc.receiveCmdCh, mc.cfg, mc.security)
 	mc.remoteTargets.m[id] = newTarget
 	return newTarget
 
@@ -356,9 +366,16 @@ func (s *grpcServer) handleConnect(msg *proto.Message, stream grpcSender, isEven
 		zap.String("remote", msg.From),
 		zap.Bool("isEvent", isEvent))
 
+	compression := negotiateCompression(s.messageCenter.cfg.Compression, msg.GetAcceptCompressions())
+	log.Info("Negotiated compression with remote target",
+		zap.Any("messageCenterID", s.messageCenter.id),
+		zap.String("remote", msg.From),
+		zap.String("compression", compression),
+		zap.Bool("isEvent", isEvent))
+
 	if isEvent {
-		return remoteTarget.runEventSendStream(stream)
+		return remoteTarget.runEventSendStream(stream, compression)
 	} else {
-		return remoteTarget.runCommandSendStream(stream)
+		return remoteTarget.runCommandSendStream(stream, compression)
 	}
 }
@@ -0,0 +1,63 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package messaging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestIOTypeValues pins the values of the message types, they are sent on the wire,
+// so the new types must be appended to the end to keep compatible with the old nodes.
+func TestIOTypeValues(t *testing.T) {
+	types := []IOType{
+		TypeInvalid,
+		TypeDMLEvent,
+		TypeDDLEvent,
+		TypeBatchResolvedTs,
+		TypeSyncPointEvent,
+		TypeHandshakeEvent,
+		TypeLogCoordinatorBroadcastRequest,
+		TypeEventStoreState,
+		TypeHeartBeatRequest,
+		TypeHeartBeatResponse,
+		TypeScheduleDispatcherRequest,
+		TypeRegisterDispatcherRequest,
+		TypeCheckpointTsMessage,
+		TypeBlockStatusRequest,
+		TypeCoordinatorBootstrapRequest,
+		TypeCoordinatorBootstrapResponse,
+		TypeAddMaintainerRequest,
+		TypeRemoveMaintainerRequest,
+		TypeMaintainerHeartbeatRequest,
+		TypeMaintainerBootstrapRequest,
+		TypeMaintainerBootstrapResponse,
+		TypeMaintainerCloseRequest,
+		TypeMaintainerCloseResponse,
+		TypeMessageError,
+		TypeMessageHandShake,
+		TypeErrorEvent,
+		TypeDrainNodeRequest,
+		TypeMoveTableRequest,
+		TypeUpdateMaintainerRequest,
+		TypeUpdateDispatcherManagerRequest,
+		TypeUpdateDispatcherManagerResponse,
+		TypeReusableEventServiceRequest,
+		TypeReusableEventServiceResponse,
+	}
+	for i, tp := range types {
+		require.Equal(t, IOType(i), tp, tp.String())
+	}
+}
@@ -45,6 +45,8 @@ type remoteMessageTarget struct {
 	targetEpoch atomic.Value
 	targetId    node.ID
 	targetAddr  string
+	// security is used to create the grpc connection to the target.
+	security *security.Credential
 
 	// For sending events and commands
 	eventSender   *sendStreamWrapper
@@ -139,6 +141,7 @@ func newRemoteMessageTarget(
 	addr string,
 	recvEventCh, recvCmdCh chan *TargetMessage,
 	cfg *config.MessageCenterConfig,
+	security *security.Credential,
 ) *remoteMessageTarget {
 	log.Info("Create remote target", zap.Stringer("local", localID), zap.Stringer("remote", targetId), zap.Any("addr", addr), zap.Any("localEpoch", localEpoch), zap.Any("targetEpoch", targetEpoch))
 	ctx, cancel := context.WithCancel(context.Background())
@@ -147,6 +150,7 @@ func newRemoteMessageTarget(
 		messageCenterEpoch: localEpoch,
 		targetAddr:         addr,
 		targetId:           targetId,
+		security:           security,
 		eventSender:        &sendStreamWrapper{ready: atomic.Bool{}},
 		commandSender:      &sendStreamWrapper{ready: atomic.Bool{}},
 		ctx:                ctx,
@@ -229,7 +233,7 @@ func (s *remoteMessageTarget) connect() {
 	if s.conn != nil {
 		return
 	}
-	conn, err := conn.Connect(string(s.targetAddr), &security.Credential{})
+	conn, err := conn.Connect(string(s.targetAddr), s.security)
 	if err != nil {
 		log.Info("Cannot create grpc client",
 			zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
@@ -245,6 +249,9 @@ func (s *remoteMessageTarget) connect() {
 		To:    string(s.targetId),
 		Epoch: uint64(s.messageCenterEpoch),
 		Type:  int32(TypeMessageHandShake),
+		// The remote sends messages to this server by the streams,
+		// so it's told which compressions can be used.
+		AcceptCompressions: acceptCompressions,
 	}
 
 	eventStream, err := client.SendEvents(s.ctx, handshake)
@@ -303,33 +310,50 @@ LOOP:
 	s.connect()
 }
 
-func (s *remoteMessageTarget) runEventSendStream(eventStream grpcSender) error {
+// runEventSendStream sends the events to the remote by the stream,
+// compression is the compression negotiated with the remote, empty means no compression.
+func (s *remoteMessageTarget) runEventSendStream(eventStream grpcSender, compression string) error {
 	s.eventSender.stream = eventStream
 	s.eventSender.ready.Store(true)
-	err := s.runSendMessages(s.ctx, s.eventSender.stream, s.sendEventCh)
+	err := s.runSendMessages(s.ctx, s.eventSender.stream, s.sendEventCh, compression)
 	log.Info("Event send stream closed",
 		zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
 	s.eventSender.ready.Store(false)
 	return err
 }
 
-func (s *remoteMessageTarget) runCommandSendStream(commandStream grpcSender) error {
+// runCommandSendStream sends the commands to the remote by the stream,
+// compression is the compression negotiated with the remote, empty means no compression.
+func (s *remoteMessageTarget) runCommandSendStream(commandStream grpcSender, compression string) error {
 	s.commandSender.stream = commandStream
 	s.commandSender.ready.Store(true)
-	err := s.runSendMessages(s.ctx, s.commandSender.stream, s.sendCmdCh)
+	err := s.runSendMessages(s.ctx, s.commandSender.stream, s.sendCmdCh, compression)
 	log.Info("Command send stream closed",
 		zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId), zap.Error(err))
 	s.commandSender.ready.Store(false)
 	return err
 }
 
-func (s *remoteMessageTarget) runSendMessages(sendCtx context.Context, stream grpcSender, sendChan chan *proto.Message) error {
+func (s *remoteMessageTarget) runSendMessages(sendCtx context.Context, stream grpcSender, sendChan chan *proto.Message, compression string) error {
 	for {
 		select {
 		case <-sendCtx.Done():
 			return sendCtx.Err()
 		case message := <-sendChan:
-			if err := stream.Send(message); err != nil {
+			// The message is compressed here instead of when it's created,
+			// since the compression is decided by the stream it's sent by.
+			rawPayloads := message.Payload
+			rawSize := payloadSize(message)
+			compressMessage(message, compression)
+			metrics.MessagingSendBytesCounter.WithLabelValues(s.targetAddr, message.Topic, "raw").Add(float64(rawSize))
+			metrics.MessagingSendBytesCounter.WithLabelValues(s.targetAddr, message.Topic, "wire").Add(float64(payloadSize(message)))
+			// only the raw payloads replaced by the compressed ones are reused,
+			// the payloads of the sent message may still be referenced by the stream
+			if message.Compression != "" {
+				releasePayloads(rawPayloads)
+			}
+			err := stream.Send(message)
+			if err != nil {
 				log.Error("Error when sending message to remote",
 					zap.Error(err),
 					zap.Any("messageCenterID", s.messageCenterID),
@@ -363,6 +387,12 @@ func (s *remoteMessageTarget) runReceiveMessages(stream grpcReceiver, receiveCh
 				log.Info("Received handshake message", zap.Any("messageCenterID", s.messageCenterID), zap.Any("remote", s.targetId))
 				continue
 			}
+			metrics.MessagingReceiveBytesCounter.WithLabelValues(s.targetAddr, message.Topic, "wire").Add(float64(payloadSize(message)))
+			if err := decompressMessage(message); err != nil {
+				err := AppError{Type: ErrorTypeInvalidMessage, Reason: errors.Trace(err).Error()}
+				log.Panic("Failed to decompress message", zap.Error(err))
+			}
+			metrics.MessagingReceiveBytesCounter.WithLabelValues(s.targetAddr, message.Topic, "raw").Add(float64(payloadSize(message)))
 			targetMsg := &TargetMessage{
 				From:     node.ID(message.From),
 				To:       node.ID(message.To),
@@ -389,8 +419,7 @@ func (s *remoteMessageTarget) newMessage(msg ...*TargetMessage) *proto.Message {
 	msgBytes := make([][]byte, 0, len(msg))
 	for _, tm := range msg {
 		for _, im := range tm.Message {
-			// TODO: use a buffer pool to reduce the memory allocation.
-			buf, err := im.Marshal()
+			buf, err := marshalPayload(im)
 			if err != nil {
 				log.Panic("marshal message failed ",
 					zap.Any("msg", im),
@@ -113,6 +113,22 @@ var (
 			Name:      "resolved_ts_lag",
 			Help:      "Resolved ts lag of event collector in seconds",
 		})
+
+	EventCollectorMemoryQuotaGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_collector",
+			Name:      "memory_quota",
+			Help:      "The memory quota of the pending events of a changefeed in the event collector",
+		}, []string{"namespace", "changefeed"})
+
+	EventCollectorFeedbackCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "event_collector",
+			Name:      "feedback_count",
+			Help:      "The number of pause and resume feedbacks of a changefeed caused by the memory quota",
+		}, []string{"namespace", "changefeed", "type"})
 )
 
 func InitDisaptcherMetrics(registry *prometheus.Registry) {
@@ -128,5 +144,7 @@ func InitDisaptcherMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(EventCollectorRegisteredDispatcherCount)
 	registry.MustRegister(EventCollectorReceivedEventLagDuration)
 	registry.MustRegister(EventCollectorResolvedTsLagGauge)
+	registry.MustRegister(EventCollectorMemoryQuotaGauge)
+	registry.MustRegister(EventCollectorFeedbackCount)
 
 }
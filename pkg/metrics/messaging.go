@@ -49,6 +49,22 @@ var (
 			Name:      "error_counter",
 			Help:      "The counter of errors occurred in a message center",
 		}, []string{"target", "type", "message"}) // target: its addr, type: event, command, message: error info
+	MessagingSendBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "messaging",
+			Name:      "send_bytes_counter",
+			Help:      "The bytes of payloads sent to remote message centers",
+		}, []string{"target", "topic", "kind"}) // target: its addr, kind: raw, wire(after compression)
+
+	MessagingReceiveBytesCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ticdc",
+			Subsystem: "messaging",
+			Name:      "receive_bytes_counter",
+			Help:      "The bytes of payloads received from remote message centers",
+		}, []string{"target", "topic", "kind"}) // target: its addr, kind: raw, wire(before decompression)
+
 	MessagingStreamGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "ticdc",
@@ -64,5 +80,7 @@ func InitMessagingMetrics(registry *prometheus.Registry) {
 	registry.MustRegister(MessagingReceiveMsgCounter)
 	registry.MustRegister(MessagingDropMsgCounter)
 	registry.MustRegister(MessagingErrorCounter)
+	registry.MustRegister(MessagingSendBytesCounter)
+	registry.MustRegister(MessagingReceiveBytesCounter)
 	registry.MustRegister(MessagingStreamGauge)
 }
@@ -34,14 +34,23 @@ type Coordinator interface {
 	ListChangefeeds(ctx context.Context) ([]*config.ChangeFeedInfo, []*config.ChangeFeedStatus, error)
 	// GetChangefeed returns a changefeed
 	GetChangefeed(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*config.ChangeFeedInfo, *config.ChangeFeedStatus, error)
+	// GetMaintainerNode returns the node that the maintainer of the changefeed is running on
+	GetMaintainerNode(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName) (*Info, error)
 	// CreateChangefeed creates a new changefeed
 	CreateChangefeed(ctx context.Context, info *config.ChangeFeedInfo) error
 	// RemoveChangefeed gets a changefeed
 	RemoveChangefeed(ctx context.Context, id common.ChangeFeedID) (uint64, error)
 	// PauseChangefeed pauses a changefeed
 	PauseChangefeed(ctx context.Context, id common.ChangeFeedID) error
-	// ResumeChangefeed resumes a changefeed
-	ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64) error
+	// ResumeChangefeed resumes a changefeed, the checkpoint is overwritten if newCheckpointTs is not 0,
+	// skipBlockingDDL indicates that newCheckpointTs is the commit ts of the blocking ddl to skip
+	ResumeChangefeed(ctx context.Context, id common.ChangeFeedID, newCheckpointTs uint64, skipBlockingDDL bool) error
 	// UpdateChangefeed updates a changefeed
 	UpdateChangefeed(ctx context.Context, change *config.ChangeFeedInfo) error
+	// DrainNode drains the node, it returns the number of maintainers and table spans still running on the node
+	DrainNode(ctx context.Context, target ID) (int, int, error)
+	// UndrainNode cancels the draining of the node
+	UndrainNode(ctx context.Context, target ID) error
+	// MoveTable moves the table of a changefeed to the target node, it returns true if the table is moved
+	MoveTable(ctx context.Context, changefeedDisplayName common.ChangeFeedDisplayName, tableID int64, target ID) (bool, error)
 }
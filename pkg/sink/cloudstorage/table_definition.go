@@ -0,0 +1,227 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudstorage
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/types"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"github.com/pingcap/tiflow/pkg/hash"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultTableDefinitionVersion = 1
+	marshalPrefix                 = ""
+	marshalIndent                 = "    "
+)
+
+// TableCol denotes the column info for a table definition.
+type TableCol struct {
+	ID        string      `json:"ColumnId,omitempty"`
+	Name      string      `json:"ColumnName" `
+	Tp        string      `json:"ColumnType"`
+	Default   interface{} `json:"ColumnDefault,omitempty"`
+	Precision string      `json:"ColumnPrecision,omitempty"`
+	Scale     string      `json:"ColumnScale,omitempty"`
+	Nullable  string      `json:"ColumnNullable,omitempty"`
+	IsPK      string      `json:"ColumnIsPk,omitempty"`
+}
+
+// FromTiColumnInfo converts from TiDB ColumnInfo to TableCol.
+func (t *TableCol) FromTiColumnInfo(col *timodel.ColumnInfo, outputColumnID bool) {
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(col.GetType())
+	isDecimalNotDefault := col.GetDecimal() != defaultDecimal &&
+		col.GetDecimal() != 0 &&
+		col.GetDecimal() != types.UnspecifiedLength
+
+	displayFlen, displayDecimal := col.GetFlen(), col.GetDecimal()
+	if displayFlen == types.UnspecifiedLength {
+		displayFlen = defaultFlen
+	}
+	if displayDecimal == types.UnspecifiedLength {
+		displayDecimal = defaultDecimal
+	}
+
+	if outputColumnID {
+		t.ID = strconv.FormatInt(col.ID, 10)
+	}
+	t.Name = col.Name.O
+	t.Tp = strings.ToUpper(types.TypeToStr(col.GetType(), col.GetCharset()))
+	if mysql.HasUnsignedFlag(col.GetFlag()) {
+		t.Tp += " UNSIGNED"
+	}
+	if mysql.HasPriKeyFlag(col.GetFlag()) {
+		t.IsPK = "true"
+	}
+	if mysql.HasNotNullFlag(col.GetFlag()) {
+		t.Nullable = "false"
+	}
+	t.Default = common.GetColumnDefaultValue(col)
+
+	switch col.GetType() {
+	case mysql.TypeTimestamp, mysql.TypeDatetime, mysql.TypeDuration:
+		if isDecimalNotDefault {
+			t.Scale = strconv.Itoa(displayDecimal)
+		}
+	case mysql.TypeDouble, mysql.TypeFloat:
+		t.Precision = strconv.Itoa(displayFlen)
+		if isDecimalNotDefault {
+			t.Scale = strconv.Itoa(displayDecimal)
+		}
+	case mysql.TypeNewDecimal:
+		t.Precision = strconv.Itoa(displayFlen)
+		t.Scale = strconv.Itoa(displayDecimal)
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
+		mysql.TypeBit, mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeBlob,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		t.Precision = strconv.Itoa(displayFlen)
+	case mysql.TypeYear:
+		t.Precision = strconv.Itoa(displayFlen)
+	}
+}
+
+// TableDefinition is the detailed table definition used for cloud storage sink.
+type TableDefinition struct {
+	Table        string             `json:"Table"`
+	Schema       string             `json:"Schema"`
+	Version      uint64             `json:"Version"`
+	TableVersion uint64             `json:"TableVersion"`
+	Query        string             `json:"Query"`
+	Type         timodel.ActionType `json:"Type"`
+	Columns      []TableCol         `json:"TableColumns"`
+	TotalColumns int                `json:"TableColumnsTotal"`
+}
+
+// tableDefWithoutQuery is the table definition without query, which ignores the
+// Query, Type and TableVersion field.
+type tableDefWithoutQuery struct {
+	Table        string     `json:"Table"`
+	Schema       string     `json:"Schema"`
+	Version      uint64     `json:"Version"`
+	Columns      []TableCol `json:"TableColumns"`
+	TotalColumns int        `json:"TableColumnsTotal"`
+}
+
+// FromDDLEvent converts from DDLEvent to TableDefinition.
+// The finished ts of the DDL is used as the table version, so that every DDL
+// has its own schema file.
+func (t *TableDefinition) FromDDLEvent(event *commonEvent.DDLEvent, outputColumnID bool) {
+	if event.TableInfo != nil {
+		t.FromTableInfo(event.TableInfo, event.FinishedTs, outputColumnID)
+	} else {
+		// Schema level DDLs, such as `CREATE DATABASE`, do not carry the table info.
+		t.Version = defaultTableDefinitionVersion
+		t.TableVersion = event.FinishedTs
+		t.Schema = event.SchemaName
+	}
+	t.Query = event.Query
+	t.Type = timodel.ActionType(event.GetDDLType())
+}
+
+// FromTableInfo converts from TableInfo to TableDefinition.
+func (t *TableDefinition) FromTableInfo(
+	info *common.TableInfo, tableInfoVersion uint64, outputColumnID bool,
+) {
+	t.Version = defaultTableDefinitionVersion
+	t.TableVersion = tableInfoVersion
+
+	t.Schema = info.TableName.Schema
+	if info.TableInfo == nil {
+		return
+	}
+	t.Table = info.TableName.Table
+	t.TotalColumns = len(info.Columns)
+	for _, col := range info.Columns {
+		var tableCol TableCol
+		tableCol.FromTiColumnInfo(col, outputColumnID)
+		t.Columns = append(t.Columns, tableCol)
+	}
+}
+
+// IsTableSchema returns whether the TableDefinition is a table schema.
+func (t *TableDefinition) IsTableSchema() bool {
+	if len(t.Columns) != t.TotalColumns {
+		log.Panic("invalid table definition", zap.Any("tableDef", t))
+	}
+	return t.TotalColumns != 0
+}
+
+// MarshalWithQuery marshals TableDefinition with Query field.
+func (t *TableDefinition) MarshalWithQuery() ([]byte, error) {
+	data, err := json.MarshalIndent(t, marshalPrefix, marshalIndent)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrMarshalFailed, err)
+	}
+	return data, nil
+}
+
+// marshalWithoutQuery marshals TableDefinition without Query field.
+func (t *TableDefinition) marshalWithoutQuery() ([]byte, error) {
+	// sort columns by name
+	sortedColumns := make([]TableCol, len(t.Columns))
+	copy(sortedColumns, t.Columns)
+	sort.Slice(sortedColumns, func(i, j int) bool {
+		return sortedColumns[i].Name < sortedColumns[j].Name
+	})
+
+	defWithoutQuery := tableDefWithoutQuery{
+		Table:        t.Table,
+		Schema:       t.Schema,
+		Columns:      sortedColumns,
+		TotalColumns: t.TotalColumns,
+	}
+
+	data, err := json.MarshalIndent(defWithoutQuery, marshalPrefix, marshalIndent)
+	if err != nil {
+		return nil, errors.WrapError(errors.ErrMarshalFailed, err)
+	}
+	return data, nil
+}
+
+// Sum32 returns the 32-bits hash value of TableDefinition.
+func (t *TableDefinition) Sum32(hasher *hash.PositionInertia) (uint32, error) {
+	if hasher == nil {
+		hasher = hash.NewPositionInertia()
+	}
+	hasher.Reset()
+	data, err := t.marshalWithoutQuery()
+	if err != nil {
+		return 0, err
+	}
+
+	hasher.Write(data)
+	return hasher.Sum32(), nil
+}
+
+// GenerateSchemaFilePath generates the schema file path for TableDefinition.
+func (t *TableDefinition) GenerateSchemaFilePath() (string, error) {
+	checksum, err := t.Sum32(nil)
+	if err != nil {
+		return "", err
+	}
+	if !t.IsTableSchema() && t.Table != "" {
+		log.Panic("invalid table definition", zap.Any("tableDef", t))
+	}
+	return generateSchemaFilePath(t.Schema, t.Table, t.TableVersion, checksum), nil
+}
@@ -27,14 +27,16 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
-	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
-	"github.com/pingcap/tidb/pkg/util/rowcodec"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/tikv/client-go/v2/oracle"
@@ -47,16 +49,22 @@ type BatchEncoder struct {
 	schemaM   SchemaManager
 	result    []*ticommon.Message
 
-	config *ticommon.Config
+	config *newcommon.Config
 }
 
+// avroEncodeInput holds the columns of one row that should be encoded together,
+// either the handle key columns for the message key or all selected columns for the value.
 type avroEncodeInput struct {
-	columns  []*common.Column
-	colInfos []rowcodec.ColInfo
+	row *chunk.Row
+	// columns and offsets are always of the same length,
+	// offsets[i] is the index of columns[i] in the row.
+	columns   []*timodel.ColumnInfo
+	offsets   []int
+	tableInfo *common.TableInfo
 }
 
 func (r *avroEncodeInput) Less(i, j int) bool {
-	return r.colInfos[i].ID < r.colInfos[j].ID
+	return r.columns[i].ID < r.columns[j].ID
 }
 
 func (r *avroEncodeInput) Len() int {
@@ -64,8 +72,44 @@ func (r *avroEncodeInput) Len() int {
 }
 
 func (r *avroEncodeInput) Swap(i, j int) {
-	r.colInfos[i], r.colInfos[j] = r.colInfos[j], r.colInfos[i]
 	r.columns[i], r.columns[j] = r.columns[j], r.columns[i]
+	r.offsets[i], r.offsets[j] = r.offsets[j], r.offsets[i]
+}
+
+func (r *avroEncodeInput) flag(col *timodel.ColumnInfo) *common.ColumnFlagType {
+	return r.tableInfo.ForceGetColumnFlagType(col.ID)
+}
+
+// newAvroEncodeInput collects the columns of the row which are visible to CDC.
+// If onlyHandleKey is true, only the handle key columns are collected,
+// otherwise the columns are filtered by the column selector.
+func newAvroEncodeInput(
+	row *chunk.Row,
+	tableInfo *common.TableInfo,
+	selector columnselector.Selector,
+	onlyHandleKey bool,
+) *avroEncodeInput {
+	input := &avroEncodeInput{
+		row:       row,
+		columns:   make([]*timodel.ColumnInfo, 0, len(tableInfo.Columns)),
+		offsets:   make([]int, 0, len(tableInfo.Columns)),
+		tableInfo: tableInfo,
+	}
+	for idx, col := range tableInfo.Columns {
+		if col == nil || !common.IsColCDCVisible(col) {
+			continue
+		}
+		if onlyHandleKey {
+			if !tableInfo.ForceGetColumnFlagType(col.ID).IsHandleKey() {
+				continue
+			}
+		} else if selector != nil && !selector.Select(col) {
+			continue
+		}
+		input.columns = append(input.columns, col)
+		input.offsets = append(input.offsets, idx)
+	}
+	return input
 }
 
 type avroEncodeResult struct {
@@ -76,19 +120,19 @@ type avroEncodeResult struct {
 	header []byte
 }
 
-func (a *BatchEncoder) encodeKey(ctx context.Context, topic string, e *commonEvent.RowChangedEvent) ([]byte, error) {
-	cols, colInfos := e.HandleKeyColInfos()
+func (a *BatchEncoder) encodeKey(ctx context.Context, topic string, e *commonEvent.RowEvent) ([]byte, error) {
+	row := e.GetRows()
+	if e.IsDelete() {
+		row = e.GetPreRows()
+	}
+	keyColumns := newAvroEncodeInput(row, e.TableInfo, nil, true)
 	// result may be nil if the event has no handle key columns, this may happen in the force replicate mode.
 	// todo: disallow force replicate mode if using the avro.
-	if len(cols) == 0 {
+	if keyColumns.Len() == 0 {
 		return nil, nil
 	}
 
-	keyColumns := &avroEncodeInput{
-		columns:  cols,
-		colInfos: colInfos,
-	}
-	avroCodec, header, err := a.getKeySchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.Version, keyColumns)
+	avroCodec, header, err := a.getKeySchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.GetVersion(), keyColumns)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -160,20 +204,17 @@ func (a *BatchEncoder) getKeySchemaCodec(
 	return avroCodec, header, nil
 }
 
-func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonEvent.RowChangedEvent) ([]byte, error) {
+func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonEvent.RowEvent) ([]byte, error) {
 	if e.IsDelete() {
 		return nil, nil
 	}
 
-	input := &avroEncodeInput{
-		columns:  e.GetColumns(),
-		colInfos: e.TableInfo.GetColInfosForRowChangedEvent(),
-	}
-	if len(input.columns) == 0 {
+	input := newAvroEncodeInput(e.GetRows(), e.TableInfo, e.ColumnSelector, false)
+	if input.Len() == 0 {
 		return nil, nil
 	}
 
-	avroCodec, header, err := a.getValueSchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.Version, input)
+	avroCodec, header, err := a.getValueSchemaCodec(ctx, topic, &e.TableInfo.TableName, e.TableInfo.GetVersion(), input)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -209,20 +250,19 @@ func (a *BatchEncoder) encodeValue(ctx context.Context, topic string, e *commonE
 func (a *BatchEncoder) AppendRowChangedEvent(
 	ctx context.Context,
 	topic string,
-	e *commonEvent.RowChangedEvent,
-	callback func(),
+	e *commonEvent.RowEvent,
 ) error {
 	topic = sanitizeTopic(topic)
 
 	key, err := a.encodeKey(ctx, topic, e)
 	if err != nil {
-		log.Error("avro encoding key failed", zap.Error(err), zap.Any("event", e))
+		log.Error("avro encoding key failed", zap.Error(err), zap.Any("table", e.TableInfo.TableName))
 		return errors.Trace(err)
 	}
 
 	value, err := a.encodeValue(ctx, topic, e)
 	if err != nil {
-		log.Error("avro encoding value failed", zap.Error(err), zap.Any("event", e))
+		log.Error("avro encoding value failed", zap.Error(err), zap.Any("table", e.TableInfo.TableName))
 		return errors.Trace(err)
 	}
 
@@ -235,7 +275,7 @@ func (a *BatchEncoder) AppendRowChangedEvent(
 		e.TableInfo.GetSchemaNamePtr(),
 		e.TableInfo.GetTableNamePtr(),
 	)
-	message.Callback = callback
+	message.Callback = e.Callback
 	message.IncRowsCount()
 
 	if message.Length() > a.config.MaxMessageBytes {
@@ -269,6 +309,12 @@ func (a *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, erro
 	return nil, nil
 }
 
+// EncodeSyncPointEvent is not supported by the avro protocol,
+// since there is no schema registered for the sync point event.
+func (a *BatchEncoder) EncodeSyncPointEvent(_, _ uint64) (*ticommon.Message, error) {
+	return nil, nil
+}
+
 type ddlEvent struct {
 	Query    string             `json:"query"`
 	Type     timodel.ActionType `json:"type"`
@@ -279,27 +325,30 @@ type ddlEvent struct {
 
 // EncodeDDLEvent only encode DDL event if the watermark event is enabled
 // it's only used for the testing purpose.
+// The schema evolution caused by the DDL is not sent here, the new schema is
+// registered lazily when the first row with the new table version is encoded.
 func (a *BatchEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*ticommon.Message, error) {
-	// if a.config.EnableTiDBExtension && a.config.AvroEnableWatermark {
-	// 	buf := new(bytes.Buffer)
-	// 	_ = binary.Write(buf, binary.BigEndian, ddlByte)
-
-	// 	event := &ddlEvent{
-	// 		Query:    e.Query,
-	// 		Type:     e.Type,
-	// 		Schema:   e.TableInfo.TableName.Schema,
-	// 		Table:    e.TableInfo.TableName.Table,
-	// 		CommitTs: e.CommitTs,
-	// 	}
-	// 	data, err := json.Marshal(event)
-	// 	if err != nil {
-	// 		return nil, cerror.WrapError(cerror.ErrAvroToEnvelopeError, err)
-	// 	}
-	// 	buf.Write(data)
-
-	// 	value := buf.Bytes()
-	// 	return ticommon.NewDDLMsg(config.ProtocolAvro, nil, value, e), nil
-	// }
+	if a.config.EnableTiDBExtension && a.config.AvroEnableWatermark {
+		buf := new(bytes.Buffer)
+		_ = binary.Write(buf, binary.BigEndian, ddlByte)
+
+		event := &ddlEvent{
+			Query:    e.Query,
+			Type:     timodel.ActionType(e.Type),
+			Schema:   e.SchemaName,
+			Table:    e.TableName,
+			CommitTs: e.FinishedTs,
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrAvroToEnvelopeError, err)
+		}
+		buf.Write(data)
+
+		value := buf.Bytes()
+		return ticommon.NewMsg(config.ProtocolAvro, nil, value, e.FinishedTs,
+			model.MessageTypeDDL, &e.SchemaName, &e.TableName), nil
+	}
 
 	return nil, nil
 }
@@ -316,7 +365,7 @@ const (
 	updateOperation = "u"
 )
 
-func getOperation(e *commonEvent.RowChangedEvent) string {
+func getOperation(e *commonEvent.RowEvent) string {
 	if e.IsInsert() {
 		return insertOperation
 	} else if e.IsUpdate() {
@@ -325,9 +374,12 @@ func getOperation(e *commonEvent.RowChangedEvent) string {
 	return ""
 }
 
+// nativeValueWithExtension adds the TiDB extension fields into the native value.
+// The row level checksum fields are left to their default values if the row event
+// does not carry the checksum.
 func (a *BatchEncoder) nativeValueWithExtension(
 	native map[string]interface{},
-	e *commonEvent.RowChangedEvent,
+	e *commonEvent.RowEvent,
 ) map[string]interface{} {
 	native[tidbOp] = getOperation(e)
 	native[tidbCommitTs] = int64(e.CommitTs)
@@ -387,19 +439,19 @@ var type2TiDBType = map[byte]string{
 	mysql.TypeYear:       "YEAR",
 }
 
-func getTiDBTypeFromColumn(col *common.Column) string {
-	tt := type2TiDBType[col.Type]
-	if col.Flag.IsUnsigned() && (tt == "INT" || tt == "BIGINT") {
+func getTiDBTypeFromColumn(col *timodel.ColumnInfo, flag *common.ColumnFlagType) string {
+	tt := type2TiDBType[col.GetType()]
+	if flag.IsUnsigned() && (tt == "INT" || tt == "BIGINT") {
 		return tt + " UNSIGNED"
 	}
-	if col.Flag.IsBinary() && tt == "TEXT" {
+	if flag.IsBinary() && tt == "TEXT" {
 		return "BLOB"
 	}
 	return tt
 }
 
-func flagFromTiDBType(tp string) model.ColumnFlagType {
-	var flag model.ColumnFlagType
+func flagFromTiDBType(tp string) common.ColumnFlagType {
+	var flag common.ColumnFlagType
 	if strings.Contains(tp, "UNSIGNED") {
 		flag.SetIsUnsigned()
 	}
@@ -570,20 +622,16 @@ func (a *BatchEncoder) columns2AvroSchema(
 		Namespace: getAvroNamespace(a.namespace, tableName.Schema),
 		Fields:    nil,
 	}
-	for i, col := range input.columns {
-		if col == nil {
-			continue
-		}
-		avroType, err := a.columnToAvroSchema(col, input.colInfos[i].Ft)
+	for _, col := range input.columns {
+		flag := input.flag(col)
+		avroType, err := a.columnToAvroSchema(col, flag)
 		if err != nil {
 			return nil, err
 		}
 		field := make(map[string]interface{})
-		field["name"] = sanitizeName(col.Name)
+		field["name"] = sanitizeName(col.Name.O)
 
-		copied := *col
-		copied.Value = copied.Default
-		defaultValue, _, err := a.columnToAvroData(&copied, input.colInfos[i].Ft)
+		defaultValue, _, err := a.columnToAvroData(common.GetColumnDefaultValue(col), col, flag)
 		if err != nil {
 			log.Error("fail to get default value for avro schema")
 			return nil, errors.Trace(err)
@@ -591,14 +639,14 @@ func (a *BatchEncoder) columns2AvroSchema(
 		// goavro doesn't support set default value for logical type
 		// https://github.com/linkedin/goavro/issues/202
 		if _, ok := avroType.(avroLogicalTypeSchema); ok {
-			if col.Flag.IsNullable() {
+			if flag.IsNullable() {
 				field["type"] = []interface{}{"null", avroType}
 				field["default"] = nil
 			} else {
 				field["type"] = avroType
 			}
 		} else {
-			if col.Flag.IsNullable() {
+			if flag.IsNullable() {
 				// https://stackoverflow.com/questions/22938124/avro-field-default-values
 				if defaultValue == nil {
 					field["type"] = []interface{}{"null", avroType}
@@ -668,19 +716,21 @@ func (a *BatchEncoder) columns2AvroData(
 ) (map[string]interface{}, error) {
 	ret := make(map[string]interface{}, len(input.columns))
 	for i, col := range input.columns {
-		if col == nil {
-			continue
+		value, err := common.FormatColVal(input.row, col, input.offsets[i])
+		if err != nil {
+			return nil, cerror.WrapError(cerror.ErrAvroEncodeFailed, err)
 		}
-		data, str, err := a.columnToAvroData(col, input.colInfos[i].Ft)
+		flag := input.flag(col)
+		data, str, err := a.columnToAvroData(value, col, flag)
 		if err != nil {
 			return nil, err
 		}
 
 		// https: //pkg.go.dev/github.com/linkedin/goavro/v2#Union
-		if col.Flag.IsNullable() {
-			ret[sanitizeName(col.Name)] = goavro.Union(str, data)
+		if flag.IsNullable() {
+			ret[sanitizeName(col.Name.O)] = goavro.Union(str, data)
 		} else {
-			ret[sanitizeName(col.Name)] = data
+			ret[sanitizeName(col.Name.O)] = data
 		}
 	}
 
@@ -689,11 +739,12 @@ func (a *BatchEncoder) columns2AvroData(
 }
 
 func (a *BatchEncoder) columnToAvroSchema(
-	col *common.Column,
-	ft *types.FieldType,
+	col *timodel.ColumnInfo,
+	flag *common.ColumnFlagType,
 ) (interface{}, error) {
-	tt := getTiDBTypeFromColumn(col)
-	switch col.Type {
+	ft := &col.FieldType
+	tt := getTiDBTypeFromColumn(col, flag)
+	switch col.GetType() {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24:
 		// BOOL/TINYINT/SMALLINT/MEDIUMINT
 		return avroSchema{
@@ -701,7 +752,7 @@ func (a *BatchEncoder) columnToAvroSchema(
 			Parameters: map[string]string{tidbType: tt},
 		}, nil
 	case mysql.TypeLong: // INT
-		if col.Flag.IsUnsigned() {
+		if flag.IsUnsigned() {
 			return avroSchema{
 				Type:       "long",
 				Parameters: map[string]string{tidbType: tt},
@@ -713,8 +764,8 @@ func (a *BatchEncoder) columnToAvroSchema(
 		}, nil
 	case mysql.TypeLonglong: // BIGINT
 		t := "long"
-		if col.Flag.IsUnsigned() &&
-			a.config.AvroBigintUnsignedHandlingMode == ticommon.BigintUnsignedHandlingModeString {
+		if flag.IsUnsigned() &&
+			a.config.AvroBigintUnsignedHandlingMode == newcommon.BigintUnsignedHandlingModeString {
 			t = "string"
 		}
 		return avroSchema{
@@ -734,7 +785,7 @@ func (a *BatchEncoder) columnToAvroSchema(
 	case mysql.TypeBit:
 		displayFlen := ft.GetFlen()
 		if displayFlen == -1 {
-			displayFlen, _ = mysql.GetDefaultFieldLengthAndDecimal(col.Type)
+			displayFlen, _ = mysql.GetDefaultFieldLengthAndDecimal(col.GetType())
 		}
 		return avroSchema{
 			Type: "bytes",
@@ -744,7 +795,7 @@ func (a *BatchEncoder) columnToAvroSchema(
 			},
 		}, nil
 	case mysql.TypeNewDecimal:
-		if a.config.AvroDecimalHandlingMode == ticommon.DecimalHandlingModePrecise {
+		if a.config.AvroDecimalHandlingMode == newcommon.DecimalHandlingModePrecise {
 			defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
 			displayFlen, displayDecimal := ft.GetFlen(), ft.GetDecimal()
 			// length not specified, set it to system type default
@@ -779,7 +830,7 @@ func (a *BatchEncoder) columnToAvroSchema(
 		mysql.TypeLongBlob,
 		mysql.TypeBlob:
 		t := "string"
-		if col.Flag.IsBinary() {
+		if flag.IsBinary() {
 			t = "bytes"
 		}
 		return avroSchema{
@@ -815,51 +866,55 @@ func (a *BatchEncoder) columnToAvroSchema(
 			Parameters: map[string]string{tidbType: tt},
 		}, nil
 	default:
-		log.Error("unknown mysql type", zap.Any("mysqlType", col.Type))
+		log.Error("unknown mysql type", zap.Any("mysqlType", col.GetType()))
 		return nil, cerror.ErrAvroEncodeFailed.GenWithStack("unknown mysql type")
 	}
 }
 
+// columnToAvroData converts the value of the column into the avro native data,
+// the value should be formatted by `common.FormatColVal`, or be the default value of the column.
 func (a *BatchEncoder) columnToAvroData(
-	col *common.Column,
-	ft *types.FieldType,
+	value interface{},
+	col *timodel.ColumnInfo,
+	flag *common.ColumnFlagType,
 ) (interface{}, string, error) {
-	if col.Value == nil {
+	if value == nil {
 		return nil, "null", nil
 	}
 
-	switch col.Type {
+	ft := &col.FieldType
+	switch col.GetType() {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24:
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			n, err := strconv.ParseInt(v, 10, 32)
 			if err != nil {
 				return nil, "", cerror.WrapError(cerror.ErrAvroEncodeFailed, err)
 			}
 			return int32(n), "int", nil
 		}
-		if col.Flag.IsUnsigned() {
-			return int32(col.Value.(uint64)), "int", nil
+		if flag.IsUnsigned() {
+			return int32(value.(uint64)), "int", nil
 		}
-		return int32(col.Value.(int64)), "int", nil
+		return int32(value.(int64)), "int", nil
 	case mysql.TypeLong:
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			n, err := strconv.ParseInt(v, 10, 64)
 			if err != nil {
 				return nil, "", cerror.WrapError(cerror.ErrAvroEncodeFailed, err)
 			}
-			if col.Flag.IsUnsigned() {
+			if flag.IsUnsigned() {
 				return n, "long", nil
 			}
 			return int32(n), "int", nil
 		}
-		if col.Flag.IsUnsigned() {
-			return int64(col.Value.(uint64)), "long", nil
+		if flag.IsUnsigned() {
+			return int64(value.(uint64)), "long", nil
 		}
-		return int32(col.Value.(int64)), "int", nil
+		return int32(value.(int64)), "int", nil
 	case mysql.TypeLonglong:
-		if v, ok := col.Value.(string); ok {
-			if col.Flag.IsUnsigned() {
-				if a.config.AvroBigintUnsignedHandlingMode == ticommon.BigintUnsignedHandlingModeString {
+		if v, ok := value.(string); ok {
+			if flag.IsUnsigned() {
+				if a.config.AvroBigintUnsignedHandlingMode == newcommon.BigintUnsignedHandlingModeString {
 					return v, "string", nil
 				}
 				n, err := strconv.ParseUint(v, 10, 64)
@@ -874,40 +929,40 @@ func (a *BatchEncoder) columnToAvroData(
 			}
 			return n, "long", nil
 		}
-		if col.Flag.IsUnsigned() {
-			if a.config.AvroBigintUnsignedHandlingMode == ticommon.BigintUnsignedHandlingModeLong {
-				return int64(col.Value.(uint64)), "long", nil
+		if flag.IsUnsigned() {
+			if a.config.AvroBigintUnsignedHandlingMode == newcommon.BigintUnsignedHandlingModeLong {
+				return int64(value.(uint64)), "long", nil
 			}
 			// bigintUnsignedHandlingMode == "string"
-			return strconv.FormatUint(col.Value.(uint64), 10), "string", nil
+			return strconv.FormatUint(value.(uint64), 10), "string", nil
 		}
-		return col.Value.(int64), "long", nil
+		return value.(int64), "long", nil
 	case mysql.TypeFloat:
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			n, err := strconv.ParseFloat(v, 32)
 			if err != nil {
 				return nil, "", cerror.WrapError(cerror.ErrAvroEncodeFailed, err)
 			}
 			return n, "float", nil
 		}
-		return col.Value.(float32), "float", nil
+		return value.(float32), "float", nil
 	case mysql.TypeDouble:
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			n, err := strconv.ParseFloat(v, 64)
 			if err != nil {
 				return nil, "", cerror.WrapError(cerror.ErrAvroEncodeFailed, err)
 			}
 			return n, "double", nil
 		}
-		return col.Value.(float64), "double", nil
+		return value.(float64), "double", nil
 	case mysql.TypeBit:
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			return []byte(v), "bytes", nil
 		}
-		return []byte(types.NewBinaryLiteralFromUint(col.Value.(uint64), -1)), "bytes", nil
+		return []byte(types.NewBinaryLiteralFromUint(value.(uint64), -1)), "bytes", nil
 	case mysql.TypeNewDecimal:
-		if a.config.AvroDecimalHandlingMode == ticommon.DecimalHandlingModePrecise {
-			v, succ := new(big.Rat).SetString(col.Value.(string))
+		if a.config.AvroDecimalHandlingMode == newcommon.DecimalHandlingModePrecise {
+			v, succ := new(big.Rat).SetString(value.(string))
 			if !succ {
 				return nil, "", cerror.ErrAvroEncodeFailed.GenWithStack(
 					"fail to encode Decimal value",
@@ -916,7 +971,7 @@ func (a *BatchEncoder) columnToAvroData(
 			return v, "bytes.decimal", nil
 		}
 		// decimalHandlingMode == "string"
-		return col.Value.(string), "string", nil
+		return value.(string), "string", nil
 	case mysql.TypeVarchar,
 		mysql.TypeString,
 		mysql.TypeVarString,
@@ -924,22 +979,22 @@ func (a *BatchEncoder) columnToAvroData(
 		mysql.TypeBlob,
 		mysql.TypeMediumBlob,
 		mysql.TypeLongBlob:
-		if col.Flag.IsBinary() {
-			if v, ok := col.Value.(string); ok {
+		if flag.IsBinary() {
+			if v, ok := value.(string); ok {
 				return []byte(v), "bytes", nil
 			}
-			return col.Value, "bytes", nil
+			return value, "bytes", nil
 		}
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			return v, "string", nil
 		}
-		return string(col.Value.([]byte)), "string", nil
+		return string(value.([]byte)), "string", nil
 	case mysql.TypeEnum:
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			return v, "string", nil
 		}
 		elements := ft.GetElems()
-		number := col.Value.(uint64)
+		number := value.(uint64)
 		enumVar, err := types.ParseEnumValue(elements, number)
 		if err != nil {
 			log.Info("avro encoder parse enum value failed", zap.Strings("elements", elements), zap.Uint64("number", number))
@@ -947,11 +1002,11 @@ func (a *BatchEncoder) columnToAvroData(
 		}
 		return enumVar.Name, "string", nil
 	case mysql.TypeSet:
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			return v, "string", nil
 		}
 		elements := ft.GetElems()
-		number := col.Value.(uint64)
+		number := value.(uint64)
 		setVar, err := types.ParseSetValue(elements, number)
 		if err != nil {
 			log.Info("avro encoder parse set value failed",
@@ -960,11 +1015,11 @@ func (a *BatchEncoder) columnToAvroData(
 		}
 		return setVar.Name, "string", nil
 	case mysql.TypeJSON:
-		return col.Value.(string), "string", nil
+		return value.(string), "string", nil
 	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration:
-		return col.Value.(string), "string", nil
+		return value.(string), "string", nil
 	case mysql.TypeYear:
-		if v, ok := col.Value.(string); ok {
+		if v, ok := value.(string); ok {
 			n, err := strconv.ParseInt(v, 10, 32)
 			if err != nil {
 				log.Info("avro encoder parse year value failed", zap.String("value", v), zap.Error(err))
@@ -972,9 +1027,9 @@ func (a *BatchEncoder) columnToAvroData(
 			}
 			return int32(n), "int", nil
 		}
-		return int32(col.Value.(int64)), "int", nil
+		return int32(value.(int64)), "int", nil
 	default:
-		log.Error("unknown mysql type", zap.Any("value", col.Value), zap.Any("mysqlType", col.Type))
+		log.Error("unknown mysql type", zap.Any("value", value), zap.Any("mysqlType", col.GetType()))
 		return nil, "", cerror.ErrAvroEncodeFailed.GenWithStack("unknown mysql type")
 	}
 }
@@ -1000,30 +1055,24 @@ func (r *avroEncodeResult) toEnvelope() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
-type batchEncoderBuilder struct {
-	namespace string
-	config    *ticommon.Config
-	schemaM   SchemaManager
-}
-
 const (
 	keySchemaSuffix   = "-key"
 	valueSchemaSuffix = "-value"
 )
 
 // NewAvroEncoder return a avro encoder.
-func NewAvroEncoder(ctx context.Context, config *ticommon.Config) (encoder.EventEncoder, error) {
+func NewAvroEncoder(ctx context.Context, config *newcommon.Config) (encoder.EventEncoder, error) {
 	var schemaM SchemaManager
 	var err error
 
 	schemaRegistryType := config.SchemaRegistryType()
 	switch schemaRegistryType {
-	case ticommon.SchemaRegistryTypeConfluent:
+	case newcommon.SchemaRegistryTypeConfluent:
 		schemaM, err = NewConfluentSchemaManager(ctx, config.AvroConfluentSchemaRegistry, nil)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
-	case ticommon.SchemaRegistryTypeGlue:
+	case newcommon.SchemaRegistryTypeGlue:
 		schemaM, err = NewGlueSchemaManager(ctx, config.AvroGlueSchemaRegistry)
 		if err != nil {
 			return nil, errors.Trace(err)
@@ -1032,33 +1081,9 @@ func NewAvroEncoder(ctx context.Context, config *ticommon.Config) (encoder.Event
 		return nil, cerror.ErrAvroSchemaAPIError.GenWithStackByArgs(schemaRegistryType)
 	}
 	return &BatchEncoder{
-		namespace: config.ChangefeedID.Namespace,
+		namespace: config.ChangefeedID.Namespace(),
 		schemaM:   schemaM,
 		result:    make([]*ticommon.Message, 0, 1),
 		config:    config,
 	}, nil
 }
-
-// // SetupEncoderAndSchemaRegistry4Testing start a local schema registry for testing.
-// func SetupEncoderAndSchemaRegistry4Testing(
-// 	ctx context.Context,
-// 	config *ticommon.Config,
-// ) (*BatchEncoder, error) {
-// 	startHTTPInterceptForTestingRegistry()
-// 	schemaM, err := NewConfluentSchemaManager(ctx, "http://127.0.0.1:8081", nil)
-// 	if err != nil {
-// 		return nil, errors.Trace(err)
-// 	}
-
-// 	return &BatchEncoder{
-// 		namespace: model.DefaultNamespace,
-// 		schemaM:   schemaM,
-// 		result:    make([]*ticommon.Message, 0, 1),
-// 		config:    config,
-// 	}, nil
-// }
-
-// // TeardownEncoderAndSchemaRegistry4Testing stop the local schema registry for testing.
-// func TeardownEncoderAndSchemaRegistry4Testing() {
-// 	stopHTTPInterceptForTestingRegistry()
-// }
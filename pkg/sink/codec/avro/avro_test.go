@@ -0,0 +1,351 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	pevent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/integrity"
+	"github.com/stretchr/testify/require"
+)
+
+type mockConfluentRegistrySchema struct {
+	content string
+	version int
+	ID      int
+}
+
+// mockConfluentRegistry is a fake confluent schema registry which serves the
+// endpoints used by the confluentSchemaManager over a local http server.
+type mockConfluentRegistry struct {
+	mu       sync.Mutex
+	subjects map[string]*mockConfluentRegistrySchema
+	newID    int
+
+	server *httptest.Server
+}
+
+func newMockConfluentRegistry() *mockConfluentRegistry {
+	registry := &mockConfluentRegistry{
+		subjects: make(map[string]*mockConfluentRegistrySchema),
+		newID:    1,
+	}
+	registry.server = httptest.NewServer(http.HandlerFunc(registry.serveHTTP))
+	return registry
+}
+
+func (r *mockConfluentRegistry) url() string {
+	return r.server.URL
+}
+
+func (r *mockConfluentRegistry) close() {
+	r.server.Close()
+}
+
+func (r *mockConfluentRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := req.URL.Path
+	switch {
+	case req.Method == http.MethodGet && (path == "" || path == "/"):
+		_, _ = w.Write([]byte("{}"))
+	case req.Method == http.MethodPost && strings.HasPrefix(path, "/subjects/") && strings.HasSuffix(path, "/versions"):
+		subject := strings.TrimSuffix(strings.TrimPrefix(path, "/subjects/"), "/versions")
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var reqData registerRequest
+		if err := json.Unmarshal(body, &reqData); err != nil {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		item, exists := r.subjects[subject]
+		if !exists {
+			item = &mockConfluentRegistrySchema{content: reqData.Schema, version: 1, ID: r.newID}
+			r.subjects[subject] = item
+			r.newID++
+		} else if item.content != reqData.Schema {
+			item.content = reqData.Schema
+			item.version++
+			item.ID = r.newID
+			r.newID++
+		}
+		_ = json.NewEncoder(w).Encode(&registerResponse{SchemaID: item.ID})
+	case req.Method == http.MethodGet && strings.HasPrefix(path, "/schemas/ids/"):
+		id, err := strconv.Atoi(strings.TrimPrefix(path, "/schemas/ids/"))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		for subject, item := range r.subjects {
+			if item.ID == id {
+				_ = json.NewEncoder(w).Encode(&lookupResponse{Name: subject, SchemaID: id, Schema: item.content})
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case req.Method == http.MethodDelete && strings.HasPrefix(path, "/subjects/"):
+		subject := strings.TrimPrefix(path, "/subjects/")
+		item, exists := r.subjects[subject]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(r.subjects, subject)
+		_ = json.NewEncoder(w).Encode([]int{item.version})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *mockConfluentRegistry) subjectVersion(subject string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	item, ok := r.subjects[subject]
+	if !ok {
+		return 0
+	}
+	return item.version
+}
+
+func newTestAvroEncoder(t *testing.T, registryURL string) *BatchEncoder {
+	codecConfig := newcommon.NewConfig(config.ProtocolAvro).
+		WithChangefeedID(common.NewChangeFeedIDWithName("avro-test"))
+	codecConfig.AvroConfluentSchemaRegistry = registryURL
+	codecConfig.EnableTiDBExtension = true
+	codecConfig.AvroEnableWatermark = true
+	require.NoError(t, codecConfig.Validate())
+
+	e, err := NewAvroEncoder(context.Background(), codecConfig)
+	require.NoError(t, err)
+	return e.(*BatchEncoder)
+}
+
+// decodeAvroMessage decodes the avro envelope by the schema registered in the registry.
+func decodeAvroMessage(t *testing.T, encoder *BatchEncoder, subject string, data []byte) map[string]interface{} {
+	require.Equal(t, magicByte, data[0])
+	id, err := getConfluentSchemaIDFromHeader(data)
+	require.NoError(t, err)
+
+	codec, err := encoder.schemaM.Lookup(context.Background(), subject, schemaID{confluentSchemaID: int(id)})
+	require.NoError(t, err)
+	native, _, err := codec.NativeFromBinary(data[5:])
+	require.NoError(t, err)
+	return native.(map[string]interface{})
+}
+
+func TestAvroEncodeDMLEvent(t *testing.T) {
+	registry := newMockConfluentRegistry()
+	defer registry.close()
+
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(10), c decimal(10,2), d bigint unsigned)`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, "hello", 12.34, 18446744073709551615)`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	called := false
+	rowEvent := &pevent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       417318403368288260,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() { called = true },
+	}
+
+	encoder := newTestAvroEncoder(t, registry.url())
+	err := encoder.AppendRowChangedEvent(context.Background(), "avro.topic", rowEvent)
+	require.NoError(t, err)
+
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	message := messages[0]
+	require.Equal(t, model.MessageTypeRow, message.Type)
+	require.Equal(t, 1, message.GetRowsCount())
+
+	key := decodeAvroMessage(t, encoder, "avro_topic"+keySchemaSuffix, message.Key)
+	require.Equal(t, int32(1), key["a"])
+
+	value := decodeAvroMessage(t, encoder, "avro_topic"+valueSchemaSuffix, message.Value)
+	require.Equal(t, int32(1), value["a"])
+	require.Equal(t, map[string]interface{}{"string": "hello"}, value["b"])
+	require.Contains(t, value, "c")
+	require.Equal(t, map[string]interface{}{"long": int64(-1)}, value["d"])
+	require.Equal(t, insertOperation, value[tidbOp])
+	require.Equal(t, int64(417318403368288260), value[tidbCommitTs])
+
+	message.Callback()
+	require.True(t, called)
+}
+
+func TestAvroEncodeRowChecksum(t *testing.T) {
+	registry := newMockConfluentRegistry()
+	defer registry.close()
+
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(10))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, "hello")`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	codecConfig := newcommon.NewConfig(config.ProtocolAvro).
+		WithChangefeedID(common.NewChangeFeedIDWithName("avro-test"))
+	codecConfig.AvroConfluentSchemaRegistry = registry.url()
+	codecConfig.EnableTiDBExtension = true
+	codecConfig.EnableRowChecksum = true
+	codecConfig.AvroDecimalHandlingMode = newcommon.DecimalHandlingModeString
+	codecConfig.AvroBigintUnsignedHandlingMode = newcommon.BigintUnsignedHandlingModeString
+	require.NoError(t, codecConfig.Validate())
+	e, err := NewAvroEncoder(context.Background(), codecConfig)
+	require.NoError(t, err)
+	encoder := e.(*BatchEncoder)
+
+	err = encoder.AppendRowChangedEvent(context.Background(), "avro.topic", &pevent.RowEvent{
+		TableInfo:      tableInfo,
+		CommitTs:       417318403368288260,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Checksum: &integrity.Checksum{
+			Current:   12345,
+			Corrupted: true,
+			Version:   1,
+		},
+	})
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+
+	value := decodeAvroMessage(t, encoder, "avro_topic"+valueSchemaSuffix, messages[0].Value)
+	require.Equal(t, "12345", value[tidbRowLevelChecksum])
+	require.Equal(t, true, value[tidbCorrupted])
+	require.Equal(t, int32(1), value[tidbChecksumVersion])
+}
+
+func TestAvroSchemaEvolution(t *testing.T) {
+	registry := newMockConfluentRegistry()
+	defer registry.close()
+
+	helper := pevent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	encoder := newTestAvroEncoder(t, registry.url())
+	ctx := context.Background()
+	topic := "evolution"
+	valueSubject := topic + valueSchemaSuffix
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b int)`)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, 1)`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	err := encoder.AppendRowChangedEvent(ctx, topic, &pevent.RowEvent{
+		TableInfo:      helper.GetTableInfo(job),
+		CommitTs:       1,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() {},
+	})
+	require.NoError(t, err)
+	require.Len(t, encoder.Build(), 1)
+	require.Equal(t, 1, registry.subjectVersion(valueSubject))
+
+	// the same table version hits the cache, no new schema is registered.
+	dmlEvent = helper.DML2Event("test", "t", `insert into test.t values (2, 2)`)
+	row, ok = dmlEvent.GetNextRow()
+	require.True(t, ok)
+	err = encoder.AppendRowChangedEvent(ctx, topic, &pevent.RowEvent{
+		TableInfo:      helper.GetTableInfo(job),
+		CommitTs:       2,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() {},
+	})
+	require.NoError(t, err)
+	require.Len(t, encoder.Build(), 1)
+	require.Equal(t, 1, registry.subjectVersion(valueSubject))
+
+	// the DDL changes the table version, so a new schema version is registered.
+	ddlJob := helper.DDL2Job(`alter table test.t add column c varchar(10) default "x"`)
+	ddlMessage, err := encoder.EncodeDDLEvent(&pevent.DDLEvent{
+		Type:       byte(ddlJob.Type),
+		SchemaName: "test",
+		TableName:  "t",
+		Query:      ddlJob.Query,
+		FinishedTs: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeDDL, ddlMessage.Type)
+	require.Equal(t, ddlByte, ddlMessage.Value[0])
+
+	dmlEvent = helper.DML2Event("test", "t", `insert into test.t values (3, 3, "y")`)
+	row, ok = dmlEvent.GetNextRow()
+	require.True(t, ok)
+	err = encoder.AppendRowChangedEvent(ctx, topic, &pevent.RowEvent{
+		TableInfo:      helper.GetTableInfo(ddlJob),
+		CommitTs:       4,
+		Event:          row,
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() {},
+	})
+	require.NoError(t, err)
+	messages := encoder.Build()
+	require.Len(t, messages, 1)
+	require.Equal(t, 2, registry.subjectVersion(valueSubject))
+
+	value := decodeAvroMessage(t, encoder, valueSubject, messages[0].Value)
+	require.Equal(t, map[string]interface{}{"string": "y"}, value["c"])
+}
+
+func TestAvroCheckpointAndDDLWithoutWatermark(t *testing.T) {
+	registry := newMockConfluentRegistry()
+	defer registry.close()
+
+	codecConfig := newcommon.NewConfig(config.ProtocolAvro)
+	codecConfig.AvroConfluentSchemaRegistry = registry.url()
+	e, err := NewAvroEncoder(context.Background(), codecConfig)
+	require.NoError(t, err)
+
+	message, err := e.EncodeCheckpointEvent(1)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	message, err = e.EncodeDDLEvent(&pevent.DDLEvent{SchemaName: "test", TableName: "t", FinishedTs: 1})
+	require.NoError(t, err)
+	require.Nil(t, message)
+}
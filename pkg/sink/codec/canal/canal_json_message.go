@@ -18,7 +18,15 @@ package canal
 // 	canal "github.com/pingcap/tiflow/proto/canal"
 // )
 
-const tidbWaterMarkType = "TIDB_WATERMARK"
+const (
+	tidbWaterMarkType = "TIDB_WATERMARK"
+	// tidbSyncPointType is the type of the sync point message, it's only sent when
+	// both enable-tidb-extension and send-sync-point-marker are enabled.
+	// The primary ts and secondary ts are in the TiDB extension field "_tidb",
+	// all the events whose commit ts is not larger than the primary ts are sent
+	// before it in the same partition.
+	tidbSyncPointType = "TIDB_SYNCPOINT"
+)
 
 // // The TiCDC Canal-JSON implementation extend the official format with a TiDB extension field.
 // // canalJSONMessageInterface is used to support this without affect the original format.
@@ -128,6 +136,9 @@ type tidbExtension struct {
 	WatermarkTs        uint64 `json:"watermarkTs,omitempty"`
 	OnlyHandleKey      bool   `json:"onlyHandleKey,omitempty"`
 	ClaimCheckLocation string `json:"claimCheckLocation,omitempty"`
+	// PrimaryTs and SecondaryTs are only for the sync point event.
+	PrimaryTs   uint64 `json:"primaryTs,omitempty"`
+	SecondaryTs uint64 `json:"secondaryTs,omitempty"`
 }
 
 type canalJSONMessageWithTiDBExtension struct {
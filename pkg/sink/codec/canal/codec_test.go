@@ -755,3 +755,34 @@ func TestCheckpointTs(t *testing.T) {
 	require.Equal(t, 1>>18, value.ExecutionTime)
 	require.Equal(t, 1, value.Extensions.WatermarkTs)
 }
+
+func TestSyncPointEvent(t *testing.T) {
+	protocolConfig := newcommon.NewConfig(newconfig.ProtocolCanalJSON)
+	encoder, err := NewJSONRowEventEncoder(context.Background(), protocolConfig)
+	require.NoError(t, err)
+
+	message, err := encoder.EncodeSyncPointEvent(1, 2)
+	require.NoError(t, err)
+	require.Nil(t, message)
+
+	// with extension
+	protocolConfig.EnableTiDBExtension = true
+	encoder, err = NewJSONRowEventEncoder(context.Background(), protocolConfig)
+	require.NoError(t, err)
+	message, err = encoder.EncodeSyncPointEvent(1, 2)
+	require.NoError(t, err)
+
+	require.Equal(t, config.ProtocolCanalJSON, message.Protocol)
+	require.Equal(t, model.MessageTypeResolved, message.Type)
+	require.Equal(t, uint64(1), message.Ts)
+
+	var value canalJSONMessageWithTiDBExtension
+	err = json.Unmarshal(message.Value, &value)
+	require.NoError(t, err)
+
+	require.False(t, value.IsDDL)
+	require.Equal(t, tidbSyncPointType, value.EventType)
+	require.Equal(t, uint64(1), value.Extensions.PrimaryTs)
+	require.Equal(t, uint64(2), value.Extensions.SecondaryTs)
+	require.Zero(t, value.Extensions.WatermarkTs)
+}
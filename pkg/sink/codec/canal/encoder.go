@@ -454,6 +454,43 @@ func (c *JSONRowEventEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Messag
 	return ticommon.NewResolvedMsg(config.ProtocolCanalJSON, nil, value, ts), nil
 }
 
+func (c *JSONRowEventEncoder) newJSONMessage4SyncPointEvent(
+	primaryTs, secondaryTs uint64,
+) *canalJSONMessageWithTiDBExtension {
+	return &canalJSONMessageWithTiDBExtension{
+		JSONMessage: &JSONMessage{
+			ID:            0,
+			IsDDL:         false,
+			EventType:     tidbSyncPointType,
+			ExecutionTime: convertToCanalTs(primaryTs),
+			BuildTime:     time.Now().UnixMilli(),
+		},
+		Extensions: &tidbExtension{PrimaryTs: primaryTs, SecondaryTs: secondaryTs},
+	}
+}
+
+// EncodeSyncPointEvent implements the RowEventEncoder interface
+func (c *JSONRowEventEncoder) EncodeSyncPointEvent(primaryTs, secondaryTs uint64) (*ticommon.Message, error) {
+	if !c.config.EnableTiDBExtension {
+		return nil, nil
+	}
+
+	msg := c.newJSONMessage4SyncPointEvent(primaryTs, secondaryTs)
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return nil, cerror.WrapError(cerror.ErrCanalEncodeFailed, err)
+	}
+
+	value, err = newcommon.Compress(
+		c.config.ChangefeedID, c.config.LargeMessageHandle.LargeMessageHandleCompression, value,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return ticommon.NewResolvedMsg(config.ProtocolCanalJSON, nil, value, primaryTs), nil
+}
+
 // AppendRowChangedEvent implements the interface EventJSONBatchEncoder
 func (c *JSONRowEventEncoder) AppendRowChangedEvent(
 	ctx context.Context,
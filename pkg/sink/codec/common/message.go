@@ -30,6 +30,10 @@ import (
 // which will be treated as `version = 2` by sarama producer.
 const MaxRecordOverhead = 5*binary.MaxVarintLen32 + binary.MaxVarintLen64 + 1
 
+// MessageTypeSyncPoint is the type of the sync point marker message. It's not defined
+// in model.MessageType, so it follows model.MessageTypeResolved, the last one there.
+const MessageTypeSyncPoint model.MessageType = 4
+
 // Message represents an message to the sink
 type Message struct {
 	Key       []byte
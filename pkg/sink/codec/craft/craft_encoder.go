@@ -41,6 +41,12 @@ func (e *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, erro
 		NewResolvedEventEncoder(e.allocator, ts).Encode(), ts), nil
 }
 
+// EncodeSyncPointEvent implements the RowEventEncoder interface,
+// the sync point event is not defined in the craft protocol, so it's skipped.
+func (e *BatchEncoder) EncodeSyncPointEvent(_, _ uint64) (*ticommon.Message, error) {
+	return nil, nil
+}
+
 // AppendRowChangedEvent implements the RowEventEncoder interface
 func (e *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
@@ -18,24 +18,27 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 	"time"
 
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
-	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/util"
 	"github.com/tikv/client-go/v2/oracle"
 	"go.uber.org/zap"
 )
 
 type dbzCodec struct {
-	config    *ticommon.Config
+	config    *newcommon.Config
 	clusterID string
 	nowFunc   func() time.Time
 }
@@ -43,14 +46,17 @@ type dbzCodec struct {
 func (c *dbzCodec) writeDebeziumFieldValues(
 	writer *util.JSONWriter,
 	fieldName string,
-	cols []*common.Column,
+	row *chunk.Row,
 	tableInfo *common.TableInfo,
+	selector columnselector.Selector,
 ) error {
 	var err error
-	colInfos := tableInfo.GetColInfosForRowChangedEvent()
 	writer.WriteObjectField(fieldName, func() {
-		for i, col := range cols {
-			err = c.writeDebeziumFieldValue(writer, col, colInfos[i].Ft)
+		for idx, col := range tableInfo.Columns {
+			if !selector.Select(col) {
+				continue
+			}
+			err = c.writeDebeziumFieldValue(writer, row, col, idx, tableInfo)
 			if err != nil {
 				break
 			}
@@ -59,19 +65,38 @@ func (c *dbzCodec) writeDebeziumFieldValues(
 	return err
 }
 
+// writeDebeziumFieldsSchema writes the schema of the selected columns, it's shared
+// by the `before` and `after` fields of the value and the key.
+func (c *dbzCodec) writeDebeziumFieldsSchema(
+	writer *util.JSONWriter,
+	tableInfo *common.TableInfo,
+	selector columnselector.Selector,
+	onlyHandleKeyColumns bool,
+) {
+	for _, col := range tableInfo.Columns {
+		if !selector.Select(col) {
+			continue
+		}
+		if onlyHandleKeyColumns && !tableInfo.ForceGetColumnFlagType(col.ID).IsHandleKey() {
+			continue
+		}
+		c.writeDebeziumFieldSchema(writer, col)
+	}
+}
+
 func (c *dbzCodec) writeDebeziumFieldSchema(
 	writer *util.JSONWriter,
-	col *common.Column,
-	ft *types.FieldType,
+	col *timodel.ColumnInfo,
 ) {
-	switch col.Type {
+	ft := &col.FieldType
+	switch col.GetType() {
 	case mysql.TypeBit:
 		n := ft.GetFlen()
 		if n == 1 {
 			writer.WriteObjectElement(func() {
 				writer.WriteStringField("type", "boolean")
 				writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-				writer.WriteStringField("field", col.Name)
+				writer.WriteStringField("field", col.Name.O)
 			})
 		} else {
 			writer.WriteObjectElement(func() {
@@ -82,7 +107,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 				writer.WriteObjectField("parameters", func() {
 					writer.WriteStringField("length", fmt.Sprintf("%d", n))
 				})
-				writer.WriteStringField("field", col.Name)
+				writer.WriteStringField("field", col.Name.O)
 			})
 		}
 
@@ -91,7 +116,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "string")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeEnum:
@@ -103,7 +128,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteObjectField("parameters", func() {
 				writer.WriteStringField("allowed", strings.Join(ft.GetElems(), ","))
 			})
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeSet:
@@ -115,14 +140,14 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteObjectField("parameters", func() {
 				writer.WriteStringField("allowed", strings.Join(ft.GetElems(), ","))
 			})
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeNewDecimal:
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "double")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeDate, mysql.TypeNewDate:
@@ -131,7 +156,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.time.Date")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeDatetime:
@@ -144,7 +169,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 				writer.WriteStringField("name", "io.debezium.time.MicroTimestamp")
 			}
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeTimestamp:
@@ -153,7 +178,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.time.ZonedTimestamp")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeDuration:
@@ -162,7 +187,7 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.time.MicroTime")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeJSON:
@@ -171,14 +196,14 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.data.Json")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeTiny: // TINYINT
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "int16")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeShort: // SMALLINT
@@ -189,14 +214,14 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 				writer.WriteStringField("type", "int16")
 			}
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeInt24: // MEDIUMINT
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "int32")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeLong: // INT
@@ -207,28 +232,28 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 				writer.WriteStringField("type", "int32")
 			}
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeLonglong: // BIGINT
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "int64")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeFloat:
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "float")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeDouble:
 		writer.WriteObjectElement(func() {
 			writer.WriteStringField("type", "double")
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	case mysql.TypeYear:
@@ -237,14 +262,14 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 			writer.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
 			writer.WriteStringField("name", "io.debezium.time.Year")
 			writer.WriteIntField("version", 1)
-			writer.WriteStringField("field", col.Name)
+			writer.WriteStringField("field", col.Name.O)
 		})
 
 	default:
 		log.Warn(
 			"meet unsupported field type",
-			zap.Any("fieldType", col.Type),
-			zap.Any("column", col.Name),
+			zap.Any("fieldType", col.GetType()),
+			zap.Any("column", col.Name.O),
 		)
 	}
 }
@@ -254,21 +279,23 @@ func (c *dbzCodec) writeDebeziumFieldSchema(
 //revive:disable indent-error-flow
 func (c *dbzCodec) writeDebeziumFieldValue(
 	writer *util.JSONWriter,
-	col *common.Column,
-	ft *types.FieldType,
+	row *chunk.Row,
+	col *timodel.ColumnInfo,
+	idx int,
+	tableInfo *common.TableInfo,
 ) error {
-	if col.Value == nil {
-		writer.WriteNullField(col.Name)
+	name := col.Name.O
+	if row.IsNull(idx) {
+		writer.WriteNullField(name)
 		return nil
 	}
-	switch col.Type {
+	ft := &col.FieldType
+	switch col.GetType() {
 	case mysql.TypeBit:
-		v, ok := col.Value.(uint64)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for bit column %s",
-				col.Value,
-				col.Name)
+		d := row.GetDatum(idx, ft)
+		v, err := d.GetBinaryLiteral().ToInt(types.DefaultStmtNoWarningContext)
+		if err != nil {
+			return cerror.WrapError(cerror.ErrDebeziumEncodeFailed, err)
 		}
 
 		// Debezium behavior:
@@ -277,7 +304,7 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		//						contain the specified number of bits.
 		n := ft.GetFlen()
 		if n == 1 {
-			writer.WriteBoolField(col.Name, v != 0)
+			writer.WriteBoolField(name, v != 0)
 			return nil
 		} else {
 			var buf [8]byte
@@ -286,108 +313,71 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 			if n%8 != 0 {
 				numBytes += 1
 			}
-			c.writeBinaryField(writer, col.Name, buf[:numBytes])
+			c.writeBinaryField(writer, name, buf[:numBytes])
 			return nil
 		}
 
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
 		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-		v, ok := col.Value.([]byte)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for string column %s",
-				col.Value,
-				col.Name)
-		}
-
-		if col.Flag.IsBinary() {
-			c.writeBinaryField(writer, col.Name, v)
+		v := row.GetBytes(idx)
+		if tableInfo.ForceGetColumnFlagType(col.ID).IsBinary() {
+			c.writeBinaryField(writer, name, v)
 			return nil
 		} else {
-			writer.WriteStringField(col.Name, string(hack.String(v)))
+			writer.WriteStringField(name, string(hack.String(v)))
 			return nil
 		}
 
 	case mysql.TypeEnum:
-		v, ok := col.Value.(uint64)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for enum column %s",
-				col.Value,
-				col.Name)
-		}
-
+		v := row.GetEnum(idx).Value
 		enumVar, err := types.ParseEnumValue(ft.GetElems(), v)
 		if err != nil {
 			// Invalid enum value inserted in non-strict mode.
-			writer.WriteStringField(col.Name, "")
+			writer.WriteStringField(name, "")
 			return nil
 		}
 
-		writer.WriteStringField(col.Name, enumVar.Name)
+		writer.WriteStringField(name, enumVar.Name)
 		return nil
 
 	case mysql.TypeSet:
-		v, ok := col.Value.(uint64)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for set column %s",
-				col.Value,
-				col.Name)
-		}
-
+		v := row.GetSet(idx).Value
 		setVar, err := types.ParseSetValue(ft.GetElems(), v)
 		if err != nil {
 			// Invalid enum value inserted in non-strict mode.
-			writer.WriteStringField(col.Name, "")
+			writer.WriteStringField(name, "")
 			return nil
 		}
 
-		writer.WriteStringField(col.Name, setVar.Name)
+		writer.WriteStringField(name, setVar.Name)
 		return nil
 
 	case mysql.TypeNewDecimal:
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for decimal column %s",
-				col.Value,
-				col.Name)
-		}
-
-		floatV, err := strconv.ParseFloat(v, 64)
+		floatV, err := row.GetMyDecimal(idx).ToFloat64()
 		if err != nil {
 			return cerror.WrapError(
 				cerror.ErrDebeziumEncodeFailed,
 				err)
 		}
 
-		writer.WriteFloat64Field(col.Name, floatV)
+		writer.WriteFloat64Field(name, floatV)
 		return nil
 
 	case mysql.TypeDate, mysql.TypeNewDate:
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for date column %s",
-				col.Value,
-				col.Name)
-		}
-
-		t, err := time.Parse("2006-01-02", v)
+		t, err := time.Parse("2006-01-02", row.GetTime(idx).String())
 		if err != nil {
 			// For example, time may be invalid like 1000-00-00
 			// return nil, nil
 			if mysql.HasNotNullFlag(ft.GetFlag()) {
-				writer.WriteInt64Field(col.Name, 0)
+				writer.WriteInt64Field(name, 0)
 				return nil
 			} else {
-				writer.WriteNullField(col.Name)
+				writer.WriteNullField(name)
 				return nil
 			}
 		}
 
-		writer.WriteInt64Field(col.Name, t.Unix()/60/60/24)
+		writer.WriteInt64Field(name, t.Unix()/60/60/24)
 		return nil
 
 	case mysql.TypeDatetime:
@@ -396,31 +386,23 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		// > column's precision by using UTC.
 
 		// TODO: For Default Value = CURRENT_TIMESTAMP, the result is incorrect.
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for datetime column %s",
-				col.Value,
-				col.Name)
-		}
-
-		t, err := time.Parse("2006-01-02 15:04:05.999999", v)
+		t, err := time.Parse("2006-01-02 15:04:05.999999", row.GetTime(idx).String())
 		if err != nil {
 			// For example, time may be 1000-00-00
 			if mysql.HasNotNullFlag(ft.GetFlag()) {
-				writer.WriteInt64Field(col.Name, 0)
+				writer.WriteInt64Field(name, 0)
 				return nil
 			} else {
-				writer.WriteNullField(col.Name)
+				writer.WriteNullField(name)
 				return nil
 			}
 		}
 
 		if ft.GetDecimal() <= 3 {
-			writer.WriteInt64Field(col.Name, t.UnixMilli())
+			writer.WriteInt64Field(name, t.UnixMilli())
 			return nil
 		} else {
-			writer.WriteInt64Field(col.Name, t.UnixMicro())
+			writer.WriteInt64Field(name, t.UnixMicro())
 			return nil
 		}
 
@@ -434,21 +416,13 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		// > based on the server (or session's) current time zone. The time zone will be queried from
 		// > the server by default. If this fails, it must be specified explicitly by the database
 		// > connectionTimeZone MySQL configuration option.
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for timestamp column %s",
-				col.Value,
-				col.Name)
-		}
-
-		t, err := time.ParseInLocation("2006-01-02 15:04:05.999999", v, c.config.TimeZone)
+		t, err := time.ParseInLocation("2006-01-02 15:04:05.999999", row.GetTime(idx).String(), c.config.TimeZone)
 		if err != nil {
 			// For example, time may be invalid like 1000-00-00
 			if mysql.HasNotNullFlag(ft.GetFlag()) {
 				t = time.Unix(0, 0)
 			} else {
-				writer.WriteNullField(col.Name)
+				writer.WriteNullField(name)
 				return nil
 			}
 		}
@@ -461,44 +435,26 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		}
 		str += "Z"
 
-		writer.WriteStringField(col.Name, str)
+		writer.WriteStringField(name, str)
 		return nil
 
 	case mysql.TypeDuration:
 		// Debezium behavior from doc:
 		// > Represents the time value in microseconds and does not include
 		// > time zone information. MySQL allows M to be in the range of 0-6.
-		v, ok := col.Value.(string)
-		if !ok {
-			return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-				"unexpected column value type %T for time column %s",
-				col.Value,
-				col.Name)
-		}
-
-		d, _, _, err := types.StrToDuration(types.DefaultStmtNoWarningContext, v, ft.GetDecimal())
-		if err != nil {
-			return cerror.WrapError(
-				cerror.ErrDebeziumEncodeFailed,
-				err)
-		}
+		d := row.GetDuration(idx, ft.GetDecimal())
+		writer.WriteInt64Field(name, d.Duration.Microseconds())
+		return nil
 
-		writer.WriteInt64Field(col.Name, d.Microseconds())
+	case mysql.TypeJSON:
+		writer.WriteStringField(name, row.GetJSON(idx).String())
 		return nil
 
 	case mysql.TypeLonglong:
-		if col.Flag.IsUnsigned() {
+		if mysql.HasUnsignedFlag(ft.GetFlag()) {
 			// Handle with BIGINT UNSIGNED.
 			// Debezium always produce INT64 instead of UINT64 for BIGINT.
-			v, ok := col.Value.(uint64)
-			if !ok {
-				return cerror.ErrDebeziumEncodeFailed.GenWithStack(
-					"unexpected column value type %T for unsigned bigint column %s",
-					col.Value,
-					col.Name)
-			}
-
-			writer.WriteInt64Field(col.Name, int64(v))
+			writer.WriteInt64Field(name, int64(row.GetUint64(idx)))
 			return nil
 		}
 
@@ -507,7 +463,8 @@ func (c *dbzCodec) writeDebeziumFieldValue(
 		// So we only handle with TypeLonglong here.
 	}
 
-	writer.WriteAnyField(col.Name, col.Value)
+	d := row.GetDatum(idx, ft)
+	writer.WriteAnyField(name, d.GetValue())
 	return nil
 }
 
@@ -516,42 +473,112 @@ func (c *dbzCodec) writeBinaryField(writer *util.JSONWriter, fieldName string, v
 	writer.WriteBase64StringField(fieldName, value)
 }
 
-func (c *dbzCodec) EncodeRowChangedEvent(
-	e *common.RowChangedEvent,
+// hasHandleKeyColumns returns whether the table has columns to identify a row,
+// the key of the data change events is null if not.
+func hasHandleKeyColumns(tableInfo *common.TableInfo) bool {
+	for _, col := range tableInfo.Columns {
+		if tableInfo.ForceGetColumnFlagType(col.ID).IsHandleKey() {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeKey encodes the handle key columns of the row into the message key.
+func (c *dbzCodec) EncodeKey(
+	e *commonEvent.RowEvent,
 	dest io.Writer,
 ) error {
+	// schema field describes the structure of the primary key, or the unique key if the table does not have a primary key, for the table that was changed.
+	// see https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-events
+	row := e.GetRows()
+	if e.IsDelete() {
+		row = e.GetPreRows()
+	}
+	tableInfo := e.TableInfo
+
 	jWriter := util.BorrowJSONWriter(dest)
 	defer util.ReturnJSONWriter(jWriter)
 
-	commitTime := oracle.GetTimeFromTS(e.CommitTs)
+	var err error
+	jWriter.WriteObject(func() {
+		jWriter.WriteObjectField("payload", func() {
+			for idx, col := range tableInfo.Columns {
+				if !tableInfo.ForceGetColumnFlagType(col.ID).IsHandleKey() {
+					continue
+				}
+				err = c.writeDebeziumFieldValue(jWriter, row, col, idx, tableInfo)
+				if err != nil {
+					break
+				}
+			}
+		})
+		if !c.config.DebeziumDisableSchema {
+			jWriter.WriteObjectField("schema", func() {
+				jWriter.WriteStringField("type", "struct")
+				jWriter.WriteStringField("name", fmt.Sprintf("%s.%s.%s.Key",
+					c.clusterID,
+					tableInfo.GetSchemaName(),
+					tableInfo.GetTableName()))
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteArrayField("fields", func() {
+					c.writeDebeziumFieldsSchema(jWriter, tableInfo, columnselector.NewDefaultColumnSelector(), true)
+				})
+			})
+		}
+	})
+	return err
+}
+
+func (c *dbzCodec) writeSource(
+	jWriter *util.JSONWriter,
+	commitTs uint64,
+	schemaName string,
+	tableName string,
+) {
+	commitTime := oracle.GetTimeFromTS(commitTs)
+	jWriter.WriteObjectField("source", func() {
+		jWriter.WriteStringField("version", "2.4.0.Final")
+		jWriter.WriteStringField("connector", "TiCDC")
+		jWriter.WriteStringField("name", c.clusterID)
+		// ts_ms: In the source object, ts_ms indicates the time that the change was made in the database.
+		// https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-create-events
+		jWriter.WriteInt64Field("ts_ms", commitTime.UnixMilli())
+		// snapshot field is a string of true,last,false,incremental
+		jWriter.WriteStringField("snapshot", "false")
+		jWriter.WriteStringField("db", schemaName)
+		if tableName == "" {
+			jWriter.WriteNullField("table")
+		} else {
+			jWriter.WriteStringField("table", tableName)
+		}
+		jWriter.WriteInt64Field("server_id", 0)
+		jWriter.WriteNullField("gtid")
+		jWriter.WriteStringField("file", "")
+		jWriter.WriteInt64Field("pos", 0)
+		jWriter.WriteInt64Field("row", 0)
+		jWriter.WriteInt64Field("thread", 0)
+		jWriter.WriteNullField("query")
+
+		// The followings are TiDB extended fields
+		jWriter.WriteUint64Field("commit_ts", commitTs)
+		jWriter.WriteStringField("cluster_id", c.clusterID)
+	})
+}
+
+// EncodeValue encodes the row into a data change event.
+func (c *dbzCodec) EncodeValue(
+	e *commonEvent.RowEvent,
+	dest io.Writer,
+) error {
+	jWriter := util.BorrowJSONWriter(dest)
+	defer util.ReturnJSONWriter(jWriter)
 
 	var err error
 
 	jWriter.WriteObject(func() {
 		jWriter.WriteObjectField("payload", func() {
-			jWriter.WriteObjectField("source", func() {
-				jWriter.WriteStringField("version", "2.4.0.Final")
-				jWriter.WriteStringField("connector", "TiCDC")
-				jWriter.WriteStringField("name", c.clusterID)
-				// ts_ms: In the source object, ts_ms indicates the time that the change was made in the database.
-				// https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-create-events
-				jWriter.WriteInt64Field("ts_ms", commitTime.UnixMilli())
-				// snapshot field is a string of true,last,false,incremental
-				jWriter.WriteStringField("snapshot", "false")
-				jWriter.WriteStringField("db", e.TableInfo.GetSchemaName())
-				jWriter.WriteStringField("table", e.TableInfo.GetTableName())
-				jWriter.WriteInt64Field("server_id", 0)
-				jWriter.WriteNullField("gtid")
-				jWriter.WriteStringField("file", "")
-				jWriter.WriteInt64Field("pos", 0)
-				jWriter.WriteInt64Field("row", 0)
-				jWriter.WriteInt64Field("thread", 0)
-				jWriter.WriteNullField("query")
-
-				// The followings are TiDB extended fields
-				jWriter.WriteUint64Field("commit_ts", e.CommitTs)
-				jWriter.WriteStringField("cluster_id", c.clusterID)
-			})
+			c.writeSource(jWriter, e.CommitTs, e.TableInfo.GetSchemaName(), e.TableInfo.GetTableName())
 
 			// ts_ms: displays the time at which the connector processed the event
 			// https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-create-events
@@ -577,18 +604,18 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 				// after: An optional field that specifies the state of the row after the event occurred.
 				// Optional field that specifies the state of the row after the event occurred.
 				// In a delete event value, the after field is null, signifying that the row no longer exists.
-				err = c.writeDebeziumFieldValues(jWriter, "after", e.GetColumns(), e.TableInfo)
+				err = c.writeDebeziumFieldValues(jWriter, "after", e.GetRows(), e.TableInfo, e.ColumnSelector)
 			} else if e.IsDelete() {
 				jWriter.WriteStringField("op", "d")
 				jWriter.WriteNullField("after")
-				err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreColumns(), e.TableInfo)
+				err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreRows(), e.TableInfo, e.ColumnSelector)
 			} else if e.IsUpdate() {
 				jWriter.WriteStringField("op", "u")
 				if c.config.DebeziumOutputOldValue {
-					err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreColumns(), e.TableInfo)
+					err = c.writeDebeziumFieldValues(jWriter, "before", e.GetPreRows(), e.TableInfo, e.ColumnSelector)
 				}
 				if err == nil {
-					err = c.writeDebeziumFieldValues(jWriter, "after", e.GetColumns(), e.TableInfo)
+					err = c.writeDebeziumFieldValues(jWriter, "after", e.GetRows(), e.TableInfo, e.ColumnSelector)
 				}
 			}
 		})
@@ -609,18 +636,7 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 					{
 						fieldsBuf := &bytes.Buffer{}
 						fieldsWriter := util.BorrowJSONWriter(fieldsBuf)
-						var validCols []*common.Column
-						if e.IsInsert() {
-							validCols = e.GetColumns()
-						} else if e.IsDelete() {
-							validCols = e.GetPreColumns()
-						} else if e.IsUpdate() {
-							validCols = e.GetColumns()
-						}
-						colInfos := e.TableInfo.GetColInfosForRowChangedEvent()
-						for i, col := range validCols {
-							c.writeDebeziumFieldSchema(fieldsWriter, col, colInfos[i].Ft)
-						}
+						c.writeDebeziumFieldsSchema(fieldsWriter, e.TableInfo, e.ColumnSelector, false)
 						util.ReturnJSONWriter(fieldsWriter)
 						fieldsJSON = fieldsBuf.String()
 					}
@@ -648,106 +664,7 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 							jWriter.WriteRaw(fieldsJSON)
 						})
 					})
-					jWriter.WriteObjectElement(func() {
-						jWriter.WriteStringField("type", "struct")
-						jWriter.WriteArrayField("fields", func() {
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "version")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "connector")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "name")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int64")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "ts_ms")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("name", "io.debezium.data.Enum")
-								jWriter.WriteIntField("version", 1)
-								jWriter.WriteObjectField("parameters", func() {
-									jWriter.WriteStringField("allowed", "true,last,false,incremental")
-								})
-								jWriter.WriteStringField("default", "false")
-								jWriter.WriteStringField("field", "snapshot")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "db")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "sequence")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "table")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int64")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "server_id")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "gtid")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "file")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int64")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "pos")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int32")
-								jWriter.WriteBoolField("optional", false)
-								jWriter.WriteStringField("field", "row")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "int64")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "thread")
-							})
-							jWriter.WriteObjectElement(func() {
-								jWriter.WriteStringField("type", "string")
-								jWriter.WriteBoolField("optional", true)
-								jWriter.WriteStringField("field", "query")
-							})
-							// Below are extra TiDB fields
-							// jWriter.WriteObjectElement(func() {
-							// 	jWriter.WriteStringField("type", "int64")
-							// 	jWriter.WriteBoolField("optional", false)
-							// 	jWriter.WriteStringField("field", "commit_ts")
-							// })
-							// jWriter.WriteObjectElement(func() {
-							// 	jWriter.WriteStringField("type", "string")
-							// 	jWriter.WriteBoolField("optional", false)
-							// 	jWriter.WriteStringField("field", "cluster_id")
-							// })
-						})
-						jWriter.WriteBoolField("optional", false)
-						jWriter.WriteStringField("name", "io.debezium.connector.mysql.Source")
-						jWriter.WriteStringField("field", "source")
-					})
+					writeSourceSchema(jWriter)
 					jWriter.WriteObjectElement(func() {
 						jWriter.WriteStringField("type", "string")
 						jWriter.WriteBoolField("optional", false)
@@ -789,3 +706,377 @@ func (c *dbzCodec) EncodeRowChangedEvent(
 
 	return err
 }
+
+// writeSourceSchema writes the schema of the `source` field, which is shared by
+// the data change events and the schema change events.
+func writeSourceSchema(jWriter *util.JSONWriter) {
+	jWriter.WriteObjectElement(func() {
+		jWriter.WriteStringField("type", "struct")
+		jWriter.WriteArrayField("fields", func() {
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "version")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "connector")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "name")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int64")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "ts_ms")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("name", "io.debezium.data.Enum")
+				jWriter.WriteIntField("version", 1)
+				jWriter.WriteObjectField("parameters", func() {
+					jWriter.WriteStringField("allowed", "true,last,false,incremental")
+				})
+				jWriter.WriteStringField("default", "false")
+				jWriter.WriteStringField("field", "snapshot")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "db")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "sequence")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "table")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int64")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "server_id")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "gtid")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "file")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int64")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "pos")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int32")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("field", "row")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "int64")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "thread")
+			})
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "string")
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("field", "query")
+			})
+			// Below are extra TiDB fields
+			// jWriter.WriteObjectElement(func() {
+			// 	jWriter.WriteStringField("type", "int64")
+			// 	jWriter.WriteBoolField("optional", false)
+			// 	jWriter.WriteStringField("field", "commit_ts")
+			// })
+			// jWriter.WriteObjectElement(func() {
+			// 	jWriter.WriteStringField("type", "string")
+			// 	jWriter.WriteBoolField("optional", false)
+			// 	jWriter.WriteStringField("field", "cluster_id")
+			// })
+		})
+		jWriter.WriteBoolField("optional", false)
+		jWriter.WriteStringField("name", "io.debezium.connector.mysql.Source")
+		jWriter.WriteStringField("field", "source")
+	})
+}
+
+// EncodeDDLKey encodes the key of the schema change event, which is the name
+// of the database the DDL applied to.
+// see https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-schema-change-topic
+func (c *dbzCodec) EncodeDDLKey(
+	e *commonEvent.DDLEvent,
+	dest io.Writer,
+) error {
+	jWriter := util.BorrowJSONWriter(dest)
+	defer util.ReturnJSONWriter(jWriter)
+
+	jWriter.WriteObject(func() {
+		jWriter.WriteObjectField("payload", func() {
+			jWriter.WriteStringField("databaseName", e.SchemaName)
+		})
+		if !c.config.DebeziumDisableSchema {
+			jWriter.WriteObjectField("schema", func() {
+				jWriter.WriteStringField("type", "struct")
+				jWriter.WriteStringField("name", "io.debezium.connector.mysql.SchemaChangeKey")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteIntField("version", 1)
+				jWriter.WriteArrayField("fields", func() {
+					writeSchemaField(jWriter, "string", false, "databaseName")
+				})
+			})
+		}
+	})
+	return nil
+}
+
+// EncodeDDLValue encodes the DDL into a schema change event.
+// see https://debezium.io/documentation/reference/stable/connectors/mysql.html#mysql-schema-change-topic
+func (c *dbzCodec) EncodeDDLValue(
+	e *commonEvent.DDLEvent,
+	dest io.Writer,
+) error {
+	jWriter := util.BorrowJSONWriter(dest)
+	defer util.ReturnJSONWriter(jWriter)
+
+	jWriter.WriteObject(func() {
+		jWriter.WriteObjectField("payload", func() {
+			c.writeSource(jWriter, e.FinishedTs, e.SchemaName, e.TableName)
+			jWriter.WriteInt64Field("ts_ms", c.nowFunc().UnixMilli())
+			jWriter.WriteStringField("databaseName", e.SchemaName)
+			// schemaName is always null for the MySQL connector.
+			jWriter.WriteNullField("schemaName")
+			jWriter.WriteStringField("ddl", e.Query)
+			jWriter.WriteArrayField("tableChanges", func() {
+				changeType := getTableChangeType(e)
+				if changeType == "" {
+					return
+				}
+				jWriter.WriteObjectElement(func() {
+					jWriter.WriteStringField("type", changeType)
+					jWriter.WriteStringField("id", fmt.Sprintf("\"%s\".\"%s\"", e.SchemaName, e.TableName))
+					if changeType == tableChangeDrop || e.TableInfo == nil {
+						jWriter.WriteNullField("table")
+						return
+					}
+					c.writeTableChange(jWriter, e.TableInfo)
+				})
+			})
+		})
+
+		if !c.config.DebeziumDisableSchema {
+			jWriter.WriteObjectField("schema", func() {
+				jWriter.WriteStringField("type", "struct")
+				jWriter.WriteBoolField("optional", false)
+				jWriter.WriteStringField("name", "io.debezium.connector.mysql.SchemaChangeValue")
+				jWriter.WriteIntField("version", 1)
+				jWriter.WriteArrayField("fields", func() {
+					writeSourceSchema(jWriter)
+					writeSchemaField(jWriter, "int64", false, "ts_ms")
+					writeSchemaField(jWriter, "string", true, "databaseName")
+					writeSchemaField(jWriter, "string", true, "schemaName")
+					writeSchemaField(jWriter, "string", true, "ddl")
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("type", "array")
+						jWriter.WriteObjectField("items", writeTableChangeSchema(jWriter))
+						jWriter.WriteBoolField("optional", false)
+						jWriter.WriteStringField("field", "tableChanges")
+					})
+				})
+			})
+		}
+	})
+	return nil
+}
+
+const (
+	tableChangeCreate = "CREATE"
+	tableChangeAlter  = "ALTER"
+	tableChangeDrop   = "DROP"
+)
+
+// getTableChangeType returns the type of the table change caused by the DDL,
+// an empty string means the DDL does not change any table, such as `CREATE DATABASE`.
+func getTableChangeType(e *commonEvent.DDLEvent) string {
+	if e.TableName == "" {
+		return ""
+	}
+	switch e.GetDDLType() {
+	case timodel.ActionCreateTable, timodel.ActionCreateTables, timodel.ActionRecoverTable,
+		timodel.ActionCreateView:
+		return tableChangeCreate
+	case timodel.ActionDropTable, timodel.ActionDropView:
+		return tableChangeDrop
+	default:
+		return tableChangeAlter
+	}
+}
+
+func (c *dbzCodec) writeTableChange(jWriter *util.JSONWriter, tableInfo *common.TableInfo) {
+	jWriter.WriteObjectField("table", func() {
+		jWriter.WriteStringField("defaultCharsetName", tableInfo.Charset)
+		jWriter.WriteArrayField("primaryKeyColumnNames", func() {
+			for _, name := range tableInfo.GetPrimaryKeyColumnNames() {
+				jWriter.WriteStringElement(name)
+			}
+		})
+		jWriter.WriteArrayField("columns", func() {
+			for idx, col := range tableInfo.Columns {
+				if !common.IsColCDCVisible(col) {
+					continue
+				}
+				ft := &col.FieldType
+				typeName := strings.ToUpper(types.TypeToStr(col.GetType(), col.GetCharset()))
+				if mysql.HasUnsignedFlag(ft.GetFlag()) {
+					typeName += " UNSIGNED"
+				}
+				autoIncremented := mysql.HasAutoIncrementFlag(ft.GetFlag())
+				jWriter.WriteObjectElement(func() {
+					jWriter.WriteStringField("name", col.Name.O)
+					jWriter.WriteIntField("jdbcType", getJDBCType(col))
+					jWriter.WriteStringField("typeName", typeName)
+					jWriter.WriteStringField("typeExpression", typeName)
+					if col.GetCharset() == "" {
+						jWriter.WriteNullField("charsetName")
+					} else {
+						jWriter.WriteStringField("charsetName", col.GetCharset())
+					}
+					jWriter.WriteIntField("length", ft.GetFlen())
+					if ft.GetDecimal() > 0 {
+						jWriter.WriteIntField("scale", ft.GetDecimal())
+					} else {
+						jWriter.WriteNullField("scale")
+					}
+					jWriter.WriteIntField("position", idx+1)
+					jWriter.WriteBoolField("optional", !mysql.HasNotNullFlag(ft.GetFlag()))
+					jWriter.WriteBoolField("autoIncremented", autoIncremented)
+					jWriter.WriteBoolField("generated", autoIncremented || col.IsGenerated())
+				})
+			}
+		})
+	})
+}
+
+// getJDBCType returns the java.sql.Types value of the column, which is
+// reported by the Debezium MySQL connector in the table changes.
+func getJDBCType(col *timodel.ColumnInfo) int {
+	switch col.GetType() {
+	case mysql.TypeBit:
+		return -7 // BIT
+	case mysql.TypeTiny:
+		return -6 // TINYINT
+	case mysql.TypeShort:
+		return 5 // SMALLINT
+	case mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		return 4 // INTEGER
+	case mysql.TypeLonglong:
+		return -5 // BIGINT
+	case mysql.TypeFloat:
+		return 7 // REAL
+	case mysql.TypeDouble:
+		return 8 // DOUBLE
+	case mysql.TypeNewDecimal:
+		return 3 // DECIMAL
+	case mysql.TypeDate, mysql.TypeNewDate:
+		return 91 // DATE
+	case mysql.TypeDuration:
+		return 92 // TIME
+	case mysql.TypeDatetime:
+		return 93 // TIMESTAMP
+	case mysql.TypeTimestamp:
+		return 2014 // TIMESTAMP_WITH_TIMEZONE
+	case mysql.TypeString, mysql.TypeEnum, mysql.TypeSet:
+		if col.GetCharset() == "binary" {
+			return -2 // BINARY
+		}
+		return 1 // CHAR
+	case mysql.TypeVarchar, mysql.TypeVarString:
+		if col.GetCharset() == "binary" {
+			return -3 // VARBINARY
+		}
+		return 12 // VARCHAR
+	case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		if col.GetCharset() == "binary" {
+			return 2004 // BLOB
+		}
+		return 2005 // CLOB
+	default:
+		return 1111 // OTHER
+	}
+}
+
+func writeSchemaField(writer *util.JSONWriter, fieldType string, optional bool, field string) {
+	writer.WriteObjectElement(func() {
+		writer.WriteStringField("type", fieldType)
+		writer.WriteBoolField("optional", optional)
+		writer.WriteStringField("field", field)
+	})
+}
+
+// writeTableChangeSchema returns a function writes the schema of the elements in `tableChanges`.
+func writeTableChangeSchema(jWriter *util.JSONWriter) func() {
+	return func() {
+		jWriter.WriteStringField("type", "struct")
+		jWriter.WriteArrayField("fields", func() {
+			writeSchemaField(jWriter, "string", false, "type")
+			writeSchemaField(jWriter, "string", false, "id")
+			jWriter.WriteObjectElement(func() {
+				jWriter.WriteStringField("type", "struct")
+				jWriter.WriteArrayField("fields", func() {
+					writeSchemaField(jWriter, "string", true, "defaultCharsetName")
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("type", "array")
+						jWriter.WriteObjectField("items", func() {
+							jWriter.WriteStringField("type", "string")
+							jWriter.WriteBoolField("optional", false)
+						})
+						jWriter.WriteBoolField("optional", true)
+						jWriter.WriteStringField("field", "primaryKeyColumnNames")
+					})
+					jWriter.WriteObjectElement(func() {
+						jWriter.WriteStringField("type", "array")
+						jWriter.WriteObjectField("items", func() {
+							jWriter.WriteStringField("type", "struct")
+							jWriter.WriteArrayField("fields", func() {
+								writeSchemaField(jWriter, "string", false, "name")
+								writeSchemaField(jWriter, "int32", false, "jdbcType")
+								writeSchemaField(jWriter, "string", false, "typeName")
+								writeSchemaField(jWriter, "string", true, "typeExpression")
+								writeSchemaField(jWriter, "string", true, "charsetName")
+								writeSchemaField(jWriter, "int32", true, "length")
+								writeSchemaField(jWriter, "int32", true, "scale")
+								writeSchemaField(jWriter, "int32", false, "position")
+								writeSchemaField(jWriter, "boolean", true, "optional")
+								writeSchemaField(jWriter, "boolean", true, "autoIncremented")
+								writeSchemaField(jWriter, "boolean", true, "generated")
+							})
+							jWriter.WriteBoolField("optional", false)
+							jWriter.WriteStringField("name", "io.debezium.connector.schema.Column")
+							jWriter.WriteIntField("version", 1)
+						})
+						jWriter.WriteBoolField("optional", false)
+						jWriter.WriteStringField("field", "columns")
+					})
+				})
+				jWriter.WriteBoolField("optional", true)
+				jWriter.WriteStringField("name", "io.debezium.connector.schema.Table")
+				jWriter.WriteIntField("version", 1)
+				jWriter.WriteStringField("field", "table")
+			})
+		})
+		jWriter.WriteBoolField("optional", false)
+		jWriter.WriteStringField("name", "io.debezium.connector.schema.Change")
+		jWriter.WriteIntField("version", 1)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debezium
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEncoder(disableSchema bool) *BatchEncoder {
+	cfg := newcommon.NewConfig(config.ProtocolDebezium)
+	// the test helper decodes the timestamp in the local time zone.
+	cfg.TimeZone = time.Local
+	cfg.DebeziumDisableSchema = disableSchema
+	encoder := NewBatchEncoder(cfg, "dbserver1").(*BatchEncoder)
+	encoder.codec.nowFunc = func() time.Time { return time.Unix(1701326309, 0) }
+	return encoder
+}
+
+// requireDebeziumJSONEq checks the output of TiCDC equals to the output of the
+// Debezium MySQL connector, the fields which are different by nature are ignored.
+func requireDebeziumJSONEq(t *testing.T, dbzOutput []byte, tiCDCOutput []byte, disableSchema bool) {
+	var expected, actual map[string]any
+	require.NoError(t, json.Unmarshal(dbzOutput, &expected), "Failed to unmarshal Debezium JSON")
+	require.NoError(t, json.Unmarshal(tiCDCOutput, &actual), "Failed to unmarshal TiCDC JSON")
+
+	for _, obj := range []map[string]any{expected, actual} {
+		if disableSchema {
+			delete(obj, "schema")
+		}
+		if payload, ok := obj["payload"].(map[string]any); ok {
+			delete(payload, "source")
+			delete(payload, "ts_ms")
+		}
+	}
+	require.Equal(t, expected, actual)
+}
+
+func TestDataTypes(t *testing.T) {
+	dataDDL, err := os.ReadFile("testdata/datatype.ddl.sql")
+	require.NoError(t, err)
+	dataDML, err := os.ReadFile("testdata/datatype.dml.sql")
+	require.NoError(t, err)
+	valueDbzOutput, err := os.ReadFile("testdata/datatype.dbz.json")
+	require.NoError(t, err)
+	keyDbzOutput, err := os.ReadFile("testdata/datatype.dbz.key.json")
+	require.NoError(t, err)
+
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(string(dataDDL))
+	helper.Tk().MustExec(`SET sql_mode='';`)
+	helper.Tk().MustExec(`SET time_zone='UTC';`)
+	dmlEvent := helper.DML2Event("test", "foo", string(dataDML))
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	for _, disableSchema := range []bool{false, true} {
+		encoder := newTestEncoder(disableSchema)
+		err = encoder.AppendRowChangedEvent(context.Background(), "", &commonEvent.RowEvent{
+			TableInfo:      helper.GetTableInfo(job),
+			CommitTs:       1,
+			Event:          row,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		})
+		require.NoError(t, err)
+
+		messages := encoder.Build()
+		require.Len(t, messages, 1)
+		requireDebeziumJSONEq(t, valueDbzOutput, messages[0].Value, disableSchema)
+		requireDebeziumJSONEq(t, keyDbzOutput, messages[0].Key, disableSchema)
+	}
+}
+
+func TestEncodeDeleteWithTombstone(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(10))`)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, "foo")`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	called := 0
+	encoder := newTestEncoder(true)
+	err := encoder.AppendRowChangedEvent(context.Background(), "", &commonEvent.RowEvent{
+		TableInfo: helper.GetTableInfo(job),
+		CommitTs:  1,
+		Event: commonEvent.RowChange{
+			PreRow: row.Row,
+			Row:    chunk.Row{},
+		},
+		ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		Callback:       func() { called++ },
+	})
+	require.NoError(t, err)
+
+	messages := encoder.Build()
+	require.Len(t, messages, 2)
+	require.JSONEq(t, `{"payload":{"a":1}}`, string(messages[0].Key))
+	var value map[string]any
+	require.NoError(t, json.Unmarshal(messages[0].Value, &value))
+	payload := value["payload"].(map[string]any)
+	require.Equal(t, "d", payload["op"])
+	require.Nil(t, payload["after"])
+	require.Equal(t, map[string]any{"a": float64(1), "b": "foo"}, payload["before"])
+	require.Nil(t, messages[0].Callback)
+
+	// the tombstone has the same key with a null value.
+	require.Equal(t, messages[0].Key, messages[1].Key)
+	require.Nil(t, messages[1].Value)
+	messages[1].Callback()
+	require.Equal(t, 1, called)
+}
+
+func TestEncodeDDLEvent(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key auto_increment, b varchar(10) not null)`)
+	encoder := newTestEncoder(true)
+	message, err := encoder.EncodeDDLEvent(&commonEvent.DDLEvent{
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		Query:      job.Query,
+		TableInfo:  helper.GetTableInfo(job),
+		FinishedTs: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.MessageTypeDDL, message.Type)
+	require.JSONEq(t, `{"payload":{"databaseName":"test"}}`, string(message.Key))
+
+	var value map[string]any
+	require.NoError(t, json.Unmarshal(message.Value, &value))
+	payload := value["payload"].(map[string]any)
+	delete(payload, "source")
+	expected := `{
+		"ts_ms": 1701326309000,
+		"databaseName": "test",
+		"schemaName": null,
+		"ddl": "create table test.t(a int primary key auto_increment, b varchar(10) not null)",
+		"tableChanges": [{
+			"type": "CREATE",
+			"id": "\"test\".\"t\"",
+			"table": {
+				"defaultCharsetName": "utf8mb4",
+				"primaryKeyColumnNames": ["a"],
+				"columns": [{
+					"name": "a",
+					"jdbcType": 4,
+					"typeName": "INT",
+					"typeExpression": "INT",
+					"charsetName": "binary",
+					"length": 11,
+					"scale": null,
+					"position": 1,
+					"optional": false,
+					"autoIncremented": true,
+					"generated": true
+				}, {
+					"name": "b",
+					"jdbcType": 12,
+					"typeName": "VARCHAR",
+					"typeExpression": "VARCHAR",
+					"charsetName": "utf8mb4",
+					"length": 10,
+					"scale": null,
+					"position": 2,
+					"optional": false,
+					"autoIncremented": false,
+					"generated": false
+				}]
+			}
+		}]
+	}`
+	actual, err := json.Marshal(payload)
+	require.NoError(t, err)
+	require.JSONEq(t, expected, string(actual))
+
+	// the DDL which does not change any table has empty table changes.
+	message, err = encoder.EncodeDDLEvent(&commonEvent.DDLEvent{
+		Type:       byte(timodel.ActionCreateSchema),
+		SchemaName: "test2",
+		Query:      "create database test2",
+		FinishedTs: 2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(message.Value, &value))
+	require.Empty(t, value["payload"].(map[string]any)["tableChanges"])
+}
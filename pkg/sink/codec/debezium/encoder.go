@@ -19,6 +19,7 @@ import (
 	"time"
 
 	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/config"
@@ -30,7 +31,7 @@ import (
 type BatchEncoder struct {
 	messages []*ticommon.Message
 
-	config *ticommon.Config
+	config *newcommon.Config
 	codec  *dbzCodec
 }
 
@@ -40,48 +41,114 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, erro
 	return nil, nil
 }
 
+// EncodeSyncPointEvent implements the RowEventEncoder interface
+func (d *BatchEncoder) EncodeSyncPointEvent(_, _ uint64) (*ticommon.Message, error) {
+	// Currently ignored. Debezium MySQL Connector does not emit such event.
+	return nil, nil
+}
+
+func (d *BatchEncoder) compress(buf *bytes.Buffer) ([]byte, error) {
+	// TODO: Use a streaming compression is better.
+	return newcommon.Compress(
+		d.config.ChangefeedID,
+		d.config.LargeMessageHandle.LargeMessageHandleCompression,
+		buf.Bytes(),
+	)
+}
+
+func (d *BatchEncoder) encodeKey(e *commonEvent.RowEvent) ([]byte, error) {
+	// The key is null if the table has no primary key or not null unique key.
+	if !hasHandleKeyColumns(e.TableInfo) {
+		return nil, nil
+	}
+	keyBuf := bytes.Buffer{}
+	err := d.codec.EncodeKey(e, &keyBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return d.compress(&keyBuf)
+}
+
+func (d *BatchEncoder) encodeValue(e *commonEvent.RowEvent) ([]byte, error) {
+	valueBuf := bytes.Buffer{}
+	err := d.codec.EncodeValue(e, &valueBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return d.compress(&valueBuf)
+}
+
 // AppendRowChangedEvent implements the RowEventEncoder interface
 func (d *BatchEncoder) AppendRowChangedEvent(
 	_ context.Context,
 	_ string,
-	e *commonEvent.RowChangedEvent,
-	callback func(),
+	e *commonEvent.RowEvent,
 ) error {
-	valueBuf := bytes.Buffer{}
-	err := d.codec.EncodeRowChangedEvent(e, &valueBuf)
+	key, err := d.encodeKey(e)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	// TODO: Use a streaming compression is better.
-	value, err := ticommon.Compress(
-		d.config.ChangefeedID,
-		d.config.LargeMessageHandle.LargeMessageHandleCompression,
-		valueBuf.Bytes(),
-	)
+	value, err := d.encodeValue(e)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	m := &ticommon.Message{
-		Key:      nil,
+		Key:      key,
 		Value:    value,
 		Ts:       e.CommitTs,
 		Schema:   e.TableInfo.GetSchemaNamePtr(),
 		Table:    e.TableInfo.GetTableNamePtr(),
 		Type:     model.MessageTypeRow,
 		Protocol: config.ProtocolDebezium,
-		Callback: callback,
+		Callback: e.Callback,
 	}
 	m.IncRowsCount()
 
-	d.messages = append(d.messages, m)
+	if !e.IsDelete() || key == nil {
+		d.messages = append(d.messages, m)
+		return nil
+	}
+
+	// Debezium MySQL Connector emits a tombstone event after the delete event,
+	// which has the same key and a null value, so that Kafka can remove all
+	// messages with the key during log compaction.
+	tombstone := &ticommon.Message{
+		Key:      key,
+		Value:    nil,
+		Ts:       e.CommitTs,
+		Schema:   m.Schema,
+		Table:    m.Table,
+		Type:     model.MessageTypeRow,
+		Protocol: config.ProtocolDebezium,
+		Callback: e.Callback,
+	}
+	// the callback is only called once after both messages are sent.
+	m.Callback = nil
+	d.messages = append(d.messages, m, tombstone)
 	return nil
 }
 
 // EncodeDDLEvent implements the RowEventEncoder interface
 // DDL message unresolved tso
 func (d *BatchEncoder) EncodeDDLEvent(e *commonEvent.DDLEvent) (*ticommon.Message, error) {
-	// Schema Change Events are currently not supported.
-	return nil, nil
+	keyBuf := bytes.Buffer{}
+	if err := d.codec.EncodeDDLKey(e, &keyBuf); err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := d.compress(&keyBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	valueBuf := bytes.Buffer{}
+	if err = d.codec.EncodeDDLValue(e, &valueBuf); err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, err := d.compress(&valueBuf)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ticommon.NewMsg(config.ProtocolDebezium, key, value, e.FinishedTs, model.MessageTypeDDL, &e.SchemaName, &e.TableName), nil
 }
 
 // Build implements the RowEventEncoder interface
@@ -97,8 +164,8 @@ func (d *BatchEncoder) Build() []*ticommon.Message {
 
 func (d *BatchEncoder) Clean() {}
 
-// newBatchEncoder creates a new Debezium BatchEncoder.
-func NewBatchEncoder(c *ticommon.Config, clusterID string) encoder.EventEncoder {
+// NewBatchEncoder creates a new Debezium BatchEncoder.
+func NewBatchEncoder(c *newcommon.Config, clusterID string) encoder.EventEncoder {
 	batch := &BatchEncoder{
 		messages: nil,
 		config:   c,
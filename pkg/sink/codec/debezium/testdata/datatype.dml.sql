@@ -0,0 +1,69 @@
+INSERT INTO foo VALUES (
+  1,
+
+  '2023-11-30',
+  '1000-01-01',
+  '9999-12-31',
+  '0000-00-00',
+
+  '2023-11-30 12:34:56.123456',
+  '2023-11-30 12:34:56.123456',
+  '2023-11-30 12:34:56.123456',
+  '2023-11-30 12:34:56.123456',
+  '2023-11-30 12:34:56.123456',
+  '0000-00-00 00:00:00.000000',
+
+  '12:34:56.123456',
+  '12:34:56.123456',
+  '12:34:56.123456',
+  '12:34:56.123456',
+  '12:34:56.123456',
+  '-12:34:56.123456',
+
+  '2023-11-30 12:34:56.123456',
+  '2023-11-30 12:34:56.123456',
+  '2023-11-30 12:34:56.123456',
+  '2023-11-30 12:34:56.123456',
+  '2023-11-30 12:34:56.123456',
+
+  '2023',
+
+  1,
+  16,
+  16,
+  16,
+
+  'foo',
+  'foo',
+  'foo',
+  'foo',
+  'foo',
+
+  12345.12345,
+  12345.12345,
+
+  12345.12345,
+  12345.12345,
+
+  -2147483648,
+  4294967295,
+
+  -128,
+  255,
+
+  -32768,
+  65535,
+
+  -8388608,
+  16777215,
+
+  -9223372036854775808,
+  18446744073709551615,
+
+  'a',
+  'd',
+  'a,b',
+  'd',
+
+  '["foo"]'
+);
@@ -34,6 +34,11 @@ type EventEncoder interface {
 	// EncodeCheckpointEvent appends a checkpoint event into the batch.
 	// This event will be broadcast to all partitions to signal a global checkpoint.
 	EncodeCheckpointEvent(ts uint64) (*ticommon.Message, error)
+	// EncodeSyncPointEvent encodes a sync point event, the primaryTs and secondaryTs are
+	// the same as the ones recorded in the syncpoint table of the mysql sink.
+	// This event will be broadcast to all partitions to signal a consistent snapshot.
+	// nil is returned if the protocol doesn't support it.
+	EncodeSyncPointEvent(primaryTs, secondaryTs uint64) (*ticommon.Message, error)
 	// EncodeDDLEvent appends a DDL event into the batch
 	EncodeDDLEvent(e *commonEvent.DDLEvent) (*ticommon.Message, error)
 	// AppendRowChangedEvent appends a row changed event into the batch or buffer.
@@ -44,6 +49,14 @@ type EventEncoder interface {
 	Clean()
 }
 
+// TxnEventEncoder is an abstraction for txn events encoder.
+type TxnEventEncoder interface {
+	// AppendTxnEvent append a txn event into the buffer.
+	AppendTxnEvent(*commonEvent.DMLEvent) error
+	// Build builds all the appended txn events into messages, and resets the buffer.
+	Build() []*ticommon.Message
+}
+
 // IsColumnValueEqual checks whether the preValue and updatedValue are equal.
 func IsColumnValueEqual(preValue, updatedValue interface{}) bool {
 	if preValue == nil || updatedValue == nil {
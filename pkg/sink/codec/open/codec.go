@@ -178,6 +178,52 @@ func encodeResolvedTs(ts uint64) ([]byte, []byte, error) {
 	return keyOutput.Bytes(), valueOutput.Bytes(), nil
 }
 
+// encodeSyncPoint encodes the sync point marker message. The message is only sent when
+// send-sync-point-marker is enabled, the consumers of the old versions fail on the
+// unknown type. The key is {"ts":<primary ts>,"t":4}, and the value is
+// {"pts":<primary ts>,"sts":<secondary ts>}, like the ones in the syncpoint table of
+// the mysql sink. All the events whose commit ts is not larger than the primary ts
+// are sent before it in the same partition.
+func encodeSyncPoint(primaryTs, secondaryTs uint64) ([]byte, []byte, error) {
+	keyBuf := &bytes.Buffer{}
+	valueBuf := &bytes.Buffer{}
+	keyWriter := util.BorrowJSONWriter(keyBuf)
+	valueWriter := util.BorrowJSONWriter(valueBuf)
+
+	keyWriter.WriteObject(func() {
+		keyWriter.WriteUint64Field("ts", primaryTs)
+		keyWriter.WriteIntField("t", int(newcommon.MessageTypeSyncPoint))
+	})
+	valueWriter.WriteObject(func() {
+		valueWriter.WriteUint64Field("pts", primaryTs)
+		valueWriter.WriteUint64Field("sts", secondaryTs)
+	})
+
+	util.ReturnJSONWriter(keyWriter)
+	util.ReturnJSONWriter(valueWriter)
+
+	key := keyBuf.Bytes()
+	value := valueBuf.Bytes()
+
+	var keyLenByte [8]byte
+	var valueLenByte [8]byte
+	var versionByte [8]byte
+	binary.BigEndian.PutUint64(keyLenByte[:], uint64(len(key)))
+	binary.BigEndian.PutUint64(valueLenByte[:], uint64(len(value)))
+	binary.BigEndian.PutUint64(versionByte[:], encoder.BatchVersion1)
+
+	keyOutput := new(bytes.Buffer)
+	keyOutput.Write(versionByte[:])
+	keyOutput.Write(keyLenByte[:])
+	keyOutput.Write(key)
+
+	valueOutput := new(bytes.Buffer)
+	valueOutput.Write(valueLenByte[:])
+	valueOutput.Write(value)
+
+	return keyOutput.Bytes(), valueOutput.Bytes(), nil
+}
+
 func writeColumnFieldValue(writer *util.JSONWriter, col *timodel.ColumnInfo, row *chunk.Row, idx int, tableInfo *common.TableInfo) error {
 	colType := col.GetType()
 	flag := *tableInfo.ColumnsFlag[col.ID]
@@ -221,6 +221,14 @@ func TestResolvedTsEvent(t *testing.T) {
 
 }
 
+func TestSyncPointEvent(t *testing.T) {
+	key, value, err := encodeSyncPoint(12345678, 12345679)
+	require.NoError(t, err)
+
+	require.Equal(t, `{"ts":12345678,"t":4}`, string(key)[16:])
+	require.Equal(t, `{"pts":12345678,"sts":12345679}`, string(value)[8:])
+}
+
 func TestEncodeWithColumnSelector(t *testing.T) {
 	helper := pevent.NewEventTestHelper(t)
 	defer helper.Close()
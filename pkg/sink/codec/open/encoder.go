@@ -231,3 +231,19 @@ func (d *BatchEncoder) EncodeCheckpointEvent(ts uint64) (*ticommon.Message, erro
 		Protocol: config.ProtocolOpen,
 	}, nil
 }
+
+// EncodeSyncPointEvent implements the RowEventEncoder interface
+func (d *BatchEncoder) EncodeSyncPointEvent(primaryTs, secondaryTs uint64) (*ticommon.Message, error) {
+	key, value, err := encodeSyncPoint(primaryTs, secondaryTs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &ticommon.Message{
+		Key:      key,
+		Value:    value,
+		Ts:       primaryTs,
+		Type:     model.MessageTypeResolved,
+		Protocol: config.ProtocolOpen,
+	}, nil
+}
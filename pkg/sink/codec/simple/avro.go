@@ -14,17 +14,19 @@
 package simple
 
 import (
-	"sort"
 	"sync"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/types"
-	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 )
 
-func newTableSchemaMap(tableInfo *model.TableInfo) interface{} {
+func newTableSchemaMap(tableInfo *common.TableInfo) interface{} {
 	pkInIndexes := false
 	indexesSchema := make([]interface{}, 0, len(tableInfo.Indices))
 	for _, idx := range tableInfo.Indices {
@@ -65,12 +67,8 @@ func newTableSchemaMap(tableInfo *model.TableInfo) interface{} {
 		}
 	}
 
-	sort.SliceStable(tableInfo.Columns, func(i, j int) bool {
-		return tableInfo.Columns[i].ID < tableInfo.Columns[j].ID
-	})
-
 	columnsSchema := make([]interface{}, 0, len(tableInfo.Columns))
-	for _, col := range tableInfo.Columns {
+	for _, col := range sortColumnsByID(tableInfo.Columns) {
 		mysqlType := map[string]interface{}{
 			"mysqlType": types.TypeToStr(col.GetType(), col.GetCharset()),
 			"charset":   col.GetCharset(),
@@ -110,7 +108,7 @@ func newTableSchemaMap(tableInfo *model.TableInfo) interface{} {
 			"nullable": !mysql.HasNotNullFlag(col.GetFlag()),
 			"default":  nil,
 		}
-		defaultValue := model.GetColumnDefaultValue(col)
+		defaultValue := common.GetColumnDefaultValue(col)
 		if defaultValue != nil {
 			// according to TiDB source code, the default value is converted to string if not nil.
 			column["default"] = map[string]interface{}{
@@ -125,7 +123,7 @@ func newTableSchemaMap(tableInfo *model.TableInfo) interface{} {
 		"database": tableInfo.TableName.Schema,
 		"table":    tableInfo.TableName.Table,
 		"tableID":  tableInfo.ID,
-		"version":  int64(tableInfo.UpdateTS),
+		"version":  int64(tableInfo.GetVersion()),
 		"columns":  columnsSchema,
 		"indexes":  indexesSchema,
 	}
@@ -154,7 +152,7 @@ func newResolvedMessageMap(ts uint64) map[string]interface{} {
 	}
 }
 
-func newBootstrapMessageMap(tableInfo *model.TableInfo) map[string]interface{} {
+func newBootstrapMessageMap(tableInfo *common.TableInfo) map[string]interface{} {
 	m := map[string]interface{}{
 		"version":     defaultVersion,
 		"type":        string(MessageTypeBootstrap),
@@ -176,12 +174,12 @@ func newBootstrapMessageMap(tableInfo *model.TableInfo) map[string]interface{} {
 	}
 }
 
-func newDDLMessageMap(ddl *model.DDLEvent) map[string]interface{} {
+func newDDLMessageMap(ddl *commonEvent.DDLEvent) map[string]interface{} {
 	result := map[string]interface{}{
 		"version":  defaultVersion,
-		"type":     string(getDDLType(ddl.Type)),
+		"type":     string(getDDLType(ddl.GetDDLType())),
 		"sql":      ddl.Query,
-		"commitTs": int64(ddl.CommitTs),
+		"commitTs": int64(ddl.FinishedTs),
 		"buildTs":  time.Now().UnixMilli(),
 	}
 
@@ -191,12 +189,6 @@ func newDDLMessageMap(ddl *model.DDLEvent) map[string]interface{} {
 			"com.pingcap.simple.avro.TableSchema": tableSchema,
 		}
 	}
-	if ddl.PreTableInfo != nil && ddl.PreTableInfo.TableInfo != nil {
-		tableSchema := newTableSchemaMap(ddl.PreTableInfo)
-		result["preTableSchema"] = map[string]interface{}{
-			"com.pingcap.simple.avro.TableSchema": tableSchema,
-		}
-	}
 
 	result = map[string]interface{}{
 		"com.pingcap.simple.avro.DDL": result,
@@ -245,10 +237,10 @@ var (
 )
 
 func (a *avroMarshaller) newDMLMessageMap(
-	event *common.RowChangedEvent,
+	event *commonEvent.RowEvent,
 	onlyHandleKey bool,
 	claimCheckFileName string,
-) map[string]interface{} {
+) (map[string]interface{}, error) {
 	dmlMessagePayload := dmlMessagePayloadPool.Get().(map[string]interface{})
 	dmlMessagePayload["version"] = defaultVersion
 	dmlMessagePayload["database"] = event.TableInfo.GetSchemaName()
@@ -256,7 +248,7 @@ func (a *avroMarshaller) newDMLMessageMap(
 	dmlMessagePayload["tableID"] = event.TableInfo.ID
 	dmlMessagePayload["commitTs"] = int64(event.CommitTs)
 	dmlMessagePayload["buildTs"] = time.Now().UnixMilli()
-	dmlMessagePayload["schemaVersion"] = int64(event.TableInfo.UpdateTS)
+	dmlMessagePayload["schemaVersion"] = int64(event.TableInfo.GetVersion())
 
 	if !a.config.LargeMessageHandle.Disabled() && onlyHandleKey {
 		dmlMessagePayload["handleKeyOnly"] = map[string]interface{}{
@@ -270,32 +262,18 @@ func (a *avroMarshaller) newDMLMessageMap(
 		}
 	}
 
-	if a.config.EnableRowChecksum && event.Checksum != nil {
-		cc := map[string]interface{}{
-			"version":   event.Checksum.Version,
-			"corrupted": event.Checksum.Corrupted,
-			"current":   int64(event.Checksum.Current),
-			"previous":  int64(event.Checksum.Previous),
-		}
-
-		holder := genericMapPool.Get().(map[string]interface{})
-		holder["com.pingcap.simple.avro.Checksum"] = cc
-		dmlMessagePayload["checksum"] = holder
-	}
-
+	var err error
 	if event.IsInsert() {
-		data := a.collectColumns(event.Columns, event.TableInfo, onlyHandleKey)
-		dmlMessagePayload["data"] = data
+		dmlMessagePayload["data"], err = a.collectColumns(event.GetRows(), event.TableInfo, event.ColumnSelector, onlyHandleKey)
 		dmlMessagePayload["type"] = string(DMLTypeInsert)
 	} else if event.IsDelete() {
-		old := a.collectColumns(event.PreColumns, event.TableInfo, onlyHandleKey)
-		dmlMessagePayload["old"] = old
+		dmlMessagePayload["old"], err = a.collectColumns(event.GetPreRows(), event.TableInfo, event.ColumnSelector, onlyHandleKey)
 		dmlMessagePayload["type"] = string(DMLTypeDelete)
 	} else if event.IsUpdate() {
-		data := a.collectColumns(event.Columns, event.TableInfo, onlyHandleKey)
-		dmlMessagePayload["data"] = data
-		old := a.collectColumns(event.PreColumns, event.TableInfo, onlyHandleKey)
-		dmlMessagePayload["old"] = old
+		dmlMessagePayload["data"], err = a.collectColumns(event.GetRows(), event.TableInfo, event.ColumnSelector, onlyHandleKey)
+		if err == nil {
+			dmlMessagePayload["old"], err = a.collectColumns(event.GetPreRows(), event.TableInfo, event.ColumnSelector, onlyHandleKey)
+		}
 		dmlMessagePayload["type"] = string(DMLTypeUpdate)
 	}
 
@@ -310,7 +288,11 @@ func (a *avroMarshaller) newDMLMessageMap(
 	messageHolder := messageHolderPool.Get().(map[string]interface{})
 	messageHolder["com.pingcap.simple.avro.Message"] = dmlMessage
 
-	return messageHolder
+	if err != nil {
+		recycleMap(messageHolder)
+		return nil, errors.Trace(err)
+	}
+	return messageHolder, nil
 }
 
 func recycleMap(m map[string]interface{}) {
@@ -359,26 +341,26 @@ func recycleMap(m map[string]interface{}) {
 }
 
 func (a *avroMarshaller) collectColumns(
-	columns []*common.Column, tableInfo *common.TableInfo, onlyHandleKey bool,
-) map[string]interface{} {
+	row *chunk.Row, tableInfo *common.TableInfo, selector columnselector.Selector, onlyHandleKey bool,
+) (map[string]interface{}, error) {
 	result := rowMapPool.Get().(map[string]interface{})
-	for _, col := range columns {
-		if col != nil {
-			colFlag := col.Flag
-			if onlyHandleKey && !colFlag.IsHandleKey() {
-				continue
-			}
-			colId := tableInfo.ForceGetColumnIDByName(col.Name)
-			colInfo := tableInfo.ForceGetColumnInfo(colId)
-			value, avroType := a.encodeValue4Avro(col.Value, &colInfo.FieldType)
-			holder := genericMapPool.Get().(map[string]interface{})
-			holder[avroType] = value
-			result[colInfo.Name.O] = holder
-		}
-	}
-	return map[string]interface{}{
+	holder := map[string]interface{}{
 		"map": result,
 	}
+	for idx, col := range tableInfo.Columns {
+		if !shouldEncodeColumn(col, tableInfo, selector, onlyHandleKey) {
+			continue
+		}
+		value, err := common.FormatColVal(row, col, idx)
+		if err != nil {
+			return holder, errors.Trace(err)
+		}
+		value, avroType := a.encodeValue4Avro(value, &col.FieldType)
+		colHolder := genericMapPool.Get().(map[string]interface{})
+		colHolder[avroType] = value
+		result[col.Name.O] = colHolder
+	}
+	return holder, nil
 }
 
 func newTableSchemaFromAvroNative(native map[string]interface{}) *TableSchema {
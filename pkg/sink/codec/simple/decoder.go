@@ -23,18 +23,19 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tiflow/cdc/model"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
-	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/util"
 	"go.uber.org/zap"
 )
 
 // Decoder implement the RowEventDecoder interface
 type Decoder struct {
-	config *ticommon.Config
+	config *newcommon.Config
 
 	marshaller marshaller
 
@@ -48,11 +49,11 @@ type Decoder struct {
 	// cachedMessages is used to store the messages which does not have received corresponding table info yet.
 	cachedMessages *list.List
 	// CachedRowChangedEvents are events just decoded from the cachedMessages
-	CachedRowChangedEvents []*common.RowChangedEvent
+	CachedRowChangedEvents []*commonEvent.RowChangedEvent
 }
 
 // NewDecoder returns a new Decoder
-func NewDecoder(ctx context.Context, config *ticommon.Config, db *sql.DB) (*Decoder, error) {
+func NewDecoder(ctx context.Context, config *newcommon.Config, db *sql.DB) (*Decoder, error) {
 	var (
 		externalStorage storage.ExternalStorage
 		err             error
@@ -89,7 +90,7 @@ func (d *Decoder) AddKeyValue(_, value []byte) (err error) {
 		return cerror.ErrCodecDecode.GenWithStack(
 			"Decoder value already exists, not consumed yet")
 	}
-	d.value, err = ticommon.Decompress(d.config.LargeMessageHandle.LargeMessageHandleCompression, value)
+	d.value, err = newcommon.Decompress(d.config.LargeMessageHandle.LargeMessageHandleCompression, value)
 	return err
 }
 
@@ -111,7 +112,8 @@ func (d *Decoder) HasNext() (model.MessageType, bool, error) {
 		return model.MessageTypeRow, true, nil
 	}
 
-	if m.Type == MessageTypeWatermark {
+	// The sync point event is also a watermark of its primary ts.
+	if m.Type == MessageTypeWatermark || m.Type == MessageTypeSyncPoint {
 		return model.MessageTypeResolved, true, nil
 	}
 
@@ -120,7 +122,7 @@ func (d *Decoder) HasNext() (model.MessageType, bool, error) {
 
 // NextResolvedEvent returns the next resolved event if exists
 func (d *Decoder) NextResolvedEvent() (uint64, error) {
-	if d.msg.Type != MessageTypeWatermark {
+	if d.msg.Type != MessageTypeWatermark && d.msg.Type != MessageTypeSyncPoint {
 		return 0, cerror.ErrCodecDecode.GenWithStack(
 			"not found resolved event message")
 	}
@@ -132,7 +134,7 @@ func (d *Decoder) NextResolvedEvent() (uint64, error) {
 }
 
 // NextRowChangedEvent returns the next row changed event if exists
-func (d *Decoder) NextRowChangedEvent() (*common.RowChangedEvent, error) {
+func (d *Decoder) NextRowChangedEvent() (*commonEvent.RowChangedEvent, error) {
 	if d.msg == nil || (d.msg.Data == nil && d.msg.Old == nil) {
 		return nil, cerror.ErrCodecDecode.GenWithStack(
 			"invalid row changed event message")
@@ -165,7 +167,7 @@ func (d *Decoder) NextRowChangedEvent() (*common.RowChangedEvent, error) {
 	return event, err
 }
 
-func (d *Decoder) assembleClaimCheckRowChangedEvent(claimCheckLocation string) (*common.RowChangedEvent, error) {
+func (d *Decoder) assembleClaimCheckRowChangedEvent(claimCheckLocation string) (*commonEvent.RowChangedEvent, error) {
 	_, claimCheckFileName := filepath.Split(claimCheckLocation)
 	data, err := d.storage.ReadFile(context.Background(), claimCheckFileName)
 	if err != nil {
@@ -173,14 +175,14 @@ func (d *Decoder) assembleClaimCheckRowChangedEvent(claimCheckLocation string) (
 	}
 
 	if !d.config.LargeMessageHandle.ClaimCheckRawValue {
-		claimCheckM, err := ticommon.UnmarshalClaimCheckMessage(data)
+		claimCheckM, err := newcommon.UnmarshalClaimCheckMessage(data)
 		if err != nil {
 			return nil, err
 		}
 		data = claimCheckM.Value
 	}
 
-	value, err := ticommon.Decompress(d.config.LargeMessageHandle.LargeMessageHandleCompression, data)
+	value, err := newcommon.Decompress(d.config.LargeMessageHandle.LargeMessageHandleCompression, data)
 	if err != nil {
 		return nil, err
 	}
@@ -194,7 +196,7 @@ func (d *Decoder) assembleClaimCheckRowChangedEvent(claimCheckLocation string) (
 	return d.NextRowChangedEvent()
 }
 
-func (d *Decoder) assembleHandleKeyOnlyRowChangedEvent(m *message) (*common.RowChangedEvent, error) {
+func (d *Decoder) assembleHandleKeyOnlyRowChangedEvent(m *message) (*commonEvent.RowChangedEvent, error) {
 	tableInfo := d.memo.Read(m.Schema, m.Table, m.SchemaVersion)
 	if tableInfo == nil {
 		log.Debug("table info not found for the event, "+
@@ -223,19 +225,19 @@ func (d *Decoder) assembleHandleKeyOnlyRowChangedEvent(m *message) (*common.RowC
 	}
 
 	ctx := context.Background()
-	timezone := ticommon.MustQueryTimezone(ctx, d.upstreamTiDB)
+	timezone := newcommon.MustQueryTimezone(ctx, d.upstreamTiDB)
 	switch m.Type {
 	case DMLTypeInsert:
-		holder := ticommon.MustSnapshotQuery(ctx, d.upstreamTiDB, m.CommitTs, m.Schema, m.Table, m.Data)
+		holder := newcommon.MustSnapshotQuery(ctx, d.upstreamTiDB, m.CommitTs, m.Schema, m.Table, m.Data)
 		result.Data = d.buildData(holder, fieldTypeMap, timezone)
 	case DMLTypeUpdate:
-		holder := ticommon.MustSnapshotQuery(ctx, d.upstreamTiDB, m.CommitTs, m.Schema, m.Table, m.Data)
+		holder := newcommon.MustSnapshotQuery(ctx, d.upstreamTiDB, m.CommitTs, m.Schema, m.Table, m.Data)
 		result.Data = d.buildData(holder, fieldTypeMap, timezone)
 
-		holder = ticommon.MustSnapshotQuery(ctx, d.upstreamTiDB, m.CommitTs-1, m.Schema, m.Table, m.Old)
+		holder = newcommon.MustSnapshotQuery(ctx, d.upstreamTiDB, m.CommitTs-1, m.Schema, m.Table, m.Old)
 		result.Old = d.buildData(holder, fieldTypeMap, timezone)
 	case DMLTypeDelete:
-		holder := ticommon.MustSnapshotQuery(ctx, d.upstreamTiDB, m.CommitTs-1, m.Schema, m.Table, m.Old)
+		holder := newcommon.MustSnapshotQuery(ctx, d.upstreamTiDB, m.CommitTs-1, m.Schema, m.Table, m.Old)
 		result.Old = d.buildData(holder, fieldTypeMap, timezone)
 	}
 
@@ -244,7 +246,7 @@ func (d *Decoder) assembleHandleKeyOnlyRowChangedEvent(m *message) (*common.RowC
 }
 
 func (d *Decoder) buildData(
-	holder *ticommon.ColumnsHolder, fieldTypeMap map[string]*types.FieldType, timezone string,
+	holder *newcommon.ColumnsHolder, fieldTypeMap map[string]*types.FieldType, timezone string,
 ) map[string]interface{} {
 	columnsCount := holder.Length()
 	result := make(map[string]interface{}, columnsCount)
@@ -260,16 +262,17 @@ func (d *Decoder) buildData(
 }
 
 // NextDDLEvent returns the next DDL event if exists
-func (d *Decoder) NextDDLEvent() (*model.DDLEvent, error) {
+func (d *Decoder) NextDDLEvent() (*commonEvent.DDLEvent, error) {
 	if d.msg == nil {
 		return nil, cerror.ErrCodecDecode.GenWithStack(
 			"no message found when decode DDL event")
 	}
 	ddl := newDDLEvent(d.msg)
+	d.memo.Write(ddl.TableInfo)
+	if d.msg.PreTableSchema != nil {
+		d.memo.Write(newTableInfo(d.msg.PreTableSchema))
+	}
 	d.msg = nil
-	// TODO:
-	// d.memo.Write(ddl.TableInfo)
-	// d.memo.Write(ddl.PreTableInfo)
 
 	for ele := d.cachedMessages.Front(); ele != nil; {
 		d.msg = ele.Value.(*message)
@@ -287,7 +290,7 @@ func (d *Decoder) NextDDLEvent() (*model.DDLEvent, error) {
 }
 
 // GetCachedEvents returns the cached events
-func (d *Decoder) GetCachedEvents() []*common.RowChangedEvent {
+func (d *Decoder) GetCachedEvents() []*commonEvent.RowChangedEvent {
 	result := d.CachedRowChangedEvents
 	d.CachedRowChangedEvents = nil
 	return result
@@ -319,7 +322,7 @@ func (m *memoryTableInfoProvider) Write(info *common.TableInfo) {
 	key := tableSchemaKey{
 		schema:  info.TableName.Schema,
 		table:   info.TableName.Table,
-		version: info.UpdateTS,
+		version: info.GetVersion(),
 	}
 
 	_, ok := m.memo[key]
@@ -327,7 +330,7 @@ func (m *memoryTableInfoProvider) Write(info *common.TableInfo) {
 		log.Debug("table info not stored, since it already exists",
 			zap.String("schema", info.TableName.Schema),
 			zap.String("table", info.TableName.Table),
-			zap.Uint64("version", info.UpdateTS))
+			zap.Uint64("version", info.GetVersion()))
 		return
 	}
 
@@ -335,7 +338,7 @@ func (m *memoryTableInfoProvider) Write(info *common.TableInfo) {
 	log.Info("table info stored",
 		zap.String("schema", info.TableName.Schema),
 		zap.String("table", info.TableName.Table),
-		zap.Uint64("version", info.UpdateTS))
+		zap.Uint64("version", info.GetVersion()))
 }
 
 // Read returns the table info with the exact (schema, table, version)
@@ -0,0 +1,299 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simple
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	"github.com/pingcap/ticdc/pkg/config"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/sink/codec/encoder"
+	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tiflow/cdc/model"
+	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
+	"github.com/stretchr/testify/require"
+)
+
+const createTableSQL = `create table test.t(
+	id int primary key,
+	c_varchar varchar(16),
+	c_blob blob,
+	c_decimal decimal(10, 2),
+	c_timestamp timestamp,
+	c_time time(3),
+	c_enum enum('a', 'b', 'c'),
+	c_set set('a', 'b', 'c'),
+	c_bit bit(10),
+	c_json json,
+	c_bigint_unsigned bigint unsigned,
+	c_nullable int)`
+
+const insertSQL = `insert into test.t values (
+	1, "hello", x'0102', 12.34, "2024-01-01 00:00:00", "01:02:03.456",
+	"b", "a,c", b'1000000001', '{"key": "value"}', 18446744073709551615, null)`
+
+func newTestEncoderAndDecoder(
+	t *testing.T, format newcommon.EncodingFormatType,
+) (encoder.EventEncoder, *Decoder) {
+	codecConfig := newcommon.NewConfig(config.ProtocolSimple).
+		WithChangefeedID(common.NewChangeFeedIDWithName("simple-test"))
+	codecConfig.EncodingFormat = format
+
+	ctx := context.Background()
+	enc, err := NewEncoder(ctx, codecConfig)
+	require.NoError(t, err)
+	dec, err := NewDecoder(ctx, codecConfig, nil)
+	require.NoError(t, err)
+	return enc, dec
+}
+
+func newDDLEventForTest(job *timodel.Job, tableInfo *common.TableInfo) *commonEvent.DDLEvent {
+	return &commonEvent.DDLEvent{
+		Type:       byte(job.Type),
+		SchemaName: job.SchemaName,
+		TableName:  job.TableName,
+		Query:      job.Query,
+		TableInfo:  tableInfo,
+		FinishedTs: job.BinlogInfo.FinishedTS,
+	}
+}
+
+func decodeDDLEvent(t *testing.T, dec *Decoder, m *ticommon.Message) *commonEvent.DDLEvent {
+	require.NoError(t, dec.AddKeyValue(m.Key, m.Value))
+	messageType, hasNext, err := dec.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeDDL, messageType)
+
+	event, err := dec.NextDDLEvent()
+	require.NoError(t, err)
+	return event
+}
+
+func decodeRowChangedEvent(t *testing.T, dec *Decoder, m *ticommon.Message) *commonEvent.RowChangedEvent {
+	require.NoError(t, dec.AddKeyValue(m.Key, m.Value))
+	messageType, hasNext, err := dec.HasNext()
+	require.NoError(t, err)
+	require.True(t, hasNext)
+	require.Equal(t, model.MessageTypeRow, messageType)
+
+	event, err := dec.NextRowChangedEvent()
+	require.NoError(t, err)
+	return event
+}
+
+func columnValues(columns []*common.Column) map[string]interface{} {
+	result := make(map[string]interface{}, len(columns))
+	for _, col := range columns {
+		result[col.Name] = col.Value
+	}
+	return result
+}
+
+func requireRowValues(t *testing.T, columns []*common.Column) {
+	values := columnValues(columns)
+	require.Len(t, values, 12)
+	require.EqualValues(t, 1, values["id"])
+	require.Equal(t, "hello", values["c_varchar"])
+	require.Equal(t, []byte{0x01, 0x02}, values["c_blob"])
+	require.Equal(t, "12.34", values["c_decimal"])
+	require.Equal(t, "2024-01-01 00:00:00", values["c_timestamp"])
+	require.Equal(t, "01:02:03.456", values["c_time"])
+	require.EqualValues(t, 2, values["c_enum"])
+	require.EqualValues(t, 5, values["c_set"])
+	require.EqualValues(t, 513, values["c_bit"])
+	require.Equal(t, `{"key": "value"}`, values["c_json"])
+	require.Equal(t, uint64(18446744073709551615), values["c_bigint_unsigned"])
+	require.Nil(t, values["c_nullable"])
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(createTableSQL)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", insertSQL)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	for _, format := range []newcommon.EncodingFormatType{
+		newcommon.EncodingFormatJSON,
+		newcommon.EncodingFormatAvro,
+	} {
+		enc, dec := newTestEncoderAndDecoder(t, format)
+
+		// the bootstrap message carries the table schema, the decoder uses it to decode the DML events.
+		m, err := enc.EncodeDDLEvent(&commonEvent.DDLEvent{
+			SchemaName:  "test",
+			TableName:   "t",
+			TableInfo:   tableInfo,
+			IsBootstrap: true,
+		})
+		require.NoError(t, err)
+		bootstrap := decodeDDLEvent(t, dec, m)
+		require.True(t, bootstrap.IsBootstrap)
+		require.Equal(t, "test", bootstrap.SchemaName)
+		require.Equal(t, "t", bootstrap.TableName)
+		require.Equal(t, tableInfo.GetVersion(), bootstrap.TableInfo.GetVersion())
+		require.Len(t, bootstrap.TableInfo.Columns, len(tableInfo.Columns))
+		require.Equal(t, []string{"id"}, bootstrap.TableInfo.GetPrimaryKeyColumnNames())
+
+		m, err = enc.EncodeDDLEvent(newDDLEventForTest(job, tableInfo))
+		require.NoError(t, err)
+		require.Equal(t, job.BinlogInfo.FinishedTS, m.Ts)
+		ddl := decodeDDLEvent(t, dec, m)
+		require.False(t, ddl.IsBootstrap)
+		require.Equal(t, job.Query, ddl.Query)
+		require.Equal(t, job.BinlogInfo.FinishedTS, ddl.FinishedTs)
+
+		called := false
+		err = enc.AppendRowChangedEvent(context.Background(), "", &commonEvent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       job.BinlogInfo.FinishedTS + 1,
+			Event:          row,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+			Callback:       func() { called = true },
+		})
+		require.NoError(t, err)
+		messages := enc.Build()
+		require.Len(t, messages, 1)
+		messages[0].Callback()
+		require.True(t, called)
+
+		insert := decodeRowChangedEvent(t, dec, messages[0])
+		require.True(t, insert.IsInsert())
+		require.Equal(t, job.BinlogInfo.FinishedTS+1, insert.CommitTs)
+		require.Equal(t, "test", insert.TableInfo.GetSchemaName())
+		require.Equal(t, "t", insert.TableInfo.GetTableName())
+		requireRowValues(t, insert.Columns)
+
+		// the delete event only carries the old value.
+		err = enc.AppendRowChangedEvent(context.Background(), "", &commonEvent.RowEvent{
+			TableInfo: tableInfo,
+			CommitTs:  job.BinlogInfo.FinishedTS + 2,
+			Event: commonEvent.RowChange{
+				PreRow: row.Row,
+			},
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		})
+		require.NoError(t, err)
+		messages = enc.Build()
+		require.Len(t, messages, 1)
+		deleted := decodeRowChangedEvent(t, dec, messages[0])
+		require.True(t, deleted.IsDelete())
+		requireRowValues(t, deleted.PreColumns)
+
+		m, err = enc.EncodeCheckpointEvent(job.BinlogInfo.FinishedTS + 3)
+		require.NoError(t, err)
+		require.NoError(t, dec.AddKeyValue(m.Key, m.Value))
+		messageType, hasNext, err := dec.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeResolved, messageType)
+		ts, err := dec.NextResolvedEvent()
+		require.NoError(t, err)
+		require.Equal(t, job.BinlogInfo.FinishedTS+3, ts)
+
+		// the sync point event is only supported by the json format.
+		m, err = enc.EncodeSyncPointEvent(job.BinlogInfo.FinishedTS+4, job.BinlogInfo.FinishedTS+5)
+		require.NoError(t, err)
+		if format == newcommon.EncodingFormatAvro {
+			require.Nil(t, m)
+			continue
+		}
+		require.NoError(t, dec.AddKeyValue(m.Key, m.Value))
+		messageType, hasNext, err = dec.HasNext()
+		require.NoError(t, err)
+		require.True(t, hasNext)
+		require.Equal(t, model.MessageTypeResolved, messageType)
+		require.Equal(t, MessageTypeSyncPoint, dec.msg.Type)
+		require.Equal(t, job.BinlogInfo.FinishedTS+5, dec.msg.SecondaryTs)
+		ts, err = dec.NextResolvedEvent()
+		require.NoError(t, err)
+		require.Equal(t, job.BinlogInfo.FinishedTS+4, ts)
+	}
+}
+
+func TestDMLEventCachedUntilSchemaReceived(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	job := helper.DDL2Job(`create table test.t(a int primary key, b varchar(10))`)
+	tableInfo := helper.GetTableInfo(job)
+	dmlEvent := helper.DML2Event("test", "t", `insert into test.t values (1, "a")`)
+	row, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+
+	for _, format := range []newcommon.EncodingFormatType{
+		newcommon.EncodingFormatJSON,
+		newcommon.EncodingFormatAvro,
+	} {
+		enc, dec := newTestEncoderAndDecoder(t, format)
+
+		err := enc.AppendRowChangedEvent(context.Background(), "", &commonEvent.RowEvent{
+			TableInfo:      tableInfo,
+			CommitTs:       job.BinlogInfo.FinishedTS + 1,
+			Event:          row,
+			ColumnSelector: columnselector.NewDefaultColumnSelector(),
+		})
+		require.NoError(t, err)
+		messages := enc.Build()
+		require.Len(t, messages, 1)
+
+		// the schema of the table version is unknown yet, the event is cached by the decoder.
+		event := decodeRowChangedEvent(t, dec, messages[0])
+		require.Nil(t, event)
+		require.Empty(t, dec.GetCachedEvents())
+
+		m, err := enc.EncodeDDLEvent(&commonEvent.DDLEvent{
+			SchemaName:  "test",
+			TableName:   "t",
+			TableInfo:   tableInfo,
+			IsBootstrap: true,
+		})
+		require.NoError(t, err)
+		decodeDDLEvent(t, dec, m)
+
+		cached := dec.GetCachedEvents()
+		require.Len(t, cached, 1)
+		require.True(t, cached[0].IsInsert())
+		values := columnValues(cached[0].Columns)
+		require.EqualValues(t, 1, values["a"])
+		require.Equal(t, "a", values["b"])
+	}
+}
+
+func TestTableInfoColumnsNotReordered(t *testing.T) {
+	helper := commonEvent.NewEventTestHelper(t)
+	defer helper.Close()
+	helper.Tk().MustExec("use test")
+
+	helper.DDL2Job(`create table test.t(a int primary key, b int)`)
+	job := helper.DDL2Job(`alter table test.t add column c int first`)
+	tableInfo := helper.GetTableInfo(job)
+	require.Equal(t, "c", tableInfo.Columns[0].Name.O)
+
+	schema := newTableSchema(tableInfo)
+	require.Equal(t, "a", schema.Columns[0].Name)
+	require.Equal(t, "c", schema.Columns[2].Name)
+	// the columns of the shared table info keep the original order.
+	require.Equal(t, "c", tableInfo.Columns[0].Name.O)
+}
@@ -18,10 +18,9 @@ import (
 	"encoding/json"
 
 	"github.com/linkedin/goavro/v2"
-	"github.com/pingcap/ticdc/pkg/common"
-	"github.com/pingcap/tiflow/cdc/model"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/errors"
-	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 )
 
 //go:embed message.json
@@ -31,36 +30,40 @@ type marshaller interface {
 	// MarshalCheckpoint marshals the checkpoint ts into bytes.
 	MarshalCheckpoint(ts uint64) ([]byte, error)
 
+	// MarshalSyncPoint marshals the sync point into bytes,
+	// nil is returned if the encoding format doesn't support it.
+	MarshalSyncPoint(primaryTs, secondaryTs uint64) ([]byte, error)
+
 	// MarshalDDLEvent marshals the DDL event into bytes.
-	MarshalDDLEvent(event *model.DDLEvent) ([]byte, error)
+	MarshalDDLEvent(event *commonEvent.DDLEvent) ([]byte, error)
 
 	// MarshalRowChangedEvent marshals the row changed event into bytes.
-	MarshalRowChangedEvent(event *common.RowChangedEvent,
+	MarshalRowChangedEvent(event *commonEvent.RowEvent,
 		handleKeyOnly bool, claimCheckFileName string) ([]byte, error)
 
 	// Unmarshal the bytes into the given value.
 	Unmarshal(data []byte, v any) error
 }
 
-func newMarshaller(config *ticommon.Config) (marshaller, error) {
+func newMarshaller(config *newcommon.Config) (marshaller, error) {
 	var (
 		result marshaller
 		err    error
 	)
 	switch config.EncodingFormat {
-	case ticommon.EncodingFormatJSON:
+	case newcommon.EncodingFormatJSON:
 		result = newJSONMarshaller(config)
-	case ticommon.EncodingFormatAvro:
+	case newcommon.EncodingFormatAvro:
 		result, err = newAvroMarshaller(config, string(avroSchemaBytes))
 	}
 	return result, errors.Trace(err)
 }
 
 type JSONMarshaller struct {
-	config *ticommon.Config
+	config *newcommon.Config
 }
 
-func newJSONMarshaller(config *ticommon.Config) *JSONMarshaller {
+func newJSONMarshaller(config *newcommon.Config) *JSONMarshaller {
 	return &JSONMarshaller{
 		config: config,
 	}
@@ -73,8 +76,15 @@ func (m *JSONMarshaller) MarshalCheckpoint(ts uint64) ([]byte, error) {
 	return result, errors.WrapError(errors.ErrEncodeFailed, err)
 }
 
+// MarshalSyncPoint implement the marshaller interface
+func (m *JSONMarshaller) MarshalSyncPoint(primaryTs, secondaryTs uint64) ([]byte, error) {
+	msg := newSyncPointMessage(primaryTs, secondaryTs)
+	result, err := json.Marshal(msg)
+	return result, errors.WrapError(errors.ErrEncodeFailed, err)
+}
+
 // MarshalDDLEvent implement the marshaller interface
-func (m *JSONMarshaller) MarshalDDLEvent(event *model.DDLEvent) ([]byte, error) {
+func (m *JSONMarshaller) MarshalDDLEvent(event *commonEvent.DDLEvent) ([]byte, error) {
 	var msg *message
 	if event.IsBootstrap {
 		msg = newBootstrapMessage(event.TableInfo)
@@ -87,10 +97,13 @@ func (m *JSONMarshaller) MarshalDDLEvent(event *model.DDLEvent) ([]byte, error)
 
 // MarshalRowChangedEvent implement the marshaller interface
 func (m *JSONMarshaller) MarshalRowChangedEvent(
-	event *common.RowChangedEvent,
+	event *commonEvent.RowEvent,
 	handleKeyOnly bool, claimCheckFileName string,
 ) ([]byte, error) {
-	msg := m.newDMLMessage(event, handleKeyOnly, claimCheckFileName)
+	msg, err := m.newDMLMessage(event, handleKeyOnly, claimCheckFileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	value, err := json.Marshal(msg)
 	return value, errors.WrapError(errors.ErrEncodeFailed, err)
 }
@@ -102,10 +115,10 @@ func (m *JSONMarshaller) Unmarshal(data []byte, v any) error {
 
 type avroMarshaller struct {
 	codec  *goavro.Codec
-	config *ticommon.Config
+	config *newcommon.Config
 }
 
-func newAvroMarshaller(config *ticommon.Config, schema string) (*avroMarshaller, error) {
+func newAvroMarshaller(config *newcommon.Config, schema string) (*avroMarshaller, error) {
 	codec, err := goavro.NewCodec(schema)
 	return &avroMarshaller{
 		codec:  codec,
@@ -120,8 +133,14 @@ func (m *avroMarshaller) MarshalCheckpoint(ts uint64) ([]byte, error) {
 	return result, errors.WrapError(errors.ErrEncodeFailed, err)
 }
 
+// MarshalSyncPoint implement the marshaller interface,
+// the sync point is not defined in the avro schema, so it's skipped.
+func (m *avroMarshaller) MarshalSyncPoint(_, _ uint64) ([]byte, error) {
+	return nil, nil
+}
+
 // MarshalDDLEvent implement the marshaller interface
-func (m *avroMarshaller) MarshalDDLEvent(event *model.DDLEvent) ([]byte, error) {
+func (m *avroMarshaller) MarshalDDLEvent(event *commonEvent.DDLEvent) ([]byte, error) {
 	var msg map[string]interface{}
 	if event.IsBootstrap {
 		msg = newBootstrapMessageMap(event.TableInfo)
@@ -134,10 +153,13 @@ func (m *avroMarshaller) MarshalDDLEvent(event *model.DDLEvent) ([]byte, error)
 
 // MarshalRowChangedEvent implement the marshaller interface
 func (m *avroMarshaller) MarshalRowChangedEvent(
-	event *common.RowChangedEvent,
+	event *commonEvent.RowEvent,
 	handleKeyOnly bool, claimCheckFileName string,
 ) ([]byte, error) {
-	msg := m.newDMLMessageMap(event, handleKeyOnly, claimCheckFileName)
+	msg, err := m.newDMLMessageMap(event, handleKeyOnly, claimCheckFileName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	value, err := m.codec.BinaryFromNative(nil, msg)
 	recycleMap(msg)
 	return value, errors.WrapError(errors.ErrEncodeFailed, err)
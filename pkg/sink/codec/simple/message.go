@@ -21,17 +21,20 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/log"
 	"github.com/pingcap/ticdc/pkg/common"
-	commonNew "github.com/pingcap/ticdc/pkg/sink/codec/common"
+	"github.com/pingcap/ticdc/pkg/common/columnselector"
+	commonEvent "github.com/pingcap/ticdc/pkg/common/event"
+	newcommon "github.com/pingcap/ticdc/pkg/sink/codec/common"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	pmodel "github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/types"
 	tiTypes "github.com/pingcap/tidb/pkg/types"
-	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/integrity"
-	ticommon "github.com/pingcap/tiflow/pkg/sink/codec/common"
 	"github.com/pingcap/tiflow/pkg/sink/codec/utils"
 	"go.uber.org/zap"
 )
@@ -46,6 +49,10 @@ type MessageType string
 const (
 	// MessageTypeWatermark is the type of the watermark event.
 	MessageTypeWatermark MessageType = "WATERMARK"
+	// MessageTypeSyncPoint is the type of the sync point event, it's only sent
+	// when send-sync-point-marker is enabled. The commitTs is the primary ts and
+	// the secondaryTs is set, it's also a watermark of the primary ts.
+	MessageTypeSyncPoint MessageType = "SYNCPOINT"
 	// MessageTypeBootstrap is the type of the bootstrap event.
 	MessageTypeBootstrap MessageType = "BOOTSTRAP"
 	// MessageTypeDDL is the type of the ddl event.
@@ -146,9 +153,9 @@ func newColumnSchema(col *timodel.ColumnInfo) *columnSchema {
 		tp.Decimal = col.GetDecimal()
 	}
 
-	defaultValue := model.GetColumnDefaultValue(col)
+	defaultValue := common.GetColumnDefaultValue(col)
 	if defaultValue != nil && col.GetType() == mysql.TypeBit {
-		defaultValue = ticommon.MustBinaryLiteralToInt([]byte(defaultValue.(string)))
+		defaultValue = newcommon.MustBinaryLiteralToInt([]byte(defaultValue.(string)))
 	}
 	return &columnSchema{
 		Name:     col.Name.O,
@@ -164,7 +171,7 @@ func newTiColumnInfo(
 ) *timodel.ColumnInfo {
 	col := new(timodel.ColumnInfo)
 	col.ID = colID
-	col.Name = timodel.NewCIStr(column.Name)
+	col.Name = pmodel.NewCIStr(column.Name)
 
 	col.FieldType = *types.NewFieldType(types.StrToType(column.DataType.MySQLType))
 	col.SetCharset(column.DataType.Charset)
@@ -258,14 +265,14 @@ func newTiIndexInfo(indexSchema *IndexSchema, columns []*timodel.ColumnInfo, ind
 			}
 		}
 		indexColumns[i] = &timodel.IndexColumn{
-			Name:   timodel.NewCIStr(col),
+			Name:   pmodel.NewCIStr(col),
 			Offset: offset,
 		}
 	}
 
 	return &timodel.IndexInfo{
 		ID:      indexID,
-		Name:    timodel.NewCIStr(indexSchema.Name),
+		Name:    pmodel.NewCIStr(indexSchema.Name),
 		Columns: indexColumns,
 		Unique:  indexSchema.Unique,
 		Primary: indexSchema.Primary,
@@ -282,7 +289,7 @@ type TableSchema struct {
 	Indexes []*IndexSchema  `json:"indexes"`
 }
 
-func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
+func newTableSchema(tableInfo *common.TableInfo) *TableSchema {
 	pkInIndexes := false
 	indexes := make([]*IndexSchema, 0, len(tableInfo.Indices))
 	for _, idx := range tableInfo.Indices {
@@ -308,12 +315,8 @@ func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
 		}
 	}
 
-	sort.SliceStable(tableInfo.Columns, func(i, j int) bool {
-		return tableInfo.Columns[i].ID < tableInfo.Columns[j].ID
-	})
-
 	columns := make([]*columnSchema, 0, len(tableInfo.Columns))
-	for _, col := range tableInfo.Columns {
+	for _, col := range sortColumnsByID(tableInfo.Columns) {
 		colSchema := newColumnSchema(col)
 		columns = append(columns, colSchema)
 	}
@@ -322,26 +325,34 @@ func newTableSchema(tableInfo *model.TableInfo) *TableSchema {
 		Schema:  tableInfo.TableName.Schema,
 		Table:   tableInfo.TableName.Table,
 		TableID: tableInfo.ID,
-		Version: tableInfo.UpdateTS,
+		Version: tableInfo.GetVersion(),
 		Columns: columns,
 		Indexes: indexes,
 	}
 }
 
+// sortColumnsByID returns a copy of the columns sorted by the column ID.
+// The table info is shared with the row events, whose values are laid out by the
+// column offset, so the columns of the table info must not be reordered in place.
+func sortColumnsByID(columns []*timodel.ColumnInfo) []*timodel.ColumnInfo {
+	result := make([]*timodel.ColumnInfo, len(columns))
+	copy(result, columns)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
 // newTableInfo converts from TableSchema to TableInfo.
-func newTableInfo(m *TableSchema) *model.TableInfo {
-	var (
-		database      string
-		schemaVersion uint64
-	)
+func newTableInfo(m *TableSchema) *common.TableInfo {
+	var database string
 
 	tidbTableInfo := &timodel.TableInfo{}
 	if m != nil {
 		database = m.Schema
-		schemaVersion = m.Version
 
 		tidbTableInfo.ID = m.TableID
-		tidbTableInfo.Name = timodel.NewCIStr(m.Table)
+		tidbTableInfo.Name = pmodel.NewCIStr(m.Table)
 		tidbTableInfo.UpdateTS = m.Version
 
 		nextMockID := int64(100)
@@ -358,34 +369,27 @@ func newTableInfo(m *TableSchema) *model.TableInfo {
 			mockIndexID += 1
 		}
 	}
-	return model.WrapTableInfo(100, database, schemaVersion, tidbTableInfo)
+	return common.WrapTableInfo(100, database, tidbTableInfo)
 }
 
 // newDDLEvent converts from message to DDLEvent.
-func newDDLEvent(msg *message) *model.DDLEvent {
-	var (
-		tableInfo    *model.TableInfo
-		preTableInfo *model.TableInfo
-	)
-
-	tableInfo = newTableInfo(msg.TableSchema)
-	if msg.PreTableSchema != nil {
-		preTableInfo = newTableInfo(msg.PreTableSchema)
-	}
-	return &model.DDLEvent{
-		StartTs:      msg.CommitTs,
-		CommitTs:     msg.CommitTs,
-		TableInfo:    tableInfo,
-		PreTableInfo: preTableInfo,
-		Query:        msg.SQL,
+func newDDLEvent(msg *message) *commonEvent.DDLEvent {
+	tableInfo := newTableInfo(msg.TableSchema)
+	return &commonEvent.DDLEvent{
+		SchemaName:  tableInfo.TableName.Schema,
+		TableName:   tableInfo.TableName.Table,
+		TableInfo:   tableInfo,
+		Query:       msg.SQL,
+		FinishedTs:  msg.CommitTs,
+		IsBootstrap: msg.Type == MessageTypeBootstrap,
 	}
 }
 
 // buildRowChangedEvent converts from message to RowChangedEvent.
 func buildRowChangedEvent(
 	msg *message, tableInfo *common.TableInfo, enableRowChecksum bool, db *sql.DB,
-) (*common.RowChangedEvent, error) {
-	result := &common.RowChangedEvent{
+) (*commonEvent.RowChangedEvent, error) {
+	result := &commonEvent.RowChangedEvent{
 		CommitTs:        msg.CommitTs,
 		PhysicalTableID: msg.TableID,
 		TableInfo:       tableInfo,
@@ -401,7 +405,7 @@ func buildRowChangedEvent(
 			Version:   msg.Checksum.Version,
 		}
 
-		err := commonNew.VerifyChecksum(result, db)
+		err := newcommon.VerifyChecksum(result, db)
 		if err != nil || msg.Checksum.Corrupted {
 			log.Warn("consumer detect checksum corrupted",
 				zap.String("schema", msg.Schema), zap.String("table", msg.Table))
@@ -411,19 +415,17 @@ func buildRowChangedEvent(
 	}
 
 	for _, col := range result.Columns {
-		id := tableInfo.ForceGetColumnIDByName(col.Name)
-		adjustTimestampValue(col, tableInfo.ForceGetColumnInfo(id).FieldType)
+		adjustTimestampValue(col)
 	}
 	for _, col := range result.PreColumns {
-		id := tableInfo.ForceGetColumnIDByName(col.Name)
-		adjustTimestampValue(col, tableInfo.ForceGetColumnInfo(id).FieldType)
+		adjustTimestampValue(col)
 	}
 
 	return result, nil
 }
 
-func adjustTimestampValue(column *common.Column, flag types.FieldType) {
-	if flag.GetType() != mysql.TypeTimestamp {
+func adjustTimestampValue(column *common.Column) {
+	if column.Type != mysql.TypeTimestamp {
 		return
 	}
 	if column.Value != nil {
@@ -453,12 +455,16 @@ func decodeColumns(
 				zap.String("column", info.Name.O))
 			continue
 		}
-		columnID := tableInfo.ForceGetColumnIDByName(info.Name.O)
-		col := decodeColumn(value, columnID, &info.FieldType)
+		col := decodeColumn(value, &info.FieldType)
 		if col == nil {
 			log.Panic("cannot decode column",
 				zap.String("name", info.Name.O), zap.Any("data", value))
 		}
+		col.Name = info.Name.O
+		col.Type = info.GetType()
+		col.Charset = info.GetCharset()
+		col.Collation = info.GetCollate()
+		col.Flag = *tableInfo.ForceGetColumnFlagType(info.ID)
 
 		result = append(result, col)
 	}
@@ -483,6 +489,8 @@ type message struct {
 	SQL      string `json:"sql,omitempty"`
 	CommitTs uint64 `json:"commitTs"`
 	BuildTs  int64  `json:"buildTs"`
+	// SecondaryTs is only for the sync point event, whose CommitTs is the primary ts.
+	SecondaryTs uint64 `json:"secondaryTs,omitempty"`
 	// SchemaVersion is for the DML event.
 	SchemaVersion uint64 `json:"schemaVersion,omitempty"`
 
@@ -513,7 +521,17 @@ func newResolvedMessage(ts uint64) *message {
 	}
 }
 
-func newBootstrapMessage(tableInfo *model.TableInfo) *message {
+func newSyncPointMessage(primaryTs, secondaryTs uint64) *message {
+	return &message{
+		Version:     defaultVersion,
+		Type:        MessageTypeSyncPoint,
+		CommitTs:    primaryTs,
+		BuildTs:     time.Now().UnixMilli(),
+		SecondaryTs: secondaryTs,
+	}
+}
+
+func newBootstrapMessage(tableInfo *common.TableInfo) *message {
 	schema := newTableSchema(tableInfo)
 	msg := &message{
 		Version:     defaultVersion,
@@ -524,35 +542,29 @@ func newBootstrapMessage(tableInfo *model.TableInfo) *message {
 	return msg
 }
 
-func newDDLMessage(ddl *model.DDLEvent) *message {
-	var (
-		schema    *TableSchema
-		preSchema *TableSchema
-	)
+func newDDLMessage(ddl *commonEvent.DDLEvent) *message {
+	var schema *TableSchema
 	// the tableInfo maybe nil if the DDL is `drop database`
 	if ddl.TableInfo != nil && ddl.TableInfo.TableInfo != nil {
 		schema = newTableSchema(ddl.TableInfo)
 	}
-	// `PreTableInfo` may not exist for some DDL, such as `create table`
-	if ddl.PreTableInfo != nil && ddl.PreTableInfo.TableInfo != nil {
-		preSchema = newTableSchema(ddl.PreTableInfo)
-	}
+	// the DDL event only carries the table info after the DDL executed,
+	// so the `PreTableSchema` is not set.
 	msg := &message{
-		Version:        defaultVersion,
-		Type:           getDDLType(ddl.Type),
-		CommitTs:       ddl.CommitTs,
-		BuildTs:        time.Now().UnixMilli(),
-		SQL:            ddl.Query,
-		TableSchema:    schema,
-		PreTableSchema: preSchema,
+		Version:     defaultVersion,
+		Type:        getDDLType(ddl.GetDDLType()),
+		CommitTs:    ddl.FinishedTs,
+		BuildTs:     time.Now().UnixMilli(),
+		SQL:         ddl.Query,
+		TableSchema: schema,
 	}
 	return msg
 }
 
 func (a *JSONMarshaller) newDMLMessage(
-	event *common.RowChangedEvent,
+	event *commonEvent.RowEvent,
 	onlyHandleKey bool, claimCheckFileName string,
-) *message {
+) (*message, error) {
 	m := &message{
 		Version:            defaultVersion,
 		Schema:             event.TableInfo.GetSchemaName(),
@@ -560,49 +572,62 @@ func (a *JSONMarshaller) newDMLMessage(
 		TableID:            event.TableInfo.ID,
 		CommitTs:           event.CommitTs,
 		BuildTs:            time.Now().UnixMilli(),
-		SchemaVersion:      event.TableInfo.UpdateTS,
+		SchemaVersion:      event.TableInfo.GetVersion(),
 		HandleKeyOnly:      onlyHandleKey,
 		ClaimCheckLocation: claimCheckFileName,
 	}
+	var err error
 	if event.IsInsert() {
 		m.Type = DMLTypeInsert
-		m.Data = a.formatColumns(event.Columns, event.TableInfo, onlyHandleKey)
+		m.Data, err = a.formatColumns(event.GetRows(), event.TableInfo, event.ColumnSelector, onlyHandleKey)
 	} else if event.IsDelete() {
 		m.Type = DMLTypeDelete
-		m.Old = a.formatColumns(event.PreColumns, event.TableInfo, onlyHandleKey)
+		m.Old, err = a.formatColumns(event.GetPreRows(), event.TableInfo, event.ColumnSelector, onlyHandleKey)
 	} else if event.IsUpdate() {
 		m.Type = DMLTypeUpdate
-		m.Data = a.formatColumns(event.Columns, event.TableInfo, onlyHandleKey)
-		m.Old = a.formatColumns(event.PreColumns, event.TableInfo, onlyHandleKey)
-	}
-	if a.config.EnableRowChecksum && event.Checksum != nil {
-		m.Checksum = &checksum{
-			Version:   event.Checksum.Version,
-			Corrupted: event.Checksum.Corrupted,
-			Current:   event.Checksum.Current,
-			Previous:  event.Checksum.Previous,
+		m.Data, err = a.formatColumns(event.GetRows(), event.TableInfo, event.ColumnSelector, onlyHandleKey)
+		if err != nil {
+			return nil, err
 		}
+		m.Old, err = a.formatColumns(event.GetPreRows(), event.TableInfo, event.ColumnSelector, onlyHandleKey)
 	}
-
-	return m
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 func (a *JSONMarshaller) formatColumns(
-	columns []*common.Column, tableInfo *common.TableInfo, onlyHandleKey bool,
-) map[string]interface{} {
-	result := make(map[string]interface{}, len(columns))
-	colInfos := tableInfo.GetColInfosForRowChangedEvent()
-	for i, col := range columns {
-		if col != nil {
-			flag := col.Flag
-			if onlyHandleKey && !flag.IsHandleKey() {
-				continue
-			}
-			value := encodeValue(col.Value, colInfos[i].Ft, a.config.TimeZone.String())
-			result[col.Name] = value
+	row *chunk.Row, tableInfo *common.TableInfo, selector columnselector.Selector, onlyHandleKey bool,
+) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(tableInfo.Columns))
+	for idx, col := range tableInfo.Columns {
+		if !shouldEncodeColumn(col, tableInfo, selector, onlyHandleKey) {
+			continue
+		}
+		value, err := common.FormatColVal(row, col, idx)
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
+		result[col.Name.O] = encodeValue(value, &col.FieldType, a.config.TimeZone.String())
 	}
-	return result
+	return result, nil
+}
+
+// shouldEncodeColumn returns true if the column should be encoded into the DML message.
+func shouldEncodeColumn(
+	col *timodel.ColumnInfo, tableInfo *common.TableInfo, selector columnselector.Selector, onlyHandleKey bool,
+) bool {
+	if col == nil || !common.IsColCDCVisible(col) {
+		return false
+	}
+	if selector != nil && !selector.Select(col) {
+		return false
+	}
+	if onlyHandleKey && !tableInfo.ForceGetColumnFlagType(col.ID).IsHandleKey() {
+		return false
+	}
+	return true
 }
 
 func (a *avroMarshaller) encodeValue4Avro(
@@ -660,7 +685,7 @@ func encodeValue(
 	case mysql.TypeBit:
 		switch v := value.(type) {
 		case []uint8:
-			value = ticommon.MustBinaryLiteralToInt(v)
+			value = newcommon.MustBinaryLiteralToInt(v)
 		default:
 		}
 	case mysql.TypeTimestamp:
@@ -725,7 +750,7 @@ func encodeValue(
 	return result
 }
 
-func decodeColumn(value interface{}, id int64, fieldType *types.FieldType) *common.Column {
+func decodeColumn(value interface{}, fieldType *types.FieldType) *common.Column {
 	result := &common.Column{
 		Value: value,
 	}
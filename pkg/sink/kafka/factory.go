@@ -36,6 +36,9 @@ type Factory interface {
 	SyncProducer(ctx context.Context) (SyncProducer, error)
 	// AsyncProducer creates an async producer to writer message to kafka
 	AsyncProducer(ctx context.Context, failpointCh chan error) (tikafka.AsyncProducer, error)
+	// TransactionalProducer creates a producer to write messages to kafka in transactions,
+	// the marker topic is created if it doesn't exist.
+	TransactionalProducer(ctx context.Context, transactionalID string, markerTopic string) (TransactionalProducer, error)
 	// MetricsCollector returns the kafka metrics collector
 	MetricsCollector(role util.Role, adminClient tikafka.ClusterAdminClient) tikafka.MetricsCollector
 }
@@ -64,6 +67,36 @@ type SyncProducer interface {
 	Close()
 }
 
+// TransactionalMessage is a message sent in a transaction.
+type TransactionalMessage struct {
+	Topic     string
+	Partition int32
+	Key       []byte
+	Value     []byte
+}
+
+// TransactionalProducer is the kafka producer which sends messages in transactions,
+// the messages are visible to the `read_committed` consumers only after the transaction is committed.
+type TransactionalProducer interface {
+	// SendTransaction sends the messages and the marker in one transaction and commits it,
+	// either all of them or none of them are committed. The marker is sent to the marker
+	// topic and keyed by the transactional id.
+	SendTransaction(ctx context.Context, messages []*TransactionalMessage, marker []byte) error
+
+	// ReadMarkers returns the last committed marker of each transactional id in the marker topic.
+	ReadMarkers(ctx context.Context) (map[string][]byte, error)
+
+	// TransactionalID returns the transactional id of the producer.
+	TransactionalID() string
+
+	// Fence initializes the given transactional id to abort its transaction not committed,
+	// the producer with that id can't commit any transactions after it's fenced.
+	Fence(ctx context.Context, transactionalID string) error
+
+	// Close shuts down the producer, the transaction not committed is aborted.
+	Close()
+}
+
 // // AsyncProducer is the kafka async producer
 // type AsyncProducer interface {
 // 	// Close shuts down the producer and waits for any buffered messages to be
@@ -0,0 +1,135 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"sync"
+
+	cerror "github.com/pingcap/tiflow/pkg/errors"
+)
+
+// MockTransactionStore keeps the committed messages and markers in memory,
+// it can be shared by the MockTransactionalProducers to simulate restarts.
+type MockTransactionStore struct {
+	mu       sync.Mutex
+	messages []*TransactionalMessage
+	markers  map[string][]byte
+	// epochs is increased every time a producer with the transactional id is initialized,
+	// the producers with the previous epochs are fenced.
+	epochs map[string]int
+}
+
+// NewMockTransactionStore creates an empty MockTransactionStore.
+func NewMockTransactionStore() *MockTransactionStore {
+	return &MockTransactionStore{
+		markers: make(map[string][]byte),
+		epochs:  make(map[string]int),
+	}
+}
+
+// CommittedMessages returns the messages of the committed transactions in order.
+func (s *MockTransactionStore) CommittedMessages() []*TransactionalMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*TransactionalMessage(nil), s.messages...)
+}
+
+// MockTransactionalProducer is an in-memory implementation of TransactionalProducer.
+type MockTransactionalProducer struct {
+	transactionalID string
+	store           *MockTransactionStore
+	epoch           int
+
+	mu sync.Mutex
+	// err is returned by the next SendTransaction, and the transaction is aborted.
+	err    error
+	closed bool
+}
+
+// NewMockTransactionalProducer creates a MockTransactionalProducer which commits
+// the transactions to the store.
+func NewMockTransactionalProducer(transactionalID string, store *MockTransactionStore) *MockTransactionalProducer {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.epochs[transactionalID]++
+	return &MockTransactionalProducer{
+		transactionalID: transactionalID,
+		store:           store,
+		epoch:           store.epochs[transactionalID],
+	}
+}
+
+// InjectError makes the next transaction fail with the err.
+func (p *MockTransactionalProducer) InjectError(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.err = err
+}
+
+// SendTransaction commits the messages and the marker to the store.
+func (p *MockTransactionalProducer) SendTransaction(
+	ctx context.Context, messages []*TransactionalMessage, marker []byte,
+) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return cerror.ErrKafkaProducerClosed.GenWithStackByArgs()
+	}
+	if p.err != nil {
+		err := p.err
+		p.err = nil
+		return err
+	}
+
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	if p.epoch != p.store.epochs[p.transactionalID] {
+		return cerror.ErrKafkaSendMessage.GenWithStack("producer %s is fenced", p.transactionalID)
+	}
+	p.store.messages = append(p.store.messages, messages...)
+	p.store.markers[p.transactionalID] = marker
+	return nil
+}
+
+// ReadMarkers returns the last committed marker of each transactional id.
+func (p *MockTransactionalProducer) ReadMarkers(_ context.Context) (map[string][]byte, error) {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	markers := make(map[string][]byte, len(p.store.markers))
+	for id, marker := range p.store.markers {
+		markers[id] = marker
+	}
+	return markers, nil
+}
+
+// TransactionalID returns the transactional id of the producer.
+func (p *MockTransactionalProducer) TransactionalID() string {
+	return p.transactionalID
+}
+
+// Fence bumps the epoch of the transactional id.
+func (p *MockTransactionalProducer) Fence(_ context.Context, transactionalID string) error {
+	p.store.mu.Lock()
+	defer p.store.mu.Unlock()
+	p.store.epochs[transactionalID]++
+	return nil
+}
+
+// Close closes the producer.
+func (p *MockTransactionalProducer) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.closed = true
+}
@@ -126,6 +126,7 @@ type urlConfig struct {
 	Cert                         *string `form:"cert"`
 	Key                          *string `form:"key"`
 	InsecureSkipVerify           *bool   `form:"insecure-skip-verify"`
+	ExactlyOnce                  *bool   `form:"exactly-once"`
 }
 
 // Options stores user specified configurations
@@ -158,6 +159,11 @@ type Options struct {
 	DialTimeout  time.Duration
 	WriteTimeout time.Duration
 	ReadTimeout  time.Duration
+
+	// ExactlyOnce sends the DML messages in kafka transactions, and records the committed
+	// ts of each table in the marker topic, so the messages are not duplicated after restarts.
+	// The consumers should read the messages with the `read_committed` isolation level.
+	ExactlyOnce bool
 }
 
 // NewOptions returns a default Kafka configuration
@@ -296,6 +302,10 @@ func (o *Options) Apply(changefeedID common.ChangeFeedID,
 		o.RequiredAcks = r
 	}
 
+	if urlParameter.ExactlyOnce != nil {
+		o.ExactlyOnce = *urlParameter.ExactlyOnce
+	}
+
 	err = o.applySASL(urlParameter, sinkConfig)
 	if err != nil {
 		return err
@@ -343,6 +353,7 @@ func mergeConfig(
 		dest.Cert = fileConifg.Cert
 		dest.Key = fileConifg.Key
 		dest.InsecureSkipVerify = fileConifg.InsecureSkipVerify
+		dest.ExactlyOnce = fileConifg.ExactlyOnce
 	}
 	if err := mergo.Merge(dest, urlParameters, mergo.WithOverride); err != nil {
 		return nil, err
@@ -553,6 +564,23 @@ func NewKafkaClientID(captureAddr string,
 	return
 }
 
+// NewTransactionalID generates the transactional id of the exactly-once producer.
+// It's stable across the restarts of the capture, so the transaction left by
+// the previous producer is aborted when the new one is initialized.
+// When the capture is dead, its transactional id is fenced by the producers
+// restarting its tables, see KafkaTransactionalDMLProducer.CheckStartTsList.
+func NewTransactionalID(captureAddr string, changefeedID common.ChangeFeedID) string {
+	transactionalID := fmt.Sprintf("TiCDC_txn_%s_%s_%s",
+		captureAddr, changefeedID.Namespace(), changefeedID.Name())
+	return commonInvalidChar.ReplaceAllString(transactionalID, "_")
+}
+
+// MarkerTopic returns the topic where the exactly-once producers of the changefeed
+// record the committed ts of the tables, it has only one partition.
+func MarkerTopic(changefeedID common.ChangeFeedID) string {
+	return fmt.Sprintf("_ticdc_%s_%s_marker", changefeedID.Namespace(), changefeedID.Name())
+}
+
 // AdjustOptions adjust the `Options` and `sarama.Config` by condition.
 func AdjustOptions(
 	ctx context.Context,
@@ -0,0 +1,278 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafka
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/pingcap/log"
+	"github.com/pingcap/ticdc/pkg/common"
+	"github.com/pingcap/tiflow/pkg/errors"
+	"go.uber.org/zap"
+)
+
+const (
+	// markerPartition is the only partition of the marker topic.
+	markerPartition = 0
+	// readMarkersIdleTimeout is the time to wait for the next marker when reading the marker topic,
+	// the records left are the control records or not committed, which are skipped by the consumer.
+	readMarkersIdleTimeout = time.Second
+)
+
+// TransactionalProducer returns a producer which sends messages in transactions,
+// it should be the caller's responsibility to close the producer
+func (f *saramaFactory) TransactionalProducer(
+	ctx context.Context, transactionalID string, markerTopic string,
+) (TransactionalProducer, error) {
+	config, err := NewSaramaConfig(ctx, f.option)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Version.IsAtLeast(sarama.V0_11_0_0) {
+		return nil, errors.ErrKafkaInvalidConfig.GenWithStack(
+			"exactly-once requires kafka version 0.11.0 or later, but got %s", config.Version)
+	}
+	if err = createMarkerTopic(f.option, config, markerTopic); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	config.MetricRegistry = f.registry
+	config.Producer.Idempotent = true
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Transaction.ID = transactionalID
+	config.Net.MaxOpenRequests = 1
+	// The consumer is used to read the markers, it should skip the aborted ones.
+	config.Consumer.IsolationLevel = sarama.ReadCommitted
+	config.Consumer.Return.Errors = true
+
+	client, err := sarama.NewClient(f.option.BrokerEndpoints, config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The transaction left by the previous producer with the same transactional id
+	// is aborted when the new producer is initialized.
+	p, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, errors.Trace(err)
+	}
+	log.Info("Kafka transactional producer created",
+		zap.String("namespace", f.changefeedID.Namespace()),
+		zap.String("changefeed", f.changefeedID.Name()),
+		zap.String("transactionalID", transactionalID),
+		zap.String("markerTopic", markerTopic))
+	return &saramaTransactionalProducer{
+		id:              f.changefeedID,
+		transactionalID: transactionalID,
+		markerTopic:     markerTopic,
+		brokers:         f.option.BrokerEndpoints,
+		config:          config,
+		client:          client,
+		producer:        p,
+	}, nil
+}
+
+// createMarkerTopic creates the marker topic with one partition if it doesn't exist,
+// only the last marker of each producer is needed, so the topic is compacted.
+func createMarkerTopic(o *Options, config *sarama.Config, markerTopic string) error {
+	admin, err := sarama.NewClusterAdmin(o.BrokerEndpoints, config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer admin.Close()
+
+	cleanupPolicy := "compact"
+	err = admin.CreateTopic(markerTopic, &sarama.TopicDetail{
+		NumPartitions:     1,
+		ReplicationFactor: o.ReplicationFactor,
+		ConfigEntries: map[string]*string{
+			"cleanup.policy": &cleanupPolicy,
+		},
+	}, false)
+	// Ignore the already exists error because it's not harmful.
+	if err != nil && !strings.Contains(err.Error(), sarama.ErrTopicAlreadyExists.Error()) {
+		return err
+	}
+	return nil
+}
+
+type saramaTransactionalProducer struct {
+	id              common.ChangeFeedID
+	transactionalID string
+	markerTopic     string
+	brokers         []string
+	config          *sarama.Config
+	client          sarama.Client
+	producer        sarama.SyncProducer
+}
+
+func (p *saramaTransactionalProducer) SendTransaction(
+	ctx context.Context, messages []*TransactionalMessage, marker []byte,
+) error {
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	default:
+	}
+
+	msgs := make([]*sarama.ProducerMessage, 0, len(messages)+1)
+	for _, message := range messages {
+		msgs = append(msgs, &sarama.ProducerMessage{
+			Topic:     message.Topic,
+			Partition: message.Partition,
+			Key:       sarama.ByteEncoder(message.Key),
+			Value:     sarama.ByteEncoder(message.Value),
+		})
+	}
+	msgs = append(msgs, &sarama.ProducerMessage{
+		Topic:     p.markerTopic,
+		Partition: markerPartition,
+		Key:       sarama.StringEncoder(p.transactionalID),
+		Value:     sarama.ByteEncoder(marker),
+	})
+
+	if err := p.producer.BeginTxn(); err != nil {
+		return errors.WrapError(errors.ErrKafkaSendMessage, err)
+	}
+	if err := p.producer.SendMessages(msgs); err != nil {
+		p.abort()
+		return errors.WrapError(errors.ErrKafkaSendMessage, err)
+	}
+	if err := p.producer.CommitTxn(); err != nil {
+		p.abort()
+		return errors.WrapError(errors.ErrKafkaSendMessage, err)
+	}
+	return nil
+}
+
+func (p *saramaTransactionalProducer) abort() {
+	if p.producer.TxnStatus()&sarama.ProducerTxnFlagInTransaction == 0 {
+		return
+	}
+	if err := p.producer.AbortTxn(); err != nil {
+		log.Warn("Abort kafka transaction failed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()),
+			zap.String("transactionalID", p.transactionalID),
+			zap.Error(err))
+	}
+}
+
+func (p *saramaTransactionalProducer) ReadMarkers(ctx context.Context) (map[string][]byte, error) {
+	markers := make(map[string][]byte)
+	oldest, err := p.client.GetOffset(p.markerTopic, markerPartition, sarama.OffsetOldest)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	newest, err := p.client.GetOffset(p.markerTopic, markerPartition, sarama.OffsetNewest)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if oldest >= newest {
+		return markers, nil
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(p.client)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer consumer.Close()
+	partitionConsumer, err := consumer.ConsumePartition(p.markerTopic, markerPartition, oldest)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer partitionConsumer.Close()
+
+	timer := time.NewTimer(readMarkersIdleTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, errors.Trace(ctx.Err())
+		case msg := <-partitionConsumer.Messages():
+			markers[string(msg.Key)] = msg.Value
+			// The last record is the control record of the last transaction.
+			if msg.Offset+2 >= newest {
+				return markers, nil
+			}
+			timer.Reset(readMarkersIdleTimeout)
+		case err := <-partitionConsumer.Errors():
+			return nil, errors.Trace(err)
+		case <-timer.C:
+			return markers, nil
+		}
+	}
+}
+
+func (p *saramaTransactionalProducer) TransactionalID() string {
+	return p.transactionalID
+}
+
+func (p *saramaTransactionalProducer) Fence(ctx context.Context, transactionalID string) error {
+	select {
+	case <-ctx.Done():
+		return errors.Trace(ctx.Err())
+	default:
+	}
+	config := *p.config
+	config.Producer.Transaction.ID = transactionalID
+	// The producer epoch of the transactional id is bumped when the new producer is initialized.
+	producer, err := sarama.NewSyncProducer(p.brokers, &config)
+	if err != nil {
+		return errors.WrapError(errors.ErrKafkaNewProducer, err)
+	}
+	if err = producer.Close(); err != nil {
+		log.Warn("Close kafka fencing producer failed",
+			zap.String("namespace", p.id.Namespace()),
+			zap.String("changefeed", p.id.Name()),
+			zap.String("transactionalID", transactionalID),
+			zap.Error(err))
+	}
+	log.Info("Kafka transactional id fenced",
+		zap.String("namespace", p.id.Namespace()),
+		zap.String("changefeed", p.id.Name()),
+		zap.String("transactionalID", transactionalID))
+	return nil
+}
+
+func (p *saramaTransactionalProducer) Close() {
+	go func() {
+		// Close it asynchronously to avoid getting stuck with an unhealthy kafka cluster,
+		// the transaction not committed is aborted by the transaction coordinator after timeout,
+		// or when the producer with the same transactional id is initialized.
+		start := time.Now()
+		if err := p.producer.Close(); err != nil {
+			log.Warn("Close kafka transactional producer failed",
+				zap.String("namespace", p.id.Namespace()),
+				zap.String("changefeed", p.id.Name()),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err))
+		}
+		if err := p.client.Close(); err != nil {
+			log.Warn("Close kafka transactional producer client failed",
+				zap.String("namespace", p.id.Namespace()),
+				zap.String("changefeed", p.id.Name()),
+				zap.Duration("duration", time.Since(start)),
+				zap.Error(err))
+		} else {
+			log.Info("Kafka transactional producer closed",
+				zap.String("namespace", p.id.Namespace()),
+				zap.String("changefeed", p.id.Name()),
+				zap.Duration("duration", time.Since(start)))
+		}
+	}()
+}
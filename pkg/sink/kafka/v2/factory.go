@@ -262,6 +262,20 @@ func (f *factory) AsyncProducer(
 	return aw, nil
 }
 
+// TransactionalProducer creates the transactional producer by the sarama client,
+// since kafka-go always writes the record batches with producer id -1, while the
+// kafka transactions require the producer id and epoch returned by InitProducerID.
+// The other messages are still sent by kafka-go.
+func (f *factory) TransactionalProducer(
+	ctx context.Context, transactionalID string, markerTopic string,
+) (pkafka.TransactionalProducer, error) {
+	saramaFactory, err := pkafka.NewSaramaFactory(f.options, f.changefeedID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return saramaFactory.TransactionalProducer(ctx, transactionalID, markerTopic)
+}
+
 // MetricsCollector returns the kafka metrics collector
 func (f *factory) MetricsCollector(
 	role util.Role,
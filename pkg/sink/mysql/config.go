@@ -100,7 +100,10 @@ type MysqlConfig struct {
 	// write source exists when the downstream is TiDB and version is greater than or equal to v6.5.0.
 	IsWriteSourceExisted bool
 
-	SourceID        uint64
+	SourceID uint64
+	// BDRMode is true if the changefeed is in BDR mode,
+	// only the DDLs executed by the primary cluster are replicated in BDR mode.
+	BDRMode         bool
 	BatchDMLEnable  bool
 	MultiStmtEnable bool
 	CachePrepStmts  bool
@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/ticdc/pkg/metrics"
 	"github.com/pingcap/ticdc/pkg/sink/util"
 	timodel "github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tiflow/pkg/config"
 	cerror "github.com/pingcap/tiflow/pkg/errors"
 	"github.com/pingcap/tiflow/pkg/retry"
@@ -88,11 +89,20 @@ func (w *MysqlWriter) SetTableSchemaStore(tableSchemaStore *util.TableSchemaStor
 }
 
 func (w *MysqlWriter) FlushDDLEvent(event *commonEvent.DDLEvent) error {
-	if event.GetDDLType() == timodel.ActionAddIndex && w.cfg.IsTiDB {
+	// In BDR mode, the DDLs are executed in all clusters,
+	// only the DDLs executed by the primary cluster are replicated.
+	skipped := w.cfg.BDRMode && event.BDRRole != string(ast.BDRRolePrimary)
+	if skipped {
+		log.Info("skip the ddl not executed by the primary cluster in BDR mode",
+			zap.String("query", event.GetDDLQuery()),
+			zap.String("bdrRole", event.BDRRole))
+	}
+
+	if !skipped && event.GetDDLType() == timodel.ActionAddIndex && w.cfg.IsTiDB {
 		return w.asyncExecAddIndexDDLIfTimeout(event) // todo flush checkpointTs
 	}
 
-	if !(event.TiDBOnly && !w.cfg.IsTiDB) {
+	if !skipped && !(event.TiDBOnly && !w.cfg.IsTiDB) {
 		err := w.execDDLWithMaxRetries(event)
 		if err != nil {
 			return errors.Trace(err)
@@ -762,6 +772,19 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 			zap.Uint64("firstRowCommitTs", event.CommitTs),
 			zap.Uint64("firstRowReplicatingTs", event.ReplicatingTs),
 			zap.Bool("safeMode", w.cfg.SafeMode))
+
+		// Use the batched statements to reduce the round trips when the transaction has multiple rows.
+		// The batched DELETE and UPDATE statements locate the rows by the handle key.
+		if w.cfg.BatchDMLEnable && event.Len() > 1 && hasHandleKey(event.TableInfo) {
+			batchSQLs, batchValues, err := w.batchSingleTxnDmls(event, translateToInsert)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			sqls = append(sqls, batchSQLs...)
+			values = append(values, batchValues...)
+			continue
+		}
+
 		for {
 			row, ok := event.GetNextRow()
 			if !ok {
@@ -821,6 +844,118 @@ func (w *MysqlWriter) prepareDMLs(events []*commonEvent.DMLEvent) (*preparedDMLs
 	}, nil
 }
 
+// groupRowsByType groups the rows of a transaction by their types,
+// each group contains at most MaxTxnRow insert or delete rows, or MaxMultiUpdateRowCount update rows.
+func (w *MysqlWriter) groupRowsByType(
+	event *commonEvent.DMLEvent,
+) (insertRows, updateRows, deleteRows [][]commonEvent.RowChange) {
+	preAllocateSize := int(event.Len())
+	if preAllocateSize > w.cfg.MaxTxnRow {
+		preAllocateSize = w.cfg.MaxTxnRow
+	}
+	insertRow := make([]commonEvent.RowChange, 0, preAllocateSize)
+	updateRow := make([]commonEvent.RowChange, 0, preAllocateSize)
+	deleteRow := make([]commonEvent.RowChange, 0, preAllocateSize)
+
+	for {
+		row, ok := event.GetNextRow()
+		if !ok {
+			break
+		}
+		switch row.RowType {
+		case commonEvent.RowTypeInsert:
+			insertRow = append(insertRow, row)
+			if len(insertRow) >= w.cfg.MaxTxnRow {
+				insertRows = append(insertRows, insertRow)
+				insertRow = make([]commonEvent.RowChange, 0, preAllocateSize)
+			}
+		case commonEvent.RowTypeDelete:
+			deleteRow = append(deleteRow, row)
+			if len(deleteRow) >= w.cfg.MaxTxnRow {
+				deleteRows = append(deleteRows, deleteRow)
+				deleteRow = make([]commonEvent.RowChange, 0, preAllocateSize)
+			}
+		case commonEvent.RowTypeUpdate:
+			updateRow = append(updateRow, row)
+			if len(updateRow) >= w.cfg.MaxMultiUpdateRowCount {
+				updateRows = append(updateRows, updateRow)
+				updateRow = make([]commonEvent.RowChange, 0, preAllocateSize)
+			}
+		}
+	}
+
+	if len(insertRow) > 0 {
+		insertRows = append(insertRows, insertRow)
+	}
+	if len(updateRow) > 0 {
+		updateRows = append(updateRows, updateRow)
+	}
+	if len(deleteRow) > 0 {
+		deleteRows = append(deleteRows, deleteRow)
+	}
+	return
+}
+
+// batchSingleTxnDmls builds the batched statements for the rows of a transaction.
+// The rows are executed in the order of delete, update and insert, it's safe because
+// a transaction never modifies a row more than once.
+func (w *MysqlWriter) batchSingleTxnDmls(
+	event *commonEvent.DMLEvent,
+	translateToInsert bool,
+) (sqls []string, values [][]interface{}, err error) {
+	insertRows, updateRows, deleteRows := w.groupRowsByType(event)
+	tableInfo := event.TableInfo
+
+	// handle delete
+	for _, rows := range deleteRows {
+		sql, value, err := buildMultiDelete(tableInfo, rows)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		sqls = append(sqls, sql)
+		values = append(values, value)
+	}
+
+	// handle update
+	// The behavior of update statement differs between TiDB and MySQL.
+	// So we don't use batch update statement when downstream is MySQL.
+	// Ref:https://docs.pingcap.com/tidb/stable/sql-statement-update#mysql-compatibility
+	// The multi update is also disabled when the average row size is too large.
+	multiUpdate := w.cfg.IsTiDB && event.GetRowsSize() < int64(w.cfg.MaxMultiUpdateRowSize)*int64(event.Len())
+	for _, rows := range updateRows {
+		if multiUpdate {
+			sql, value, err := buildMultiUpdate(tableInfo, rows)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			sqls = append(sqls, sql)
+			values = append(values, value)
+			continue
+		}
+		for _, row := range rows {
+			sql, value, err := buildUpdate(tableInfo, row)
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			if sql != "" {
+				sqls = append(sqls, sql)
+				values = append(values, value)
+			}
+		}
+	}
+
+	// handle insert
+	for _, rows := range insertRows {
+		sql, value, err := buildMultiInsert(tableInfo, rows, translateToInsert)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		sqls = append(sqls, sql)
+		values = append(values, value)
+	}
+	return sqls, values, nil
+}
+
 func (w *MysqlWriter) execDMLWithMaxRetries(dmls *preparedDMLs) error {
 	if len(dmls.sqls) != len(dmls.values) {
 		return cerror.ErrUnexpected.FastGenByArgs(fmt.Sprintf("unexpected number of sqls and values, sqls is %s, values is %s", dmls.sqls, dmls.values))
@@ -139,6 +139,174 @@ func buildUpdate(tableInfo *common.TableInfo, row commonEvent.RowChange) (string
 	return sql, args, nil
 }
 
+// buildMultiInsert builds a parametric multi-value INSERT/REPLACE statement as following
+// sql: `INSERT INTO `test`.`t` (`a`,`b`) VALUES (?,?),(?,?)`
+func buildMultiInsert(
+	tableInfo *common.TableInfo,
+	rows []commonEvent.RowChange,
+	translateToInsert bool,
+) (string, []interface{}, error) {
+	var sql string
+	if translateToInsert {
+		sql = tableInfo.GetPreInsertSQL()
+	} else {
+		sql = tableInfo.GetPreReplaceSQL()
+	}
+	if sql == "" {
+		log.Panic("PreInsertSQL should not be empty")
+	}
+	// The pre sql ends with the placeholders of one row, such as `(?,?)`.
+	rowPlaceHolder := sql[strings.LastIndex(sql, " VALUES ")+len(" VALUES "):]
+
+	var builder strings.Builder
+	builder.WriteString(sql)
+	args := make([]interface{}, 0, len(rows)*len(tableInfo.Columns))
+	for i, row := range rows {
+		rowArgs, err := getArgs(&row.Row, tableInfo)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		if i > 0 {
+			builder.WriteString(",")
+			builder.WriteString(rowPlaceHolder)
+		}
+		args = append(args, rowArgs...)
+	}
+	return builder.String(), args, nil
+}
+
+// buildMultiDelete builds a parametric DELETE statement deleting multiple rows as following
+// sql: `DELETE FROM `test`.`t` WHERE (`a`,`b`) IN ((?,?),(?,?))`
+// The table must have a handle key, whose values are never null.
+func buildMultiDelete(tableInfo *common.TableInfo, rows []commonEvent.RowChange) (string, []interface{}, error) {
+	var builder strings.Builder
+	builder.WriteString("DELETE FROM ")
+	builder.WriteString(tableInfo.TableName.QuoteString())
+	builder.WriteString(" WHERE ")
+
+	args := make([]interface{}, 0, len(rows))
+	var rowPlaceHolder string
+	for i, row := range rows {
+		colNames, whereArgs, err := whereSlice(&row.PreRow, tableInfo)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		if i == 0 {
+			writeColumnTuple(&builder, colNames)
+			builder.WriteString(" IN (")
+			rowPlaceHolder = "(" + placeHolder(len(colNames)) + ")"
+		} else {
+			builder.WriteString(",")
+		}
+		builder.WriteString(rowPlaceHolder)
+		args = append(args, whereArgs...)
+	}
+	builder.WriteString(")")
+	return builder.String(), args, nil
+}
+
+// buildMultiUpdate builds a parametric UPDATE statement updating multiple rows as following
+// sql: `UPDATE `test`.`t` SET `a` = CASE WHEN `a` = ? THEN ? WHEN `a` = ? THEN ? END,
+// `b` = CASE WHEN `a` = ? THEN ? WHEN `a` = ? THEN ? END WHERE (`a`) IN ((?),(?))`
+// The table must have a handle key, whose values are never null.
+func buildMultiUpdate(tableInfo *common.TableInfo, rows []commonEvent.RowChange) (string, []interface{}, error) {
+	// the values of every row, in the same order as the columns of the SET clause.
+	rowArgs := make([][]interface{}, 0, len(rows))
+	// the handle key values of every row, used to locate the rows.
+	whereArgs := make([][]interface{}, 0, len(rows))
+	var whereColNames []string
+	for _, row := range rows {
+		args, err := getArgs(&row.Row, tableInfo)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		colNames, where, err := whereSlice(&row.PreRow, tableInfo)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		whereColNames = colNames
+		rowArgs = append(rowArgs, args)
+		whereArgs = append(whereArgs, where)
+	}
+
+	var whenCase strings.Builder
+	for i, colName := range whereColNames {
+		if i > 0 {
+			whenCase.WriteString(" AND ")
+		}
+		whenCase.WriteString(quotes.QuoteName(colName))
+		whenCase.WriteString(" = ?")
+	}
+
+	var builder strings.Builder
+	builder.WriteString("UPDATE ")
+	builder.WriteString(tableInfo.TableName.QuoteString())
+	builder.WriteString(" SET ")
+	args := make([]interface{}, 0, len(rows)*(len(tableInfo.Columns)*(len(whereColNames)+1)+len(whereColNames)))
+	colIdx := 0
+	for _, col := range tableInfo.Columns {
+		if col == nil || tableInfo.ColumnsFlag[col.ID].IsGeneratedColumn() {
+			continue
+		}
+		if colIdx > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(quotes.QuoteName(col.Name.O))
+		builder.WriteString(" = CASE")
+		for i := range rows {
+			builder.WriteString(" WHEN ")
+			builder.WriteString(whenCase.String())
+			builder.WriteString(" THEN ?")
+			args = append(args, whereArgs[i]...)
+			args = append(args, rowArgs[i][colIdx])
+		}
+		builder.WriteString(" END")
+		colIdx++
+	}
+
+	builder.WriteString(" WHERE ")
+	writeColumnTuple(&builder, whereColNames)
+	builder.WriteString(" IN (")
+	rowPlaceHolder := "(" + placeHolder(len(whereColNames)) + ")"
+	for i := range rows {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(rowPlaceHolder)
+		args = append(args, whereArgs[i]...)
+	}
+	builder.WriteString(")")
+	return builder.String(), args, nil
+}
+
+// writeColumnTuple writes the quoted column names as a tuple, such as (`a`,`b`)
+func writeColumnTuple(builder *strings.Builder, colNames []string) {
+	builder.WriteString("(")
+	for i, colName := range colNames {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(quotes.QuoteName(colName))
+	}
+	builder.WriteString(")")
+}
+
+// placeHolder returns a string with n placeholders separated by commas
+func placeHolder(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// hasHandleKey returns true if the table has a handle key,
+// which is used to locate a row in the batched DELETE and UPDATE statements.
+func hasHandleKey(tableInfo *common.TableInfo) bool {
+	for _, col := range tableInfo.Columns {
+		if col != nil && tableInfo.ColumnsFlag[col.ID].IsHandleKey() {
+			return true
+		}
+	}
+	return false
+}
+
 func getArgs(row *chunk.Row, tableInfo *common.TableInfo) ([]interface{}, error) {
 	args := make([]interface{}, 0, len(tableInfo.Columns))
 	for i, col := range tableInfo.Columns {
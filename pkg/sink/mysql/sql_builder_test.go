@@ -370,3 +370,64 @@ func TestBuildUpdate(t *testing.T) {
 	require.Equal(t, expectedArgs, args)
 
 }
+
+func TestBuildMultiRows(t *testing.T) {
+	helper := event.NewEventTestHelper(t)
+	defer helper.Close()
+
+	helper.Tk().MustExec("use test")
+	createTableSQL := "create table t (id int, name varchar(32), age int, primary key (id, name));"
+	job := helper.DDL2Job(createTableSQL)
+	require.NotNil(t, job)
+
+	dmlEvent := helper.DML2Event("test", "t", "insert into t values (1, 'a', 10);", "insert into t values (2, 'b', 20);")
+	require.NotNil(t, dmlEvent)
+	tableInfo := dmlEvent.TableInfo
+	require.True(t, hasHandleKey(tableInfo))
+	row1, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	row2, ok := dmlEvent.GetNextRow()
+	require.True(t, ok)
+	rows := []pevent.RowChange{row1, row2}
+
+	// multi-value insert
+	sql, args, err := buildMultiInsert(tableInfo, rows, true)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)", sql)
+	require.Equal(t, []interface{}{int64(1), "a", int64(10), int64(2), "b", int64(20)}, args)
+	sql, _, err = buildMultiInsert(tableInfo, rows, false)
+	require.NoError(t, err)
+	require.Equal(t, "REPLACE INTO `test`.`t` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?)", sql)
+
+	// Manually set the PreRow, because the helper does not support delete and update operation
+	for i := range rows {
+		rows[i].PreRow = rows[i].Row
+	}
+
+	// delete by the composite primary key
+	sql, args, err = buildMultiDelete(tableInfo, rows)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE FROM `test`.`t` WHERE (`id`,`name`) IN ((?,?),(?,?))", sql)
+	require.Equal(t, []interface{}{int64(1), "a", int64(2), "b"}, args)
+
+	// update by the composite primary key
+	sql, args, err = buildMultiUpdate(tableInfo, rows)
+	require.NoError(t, err)
+	require.Equal(t, "UPDATE `test`.`t` SET "+
+		"`id` = CASE WHEN `id` = ? AND `name` = ? THEN ? WHEN `id` = ? AND `name` = ? THEN ? END, "+
+		"`name` = CASE WHEN `id` = ? AND `name` = ? THEN ? WHEN `id` = ? AND `name` = ? THEN ? END, "+
+		"`age` = CASE WHEN `id` = ? AND `name` = ? THEN ? WHEN `id` = ? AND `name` = ? THEN ? END "+
+		"WHERE (`id`,`name`) IN ((?,?),(?,?))", sql)
+	require.Equal(t, []interface{}{
+		int64(1), "a", int64(1), int64(2), "b", int64(2),
+		int64(1), "a", "a", int64(2), "b", "b",
+		int64(1), "a", int64(10), int64(2), "b", int64(20),
+		int64(1), "a", int64(2), "b",
+	}, args)
+
+	// the table without handle key can't use the batched statements
+	job = helper.DDL2Job("create table t2 (id int, name varchar(32));")
+	require.NotNil(t, job)
+	dmlEvent = helper.DML2Event("test", "t2", "insert into t2 values (1, 'a');")
+	require.False(t, hasHandleKey(dmlEvent.TableInfo))
+}
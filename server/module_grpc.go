@@ -28,6 +28,9 @@ type GrpcModule struct {
 	lis        net.Listener
 }
 
+// NewGrpcServer creates the message center grpc server. lis is provided by the
+// tcp server, which has already handled tls and CN verification when the
+// security config is set.
 func NewGrpcServer(lis net.Listener) common.SubModule {
 	option := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(256 * 1024 * 1024), // 256MB
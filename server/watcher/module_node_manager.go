@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/tiflow/cdc/model"
 	"github.com/pingcap/tiflow/pkg/orchestrator"
 	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.uber.org/zap"
 )
 
 const NodeManagerName = "node-manager"
@@ -39,6 +40,12 @@ type NodeManager struct {
 	etcdClient etcd.CDCEtcdClient
 	nodes      atomic.Pointer[map[node.ID]*node.Info]
 
+	// draining nodes are still alive, but no new task should be scheduled to them
+	drainingNodes struct {
+		sync.RWMutex
+		m map[node.ID]struct{}
+	}
+
 	nodeChangeHandlers struct {
 		sync.RWMutex
 		m map[node.ID]NodeChangeHandler
@@ -57,6 +64,7 @@ func NewNodeManager(
 			m map[node.ID]NodeChangeHandler
 		}{m: make(map[node.ID]NodeChangeHandler)},
 	}
+	m.drainingNodes.m = make(map[node.ID]struct{})
 	m.nodes.Store(&map[node.ID]*node.Info{})
 	return m
 }
@@ -90,6 +98,7 @@ func (c *NodeManager) Tick(
 	}
 	c.nodes.Store(&allNodes)
 	if changed {
+		c.cleanupDrainingNodes(allNodes)
 		log.Info("server change detected")
 		// handle info change event
 		c.nodeChangeHandlers.RLock()
@@ -106,6 +115,78 @@ func (c *NodeManager) GetAliveNodes() map[node.ID]*node.Info {
 	return *c.nodes.Load()
 }
 
+// DrainNode marks the node as draining, it returns false if the node is not alive
+func (c *NodeManager) DrainNode(id node.ID) bool {
+	if _, ok := c.GetAliveNodes()[id]; !ok {
+		return false
+	}
+	c.drainingNodes.Lock()
+	defer c.drainingNodes.Unlock()
+	if _, ok := c.drainingNodes.m[id]; !ok {
+		log.Info("node is marked as draining", zap.Stringer("node", id))
+		c.drainingNodes.m[id] = struct{}{}
+	}
+	return true
+}
+
+// UndrainNode marks the node as not draining, it returns false if the node is not draining
+func (c *NodeManager) UndrainNode(id node.ID) bool {
+	c.drainingNodes.Lock()
+	defer c.drainingNodes.Unlock()
+	if _, ok := c.drainingNodes.m[id]; !ok {
+		return false
+	}
+	log.Info("node is not draining any more", zap.Stringer("node", id))
+	delete(c.drainingNodes.m, id)
+	return true
+}
+
+// IsNodeDraining returns true if the node is draining
+func (c *NodeManager) IsNodeDraining(id node.ID) bool {
+	c.drainingNodes.RLock()
+	defer c.drainingNodes.RUnlock()
+	_, ok := c.drainingNodes.m[id]
+	return ok
+}
+
+// GetDrainingNodes returns all draining nodes
+func (c *NodeManager) GetDrainingNodes() []node.ID {
+	c.drainingNodes.RLock()
+	defer c.drainingNodes.RUnlock()
+	nodes := make([]node.ID, 0, len(c.drainingNodes.m))
+	for id := range c.drainingNodes.m {
+		nodes = append(nodes, id)
+	}
+	return nodes
+}
+
+// GetSchedulableNodes returns all alive nodes excluding the draining ones,
+// the caller can modify the returned map
+func (c *NodeManager) GetSchedulableNodes() map[node.ID]*node.Info {
+	aliveNodes := c.GetAliveNodes()
+	c.drainingNodes.RLock()
+	defer c.drainingNodes.RUnlock()
+	nodes := make(map[node.ID]*node.Info, len(aliveNodes))
+	for id, info := range aliveNodes {
+		if _, ok := c.drainingNodes.m[id]; !ok {
+			nodes[id] = info
+		}
+	}
+	return nodes
+}
+
+// cleanupDrainingNodes removes the draining nodes that are offline
+func (c *NodeManager) cleanupDrainingNodes(allNodes map[node.ID]*node.Info) {
+	c.drainingNodes.Lock()
+	defer c.drainingNodes.Unlock()
+	for id := range c.drainingNodes.m {
+		if _, ok := allNodes[id]; !ok {
+			log.Info("draining node is offline", zap.Stringer("node", id))
+			delete(c.drainingNodes.m, id)
+		}
+	}
+}
+
 func (c *NodeManager) Run(ctx context.Context) error {
 	cfg := config.GetGlobalServerConfig()
 	watcher := NewEtcdWatcher(c.etcdClient,
@@ -0,0 +1,64 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watcher
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/ticdc/pkg/node"
+	"github.com/pingcap/tiflow/cdc/model"
+	"github.com/pingcap/tiflow/pkg/orchestrator"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainNode(t *testing.T) {
+	ctx := context.Background()
+	m := NewNodeManager(nil, nil)
+	info1 := node.NewInfo("127.0.0.1:8300", "")
+	info2 := node.NewInfo("127.0.0.1:8400", "")
+	_, _ = m.Tick(ctx, &orchestrator.GlobalReactorState{
+		Captures: map[model.CaptureID]*model.CaptureInfo{
+			model.CaptureID(info1.ID): {ID: model.CaptureID(info1.ID), AdvertiseAddr: info1.AdvertiseAddr},
+			model.CaptureID(info2.ID): {ID: model.CaptureID(info2.ID), AdvertiseAddr: info2.AdvertiseAddr},
+		}})
+	require.Len(t, m.GetSchedulableNodes(), 2)
+
+	// unknown node can not be drained
+	require.False(t, m.DrainNode(node.ID("unknown")))
+	require.True(t, m.DrainNode(info1.ID))
+	require.True(t, m.IsNodeDraining(info1.ID))
+	require.False(t, m.IsNodeDraining(info2.ID))
+	require.Equal(t, []node.ID{info1.ID}, m.GetDrainingNodes())
+	require.Len(t, m.GetAliveNodes(), 2)
+	schedulable := m.GetSchedulableNodes()
+	require.Len(t, schedulable, 1)
+	require.Contains(t, schedulable, info2.ID)
+
+	// the node is schedulable again after it's undrained
+	require.False(t, m.UndrainNode(info2.ID))
+	require.True(t, m.UndrainNode(info1.ID))
+	require.False(t, m.IsNodeDraining(info1.ID))
+	require.Len(t, m.GetSchedulableNodes(), 2)
+	require.True(t, m.DrainNode(info1.ID))
+
+	// the draining node is removed once it goes offline
+	_, _ = m.Tick(ctx, &orchestrator.GlobalReactorState{
+		Captures: map[model.CaptureID]*model.CaptureInfo{
+			model.CaptureID(info2.ID): {ID: model.CaptureID(info2.ID), AdvertiseAddr: info2.AdvertiseAddr},
+		}})
+	require.False(t, m.IsNodeDraining(info1.ID))
+	require.Empty(t, m.GetDrainingNodes())
+	require.Len(t, m.GetSchedulableNodes(), 1)
+}
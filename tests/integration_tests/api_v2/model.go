@@ -63,10 +63,12 @@ type Tso struct {
 	LogicTime int64 `json:"logic_time"`
 }
 
-// Tables contains IneligibleTables and EligibleTables
+// Tables contains IneligibleTables, EligibleTables and FilteredTables
 type Tables struct {
 	IneligibleTables []TableName `json:"ineligible_tables,omitempty"`
 	EligibleTables   []TableName `json:"eligible_tables,omitempty"`
+	// FilteredTables are the tables ignored by the filter rules
+	FilteredTables []TableName `json:"filtered_tables,omitempty"`
 }
 
 // TableName contains table information
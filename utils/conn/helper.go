@@ -19,6 +19,8 @@ import (
 	"github.com/pingcap/tiflow/pkg/security"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
 // 这个是最基础的 connection
@@ -35,7 +37,7 @@ const (
 
 // Connect returns a new grpc client connection to the target.
 func Connect(target string, credential *security.Credential) (*grpc.ClientConn, error) {
-	grpcTLSOption, err := credential.ToGRPCDialOption()
+	grpcTLSOption, err := toGRPCDialOption(credential)
 	if err != nil {
 		return nil, err
 	}
@@ -58,3 +60,18 @@ func Connect(target string, credential *security.Credential) (*grpc.ClientConn,
 
 	return grpc.NewClient(target, dialOptions...)
 }
+
+// toGRPCDialOption returns the transport credential of the connection.
+// The common name of the remote certificate is checked against CertAllowedCN,
+// and the certificate and key are loaded on every handshake, so they can be
+// rotated without restarting the server.
+func toGRPCDialOption(credential *security.Credential) (grpc.DialOption, error) {
+	tlsConfig, err := credential.ToTLSConfigWithVerify()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return grpc.WithTransportCredentials(insecure.NewCredentials()), nil
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package conn
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tiflow/pkg/security"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const certDir = "../../tests/integration_tests/_certificates"
+
+func newTestCredential(name string, allowedCN ...string) *security.Credential {
+	return &security.Credential{
+		CAPath:        filepath.Join(certDir, "ca.pem"),
+		CertPath:      filepath.Join(certDir, name+".pem"),
+		KeyPath:       filepath.Join(certDir, name+"-key.pem"),
+		CertAllowedCN: allowedCN,
+	}
+}
+
+// startTestServer starts a grpc server with the health service,
+// the server is a TLS server if the credential is not empty.
+func startTestServer(t *testing.T, credential *security.Credential) string {
+	var options []grpc.ServerOption
+	if !credential.IsEmpty() {
+		tlsConfig, err := credential.ToTLSConfigWithVerify()
+		require.NoError(t, err)
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	server := grpc.NewServer(options...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func checkHealth(t *testing.T, addr string, credential *security.Credential) error {
+	conn, err := Connect(addr, credential)
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestToGRPCDialOption(t *testing.T) {
+	_, err := toGRPCDialOption(&security.Credential{})
+	require.NoError(t, err)
+	_, err = toGRPCDialOption(newTestCredential("client"))
+	require.NoError(t, err)
+	// the CA doesn't exist
+	credential := newTestCredential("client")
+	credential.CAPath = filepath.Join(certDir, "unknown.pem")
+	_, err = toGRPCDialOption(credential)
+	require.Error(t, err)
+}
+
+func TestConnectInsecure(t *testing.T) {
+	addr := startTestServer(t, &security.Credential{})
+	require.NoError(t, checkHealth(t, addr, &security.Credential{}))
+}
+
+func TestConnectTLS(t *testing.T) {
+	addr := startTestServer(t, newTestCredential("server", "client"))
+
+	require.NoError(t, checkHealth(t, addr, newTestCredential("client", "tidb-server")))
+	// the common name of the server is checked
+	require.Error(t, checkHealth(t, addr, newTestCredential("client", "unknown")))
+	// the insecure connection is refused by the TLS server
+	require.Error(t, checkHealth(t, addr, &security.Credential{}))
+	// the TLS connection is refused if the client certificate is not allowed by the server
+	addr = startTestServer(t, newTestCredential("server", "unknown"))
+	require.Error(t, checkHealth(t, addr, newTestCredential("client", "tidb-server")))
+}